@@ -7109,10 +7109,10 @@ def go_deps():
         name = "com_github_nats_io_nats_go",
         build_file_proto_mode = "disable_global",
         importpath = "github.com/nats-io/nats.go",
-        sha256 = "34a735d158d70685faad1fc3153f08da0ddc21c0ae42f6a0cb09430d638364b2",
-        strip_prefix = "github.com/nats-io/nats.go@v1.9.1",
+        sha256 = "3cb91adc6c85c2eb2cd55775bc9a857b74ec203cf52c78ff2a60f50a4593a907",
+        strip_prefix = "github.com/nats-io/nats.go@v1.37.0",
         urls = [
-            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nats.go/com_github_nats_io_nats_go-v1.9.1.zip",
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nats.go/com_github_nats_io_nats_go-v1.37.0.zip",
         ],
     )
     go_repository(
@@ -7129,10 +7129,10 @@ def go_deps():
         name = "com_github_nats_io_nkeys",
         build_file_proto_mode = "disable_global",
         importpath = "github.com/nats-io/nkeys",
-        sha256 = "291930a7abcd84edcaffc2cadc75aeb830ebf561144313ead6b8e1fcc03b124f",
-        strip_prefix = "github.com/nats-io/nkeys@v0.1.3",
+        sha256 = "b5ea0fc3e87853935f2903cd8222f6ad92944625b795ba3bf8c99c2cfc499b5b",
+        strip_prefix = "github.com/nats-io/nkeys@v0.4.7",
         urls = [
-            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nkeys/com_github_nats_io_nkeys-v0.1.3.zip",
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nkeys/com_github_nats_io_nkeys-v0.4.7.zip",
         ],
     )
     go_repository(
@@ -7972,6 +7972,16 @@ def go_deps():
             "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/PuerkitoBio/urlesc/com_github_puerkitobio_urlesc-v0.0.0-20170810143723-de5bf2ad4578.zip",
         ],
     )
+    go_repository(
+        name = "com_github_rabbitmq_amqp091_go",
+        build_file_proto_mode = "disable_global",
+        importpath = "github.com/rabbitmq/amqp091-go",
+        sha256 = "5caf2378d1d4a1e4109da5c5e67bcd368127c03bad018cce6756743b52ddd884",
+        strip_prefix = "github.com/rabbitmq/amqp091-go@v1.10.0",
+        urls = [
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/rabbitmq/amqp091-go/com_github_rabbitmq_amqp091_go-v1.10.0.zip",
+        ],
+    )
     go_repository(
         name = "com_github_raduberinde_axisds",
         build_file_proto_mode = "disable_global",
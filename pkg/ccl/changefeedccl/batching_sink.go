@@ -12,14 +12,17 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
@@ -42,6 +45,25 @@ type SinkClient interface {
 	CheckConnection(ctx context.Context) error
 }
 
+// transactionalSinkClient is a SinkClient that groups the payloads it flushes
+// into transactions, which are committed as the changefeed checkpoints past
+// their rows (see transactionalSink).
+type transactionalSinkClient interface {
+	SinkClient
+	// CommitTransaction commits the payloads flushed since the previous call.
+	// It is never called concurrently with Flush.
+	CommitTransaction(ctx context.Context) error
+}
+
+// resolvedPayloadsSinkClient is a SinkClient that can flush several resolved
+// payloads at once.
+type resolvedPayloadsSinkClient interface {
+	SinkClient
+	// FlushResolvedPayloads flushes the payloads, in order, like
+	// FlushResolvedPayload does for a single one.
+	FlushResolvedPayloads(context.Context, [][]byte, func(func(topic string) error) error, retry.Options) error
+}
+
 // BatchBuffer is an interface to aggregate KVs into a payload that can be sent
 // to the sink.
 type BatchBuffer interface {
@@ -84,6 +106,10 @@ type batchingSink struct {
 	pacer        *admission.Pacer
 	pacerFactory func() *admission.Pacer
 
+	// dlq, if set, receives the messages of batches that the client
+	// permanently rejected.
+	dlq *deadLetterQueue
+
 	termErr error
 	wg      ctxgroup.Group
 	hasher  hash.Hash32
@@ -148,6 +174,138 @@ func (s *batchingSink) Flush(ctx context.Context) error {
 
 var _ Sink = (*batchingSink)(nil)
 
+// isTransactional returns whether the rows emitted to the sink only become
+// visible once CommitTransaction is called.
+func (s *batchingSink) isTransactional() bool {
+	_, ok := s.client.(transactionalSinkClient)
+	return ok
+}
+
+// CommitTransaction commits the rows flushed to a transactional client. It must
+// be called right after Flush, before any more rows are emitted, since the
+// client cannot commit while payloads are being flushed.
+func (s *batchingSink) CommitTransaction(ctx context.Context) error {
+	if tc, ok := s.client.(transactionalSinkClient); ok {
+		return tc.CommitTransaction(ctx)
+	}
+	return nil
+}
+
+// transactionalSink is the sink of a change aggregator whose batchingSink has a
+// transactional client. Each transaction contains exactly the rows emitted at
+// or below the highwater of the checkpoint it is committed for, so that a
+// changefeed restarted from that checkpoint emits none of them again, while
+// consumers using read_committed isolation never see the rows of transactions
+// that were not committed. The change frontier commits the transactions before
+// it persists each checkpoint, see commit.
+//
+// Rows above the lowest highwater the next checkpoint can have are held in
+// memory, along with their allocations, until a checkpoint covers them.
+type transactionalSink struct {
+	*batchingSink
+
+	mu struct {
+		syncutil.Mutex
+		// lowerBound is the highwater of the last committed checkpoint, or the
+		// timestamp the changefeed started from. Rows at or below it are part of
+		// the open transaction.
+		lowerBound hlc.Timestamp
+		// held contains the rows above lowerBound, in the order they were
+		// emitted.
+		held []heldRow
+	}
+}
+
+// heldRow is a row emitted to a transactionalSink that is not part of the open
+// transaction yet.
+type heldRow struct {
+	topic         TopicDescriptor
+	key, value    []byte
+	updated, mvcc hlc.Timestamp
+	alloc         kvevent.Alloc
+	headers       rowHeaders
+}
+
+var _ EventSink = (*transactionalSink)(nil)
+
+// newTransactionalSink wraps a batchingSink with a transactional client.
+// lowerBound is the timestamp the changefeed starts from, which no checkpoint
+// highwater can be below.
+func newTransactionalSink(s *batchingSink, lowerBound hlc.Timestamp) *transactionalSink {
+	ts := &transactionalSink{batchingSink: s}
+	ts.mu.lowerBound = lowerBound
+	return ts
+}
+
+// EmitRow implements the EventSink interface.
+func (s *transactionalSink) EmitRow(
+	ctx context.Context,
+	topic TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+	headers rowHeaders,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if mvcc.LessEq(s.mu.lowerBound) {
+		return s.batchingSink.EmitRow(ctx, topic, key, value, updated, mvcc, alloc, headers)
+	}
+	s.mu.held = append(s.mu.held, heldRow{
+		topic: topic, key: key, value: value, updated: updated, mvcc: mvcc, alloc: alloc, headers: headers,
+	})
+	return nil
+}
+
+// Flush implements the EventSink interface. Rows held for a later transaction
+// are not flushed.
+func (s *transactionalSink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.batchingSink.Flush(ctx)
+}
+
+// commit commits a transaction containing the rows emitted at or below
+// highWater that were not committed yet. The change frontier calls it before
+// persisting a checkpoint at highWater; if the changefeed fails in between, it
+// restarts from the previous checkpoint and emits the committed rows again.
+func (s *transactionalSink) commit(ctx context.Context, highWater hlc.Timestamp) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if highWater.Less(s.mu.lowerBound) {
+		return nil
+	}
+	var remaining []heldRow
+	for _, r := range s.mu.held {
+		if highWater.Less(r.mvcc) {
+			remaining = append(remaining, r)
+			continue
+		}
+		if err := s.batchingSink.EmitRow(
+			ctx, r.topic, r.key, r.value, r.updated, r.mvcc, r.alloc, r.headers,
+		); err != nil {
+			return err
+		}
+	}
+	s.mu.held = remaining
+	s.mu.lowerBound = highWater
+	if err := s.batchingSink.Flush(ctx); err != nil {
+		return err
+	}
+	return s.batchingSink.CommitTransaction(ctx)
+}
+
+// Close implements the EventSink interface.
+func (s *transactionalSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.mu.held {
+		s.mu.held[i].alloc.Release(context.Background())
+	}
+	s.mu.held = nil
+	return s.batchingSink.Close()
+}
+
 // Topics gives the names of all topics that have been initialized
 // and will receive resolved timestamps.
 func (s *batchingSink) Topics() []string {
@@ -236,6 +394,30 @@ func (s *batchingSink) EmitResolvedTimestamp(
 	return s.client.FlushResolvedPayload(ctx, data, s.topicNamer.Each, s.retryOpts)
 }
 
+var _ resolvedPayloadsSink = (*batchingSink)(nil)
+
+// EmitResolvedPayloads implements the resolvedPayloadsSink interface.
+func (s *batchingSink) EmitResolvedPayloads(ctx context.Context, payloads []resolvedPayload) error {
+	// Flush the buffered rows.
+	if err := s.Flush(ctx); err != nil {
+		return err
+	}
+
+	if c, ok := s.client.(resolvedPayloadsSinkClient); ok {
+		bodies := make([][]byte, 0, len(payloads))
+		for _, p := range payloads {
+			bodies = append(bodies, p.body)
+		}
+		return c.FlushResolvedPayloads(ctx, bodies, s.topicNamer.Each, s.retryOpts)
+	}
+	for _, p := range payloads {
+		if err := s.client.FlushResolvedPayload(ctx, p.body, s.topicNamer.Each, s.retryOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close implements the Sink interface.
 func (s *batchingSink) Close() error {
 	close(s.doneCh)
@@ -267,6 +449,12 @@ type sinkBatch struct {
 	bufferTime  time.Time    // the earliest time a message was inserted into the batch
 	mvcc        hlc.Timestamp
 
+	// topic and events are only retained when the sink has a dead letter
+	// queue, so that the messages of a rejected batch can be written to it.
+	topic      string
+	keepEvents bool
+	events     []dlqEvent
+
 	alloc  kvevent.Alloc
 	hasher hash.Hash32
 }
@@ -313,6 +501,15 @@ func (sb *sinkBatch) Append(e *rowEvent) {
 		headers:   e.headers,
 	})
 
+	if sb.keepEvents {
+		sb.events = append(sb.events, dlqEvent{
+			topic: sb.topic,
+			key:   e.key,
+			value: e.val,
+			mvcc:  e.mvcc,
+		})
+	}
+
 	sb.keys.Add(hashToInt(sb.hasher, e.key))
 	sb.numMessages += 1
 	sb.numKVBytes += len(e.key) + len(e.val)
@@ -334,9 +531,43 @@ func (s *batchingSink) newBatchBuffer(topic string) *sinkBatch {
 	batch := newSinkBatch()
 	batch.buffer = s.client.MakeBatchBuffer(topic)
 	batch.hasher = s.hasher
+	if s.dlq != nil {
+		batch.topic = topic
+		batch.keepEvents = true
+	}
 	return batch
 }
 
+// setDeadLetterQueue makes the sink write the messages of batches that its
+// client permanently rejects to dlq rather than failing the changefeed. It
+// must be called before the first message is emitted.
+func (s *batchingSink) setDeadLetterQueue(dlq *deadLetterQueue) {
+	s.dlq = dlq
+}
+
+// writeBatchToDLQ writes the messages of a batch that the client rejected with
+// err to the dead letter queue. Unless err identifies the rejected messages,
+// every message of the batch is considered rejected.
+func (s *batchingSink) writeBatchToDLQ(ctx context.Context, batch *sinkBatch, err error) error {
+	events := batch.events
+	var rejected *rejectedMessagesError
+	if errors.As(err, &rejected) {
+		events = make([]dlqEvent, 0, len(rejected.messages))
+		for _, i := range rejected.messages {
+			events = append(events, batch.events[i])
+		}
+	}
+	log.Warningf(ctx, "writing %d messages rejected by the sink to %s: %v",
+		len(events), changefeedbase.OptDLQTable, err)
+	for _, ev := range events {
+		ev.err = err
+		if err := s.dlq.write(ctx, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // runBatchingWorker combines 1 or more row events into batches, sending the IO
 // requests out either once the batch is full or a flush request arrives.
 func (s *batchingSink) runBatchingWorker(ctx context.Context) {
@@ -358,7 +589,11 @@ func (s *batchingSink) runBatchingWorker(ctx context.Context) {
 		s.metrics.recordSinkIOInflightChange(int64(batch.numMessages))
 		defer s.metrics.timers().DownstreamClientSend.Start()()
 
-		return s.client.Flush(ctx, batch.payload)
+		err := s.client.Flush(ctx, batch.payload)
+		if err != nil && s.dlq != nil && errors.Is(err, errRejectedBySink) {
+			return s.writeBatchToDLQ(ctx, batch, err)
+		}
+		return err
 	}
 	ioEmitter := NewParallelIO(ctx, s.retryOpts, s.ioWorkers, ioHandler, s.metrics, s.settings)
 	defer ioEmitter.Close()
@@ -403,6 +403,10 @@ func makePlan(
 		if details.SinkURI == `` {
 			// Sinkless feeds get one ChangeAggregator on this node.
 			distMode = sql.LocalDistribution
+		} else if isExactlyOnce(changefeedbase.MakeStatementOptions(details.Opts)) {
+			// So do exactly-once feeds, since the ChangeFrontier commits the
+			// transactions of the aggregator's sink as it checkpoints.
+			distMode = sql.LocalDistribution
 		}
 
 		var locFilter roachpb.Locality
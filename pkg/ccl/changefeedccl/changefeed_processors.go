@@ -47,6 +47,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -96,6 +97,14 @@ type changeAggregator struct {
 	// span was forwarded to the frontier
 	recentKVCount uint64
 
+	// txnRows, if set, counts by commit timestamp the rows emitted since the
+	// last time a resolved span was forwarded to the frontier.
+	txnRows *txnRowCounter
+
+	// txnSink, if set, is the sink of an exactly-once changefeed, whose
+	// transactions are committed by the change frontier as it checkpoints.
+	txnSink *transactionalSink
+
 	// eventProducer produces the next event from the kv feed.
 	eventProducer kvevent.Reader
 	// eventConsumer consumes the event.
@@ -389,7 +398,7 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	}
 
 	ca.sink, err = getEventSink(ctx, ca.FlowCtx.Cfg, ca.spec.Feed, timestampOracle,
-		ca.spec.User(), ca.spec.JobID, recorder)
+		ca.spec.User(), ca.spec.JobID, ca.ProcessorID, recorder)
 	if err != nil {
 		err = changefeedbase.MarkRetryableError(err)
 		if log.V(2) {
@@ -405,6 +414,23 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	if b, ok := ca.sink.(*bufferSink); ok {
 		ca.changedRowBuf = &b.buf
 	}
+	if b, ok := ca.sink.(*batchingSink); ok && b.isTransactional() {
+		// Exactly-once changefeeds are planned on the coordinator node, so that
+		// the change frontier can commit the transactions of the sink through
+		// the local changefeed state as it checkpoints.
+		localState, ok := ca.FlowCtx.EvalCtx.ChangefeedState.(*cachedState)
+		if !ok {
+			err := errors.AssertionFailedf("exactly-once change aggregator running outside of the coordinator node")
+			ca.MoveToDraining(err)
+			ca.cancel()
+			return
+		}
+		lowerBound := ca.frontier.Frontier()
+		lowerBound.Forward(ca.spec.Feed.StatementTime)
+		ca.txnSink = newTransactionalSink(b, lowerBound)
+		localState.setTransactionalSink(ca.txnSink)
+		ca.sink = ca.txnSink
+	}
 
 	// If the initial scan was disabled the highwater would've already been forwarded
 	needsInitialScan := ca.frontier.Frontier().IsEmpty()
@@ -433,10 +459,13 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		ca.cancel()
 		return
 	}
+	if opts.IsSet(changefeedbase.OptTransactionBoundaries) {
+		ca.txnRows = makeTxnRowCounter()
+	}
 	ca.sink = &errorWrapperSink{wrapped: ca.sink}
 	ca.eventConsumer, ca.sink, err = newEventConsumer(
 		ctx, ca.FlowCtx.Cfg, ca.spec, feed, ca.frontier, kvFeedHighWater,
-		ca.sink, ca.metrics, ca.sliMetrics, ca.knobs)
+		ca.sink, ca.metrics, ca.sliMetrics, ca.knobs, ca.txnRows)
 	if err != nil {
 		if log.V(2) {
 			log.Infof(ca.Ctx(), "change aggregator moving to draining due to error creating event consumer: %v", err)
@@ -691,6 +720,9 @@ func (ca *changeAggregator) close() {
 		ca.closeTelemetryRecorder()
 	}
 
+	if ca.txnSink != nil {
+		ca.FlowCtx.EvalCtx.ChangefeedState.(*cachedState).setTransactionalSink(nil)
+	}
 	if ca.sink != nil {
 		// Best effort: context is often cancel by now, so we expect to see an error
 		_ = ca.sink.Close()
@@ -983,6 +1015,11 @@ func (ca *changeAggregator) emitResolved(batch jobspb.ResolvedSpans) error {
 			RecentKvCount: ca.recentKVCount,
 		},
 	}
+	if ca.txnRows != nil {
+		// The sink has been flushed, so every row counted so far has been
+		// delivered.
+		progressUpdate.TransactionRows = ca.txnRows.drain()
+	}
 	if log.V(2) {
 		log.Infof(ca.Ctx(), "progress update to be sent to change frontier: %#v", progressUpdate)
 	}
@@ -1043,6 +1080,18 @@ type changeFrontier struct {
 	// sink is the Sink to write resolved timestamps to. Rows are never written
 	// by changeFrontier.
 	sink ResolvedTimestampSink
+	// txnRows, if set, contains the rows reported by the aggregators for
+	// transactions whose boundary markers have not been emitted yet.
+	txnRows txnRows
+	// txnMarkerEncoder encodes the transaction boundary markers.
+	txnMarkerEncoder *txnMarkerEncoder
+	// txnCheckpointTS is the highest timestamp of the span-level checkpoint the
+	// changefeed was resumed from. No markers are emitted for transactions
+	// committed at or before it, see skipIncompleteTxns.
+	txnCheckpointTS hlc.Timestamp
+	// exactlyOnce is set if the sink commits the rows up to each checkpoint in
+	// a transaction, which only covers the rows up to the highwater.
+	exactlyOnce bool
 	// freqEmitResolved, if >= 0, is a lower bound on the duration between
 	// resolved timestamp emits.
 	freqEmitResolved time.Duration
@@ -1131,6 +1180,13 @@ type cachedState struct {
 	aggregatorFrontier []execinfrapb.ChangefeedMeta_FrontierSpan
 	// drainingNodes is the list of nodes that are draining.
 	drainingNodes []roachpb.NodeID
+	// txnSink is the sink of the change aggregator of an exactly-once
+	// changefeed, which runs on the coordinator node. It is accessed by both the
+	// aggregator and the change frontier.
+	txnSink struct {
+		syncutil.Mutex
+		sink *transactionalSink
+	}
 }
 
 // SetHighwater implements the eval.ChangefeedState interface.
@@ -1148,6 +1204,25 @@ func (cs *cachedState) SetCheckpoint(checkpoint *jobspb.TimestampSpansMap) {
 	cs.progress.Details.(*jobspb.Progress_Changefeed).Changefeed.SpanLevelCheckpoint = checkpoint
 }
 
+// setTransactionalSink makes the change frontier commit the transactions of
+// the given sink as it checkpoints, or stop doing so if it is nil.
+func (cs *cachedState) setTransactionalSink(s *transactionalSink) {
+	cs.txnSink.Lock()
+	defer cs.txnSink.Unlock()
+	cs.txnSink.sink = s
+}
+
+// commitTransactionalSink commits the rows emitted to the transactional sink at
+// or below highWater, if there is such a sink.
+func (cs *cachedState) commitTransactionalSink(ctx context.Context, highWater hlc.Timestamp) error {
+	cs.txnSink.Lock()
+	defer cs.txnSink.Unlock()
+	if cs.txnSink.sink == nil {
+		return nil
+	}
+	return cs.txnSink.sink.commit(ctx, highWater)
+}
+
 // AggregatorFrontierSpans returns an iterator over the spans in the aggregator
 // frontier collected during shutdown.
 func (cs *cachedState) AggregatorFrontierSpans() iter.Seq2[roachpb.Span, hlc.Timestamp] {
@@ -1322,6 +1397,11 @@ func newChangeFrontierProcessor(
 	); err != nil {
 		return nil, err
 	}
+	if opts.IsSet(changefeedbase.OptTransactionBoundaries) {
+		cf.txnRows = make(txnRows)
+		cf.txnMarkerEncoder = &txnMarkerEncoder{Encoder: cf.encoder, envelope: encodingOpts.Envelope}
+	}
+	cf.exactlyOnce = isExactlyOnce(opts)
 
 	return cf, nil
 }
@@ -1371,7 +1451,7 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 	cf.sliMetrics = sli
 
 	cf.sink, err = getResolvedTimestampSink(ctx, cf.FlowCtx.Cfg, cf.spec.Feed, nilOracle,
-		cf.spec.User(), cf.spec.JobID, sli)
+		cf.spec.User(), cf.spec.JobID, cf.ProcessorID, sli)
 	if err != nil {
 		err = changefeedbase.MarkRetryableError(err)
 		if log.V(2) {
@@ -1460,6 +1540,9 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 			log.Infof(cf.Ctx(), "change frontier encountered error on checkpoint restore: %v", err)
 		}
 	}
+	if cf.txnRows != nil {
+		cf.txnCheckpointTS = maxCheckpointTimestamp(cf.spec.SpanLevelCheckpoint)
+	}
 
 	if cf.knobs.AfterCoordinatorFrontierRestore != nil {
 		cf.knobs.AfterCoordinatorFrontierRestore(cf.frontier)
@@ -1691,6 +1774,9 @@ func (cf *changeFrontier) noteAggregatorProgress(d rowenc.EncDatum) error {
 	}
 
 	cf.maybeMarkJobIdle(resolvedSpans.Stats.RecentKvCount)
+	if cf.txnRows != nil {
+		cf.txnRows.addAll(resolvedSpans.TransactionRows)
+	}
 
 	for _, resolved := range resolvedSpans.ResolvedSpans {
 		// Inserting a timestamp less than the one the changefeed flow started at
@@ -1722,6 +1808,15 @@ func (cf *changeFrontier) forwardFrontier(resolved jobspb.ResolvedSpan) error {
 
 	maybeLogBehindSpan(cf.Ctx(), "coordinator", cf.frontier, frontierChanged, &cf.FlowCtx.Cfg.Settings.SV)
 
+	// Transaction boundary markers are emitted before the checkpoint, since
+	// the rows of their transactions are not emitted again once the highwater
+	// passes them.
+	if frontierChanged && cf.txnRows != nil {
+		if err := cf.emitTransactionMarkers(cf.frontier.Frontier()); err != nil {
+			return err
+		}
+	}
+
 	checkpointed, err := cf.maybeCheckpointJob(resolved, frontierChanged)
 	if err != nil {
 		return err
@@ -1782,7 +1877,10 @@ func (cf *changeFrontier) maybeCheckpointJob(
 	// highwater mark remains fixed while other spans may significantly outpace
 	// it, therefore to avoid losing that progress on changefeed resumption we
 	// also store as many of those leading spans as we can in the job progress
-	updateCheckpoint := (inBackfill || cf.frontier.HasLaggingSpans(&cf.js.settings.SV)) && cf.js.canCheckpointSpans()
+	// Exactly-once changefeeds don't store them, since the rows of those spans
+	// are only committed once the highwater passes them.
+	updateCheckpoint := (inBackfill || cf.frontier.HasLaggingSpans(&cf.js.settings.SV)) &&
+		!cf.exactlyOnce && cf.js.canCheckpointSpans()
 
 	// If the highwater has moved an empty checkpoint will be saved
 	var checkpoint *jobspb.TimestampSpansMap
@@ -1827,6 +1925,11 @@ func (cf *changeFrontier) checkpointJobProgress(
 	}
 	cf.metrics.FrontierUpdates.Inc(1)
 	if cf.js.job != nil {
+		// Commit the rows of an exactly-once sink up to the new highwater before
+		// persisting it.
+		if err := cf.localState.commitTransactionalSink(cf.Ctx(), frontier); err != nil {
+			return false, changefeedbase.MarkRetryableError(err)
+		}
 		var ptsUpdated bool
 		var checkpointStr string
 		if err := cf.js.job.DebugNameNoTxn(changefeedJobProgressTxnName).Update(cf.Ctx(), func(
@@ -1998,6 +2101,27 @@ func (cf *changeFrontier) maybeEmitResolved(newResolved hlc.Timestamp) error {
 	return nil
 }
 
+// emitTransactionMarkers emits the BEGIN and COMMIT markers of all the
+// transactions committed at or before resolved with a single flush of the sink,
+// and waits for the sink to deliver them.
+func (cf *changeFrontier) emitTransactionMarkers(resolved hlc.Timestamp) error {
+	txns := skipIncompleteTxns(cf.txnRows.popResolved(resolved), cf.txnCheckpointTS)
+	if len(txns) == 0 {
+		return nil
+	}
+	payloads, err := cf.txnMarkerEncoder.encodeTxnMarkers(cf.Ctx(), txns)
+	if err != nil {
+		return err
+	}
+	if err := emitResolvedPayloads(cf.Ctx(), cf.sink, payloads); err != nil {
+		return err
+	}
+	if log.V(2) {
+		log.Infof(cf.Ctx(), "emitted transaction boundaries of %d transactions up to %s", len(txns), resolved)
+	}
+	return nil
+}
+
 func frontierIsBehind(frontier hlc.Timestamp, sv *settings.Values) bool {
 	if frontier.IsEmpty() {
 		// During backfills we consider ourselves "behind" for the purposes of
@@ -723,6 +723,17 @@ func createChangefeedJobRecord(
 		}
 	}
 
+	if dlqTable, ok := opts.GetDLQTable(); ok {
+		if details.SinkURI == `` {
+			return nil, errors.Errorf(`%s is not supported for sinkless changefeeds`, changefeedbase.OptDLQTable)
+		}
+		qualified, err := qualifyDLQTableName(dlqTable, p.CurrentDatabase())
+		if err != nil {
+			return nil, err
+		}
+		opts.SetDLQTable(qualified)
+	}
+
 	if details.SinkURI == `` {
 
 		if details.Select != `` {
@@ -1032,7 +1043,7 @@ func validateSink(
 
 	var nilOracle timestampLowerBoundOracle
 	canarySink, err := getAndDialSink(ctx, &p.ExecCfg().DistSQLSrv.ServerConfig, details,
-		nilOracle, p.User(), jobID, sli)
+		nilOracle, p.User(), jobID, 0 /* processorID */, sli)
 	if err != nil {
 		return err
 	}
@@ -1053,12 +1064,20 @@ func validateSink(
 			sinkTypeWebhook:        {},
 			sinkTypeSinklessBuffer: {},
 			sinkTypeCloudstorage:   {},
+			sinkTypeNATS:           {},
+			sinkTypeAMQP:           {},
 		}
 		if _, ok := allowedSinkTypes[sinkTy]; !ok {
 			return errors.Newf("envelope=%s is incompatible with %s sink", changefeedbase.OptEnvelopeEnriched, sinkTy)
 		}
 	}
 
+	// Transaction boundary markers are emitted the way resolved timestamps are,
+	// which cloud storage sinks write to files named after the timestamp.
+	if opts.IsSet(changefeedbase.OptTransactionBoundaries) && sinkTy == sinkTypeCloudstorage {
+		return errors.Newf("%s is incompatible with %s sink", changefeedbase.OptTransactionBoundaries, sinkTy)
+	}
+
 	// If there's no projection we may need to force some options to ensure messages
 	// have enough information.
 	if details.Select == `` {
@@ -1080,6 +1099,13 @@ func validateSink(
 				" as the set of topics to fan them out to may change. Instead, use TABLE tablename FAMILY familyname"+
 				" to specify individual families to watch.", changefeedbase.OptSplitColumnFamilies)
 		}
+		if opts.IsSet(changefeedbase.OptTransactionBoundaries) &&
+			opts.IsSet(changefeedbase.OptSplitColumnFamilies) {
+			return errors.Newf("%s is not currently supported with %s for this sink"+
+				" as the set of topics to fan the markers out to may change. Instead, use TABLE tablename FAMILY familyname"+
+				" to specify individual families to watch.",
+				changefeedbase.OptTransactionBoundaries, changefeedbase.OptSplitColumnFamilies)
+		}
 
 		topics := sink.Topics()
 		for _, topic := range topics {
@@ -1266,6 +1292,10 @@ func (b *changefeedResumer) Resume(ctx context.Context, execCtx interface{}) err
 		return err
 	}
 
+	if err := b.maybeCreateDeadLetterQueueTable(ctx, execCfg, details); err != nil {
+		return b.handleChangefeedError(ctx, err, details, jobExec)
+	}
+
 	err := b.resumeWithRetries(ctx, jobExec, jobID, details, description, progress, execCfg)
 	if err != nil {
 		return b.handleChangefeedError(ctx, err, details, jobExec)
@@ -1273,6 +1303,20 @@ func (b *changefeedResumer) Resume(ctx context.Context, execCtx interface{}) err
 	return nil
 }
 
+// maybeCreateDeadLetterQueueTable creates the table named by the dlq_table
+// option, if the changefeed has one and it does not exist yet. The table is
+// created as the owner of the job, like all writes to it.
+func (b *changefeedResumer) maybeCreateDeadLetterQueueTable(
+	ctx context.Context, execCfg *sql.ExecutorConfig, details jobspb.ChangefeedDetails,
+) error {
+	dlq, err := makeDeadLetterQueue(execCfg.InternalDB, changefeedbase.MakeStatementOptions(details.Opts),
+		b.job.ID(), b.job.Payload().UsernameProto.Decode(), nil /* metrics */)
+	if err != nil || dlq == nil {
+		return err
+	}
+	return dlq.createTable(ctx)
+}
+
 // ensureClusterIDMatches verifies that this job record matches
 // the cluster ID of this cluster.
 // This check ensures that if the job has been restored from the
@@ -1355,8 +1399,9 @@ func (b *changefeedResumer) handleChangefeedError(
 		return errors.CombineErrors(changefeedErr, errErr)
 	}
 	switch onError {
-	// default behavior
-	case changefeedbase.OptOnErrorFail:
+	// default behavior; on_error=dlq only applies to individual events that
+	// cannot be emitted.
+	case changefeedbase.OptOnErrorFail, changefeedbase.OptOnErrorDLQ:
 		log.Warningf(ctx, "job failed (%v)", changefeedErr)
 		return changefeedErr
 	// pause instead of failing
@@ -7073,7 +7073,7 @@ func TestChangefeedErrors(t *testing.T) {
 		`CREATE CHANGEFEED FOR foo into $1 WITH on_error`,
 		`kafka://nope`)
 	sqlDB.ExpectErrWithTimeout(
-		t, `unknown on_error: not_valid, valid values are 'pause' and 'fail'`,
+		t, `unknown on_error: not_valid, valid values are 'pause', 'fail'`,
 		`CREATE CHANGEFEED FOR foo into $1 WITH on_error='not_valid'`,
 		`kafka://nope`)
 
@@ -16,6 +16,7 @@ import (
 var escapeRE = regexp.MustCompile(`_u[0-9a-fA-F]{2,8}_`)
 var kafkaDisallowedRE = regexp.MustCompile(`[^a-zA-Z0-9\._\-]`)
 var avroDisallowedRE = regexp.MustCompile(`[^A-Za-z0-9_]`)
+var natsDisallowedRE = regexp.MustCompile(`[^a-zA-Z0-9\._\-]`)
 
 func escapeRune(r rune) string {
 	if r <= 1<<16 {
@@ -52,6 +53,22 @@ func KafkaNameToSQLName(s string) string {
 	return unescapeSQLName(s)
 }
 
+// SQLNameToNATSSubject escapes a sql table name into a valid NATS subject.
+// This is reversible by NATSSubjectToSQLName.
+//
+// NATS subjects are made of tokens separated by `.`, which are kept so that
+// topic prefixes and fully qualified table names become subject hierarchies.
+// Wildcards (`*` and `>`), whitespace and everything else outside of
+// `[a-zA-Z0-9_\-]` is escaped with _u<hex>_, as for kafka topic names.
+func SQLNameToNATSSubject(s string) string {
+	return escapeSQLName(s, natsDisallowedRE)
+}
+
+// NATSSubjectToSQLName is the inverse of SQLNameToNATSSubject.
+func NATSSubjectToSQLName(s string) string {
+	return unescapeSQLName(s)
+}
+
 // SQLNameToAvroName escapes a sql table name into a valid avro record or field
 // name. This is reversible by AvroNameToSQLName.
 //
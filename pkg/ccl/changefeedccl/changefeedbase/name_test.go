@@ -52,6 +52,32 @@ func TestSQLNameToKafkaName(t *testing.T) {
 	require.Equal(t, `/`, KafkaNameToSQLName(`_u2F_`))
 }
 
+func TestSQLNameToNATSSubject(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tests := []struct {
+		sql, nats string
+	}{
+		{`foo`, `foo`},
+		{`0123456789_-.`, `0123456789_-.`},
+		{`cdc.d.public.foo`, `cdc.d.public.foo`},
+		{`foo bar`, `foo_u0020_bar`},
+		{`*`, `_u002a_`},
+		{`foo.>`, `foo._u003e_`},
+		{`$JS`, `_u0024_JS`},
+		{`☃`, `_u2603_`},
+	}
+	for i, test := range tests {
+		if n := SQLNameToNATSSubject(test.sql); n != test.nats {
+			t.Errorf(`%d: %s did not escape to %s got %s`, i, test.sql, test.nats, n)
+		}
+		if s := NATSSubjectToSQLName(test.nats); s != test.sql {
+			t.Errorf(`%d: %s did not unescape to %s got %s`, i, test.nats, test.sql, s)
+		}
+	}
+}
+
 func TestSQLNameToAvroName(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
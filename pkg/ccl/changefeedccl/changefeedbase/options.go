@@ -106,6 +106,7 @@ const (
 	OptWebhookAuthHeader                  = `webhook_auth_header`
 	OptWebhookClientTimeout               = `webhook_client_timeout`
 	OptOnError                            = `on_error`
+	OptDLQTable                           = `dlq_table`
 	OptMetricsScope                       = `metrics_label`
 	OptUnordered                          = `unordered`
 	OptVirtualColumns                     = `virtual_columns`
@@ -114,6 +115,15 @@ const (
 	OptLaggingRangesPollingInterval       = `lagging_ranges_polling_interval`
 	OptIgnoreDisableChangefeedReplication = `ignore_disable_changefeed_replication`
 	OptEncodeJSONValueNullAsObject        = `encode_json_value_null_as_object`
+	// OptTransactionBoundaries groups the rows by their commit timestamp and,
+	// once the changefeed is resolved past that timestamp, emits BEGIN and
+	// COMMIT markers carrying the number of rows emitted for each table. The
+	// markers are sent after the rows of their transaction, and like rows may
+	// be duplicated when the changefeed restarts. The row counts are only kept
+	// in memory, so when a changefeed resumes from a span-level checkpoint, no
+	// markers are emitted for the transactions committed at or before the
+	// highest checkpointed timestamp, whose rows may not all be emitted again.
+	OptTransactionBoundaries = `transaction_boundaries`
 	// TODO(#142273): look into whether we want to add headers to pub/sub, and other
 	// sinks as well (eg cloudstorage, webhook, ..). Currently it's kafka-only.
 	OptHeadersJSONColumnName = `headers_json_column_name`
@@ -167,13 +177,15 @@ const (
 	OptEnvelopeBare          EnvelopeType = `bare`
 	OptEnvelopeEnriched      EnvelopeType = `enriched`
 
-	OptFormatJSON    FormatType = `json`
-	OptFormatAvro    FormatType = `avro`
-	OptFormatCSV     FormatType = `csv`
-	OptFormatParquet FormatType = `parquet`
+	OptFormatJSON     FormatType = `json`
+	OptFormatAvro     FormatType = `avro`
+	OptFormatCSV      FormatType = `csv`
+	OptFormatParquet  FormatType = `parquet`
+	OptFormatProtobuf FormatType = `protobuf`
 
 	OptOnErrorFail  OnErrorType = `fail`
 	OptOnErrorPause OnErrorType = `pause`
+	OptOnErrorDLQ   OnErrorType = `dlq`
 
 	DeprecatedOptFormatAvro                   = `experimental_avro`
 	DeprecatedSinkSchemeCloudStorageAzure     = `experimental-azure`
@@ -192,6 +204,8 @@ const (
 	OptKafkaSinkConfig   = `kafka_sink_config`
 	OptPubsubSinkConfig  = `pubsub_sink_config`
 	OptWebhookSinkConfig = `webhook_sink_config`
+	OptNATSSinkConfig    = `nats_sink_config`
+	OptAMQPSinkConfig    = `amqp_sink_config`
 
 	// OptSink allows users to alter the Sink URI of an existing changefeed.
 	// Note that this option is only allowed for alter changefeed statements.
@@ -222,6 +236,9 @@ const (
 	SinkSchemeWebhookHTTP           = `webhook-http`
 	SinkSchemeWebhookHTTPS          = `webhook-https`
 	SinkSchemePulsar                = `pulsar`
+	SinkSchemeNATS                  = `nats`
+	SinkSchemeAMQP                  = `amqp`
+	SinkSchemeAMQPS                 = `amqps`
 	SinkSchemeExternalConnection    = `external`
 	SinkParamSASLEnabled            = `sasl_enabled`
 	SinkParamSASLHandshake          = `sasl_handshake`
@@ -237,6 +254,7 @@ const (
 	SinkParamSASLAwsRegion          = `sasl_aws_region`
 	SinkParamSASLAwsIAMSessionName  = `sasl_aws_iam_session_name`
 	SinkParamTableNameAttribute     = `with_table_name_attribute`
+	SinkParamExchange               = `exchange`
 
 	// These are custom fields required for proprietary oauth. They should not
 	// be documented.
@@ -374,7 +392,7 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptCustomKeyColumn:                    stringOption,
 	OptEndTime:                            timestampOption,
 	OptEnvelope:                           enum("row", "key_only", "wrapped", "deprecated_row", "bare", "enriched"),
-	OptFormat:                             enum("json", "avro", "csv", "experimental_avro", "parquet"),
+	OptFormat:                             enum("json", "avro", "csv", "experimental_avro", "parquet", "protobuf"),
 	OptFullTableName:                      flagOption,
 	OptKeyInValue:                         flagOption,
 	OptTopicInValue:                       flagOption,
@@ -394,10 +412,13 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptExpirePTSAfter:                     durationOption.thatCanBeZero(),
 	OptKafkaSinkConfig:                    jsonOption,
 	OptPubsubSinkConfig:                   jsonOption,
+	OptNATSSinkConfig:                     jsonOption,
+	OptAMQPSinkConfig:                     jsonOption,
 	OptWebhookSinkConfig:                  jsonOption,
 	OptWebhookAuthHeader:                  stringOption,
 	OptWebhookClientTimeout:               durationOption,
-	OptOnError:                            enum("pause", "fail"),
+	OptOnError:                            enum("pause", "fail", "dlq"),
+	OptDLQTable:                           stringOption,
 	OptMetricsScope:                       stringOption,
 	OptUnordered:                          flagOption,
 	OptVirtualColumns:                     enum("omitted", "null"),
@@ -406,6 +427,7 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptLaggingRangesPollingInterval:       durationOption,
 	OptIgnoreDisableChangefeedReplication: flagOption,
 	OptEncodeJSONValueNullAsObject:        flagOption,
+	OptTransactionBoundaries:              flagOption,
 	OptEnrichedProperties:                 csv(string(EnrichedPropertySource), string(EnrichedPropertySchema)),
 	OptHeadersJSONColumnName:              stringOption,
 }
@@ -417,11 +439,12 @@ var CommonOptions = makeStringSet(OptCursor, OptEndTime, OptEnvelope,
 	OptResolvedTimestamps, OptUpdatedTimestamps,
 	OptMVCCTimestamps, OptDiff, OptSplitColumnFamilies,
 	OptSchemaChangeEvents, OptSchemaChangePolicy,
-	OptOnError,
+	OptOnError, OptDLQTable,
 	OptInitialScan, OptNoInitialScan, OptInitialScanOnly, OptUnordered, OptCustomKeyColumn,
 	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, Topics, OptExpirePTSAfter,
 	OptExecutionLocality, OptLaggingRangesThreshold, OptLaggingRangesPollingInterval,
 	OptIgnoreDisableChangefeedReplication, OptEncodeJSONValueNullAsObject, OptEnrichedProperties,
+	OptTransactionBoundaries,
 )
 
 // SQLValidOptions is options exclusive to SQL sink
@@ -439,13 +462,20 @@ var WebhookValidOptions = makeStringSet(OptWebhookAuthHeader, OptWebhookClientTi
 // PubsubValidOptions is options exclusive to pubsub sink
 var PubsubValidOptions = makeStringSet(OptPubsubSinkConfig)
 
+// NATSValidOptions is options exclusive to NATS JetStream sink
+var NATSValidOptions = makeStringSet(OptNATSSinkConfig)
+
+// AMQPValidOptions is options exclusive to AMQP sink
+var AMQPValidOptions = makeStringSet(OptAMQPSinkConfig)
+
 // ExternalConnectionValidOptions is options exclusive to the external
 // connection sink.
 //
 // TODO(adityamaru): Some of these options should be supported when creating the
 // external connection rather than when setting up the changefeed. Move them once
 // we support `CREATE EXTERNAL CONNECTION ... WITH <options>`.
-var ExternalConnectionValidOptions = unionStringSets(SQLValidOptions, KafkaValidOptions, CloudStorageValidOptions, WebhookValidOptions, PubsubValidOptions)
+var ExternalConnectionValidOptions = unionStringSets(SQLValidOptions, KafkaValidOptions, CloudStorageValidOptions, WebhookValidOptions, PubsubValidOptions,
+	NATSValidOptions, AMQPValidOptions)
 
 // CaseInsensitiveOpts options which supports case Insensitive value
 var CaseInsensitiveOpts = makeStringSet(OptFormat, OptEnvelope, OptCompression, OptSchemaChangeEvents,
@@ -498,7 +528,7 @@ type OptionsSet map[string]struct{}
 // InitialScanOnlyUnsupportedOptions is options that are not supported with the
 // initial scan only option
 var InitialScanOnlyUnsupportedOptions OptionsSet = makeStringSet(OptEndTime, OptResolvedTimestamps, OptDiff,
-	OptMVCCTimestamps, OptUpdatedTimestamps)
+	OptMVCCTimestamps, OptUpdatedTimestamps, OptTransactionBoundaries)
 
 // ParquetFormatUnsupportedOptions is options that are not supported with the
 // parquet format.
@@ -562,10 +592,12 @@ func makeDirectedInvertedIndex(pairs []dependentOption) map[string][]dependentOp
 
 var incompatibleOptionsMap = makeInvertedIndex([]incompatibleOptions{
 	{opt1: OptUnordered, opt2: OptResolvedTimestamps, reason: `resolved timestamps cannot be guaranteed to be correct in unordered mode`},
+	{opt1: OptUnordered, opt2: OptTransactionBoundaries, reason: `transaction boundaries cannot be guaranteed to be correct in unordered mode`},
 })
 
 var dependentOptionsMap = makeDirectedInvertedIndex([]dependentOption{
 	{opt1: OptCustomKeyColumn, opt2: OptUnordered, reason: `using a value other than the primary key as the message key means end-to-end ordering cannot be preserved`},
+	{opt1: OptTransactionBoundaries, opt2: OptMVCCTimestamps, reason: `rows are matched to their transaction by their mvcc timestamp`},
 })
 
 // MakeStatementOptions wraps and canonicalizes the options we get
@@ -920,9 +952,9 @@ func (s StatementOptions) GetEncodingOptions() (EncodingOptions, error) {
 
 // Validate checks for incompatible encoding options.
 func (e EncodingOptions) Validate() error {
-	if e.Envelope == OptEnvelopeRow && e.Format == OptFormatAvro {
+	if e.Envelope == OptEnvelopeRow && (e.Format == OptFormatAvro || e.Format == OptFormatProtobuf) {
 		return errors.Errorf(`%s=%s is not supported with %s=%s`,
-			OptEnvelope, OptEnvelopeRow, OptFormat, OptFormatAvro,
+			OptEnvelope, OptEnvelopeRow, OptFormat, e.Format,
 		)
 	}
 	if e.Format != OptFormatJSON && e.EncodeJSONValueNullAsObject {
@@ -1064,6 +1096,18 @@ func (s StatementOptions) GetPubsubConfigJSON() SinkSpecificJSONConfig {
 	return s.getJSONValue(OptPubsubSinkConfig)
 }
 
+// GetNATSConfigJSON returns arbitrary json to be interpreted
+// by the NATS JetStream sink.
+func (s StatementOptions) GetNATSConfigJSON() SinkSpecificJSONConfig {
+	return s.getJSONValue(OptNATSSinkConfig)
+}
+
+// GetAMQPConfigJSON returns arbitrary json to be interpreted
+// by the AMQP sink.
+func (s StatementOptions) GetAMQPConfigJSON() SinkSpecificJSONConfig {
+	return s.getJSONValue(OptAMQPSinkConfig)
+}
+
 // GetResolvedTimestampInterval gets the best-effort interval at which resolved timestamps
 // should be emitted. Nil or 0 means emit as often as possible. False means do not emit at all.
 // Returns an error for negative or invalid duration value.
@@ -1202,6 +1246,19 @@ func (s StatementOptions) GetOnError() (OnErrorType, error) {
 	return OnErrorType(v), nil
 }
 
+// GetDLQTable returns the name of the table that events are written to when
+// on_error='dlq', and whether it was set.
+func (s StatementOptions) GetDLQTable() (string, bool) {
+	v, ok := s.m[OptDLQTable]
+	return v, ok
+}
+
+// SetDLQTable sets the name of the table that events are written to when
+// on_error='dlq'.
+func (s StatementOptions) SetDLQTable(table string) {
+	s.m[OptDLQTable] = table
+}
+
 func describeEnum(strs ...string) string {
 	switch len(strs) {
 	case 1:
@@ -1270,6 +1327,21 @@ func (s StatementOptions) ValidateForCreateChangefeed(isPredicateChangefeed bool
 			return err
 		}
 	}
+	if s.IsSet(OptTransactionBoundaries) {
+		if format, ok := s.m[OptFormat]; ok && format != string(OptFormatJSON) {
+			return errors.Newf(`%s is only usable with %s=%s`, OptTransactionBoundaries, OptFormat, OptFormatJSON)
+		}
+	}
+	onError, err := s.GetOnError()
+	if err != nil {
+		return err
+	}
+	if onError == OptOnErrorDLQ && !s.IsSet(OptDLQTable) {
+		return errors.Newf(`%s='%s' requires the %s option`, OptOnError, OptOnErrorDLQ, OptDLQTable)
+	}
+	if onError != OptOnErrorDLQ && s.IsSet(OptDLQTable) {
+		return errors.Newf(`%s requires %s='%s'`, OptDLQTable, OptOnError, OptOnErrorDLQ)
+	}
 	for o := range s.m {
 		for _, pair := range incompatibleOptionsMap[o] {
 			if s.IsSet(pair.opt1) && s.IsSet(pair.opt2) {
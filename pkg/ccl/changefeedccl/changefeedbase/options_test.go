@@ -37,6 +37,17 @@ func TestOptionsValidations(t *testing.T) {
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"initial_scan_only": "", "resolved": ""}, true, "cannot specify both initial_scan='only'"},
 		{map[string]string{"key_column": "b"}, false, "requires the unordered option"},
+		{map[string]string{"on_error": "dlq"}, false, "on_error='dlq' requires the dlq_table option"},
+		{map[string]string{"dlq_table": "dlq"}, false, "dlq_table requires on_error='dlq'"},
+		{map[string]string{"on_error": "pause", "dlq_table": "dlq"}, false, "dlq_table requires on_error='dlq'"},
+		{map[string]string{"on_error": "dlq", "dlq_table": "dlq"}, false, ""},
+		{map[string]string{"transaction_boundaries": ""}, false, "requires the mvcc_timestamp option"},
+		{map[string]string{"transaction_boundaries": "", "mvcc_timestamp": "", "unordered": ""}, false,
+			"unordered is not usable with transaction_boundaries"},
+		{map[string]string{"transaction_boundaries": "", "mvcc_timestamp": "", "format": "avro"}, false,
+			"transaction_boundaries is only usable with format=json"},
+		{map[string]string{"transaction_boundaries": "", "mvcc_timestamp": ""}, false, ""},
+		{map[string]string{"transaction_boundaries": "", "mvcc_timestamp": "", "format": "json"}, false, ""},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,197 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	createDLQTableStmt = `CREATE TABLE IF NOT EXISTS %s (
+	id             INT8 DEFAULT unique_rowid(),
+	job_id         INT8 NOT NULL,
+	dlq_timestamp  TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	topic          STRING,
+	event_key      BYTES,
+	event_value    BYTES,
+	event_row      JSONB,
+	mvcc_timestamp DECIMAL,
+	error          STRING NOT NULL,
+	PRIMARY KEY (job_id, dlq_timestamp, id) USING HASH
+)`
+	insertDLQStmt = `INSERT INTO %s (
+	job_id, topic, event_key, event_value, event_row, mvcc_timestamp, error
+) VALUES ($1, $2, $3, $4, $5, $6::DECIMAL, $7)`
+)
+
+// errRejectedBySink marks errors returned by a SinkClient when the sink
+// permanently rejected the messages it was sent, such that retrying them
+// cannot succeed.
+var errRejectedBySink = errors.New("rejected by sink")
+
+// markRejectedBySink marks err as a permanent rejection of the flushed
+// messages by the sink. Such messages are written to the dead letter queue
+// when on_error='dlq'.
+func markRejectedBySink(err error) error {
+	return errors.Mark(err, errRejectedBySink)
+}
+
+// rejectedMessagesError is returned by a SinkClient when the sink permanently
+// rejected only some of the messages of a flushed payload. The messages are
+// identified by the order in which they were appended to the payload's
+// BatchBuffer.
+type rejectedMessagesError struct {
+	cause    error
+	messages []int
+}
+
+func (e *rejectedMessagesError) Error() string { return e.cause.Error() }
+
+func (e *rejectedMessagesError) Cause() error { return e.cause }
+
+func (e *rejectedMessagesError) Unwrap() error { return e.cause }
+
+// markMessagesRejectedBySink is like markRejectedBySink, but only marks the
+// given messages of the flushed payload as rejected. The other messages were
+// delivered.
+func markMessagesRejectedBySink(err error, messages []int) error {
+	return markRejectedBySink(&rejectedMessagesError{cause: err, messages: messages})
+}
+
+// qualifyDLQTableName resolves the name given to the dlq_table option against
+// the given current database, so that the table a changefeed writes to does
+// not depend on the session it is resumed in.
+func qualifyDLQTableName(name string, currentDatabase string) (string, error) {
+	tn, err := parser.ParseQualifiedTableName(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid %s", changefeedbase.OptDLQTable)
+	}
+	if !tn.ExplicitCatalog {
+		if !tn.ExplicitSchema {
+			tn.SchemaName = catconstants.PublicSchemaName
+			tn.ExplicitSchema = true
+		}
+		if currentDatabase == "" {
+			return "", errors.Newf("%s %s must be qualified with a database name",
+				changefeedbase.OptDLQTable, tree.AsString(tn))
+		}
+		tn.CatalogName = tree.Name(currentDatabase)
+		tn.ExplicitCatalog = true
+	}
+	return tree.AsString(tn), nil
+}
+
+// dlqEvent is an event that could not be emitted by the changefeed.
+type dlqEvent struct {
+	topic string
+	// key and value are the encoded event, if encoding succeeded.
+	key, value []byte
+	// row is the decoded event, if available.
+	row  cdcevent.Row
+	mvcc hlc.Timestamp
+	err  error
+}
+
+// deadLetterQueue writes the events that a changefeed with on_error='dlq'
+// could not emit to the table named by the dlq_table option. Writes are
+// performed as the owner of the changefeed job.
+type deadLetterQueue struct {
+	db      isql.DB
+	table   string
+	jobID   jobspb.JobID
+	user    username.SQLUsername
+	metrics metricsRecorder
+}
+
+// makeDeadLetterQueue returns the dead letter queue of the changefeed, or nil
+// if it is not configured with on_error='dlq'.
+func makeDeadLetterQueue(
+	db isql.DB,
+	opts changefeedbase.StatementOptions,
+	jobID jobspb.JobID,
+	user username.SQLUsername,
+	metrics metricsRecorder,
+) (*deadLetterQueue, error) {
+	onError, err := opts.GetOnError()
+	if err != nil {
+		return nil, err
+	}
+	table, ok := opts.GetDLQTable()
+	if onError != changefeedbase.OptOnErrorDLQ || !ok {
+		return nil, nil
+	}
+	// The name was qualified when the changefeed was created. Parse it again so
+	// that only a well-formed name is interpolated into statements.
+	tn, err := parser.ParseQualifiedTableName(table)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", changefeedbase.OptDLQTable)
+	}
+	return &deadLetterQueue{
+		db:      db,
+		table:   tree.AsString(tn),
+		jobID:   jobID,
+		user:    user,
+		metrics: metrics,
+	}, nil
+}
+
+// createTable creates the dead letter queue table if it does not exist.
+func (d *deadLetterQueue) createTable(ctx context.Context) error {
+	if _, err := d.db.Executor().ExecEx(ctx, "changefeed-create-dlq-table", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: d.user},
+		fmt.Sprintf(createDLQTableStmt, d.table),
+	); err != nil {
+		return errors.Wrapf(err, "failed to create %s %s", changefeedbase.OptDLQTable, d.table)
+	}
+	return nil
+}
+
+// write writes the given event to the dead letter queue table.
+func (d *deadLetterQueue) write(ctx context.Context, ev dlqEvent) error {
+	var row tree.Datum = tree.DNull
+	if ev.row.IsInitialized() {
+		if j, err := ev.row.ToJSON(); err != nil {
+			log.Warningf(ctx, "failed to convert row to json for %s: %v", changefeedbase.OptDLQTable, err)
+		} else {
+			row = j
+		}
+	}
+	var topic interface{}
+	if ev.topic != "" {
+		topic = ev.topic
+	}
+	var mvcc interface{}
+	if !ev.mvcc.IsEmpty() {
+		mvcc = ev.mvcc.AsOfSystemTime()
+	}
+
+	if _, err := d.db.Executor().ExecEx(ctx, "changefeed-write-dlq", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: d.user},
+		fmt.Sprintf(insertDLQStmt, d.table),
+		d.jobID, topic, ev.key, ev.value, row, mvcc, ev.err.Error(),
+	); err != nil {
+		return errors.Wrapf(err, "failed to write to %s %s (original error: %v)",
+			changefeedbase.OptDLQTable, d.table, ev.err)
+	}
+	if d.metrics != nil {
+		d.metrics.recordDLQWrite()
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualifyDLQTableName(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		name, db string
+		expected string
+		err      string
+	}{
+		{name: `dlq`, db: `d`, expected: `d.public.dlq`},
+		{name: `s.dlq`, db: `d`, expected: `d.s.dlq`},
+		{name: `other.s.dlq`, db: `d`, expected: `other.s.dlq`},
+		{name: `"Weird Name"`, db: `d`, expected: `d.public."Weird Name"`},
+		{name: `other.s.dlq`, db: ``, expected: `other.s.dlq`},
+		{name: `dlq`, db: ``, err: `dlq_table public.dlq must be qualified with a database name`},
+		{name: `a.b.c.d`, db: `d`, err: `invalid dlq_table`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			qualified, err := qualifyDLQTableName(tc.name, tc.db)
+			if tc.err != `` {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, qualified)
+		})
+	}
+}
+
+// rejectingSinkClient is a SinkClient that permanently rejects the messages
+// at the given positions of every batch, or every message if none are given.
+type rejectingSinkClient struct {
+	messages []int
+}
+
+var _ SinkClient = rejectingSinkClient{}
+
+type rejectingBatchBuffer struct{}
+
+func (rejectingBatchBuffer) Append([]byte, []byte, attributes) {}
+func (rejectingBatchBuffer) ShouldFlush() bool                 { return false }
+func (rejectingBatchBuffer) Close() (SinkPayload, error)       { return nil, nil }
+
+func (rejectingSinkClient) MakeBatchBuffer(string) BatchBuffer { return rejectingBatchBuffer{} }
+func (rejectingSinkClient) FlushResolvedPayload(
+	context.Context, []byte, func(func(topic string) error) error, retry.Options,
+) error {
+	return nil
+}
+func (c rejectingSinkClient) Flush(context.Context, SinkPayload) error {
+	err := errors.New("message too large")
+	if c.messages != nil {
+		return markMessagesRejectedBySink(err, c.messages)
+	}
+	return markRejectedBySink(err)
+}
+func (rejectingSinkClient) Close() error                          { return nil }
+func (rejectingSinkClient) CheckConnection(context.Context) error { return nil }
+
+func TestDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+
+	opts := changefeedbase.MakeStatementOptions(map[string]string{
+		changefeedbase.OptOnError:  string(changefeedbase.OptOnErrorDLQ),
+		changefeedbase.OptDLQTable: `d.public.dlq`,
+	})
+	const jobID = jobspb.JobID(42)
+	dlq, err := makeDeadLetterQueue(s.InternalDB().(isql.DB), opts, jobID,
+		username.RootUserName(), nil /* metrics */)
+	require.NoError(t, err)
+	require.NotNil(t, dlq)
+	require.NoError(t, dlq.createTable(ctx))
+	// Creating the table is idempotent since it happens whenever the changefeed
+	// is resumed.
+	require.NoError(t, dlq.createTable(ctx))
+
+	t.Run("no dlq", func(t *testing.T) {
+		noDLQ, err := makeDeadLetterQueue(s.InternalDB().(isql.DB),
+			changefeedbase.MakeDefaultOptions(), jobID, username.RootUserName(), nil /* metrics */)
+		require.NoError(t, err)
+		require.Nil(t, noDLQ)
+	})
+
+	t.Run("encoding failure", func(t *testing.T) {
+		tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		require.NoError(t, err)
+		row := cdcevent.TestingMakeEventRow(tableDesc, 0, rowenc.EncDatumRow{
+			rowenc.EncDatum{Datum: tree.NewDInt(1)},
+			rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+		}, false)
+		require.NoError(t, dlq.write(ctx, dlqEvent{
+			key:  []byte(`[1]`),
+			row:  row,
+			mvcc: hlc.Timestamp{WallTime: 1, Logical: 2},
+			err:  errors.New("value could not be encoded"),
+		}))
+		sqlDB.CheckQueryResults(t,
+			`SELECT job_id, topic, event_key, event_value, event_row, mvcc_timestamp, error FROM d.dlq`,
+			[][]string{{`42`, `NULL`, `[1]`, `NULL`, `{"a": 1, "b": "bar"}`, `1.0000000002`,
+				`value could not be encoded`}},
+		)
+	})
+
+	t.Run("rejected by sink", func(t *testing.T) {
+		sqlDB.Exec(t, `DELETE FROM d.dlq WHERE true`)
+		sink := makeBatchingSink(ctx, sinkTypeWebhook, rejectingSinkClient{}, 0, /* minFlushFrequency */
+			retry.Options{MaxRetries: 1}, 1 /* numWorkers */, nil /* topicNamer */, nilPacerFactory,
+			timeutil.DefaultTimeSource{}, nilMetricsRecorderBuilder(false), cluster.MakeTestingClusterSettings(),
+		).(*batchingSink)
+		sink.setDeadLetterQueue(dlq)
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		for _, k := range []string{`k1`, `k2`} {
+			require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(k), []byte(`v`), zeroTS, zeroTS, zeroAlloc, nil))
+		}
+		// The rejected messages are written to the dead letter queue rather than
+		// failing the flush.
+		require.NoError(t, sink.Flush(ctx))
+		sqlDB.CheckQueryResults(t,
+			`SELECT job_id, event_key, event_value, event_row, error FROM d.dlq ORDER BY event_key`,
+			[][]string{
+				{`42`, `k1`, `v`, `NULL`, `message too large`},
+				{`42`, `k2`, `v`, `NULL`, `message too large`},
+			},
+		)
+	})
+
+	t.Run("some messages rejected by sink", func(t *testing.T) {
+		sqlDB.Exec(t, `DELETE FROM d.dlq WHERE true`)
+		client := rejectingSinkClient{messages: []int{1}}
+		sink := makeBatchingSink(ctx, sinkTypeWebhook, client, 0, /* minFlushFrequency */
+			retry.Options{MaxRetries: 1}, 1 /* numWorkers */, nil /* topicNamer */, nilPacerFactory,
+			timeutil.DefaultTimeSource{}, nilMetricsRecorderBuilder(false), cluster.MakeTestingClusterSettings(),
+		).(*batchingSink)
+		sink.setDeadLetterQueue(dlq)
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		for _, k := range []string{`k1`, `k2`, `k3`} {
+			require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(k), []byte(`v`), zeroTS, zeroTS, zeroAlloc, nil))
+		}
+		// Only the rejected message is written to the dead letter queue, since
+		// the others were delivered.
+		require.NoError(t, sink.Flush(ctx))
+		sqlDB.CheckQueryResults(t,
+			`SELECT job_id, event_key, event_value, event_row, error FROM d.dlq ORDER BY event_key`,
+			[][]string{{`42`, `k2`, `v`, `NULL`, `message too large`}},
+		)
+	})
+}
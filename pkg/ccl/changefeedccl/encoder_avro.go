@@ -350,5 +350,5 @@ func (e *confluentAvroEncoder) EncodeResolvedTimestamp(
 func (e *confluentAvroEncoder) register(
 	ctx context.Context, schema *avro.Record, subject string,
 ) (int32, error) {
-	return e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, schema.Schema())
+	return e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, schema.Schema(), confluentSchemaTypeAvro)
 }
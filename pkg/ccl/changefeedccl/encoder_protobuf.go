@@ -0,0 +1,549 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the envelope message. They are fixed regardless of which
+// envelope fields are enabled so that enabling an option on a changefeed
+// produces a schema that is compatible with the previous one.
+const (
+	protobufAfterFieldNum         protowire.Number = 1
+	protobufBeforeFieldNum        protowire.Number = 2
+	protobufUpdatedFieldNum       protowire.Number = 3
+	protobufMVCCTimestampFieldNum protowire.Number = 4
+	protobufRecordFieldNum        protowire.Number = 5
+	protobufResolvedFieldNum      protowire.Number = 6
+)
+
+// confluentProtobufEncoder encodes changefeed entries as Protobuf messages in
+// the Confluent wire format. Keys are the primary key columns in a message.
+// Values are an envelope message that embeds a message with all columns of a
+// row.
+//
+// Each message schema is derived from the columns of the row and registered
+// in the Confluent schema registry as a .proto file. Column fields are
+// numbered by column ID rather than by position, so adding or dropping a
+// column produces a schema that is backward compatible with the previous
+// version of the table.
+type confluentProtobufEncoder struct {
+	schemaRegistry                      schemaRegistry
+	updatedField, beforeField           bool
+	mvccTimestampField                  bool
+	targets                             changefeedbase.Targets
+	envelopeType                        changefeedbase.EnvelopeType
+	customKeyColumn                     string
+	keyCache                            *cache.UnorderedCache // [tableIDAndVersion]confluentRegisteredProtobufSchema
+	valueCache                          *cache.UnorderedCache // [tableIDAndVersionPair]confluentRegisteredProtobufSchema
+	resolvedCache                       map[string]confluentRegisteredProtobufSchema
+	keyBuf, valueBuf, beforeBuf, rowBuf []byte
+}
+
+var _ Encoder = &confluentProtobufEncoder{}
+
+type confluentRegisteredProtobufSchema struct {
+	// row is the message for the key, or for the row data embedded in the
+	// value envelope.
+	row *protobufMessage
+	// before is the message for the previous row data embedded in the value
+	// envelope, if the diff option is set and the previous row was encoded
+	// with a different version of the table. Otherwise, the previous row uses
+	// the same message as the updated row.
+	before *protobufMessage
+	// header is the Confluent wire format header, which includes the schema ID
+	// returned by the registry.
+	header []byte
+}
+
+// beforeMessage returns the message for the previous row data.
+func (s confluentRegisteredProtobufSchema) beforeMessage() *protobufMessage {
+	if s.before != nil {
+		return s.before
+	}
+	return s.row
+}
+
+func newConfluentProtobufEncoder(
+	opts changefeedbase.EncodingOptions,
+	targets changefeedbase.Targets,
+	p externalConnectionProvider,
+	sliMetrics *sliMetrics,
+) (*confluentProtobufEncoder, error) {
+	e := &confluentProtobufEncoder{
+		targets:            targets,
+		envelopeType:       opts.Envelope,
+		updatedField:       opts.UpdatedTimestamps,
+		beforeField:        opts.Diff,
+		mvccTimestampField: opts.MVCCTimestamps,
+		customKeyColumn:    opts.CustomKeyColumn,
+	}
+
+	for _, unsupported := range []struct {
+		opt string
+		set bool
+	}{
+		{changefeedbase.OptKeyInValue, opts.KeyInValue},
+		{changefeedbase.OptTopicInValue, opts.TopicInValue},
+		{changefeedbase.OptAvroSchemaPrefix, opts.AvroSchemaPrefix != ``},
+	} {
+		if unsupported.set {
+			return nil, errors.Errorf(`%s is not supported with %s=%s`,
+				unsupported.opt, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+		}
+	}
+	if len(opts.SchemaRegistryURI) == 0 {
+		return nil, errors.Errorf(`WITH option %s is required for %s=%s`,
+			changefeedbase.OptConfluentSchemaRegistry, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+
+	reg, err := newConfluentSchemaRegistry(opts.SchemaRegistryURI, p, sliMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	e.schemaRegistry = reg
+	e.keyCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.valueCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.resolvedCache = make(map[string]confluentRegisteredProtobufSchema)
+	return e, nil
+}
+
+// EncodeKey implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeKey(
+	ctx context.Context, row cdcevent.Row,
+) ([]byte, error) {
+	it := row.ForEachKeyColumn()
+	if e.customKeyColumn != "" {
+		var err error
+		if it, err = row.DatumNamed(e.customKeyColumn); err != nil {
+			return nil, err
+		}
+	}
+
+	// No familyID in the cache key for keys because it's the same schema for all families
+	cacheKey := tableIDAndVersion{tableID: row.TableID, version: row.Version}
+
+	var registered confluentRegisteredProtobufSchema
+	if v, ok := e.keyCache.Get(cacheKey); ok {
+		registered = v.(confluentRegisteredProtobufSchema)
+	} else {
+		tableName, err := getTableName(e.targets, "" /* schemaPrefix */, row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		registered.row, err = newProtobufMessage(it, changefeedbase.SQLNameToAvroName(tableName))
+		if err != nil {
+			return nil, err
+		}
+		file := newProtobufFile(registered.row)
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := changefeedbase.SQLNameToKafkaName(tableName) + confluentSubjectSuffixKey
+		if registered.header, err = e.register(ctx, file, subject); err != nil {
+			return nil, err
+		}
+		e.keyCache.Add(cacheKey, registered)
+	}
+
+	var err error
+	e.keyBuf = append(e.keyBuf[:0], registered.header...)
+	e.keyBuf, err = registered.row.appendRow(e.keyBuf, it)
+	return e.keyBuf, err
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeValue(
+	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
+) ([]byte, error) {
+	if e.envelopeType == changefeedbase.OptEnvelopeKeyOnly {
+		return nil, nil
+	}
+
+	var cacheKey tableIDAndVersionPair
+	if e.beforeField && prevRow.IsInitialized() {
+		cacheKey[0] = tableIDAndVersion{
+			tableID: prevRow.TableID, version: prevRow.Version, familyID: prevRow.FamilyID,
+		}
+	}
+	cacheKey[1] = tableIDAndVersion{
+		tableID: updatedRow.TableID, version: updatedRow.Version, familyID: updatedRow.FamilyID,
+	}
+
+	var registered confluentRegisteredProtobufSchema
+	if v, ok := e.valueCache.Get(cacheKey); ok {
+		registered = v.(confluentRegisteredProtobufSchema)
+	} else {
+		name, err := getTableName(e.targets, "" /* schemaPrefix */, updatedRow.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		messageName := changefeedbase.SQLNameToAvroName(name)
+		registered.row, err = newProtobufMessage(updatedRow.ForEachColumn(), messageName)
+		if err != nil {
+			return nil, err
+		}
+		if e.beforeField && prevRow.IsInitialized() && prevRow.Version != updatedRow.Version {
+			registered.before, err = newProtobufMessage(prevRow.ForEachColumn(), messageName+`_before`)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		envelope := &protobufMessage{name: messageName + `_envelope`}
+		switch e.envelopeType {
+		case changefeedbase.OptEnvelopeWrapped:
+			envelope.addMessageField(`after`, protobufAfterFieldNum, registered.row)
+			if e.beforeField {
+				envelope.addMessageField(`before`, protobufBeforeFieldNum, registered.beforeMessage())
+			}
+			if e.updatedField {
+				envelope.addStringField(`updated`, protobufUpdatedFieldNum)
+			}
+			if e.mvccTimestampField {
+				envelope.addStringField(`mvcc_timestamp`, protobufMVCCTimestampFieldNum)
+			}
+		case changefeedbase.OptEnvelopeBare:
+			envelope.addMessageField(`record`, protobufRecordFieldNum, registered.row)
+		// key_only handled above, and row is not supported in protobuf
+		default:
+			return nil, errors.AssertionFailedf(`unknown envelope type: %s`, e.envelopeType)
+		}
+
+		messages := []*protobufMessage{envelope, registered.row}
+		if registered.before != nil {
+			messages = append(messages, registered.before)
+		}
+		file := newProtobufFile(messages...)
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := changefeedbase.SQLNameToKafkaName(name) + confluentSubjectSuffixValue
+		if registered.header, err = e.register(ctx, file, subject); err != nil {
+			return nil, err
+		}
+		e.valueCache.Add(cacheKey, registered)
+	}
+
+	var err error
+	e.valueBuf = append(e.valueBuf[:0], registered.header...)
+	if e.envelopeType == changefeedbase.OptEnvelopeBare {
+		if !updatedRow.IsDeleted() {
+			e.rowBuf, err = registered.row.appendRow(e.rowBuf[:0], updatedRow.ForEachColumn())
+			if err != nil {
+				return nil, err
+			}
+			e.valueBuf = protowire.AppendTag(e.valueBuf, protobufRecordFieldNum, protowire.BytesType)
+			e.valueBuf = protowire.AppendBytes(e.valueBuf, e.rowBuf)
+		}
+		return e.valueBuf, nil
+	}
+
+	if !updatedRow.IsDeleted() {
+		e.rowBuf, err = registered.row.appendRow(e.rowBuf[:0], updatedRow.ForEachColumn())
+		if err != nil {
+			return nil, err
+		}
+		e.valueBuf = protowire.AppendTag(e.valueBuf, protobufAfterFieldNum, protowire.BytesType)
+		e.valueBuf = protowire.AppendBytes(e.valueBuf, e.rowBuf)
+	}
+	if e.beforeField && prevRow.IsInitialized() && !prevRow.IsDeleted() {
+		e.beforeBuf, err = registered.beforeMessage().appendRow(e.beforeBuf[:0], prevRow.ForEachColumn())
+		if err != nil {
+			return nil, err
+		}
+		e.valueBuf = protowire.AppendTag(e.valueBuf, protobufBeforeFieldNum, protowire.BytesType)
+		e.valueBuf = protowire.AppendBytes(e.valueBuf, e.beforeBuf)
+	}
+	if e.updatedField {
+		e.valueBuf = appendProtobufString(e.valueBuf, protobufUpdatedFieldNum, evCtx.updated.AsOfSystemTime())
+	}
+	if e.mvccTimestampField {
+		e.valueBuf = appendProtobufString(e.valueBuf, protobufMVCCTimestampFieldNum, evCtx.mvcc.AsOfSystemTime())
+	}
+	return e.valueBuf, nil
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeResolvedTimestamp(
+	ctx context.Context, topic string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	registered, ok := e.resolvedCache[topic]
+	if !ok {
+		envelope := &protobufMessage{name: changefeedbase.SQLNameToAvroName(topic) + `_envelope`}
+		envelope.addStringField(`resolved`, protobufResolvedFieldNum)
+		file := newProtobufFile(envelope)
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := changefeedbase.SQLNameToKafkaName(topic) + confluentSubjectSuffixValue
+		var err error
+		if registered.header, err = e.register(ctx, file, subject); err != nil {
+			return nil, err
+		}
+		e.resolvedCache[topic] = registered
+	}
+	e.valueBuf = append(e.valueBuf[:0], registered.header...)
+	e.valueBuf = appendProtobufString(e.valueBuf, protobufResolvedFieldNum, resolved.AsOfSystemTime())
+	return e.valueBuf, nil
+}
+
+// register registers the given .proto file for the given subject and returns
+// the Confluent wire format header for messages of the first message type in
+// the file.
+func (e *confluentProtobufEncoder) register(
+	ctx context.Context, file string, subject string,
+) ([]byte, error) {
+	id, err := e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, file, confluentSchemaTypeProtobuf)
+	if err != nil {
+		return nil, err
+	}
+	// https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+	//
+	// The header is followed by the indexes of the message type within the
+	// file. The message is always the first one in the file, whose index path
+	// [0] is encoded as a single zero byte.
+	header := []byte{
+		changefeedbase.ConfluentAvroWireFormatMagic,
+		0, 0, 0, 0, // Placeholder for the ID.
+		0, // Message indexes.
+	}
+	binary.BigEndian.PutUint32(header[1:5], uint32(id))
+	return header, nil
+}
+
+// protobufMessage is a protobuf message type derived from the columns of a
+// row, or a changefeed envelope.
+type protobufMessage struct {
+	name   string
+	fields []protobufField
+	// fieldByOrdinal maps the ordinal of a column to the index of its field.
+	fieldByOrdinal map[int]int
+}
+
+// protobufField is a field of a protobufMessage.
+type protobufField struct {
+	name     string
+	num      protowire.Number
+	typ      string
+	repeated bool
+	// encode appends the tag and value of the field for the given non-NULL
+	// datum. It is nil for envelope fields.
+	encode func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error)
+}
+
+// newProtobufMessage returns a message with a field for each column of the
+// given iterator.
+//
+// Fields are numbered by column ID so that the numbers of existing fields do
+// not change when columns are added or dropped. If any column lacks an ID, as
+// is the case for the columns of a CDC query projection, the fields are
+// numbered by position instead.
+func newProtobufMessage(it cdcevent.Iterator, name string) (*protobufMessage, error) {
+	msg := &protobufMessage{name: name, fieldByOrdinal: make(map[int]int)}
+	byPosition := false
+	if err := it.Col(func(col cdcevent.ResultColumn) error {
+		if col.PGAttributeNum == 0 {
+			byPosition = true
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := it.Col(func(col cdcevent.ResultColumn) error {
+		num := protowire.Number(col.PGAttributeNum)
+		if byPosition {
+			num = protowire.Number(len(msg.fields) + 1)
+		}
+		if !num.IsValid() {
+			return errors.Errorf(`column %s has ID %d, which is not a valid protobuf field number`,
+				col.Name, col.PGAttributeNum)
+		}
+		field, err := columnToProtobufField(col, num)
+		if err != nil {
+			return err
+		}
+		msg.fieldByOrdinal[col.Ordinal()] = len(msg.fields)
+		msg.fields = append(msg.fields, field)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// addMessageField adds a field to an envelope message that embeds the given
+// message.
+func (m *protobufMessage) addMessageField(name string, num protowire.Number, typ *protobufMessage) {
+	m.fields = append(m.fields, protobufField{name: name, num: num, typ: typ.name})
+}
+
+// addStringField adds a string field to an envelope message.
+func (m *protobufMessage) addStringField(name string, num protowire.Number) {
+	m.fields = append(m.fields, protobufField{name: name, num: num, typ: `string`})
+}
+
+// appendRow appends the encoding of the datums of the given iterator to buf.
+// NULL datums are omitted, which consumers observe as unset optional fields.
+func (m *protobufMessage) appendRow(buf []byte, it cdcevent.Iterator) ([]byte, error) {
+	err := it.Datum(func(d tree.Datum, col cdcevent.ResultColumn) (err error) {
+		idx, ok := m.fieldByOrdinal[col.Ordinal()]
+		if !ok {
+			return errors.AssertionFailedf(`column %s is not part of message %s`, col.Name, m.name)
+		}
+		if d == tree.DNull {
+			return nil
+		}
+		field := &m.fields[idx]
+		buf, err = field.encode(buf, field.num, d)
+		return err
+	})
+	return buf, err
+}
+
+// format writes the definition of the message in the proto3 language.
+func (m *protobufMessage) format(sb *strings.Builder) {
+	fmt.Fprintf(sb, "message %s {\n", m.name)
+	for _, f := range m.fields {
+		label := `optional `
+		if f.repeated {
+			label = `repeated `
+		} else if f.encode == nil {
+			// Envelope fields are implicitly optional, either because they are
+			// messages or because they are always set.
+			label = ``
+		}
+		fmt.Fprintf(sb, "  %s%s %s = %d;\n", label, f.typ, f.name, f.num)
+	}
+	sb.WriteString("}\n")
+}
+
+// newProtobufFile returns the contents of a .proto file that defines the given
+// messages. The first message is the one that is serialized in changefeed
+// messages.
+func newProtobufFile(messages ...*protobufMessage) string {
+	var sb strings.Builder
+	sb.WriteString("syntax = \"proto3\";\n")
+	for _, m := range messages {
+		sb.WriteString("\n")
+		m.format(&sb)
+	}
+	return sb.String()
+}
+
+// columnToProtobufField returns the field of a row message for the given
+// column. Types without a natural protobuf representation are encoded as
+// strings in their SQL text format.
+func columnToProtobufField(
+	col cdcevent.ResultColumn, num protowire.Number,
+) (protobufField, error) {
+	field := protobufField{name: changefeedbase.SQLNameToAvroName(col.Name)}
+	typ := col.Typ
+	if typ.Family() == types.ArrayFamily {
+		typ = typ.ArrayContents()
+		if typ.Family() == types.ArrayFamily {
+			return protobufField{}, changefeedbase.WithTerminalError(
+				errors.Errorf(`column %s: nested arrays are not supported with %s=%s`,
+					col.Name, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf))
+		}
+		field.repeated = true
+	}
+	var encodeElem func(buf []byte, num protowire.Number, d tree.Datum) []byte
+	field.typ, encodeElem = protobufScalarForType(typ)
+	field.num = num
+	if !field.repeated {
+		field.encode = func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+			return encodeElem(buf, num, d), nil
+		}
+		return field, nil
+	}
+	field.encode = func(buf []byte, num protowire.Number, d tree.Datum) ([]byte, error) {
+		arr, ok := tree.AsDArray(d)
+		if !ok {
+			return nil, errors.AssertionFailedf(`expected array datum for column %s, got %T`, col.Name, d)
+		}
+		for _, elem := range arr.Array {
+			if elem == tree.DNull {
+				return nil, errors.Errorf(
+					`column %s: NULL array elements are not supported with %s=%s`,
+					col.Name, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+			}
+			buf = encodeElem(buf, num, elem)
+		}
+		return buf, nil
+	}
+	return field, nil
+}
+
+// protobufScalarForType returns the name of the protobuf scalar type that
+// represents the given SQL type, along with a function that appends the tag
+// and value of a non-NULL datum of that type.
+func protobufScalarForType(
+	typ *types.T,
+) (string, func(buf []byte, num protowire.Number, d tree.Datum) []byte) {
+	switch typ.Family() {
+	case types.BoolFamily:
+		return `bool`, func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+			buf = protowire.AppendTag(buf, num, protowire.VarintType)
+			return protowire.AppendVarint(buf, protowire.EncodeBool(bool(tree.MustBeDBool(d))))
+		}
+	case types.IntFamily:
+		name := `int64`
+		if typ.Width() == 16 || typ.Width() == 32 {
+			name = `int32`
+		}
+		return name, func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+			buf = protowire.AppendTag(buf, num, protowire.VarintType)
+			return protowire.AppendVarint(buf, uint64(tree.MustBeDInt(d)))
+		}
+	case types.FloatFamily:
+		if typ.Width() == 32 {
+			return `float`, func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+				buf = protowire.AppendTag(buf, num, protowire.Fixed32Type)
+				return protowire.AppendFixed32(buf, math.Float32bits(float32(*d.(*tree.DFloat))))
+			}
+		}
+		return `double`, func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+			buf = protowire.AppendTag(buf, num, protowire.Fixed64Type)
+			return protowire.AppendFixed64(buf, math.Float64bits(float64(*d.(*tree.DFloat))))
+		}
+	case types.BytesFamily:
+		return `bytes`, func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+			buf = protowire.AppendTag(buf, num, protowire.BytesType)
+			return protowire.AppendString(buf, string(tree.MustBeDBytes(d)))
+		}
+	case types.StringFamily:
+		return `string`, func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+			return appendProtobufString(buf, num, string(tree.MustBeDString(d)))
+		}
+	case types.CollatedStringFamily:
+		return `string`, func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+			return appendProtobufString(buf, num, d.(*tree.DCollatedString).Contents)
+		}
+	default:
+		return `string`, func(buf []byte, num protowire.Number, d tree.Datum) []byte {
+			return appendProtobufString(buf, num, tree.AsStringWithFlags(d, tree.FmtBareStrings))
+		}
+	}
+}
+
+// appendProtobufString appends the tag and value of a string field.
+func appendProtobufString(buf []byte, num protowire.Number, s string) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendString(buf, s)
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protobufFields maps the field numbers of a decoded protobuf message to the
+// raw values of its fields, in the order they were encoded.
+type protobufFields map[protowire.Number][]interface{}
+
+// decodeProtobufMessage decodes a message in the Confluent wire format,
+// checking that it refers to a schema known to the registry.
+func decodeProtobufMessage(
+	t *testing.T, reg *cdctest.SchemaRegistry, b []byte,
+) protobufFields {
+	t.Helper()
+	require.GreaterOrEqual(t, len(b), 6)
+	require.Equal(t, changefeedbase.ConfluentAvroWireFormatMagic, b[0])
+	require.Less(t, int(binary.BigEndian.Uint32(b[1:5])), reg.RegistrationCount())
+	// The message is always the first one in the registered file.
+	require.Equal(t, byte(0), b[5])
+	return decodeProtobufFields(t, b[6:])
+}
+
+func decodeProtobufFields(t *testing.T, b []byte) protobufFields {
+	t.Helper()
+	fields := make(protobufFields)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		var v interface{}
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		fields[num] = append(fields[num], v)
+	}
+	return fields
+}
+
+func TestProtobufEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tableDesc, err := parseTableDesc(
+		`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c FLOAT, d BOOL, e INT[])`)
+	require.NoError(t, err)
+	arr := tree.NewDArray(types.Int)
+	require.NoError(t, arr.Append(tree.NewDInt(1)))
+	require.NoError(t, arr.Append(tree.NewDInt(-2)))
+	row := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+		rowenc.EncDatum{Datum: tree.DNull},
+		rowenc.EncDatum{Datum: tree.DBoolTrue},
+		rowenc.EncDatum{Datum: arr},
+	}
+	ts := hlc.Timestamp{WallTime: 1, Logical: 2}
+
+	reg := cdctest.StartTestSchemaRegistry()
+	defer reg.Close()
+
+	opts := changefeedbase.EncodingOptions{
+		Format:            changefeedbase.OptFormatProtobuf,
+		Envelope:          changefeedbase.OptEnvelopeWrapped,
+		Diff:              true,
+		UpdatedTimestamps: true,
+		SchemaRegistryURI: reg.URL(),
+	}
+	targets := changefeedbase.Targets{}
+	targets.Add(changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		TableID:           tableDesc.GetID(),
+		StatementTimeName: changefeedbase.StatementTimeName(tableDesc.GetName()),
+	})
+	e, err := getEncoder(ctx, opts, targets, false, nil, nil, getTestingEnrichedSourceProvider(t, opts))
+	require.NoError(t, err)
+
+	t.Run("key", func(t *testing.T) {
+		rowInsert := cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
+		key, err := e.EncodeKey(ctx, rowInsert)
+		require.NoError(t, err)
+		require.Equal(t, `syntax = "proto3";
+
+message foo {
+  optional int64 a = 1;
+}
+`, reg.SchemaForSubject(`foo-key`))
+		fields := decodeProtobufMessage(t, reg, key)
+		require.Equal(t, protobufFields{1: {uint64(1)}}, fields)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		rowInsert := cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
+		var prevRow cdcevent.Row
+		evCtx := eventContext{updated: ts}
+		value, err := e.EncodeValue(ctx, evCtx, rowInsert, prevRow)
+		require.NoError(t, err)
+		require.Equal(t, `syntax = "proto3";
+
+message foo_envelope {
+  foo after = 1;
+  foo before = 2;
+  string updated = 3;
+}
+
+message foo {
+  optional int64 a = 1;
+  optional string b = 2;
+  optional double c = 3;
+  optional bool d = 4;
+  repeated int64 e = 5;
+}
+`, reg.SchemaForSubject(`foo-value`))
+
+		envelope := decodeProtobufMessage(t, reg, value)
+		require.Len(t, envelope[protobufAfterFieldNum], 1)
+		require.Empty(t, envelope[protobufBeforeFieldNum])
+		require.Equal(t, []interface{}{[]byte(`1.0000000002`)}, envelope[protobufUpdatedFieldNum])
+		after := decodeProtobufFields(t, envelope[protobufAfterFieldNum][0].([]byte))
+		require.Equal(t, protobufFields{
+			1: {uint64(1)},
+			2: {[]byte(`bar`)},
+			// The NULL value of c is omitted.
+			4: {uint64(1)},
+			5: {uint64(1), uint64(math.MaxUint64 - 1)},
+		}, after)
+
+		// Deletes only have the before field.
+		rowDelete := cdcevent.TestingMakeEventRow(tableDesc, 0, row, true)
+		prevRow = cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
+		value, err = e.EncodeValue(ctx, evCtx, rowDelete, prevRow)
+		require.NoError(t, err)
+		envelope = decodeProtobufMessage(t, reg, value)
+		require.Empty(t, envelope[protobufAfterFieldNum])
+		require.Len(t, envelope[protobufBeforeFieldNum], 1)
+		before := decodeProtobufFields(t, envelope[protobufBeforeFieldNum][0].([]byte))
+		require.Equal(t, after, before)
+	})
+
+	t.Run("resolved", func(t *testing.T) {
+		resolved, err := e.EncodeResolvedTimestamp(ctx, `foo`, ts)
+		require.NoError(t, err)
+		fields := decodeProtobufMessage(t, reg, resolved)
+		require.Equal(t, protobufFields{
+			protobufResolvedFieldNum: {[]byte(`1.0000000002`)},
+		}, fields)
+	})
+
+	t.Run("schema evolution", func(t *testing.T) {
+		// Adding a column registers a new schema in which the existing columns
+		// keep their field numbers.
+		newDesc, err := parseTableDesc(
+			`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c FLOAT, d BOOL, e INT[], f INT2)`)
+		require.NoError(t, err)
+		newDesc.(*tabledesc.Mutable).Version = tableDesc.GetVersion() + 1
+		newRow := append(append(rowenc.EncDatumRow(nil), row...),
+			rowenc.EncDatum{Datum: tree.NewDInt(7)})
+
+		value, err := e.EncodeValue(ctx, eventContext{updated: ts},
+			cdcevent.TestingMakeEventRow(catalog.TableDescriptor(newDesc), 0, newRow, false),
+			cdcevent.TestingMakeEventRow(tableDesc, 0, row, false))
+		require.NoError(t, err)
+		require.Equal(t, `syntax = "proto3";
+
+message foo_envelope {
+  foo after = 1;
+  foo_before before = 2;
+  string updated = 3;
+}
+
+message foo {
+  optional int64 a = 1;
+  optional string b = 2;
+  optional double c = 3;
+  optional bool d = 4;
+  repeated int64 e = 5;
+  optional int32 f = 6;
+}
+
+message foo_before {
+  optional int64 a = 1;
+  optional string b = 2;
+  optional double c = 3;
+  optional bool d = 4;
+  repeated int64 e = 5;
+}
+`, reg.SchemaForSubject(`foo-value`))
+		envelope := decodeProtobufMessage(t, reg, value)
+		after := decodeProtobufFields(t, envelope[protobufAfterFieldNum][0].([]byte))
+		require.Equal(t, []interface{}{uint64(7)}, after[6])
+		before := decodeProtobufFields(t, envelope[protobufBeforeFieldNum][0].([]byte))
+		require.Empty(t, before[6])
+	})
+}
+
+func TestProtobufEncoderOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		opts changefeedbase.EncodingOptions
+		err  string
+	}{
+		{
+			opts: changefeedbase.EncodingOptions{},
+			err:  `WITH option confluent_schema_registry is required for format=protobuf`,
+		},
+		{
+			opts: changefeedbase.EncodingOptions{KeyInValue: true},
+			err:  `key_in_value is not supported with format=protobuf`,
+		},
+		{
+			opts: changefeedbase.EncodingOptions{AvroSchemaPrefix: `crdb_`},
+			err:  `avro_schema_prefix is not supported with format=protobuf`,
+		},
+	} {
+		tc.opts.Format = changefeedbase.OptFormatProtobuf
+		tc.opts.Envelope = changefeedbase.OptEnvelopeWrapped
+		_, err := getEncoder(context.Background(), tc.opts, changefeedbase.Targets{},
+			false, nil, nil, getTestingEnrichedSourceProvider(t, tc.opts))
+		require.EqualError(t, err, tc.err)
+	}
+
+	err := changefeedbase.EncodingOptions{
+		Format:   changefeedbase.OptFormatProtobuf,
+		Envelope: changefeedbase.OptEnvelopeRow,
+	}.Validate()
+	require.EqualError(t, err, `envelope=row is not supported with format=protobuf`)
+}
@@ -81,6 +81,13 @@ type kvEventToRowConsumer struct {
 	//
 	// The pacer is closed by kvEventToRowConsumer.Close.
 	pacer *admission.Pacer
+
+	// dlq, if set, receives the events that could not be encoded.
+	dlq *deadLetterQueue
+
+	// txnRows, if set, counts the emitted rows by commit timestamp for the
+	// transaction_boundaries option.
+	txnRows *txnRowCounter
 }
 
 func newEventConsumer(
@@ -94,6 +101,7 @@ func newEventConsumer(
 	metrics *Metrics,
 	sliMetrics *sliMetrics,
 	knobs TestingKnobs,
+	txnRows *txnRowCounter,
 ) (eventConsumer, EventSink, error) {
 	encodingOpts, err := feed.Opts.GetEncodingOptions()
 	if err != nil {
@@ -157,9 +165,14 @@ func newEventConsumer(
 			)
 		}
 
+		dlq, err := makeDeadLetterQueue(cfg.DB, feed.Opts, spec.JobID, spec.User(), sliMetrics)
+		if err != nil {
+			return nil, err
+		}
+
 		execCfg := cfg.ExecutorConfig.(*sql.ExecutorConfig)
 		return newKVEventToRowConsumer(ctx, execCfg, frontier, cursor, s,
-			encoder, feed, spec, knobs, topicNamer, sliMetrics, pacer)
+			encoder, feed, spec, knobs, topicNamer, sliMetrics, pacer, dlq, txnRows)
 	}
 
 	numWorkers := changefeedbase.EventConsumerWorkers.Get(&cfg.Settings.SV)
@@ -240,6 +253,8 @@ func newKVEventToRowConsumer(
 	topicNamer *TopicNamer,
 	metrics *sliMetrics,
 	pacer *admission.Pacer,
+	dlq *deadLetterQueue,
+	txnRows *txnRowCounter,
 ) (_ *kvEventToRowConsumer, err error) {
 	includeVirtual := details.Opts.IncludeVirtual()
 	keyOnly := details.Opts.KeyOnly()
@@ -275,6 +290,8 @@ func newKVEventToRowConsumer(
 		encodingOpts:         encodingOpts,
 		metrics:              metrics,
 		pacer:                pacer,
+		dlq:                  dlq,
+		txnRows:              txnRows,
 		sv:                   cfg.SV(),
 	}, nil
 }
@@ -350,7 +367,8 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 	prevSchemaTimestamp := schemaTimestamp
 	keyOnly := c.details.Opts.KeyOnly()
 
-	if backfillTs := ev.BackfillTimestamp(); !backfillTs.IsEmpty() {
+	backfillTs := ev.BackfillTimestamp()
+	if !backfillTs.IsEmpty() {
 		schemaTimestamp = backfillTs
 		prevSchemaTimestamp = schemaTimestamp.Prev()
 	}
@@ -396,7 +414,7 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 		}
 	}
 
-	return c.encodeAndEmit(ctx, updatedRow, prevRow, schemaTimestamp, ev.DetachAlloc())
+	return c.encodeAndEmit(ctx, updatedRow, prevRow, schemaTimestamp, !backfillTs.IsEmpty(), ev.DetachAlloc())
 }
 
 func (c *kvEventToRowConsumer) encodeAndEmit(
@@ -404,6 +422,7 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	updatedRow cdcevent.Row,
 	prevRow cdcevent.Row,
 	schemaTS hlc.Timestamp,
+	backfill bool,
 	alloc kvevent.Alloc,
 ) error {
 	topic, err := c.topicForEvent(updatedRow.Metadata)
@@ -451,14 +470,18 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	var keyCopy, valueCopy []byte
 	encodedKey, err := c.encoder.EncodeKey(ctx, updatedRow)
 	if err != nil {
-		return err
+		return c.maybeWriteToDLQ(ctx, dlqEvent{
+			topic: evCtx.topic, row: updatedRow, mvcc: updatedRow.MvccTimestamp, err: err,
+		}, alloc)
 	}
 	c.scratch, keyCopy = c.scratch.Copy(encodedKey, 0 /* extraCap */)
 	// TODO(yevgeniy): Some refactoring is needed in the encoder: namely, prevRow
 	// might not be available at all when working with changefeed expressions.
 	encodedValue, err := c.encoder.EncodeValue(ctx, evCtx, updatedRow, prevRow)
 	if err != nil {
-		return err
+		return c.maybeWriteToDLQ(ctx, dlqEvent{
+			topic: evCtx.topic, key: keyCopy, row: updatedRow, mvcc: updatedRow.MvccTimestamp, err: err,
+		}, alloc)
 	}
 	c.scratch, valueCopy = c.scratch.Copy(encodedValue, 0 /* extraCap */)
 
@@ -484,12 +507,32 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 		}
 		return err
 	}
+	if c.txnRows != nil && !backfill {
+		tableName, _ := topic.GetNameComponents()
+		c.txnRows.add(updatedRow.MvccTimestamp, string(tableName))
+	}
 	if log.V(3) {
 		log.Infof(ctx, `r %s: %s(%+v) -> %s`, updatedRow.TableName, keyCopy, headers, valueCopy)
 	}
 	return nil
 }
 
+// maybeWriteToDLQ handles an event that could not be encoded. If the
+// changefeed has a dead letter queue, the event is written to it and skipped;
+// otherwise, or if the error may go away on retry, the error is returned.
+func (c *kvEventToRowConsumer) maybeWriteToDLQ(
+	ctx context.Context, ev dlqEvent, alloc kvevent.Alloc,
+) error {
+	if c.dlq == nil || ctx.Err() != nil || changefeedbase.IsRetryableError(ev.err) {
+		return ev.err
+	}
+	if err := c.dlq.write(ctx, ev); err != nil {
+		return err
+	}
+	alloc.Release(ctx)
+	return nil
+}
+
 var jsonHeaderWrongTypeLogLim = log.Every(1 * time.Minute)
 var jsonHeaderWrongValTypeLogLim = log.Every(1 * time.Minute)
 
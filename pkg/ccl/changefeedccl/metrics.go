@@ -85,6 +85,7 @@ type AggMetrics struct {
 	CloudstorageBufferedBytes   *aggmetric.AggGauge
 	KafkaThrottlingNanos        *aggmetric.AggHistogram
 	SinkErrors                  *aggmetric.AggCounter
+	DLQWrites                   *aggmetric.AggCounter
 	MaxBehindNanos              *aggmetric.AggGauge
 
 	Timers *timers.Timers
@@ -121,6 +122,7 @@ type metricsRecorder interface {
 	getBackfillCallback() func() func()
 	getBackfillRangeCallback() func(int64) (func(), func())
 	recordSizeBasedFlush()
+	recordDLQWrite()
 	newParallelIOMetricsRecorder() parallelIOMetricsRecorder
 	recordSinkIOInflightChange(int64)
 	makeCloudstorageFileAllocCallback() func(delta int64)
@@ -170,6 +172,7 @@ type sliMetrics struct {
 	CloudstorageBufferedBytes   *aggmetric.Gauge
 	KafkaThrottlingNanos        *aggmetric.Histogram
 	SinkErrors                  *aggmetric.Counter
+	DLQWrites                   *aggmetric.Counter
 	MaxBehindNanos              *aggmetric.Gauge
 
 	Timers *timers.ScopedTimers
@@ -356,6 +359,15 @@ func (m *sliMetrics) recordSizeBasedFlush() {
 	m.SizeBasedFlushes.Inc(1)
 }
 
+// Record an event written to the dead letter queue.
+func (m *sliMetrics) recordDLQWrite() {
+	if m == nil {
+		return
+	}
+
+	m.DLQWrites.Inc(1)
+}
+
 func (m *sliMetrics) netMetrics() *cidr.NetMetrics {
 	if m == nil {
 		return nil
@@ -673,6 +685,10 @@ func (w *wrappingCostController) recordSizeBasedFlush() {
 	w.inner.recordSizeBasedFlush()
 }
 
+func (w *wrappingCostController) recordDLQWrite() {
+	w.inner.recordDLQWrite()
+}
+
 func (w *wrappingCostController) recordSinkIOInflightChange(delta int64) {
 	w.inner.recordSinkIOInflightChange(delta)
 }
@@ -1001,6 +1017,12 @@ func newAggregateMetrics(histogramWindow time.Duration, lookup *cidr.Lookup) *Ag
 		Measurement: "Count",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaDLQWrites := metric.Metadata{
+		Name:        "changefeed.dlq_writes",
+		Help:        "Number of events written to the dead letter queue table because they could not be encoded or were rejected by the sink",
+		Measurement: "Messages",
+		Unit:        metric.Unit_COUNT,
+	}
 	// TODO(dan): This was intended to be a measure of the minimum distance of
 	// any changefeed ahead of its gc ttl threshold, but keeping that correct in
 	// the face of changing zone configs is much harder, so this will have to do
@@ -1120,6 +1142,7 @@ func newAggregateMetrics(histogramWindow time.Duration, lookup *cidr.Lookup) *Ag
 			BucketConfig: metric.ChangefeedBatchLatencyBuckets,
 		}),
 		SinkErrors:        b.Counter(metaSinkErrors),
+		DLQWrites:         b.Counter(metaDLQWrites),
 		MaxBehindNanos:    b.FunctionalGauge(metaChangefeedMaxBehindNanos, functionalGaugeMaxFn),
 		Timers:            timers.New(histogramWindow),
 		NetMetrics:        lookup.MakeNetMetrics(metaNetworkBytesOut, metaNetworkBytesIn, "sink"),
@@ -1193,6 +1216,7 @@ func (a *AggMetrics) getOrCreateScope(scope string) (*sliMetrics, error) {
 		CloudstorageBufferedBytes:   a.CloudstorageBufferedBytes.AddChild(scope),
 		KafkaThrottlingNanos:        a.KafkaThrottlingNanos.AddChild(scope),
 		SinkErrors:                  a.SinkErrors.AddChild(scope),
+		DLQWrites:                   a.DLQWrites.AddChild(scope),
 
 		Timers: a.Timers.GetOrCreateScopedTimers(scope),
 
@@ -35,6 +35,34 @@ func (m *MockKafkaClientV2) EXPECT() *MockKafkaClientV2MockRecorder {
 	return m.recorder
 }
 
+// AbortBufferedRecords mocks base method.
+func (m *MockKafkaClientV2) AbortBufferedRecords(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortBufferedRecords", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortBufferedRecords indicates an expected call of AbortBufferedRecords.
+func (mr *MockKafkaClientV2MockRecorder) AbortBufferedRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortBufferedRecords", reflect.TypeOf((*MockKafkaClientV2)(nil).AbortBufferedRecords), arg0)
+}
+
+// BeginTransaction mocks base method.
+func (m *MockKafkaClientV2) BeginTransaction() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockKafkaClientV2MockRecorder) BeginTransaction() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockKafkaClientV2)(nil).BeginTransaction))
+}
+
 // Close mocks base method.
 func (m *MockKafkaClientV2) Close() {
 	m.ctrl.T.Helper()
@@ -47,6 +75,20 @@ func (mr *MockKafkaClientV2MockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockKafkaClientV2)(nil).Close))
 }
 
+// EndTransaction mocks base method.
+func (m *MockKafkaClientV2) EndTransaction(arg0 context.Context, arg1 kgo.TransactionEndTry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndTransaction indicates an expected call of EndTransaction.
+func (mr *MockKafkaClientV2MockRecorder) EndTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndTransaction", reflect.TypeOf((*MockKafkaClientV2)(nil).EndTransaction), arg0, arg1)
+}
+
 // ProduceSync mocks base method.
 func (m *MockKafkaClientV2) ProduceSync(arg0 context.Context, arg1 ...*kgo.Record) kgo.ProduceResults {
 	m.ctrl.T.Helper()
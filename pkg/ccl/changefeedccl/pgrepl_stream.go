@@ -0,0 +1,743 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvfeed"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/schemafeed"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/replslot"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+var replicationStreamKeepaliveInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"changefeed.replication_stream.keepalive_interval",
+	"the interval at which logical replication streams send keepalive messages to the client",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+var replicationStreamCheckpointInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"changefeed.replication_stream.checkpoint_interval",
+	"the interval at which logical replication streams persist the position confirmed "+
+		"by the client in their replication slot and look for newly created tables",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+// Options of the pgoutput plugin, see
+// https://www.postgresql.org/docs/current/protocol-logical-replication.html.
+const (
+	pgoutputOptProtoVersion     = "proto_version"
+	pgoutputOptPublicationNames = "publication_names"
+	pgoutputOptBinary           = "binary"
+	pgoutputOptMessages         = "messages"
+	pgoutputOptStreaming        = "streaming"
+	pgoutputOptTwoPhase         = "two_phase"
+	pgoutputOptOrigin           = "origin"
+)
+
+// errReplicationStreamDone is returned when the client ends the stream.
+var errReplicationStreamDone = errors.New("replication stream ended by the client")
+
+func init() {
+	sql.StartReplicationHook = startReplication
+}
+
+// startReplication streams the changes committed to the tables of the
+// database of the replication slot to the client, starting after the position
+// confirmed in the slot or requested by the client, whichever is later.
+//
+// Changes are read by a kvfeed and buffered until they are resolved. Changes
+// committed at the same wall time are then sent as a single transaction whose
+// LSN is that wall time. Publications are not supported, so the stream covers
+// all tables of the database which exist when it starts; tables created later
+// are picked up at the next checkpoint, without the rows written to them
+// before that.
+func startReplication(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	slot replslot.Slot,
+	stmt *pgrepltree.StartReplication,
+	conn sql.ReplicationConn,
+) error {
+	if err := validatePgoutputOptions(stmt.Options); err != nil {
+		return err
+	}
+	if err := conn.Start(ctx); err != nil {
+		return err
+	}
+
+	s := &replicationStream{
+		execCfg:         execCfg,
+		slot:            slot,
+		conn:            conn,
+		enc:             pgoutput.NewEncoder(),
+		relations:       make(map[relationKey]*pgoutput.Relation),
+		sentRelations:   make(map[descpb.ID]descpb.DescriptorVersion),
+		schemaNames:     make(map[descpb.ID]string),
+		sentLSN:         slot.ConfirmedFlushLSN,
+		flushedLSN:      slot.ConfirmedFlushLSN,
+		checkpointedLSN: slot.ConfirmedFlushLSN,
+	}
+	if stmt.LSN > s.sentLSN {
+		s.sentLSN = stmt.LSN
+	}
+	sv := &execCfg.Settings.SV
+	s.keepalive.Reset(replicationStreamKeepaliveInterval.Get(sv))
+	defer s.keepalive.Stop()
+	s.checkpoint.Reset(replicationStreamCheckpointInterval.Get(sv))
+	defer s.checkpoint.Stop()
+
+	msgs := make(chan []byte)
+	g := ctxgroup.WithContext(ctx)
+	g.GoCtx(func(ctx context.Context) error {
+		for {
+			msg, err := conn.Recv(ctx)
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return errReplicationStreamDone
+				}
+				return err
+			}
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		return s.run(ctx, msgs)
+	})
+	err := g.Wait()
+	if !errors.Is(err, errReplicationStreamDone) {
+		return err
+	}
+	// Persist the last position confirmed by the client before ending the
+	// stream, so that it does not receive the same changes again.
+	return s.saveCheckpoint(ctx)
+}
+
+// validatePgoutputOptions returns an error if the options passed to the
+// pgoutput plugin are missing or unsupported.
+func validatePgoutputOptions(opts pgrepltree.Options) error {
+	var hasProtoVersion, hasPublicationNames bool
+	for _, o := range opts {
+		val := o.StringValue()
+		switch strings.ToLower(string(o.Key)) {
+		case pgoutputOptProtoVersion:
+			hasProtoVersion = true
+			// Versions 2 to 4 only add messages for streamed and two-phase
+			// transactions, which are never produced.
+			switch val {
+			case "1", "2", "3", "4":
+			default:
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%s but server only supports protocol %d or higher",
+					val, pgoutput.ProtoVersion)
+			}
+		case pgoutputOptPublicationNames:
+			// All tables of the database are streamed, as if the publications
+			// were created FOR ALL TABLES.
+			hasPublicationNames = true
+		case pgoutputOptBinary:
+			if isTrueOption(val) {
+				return unimplemented.New("pgoutput_binary", "binary pgoutput format is not supported")
+			}
+		case pgoutputOptMessages, pgoutputOptStreaming, pgoutputOptTwoPhase, pgoutputOptOrigin:
+			// Logical decoding messages and prepared transactions do not exist,
+			// transactions are never streamed before they commit, and all
+			// changes are local.
+		default:
+			return pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized pgoutput option: %s", o.Key)
+		}
+	}
+	if !hasProtoVersion {
+		return pgerror.New(pgcode.InvalidParameterValue, "proto_version option missing")
+	}
+	if !hasPublicationNames {
+		return pgerror.New(pgcode.InvalidParameterValue, "publication_names parameter missing")
+	}
+	return nil
+}
+
+func isTrueOption(val string) bool {
+	switch strings.ToLower(val) {
+	case "", "true", "on", "1":
+		return true
+	}
+	return false
+}
+
+// relationKey identifies the version of a table described by a Relation
+// message.
+type relationKey struct {
+	id      descpb.ID
+	version descpb.DescriptorVersion
+}
+
+// replicationChange is a decoded change which has not been sent yet.
+type replicationChange struct {
+	ts  hlc.Timestamp
+	key roachpb.Key
+	rel relationKey
+	// old is set for updates and deletes if the previous value of the row is
+	// known. For deletes, only the key columns of old are set if keyOnly is
+	// set.
+	old     tree.Datums
+	new     tree.Datums
+	keyOnly bool
+}
+
+// replicationStream sends the changes to the tables of a database to a client
+// using the pgoutput protocol.
+type replicationStream struct {
+	execCfg *sql.ExecutorConfig
+	slot    replslot.Slot
+	conn    sql.ReplicationConn
+	enc     *pgoutput.Encoder
+	buf     []byte
+
+	// relations caches the Relation message of each version of the tables.
+	relations map[relationKey]*pgoutput.Relation
+	// sentRelations holds the version of each table described by the last
+	// Relation message sent to the client.
+	sentRelations map[descpb.ID]descpb.DescriptorVersion
+	schemaNames   map[descpb.ID]string
+
+	// pending holds the changes which are not resolved yet.
+	pending []replicationChange
+
+	// sentLSN is the position up to which all changes have been sent.
+	sentLSN lsn.LSN
+	// flushedLSN is the position confirmed by the client.
+	flushedLSN lsn.LSN
+	// checkpointedLSN is the position saved in the replication slot.
+	checkpointedLSN lsn.LSN
+
+	keepalive, checkpoint timeutil.Timer
+}
+
+// run streams changes until the context is canceled or an error occurs. The
+// kvfeed is restarted whenever the set of tables or the primary index of one
+// of them changes.
+func (s *replicationStream) run(ctx context.Context, msgs <-chan []byte) error {
+	from := lsnutil.LSNToHLC(s.sentLSN)
+	for {
+		restartAt, err := s.runFeed(ctx, from, msgs)
+		if err != nil {
+			return err
+		}
+		log.Infof(ctx, "restarting replication stream for slot %s at %s", s.slot.Name, restartAt)
+		from = restartAt
+	}
+}
+
+// runFeed runs a kvfeed over the tables which exist at the given timestamp,
+// starting after it. It returns the timestamp at which the feed needs to be
+// restarted.
+func (s *replicationStream) runFeed(
+	ctx context.Context, from hlc.Timestamp, msgs <-chan []byte,
+) (restartAt hlc.Timestamp, _ error) {
+	tables, err := s.fetchTables(ctx, from)
+	if err != nil {
+		return hlc.Timestamp{}, err
+	}
+	if len(tables) == 0 {
+		return s.waitForTables(ctx, msgs)
+	}
+
+	var targets changefeedbase.Targets
+	watched := make(map[descpb.ID]struct{}, len(tables))
+	spans := make([]roachpb.Span, 0, len(tables))
+	for _, t := range tables {
+		targets.Add(changefeedbase.Target{
+			Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+			TableID:           t.GetID(),
+			StatementTimeName: changefeedbase.StatementTimeName(t.GetName()),
+		})
+		watched[t.GetID()] = struct{}{}
+		spans = append(spans, t.PrimaryIndexSpan(s.execCfg.Codec))
+	}
+	frontier, err := span.MakeFrontierAt(from, spans...)
+	if err != nil {
+		return hlc.Timestamp{}, err
+	}
+	defer frontier.Release()
+
+	decoder, err := cdcevent.NewEventDecoder(ctx, s.execCfg, targets, false /* includeVirtual */, false /* keyOnly */)
+	if err != nil {
+		return hlc.Timestamp{}, err
+	}
+
+	cfg := &s.execCfg.DistSQLSrv.ServerConfig
+	metrics := s.execCfg.JobRegistry.MetricsStruct().Changefeed.(*Metrics)
+	sliMetrics, err := metrics.getSLIMetrics(defaultSLIScope)
+	if err != nil {
+		return hlc.Timestamp{}, err
+	}
+	monitoringCfg, err := makeKVFeedMonitoringCfg(ctx, sliMetrics, changefeedbase.MakeDefaultOptions(), s.execCfg.Settings)
+	if err != nil {
+		return hlc.Timestamp{}, err
+	}
+	memLimit := changefeedbase.PerChangefeedMemLimit.Get(&s.execCfg.Settings.SV)
+	memMon := mon.NewMonitorInheritWithLimit(
+		mon.MakeName("replication-stream"), memLimit, cfg.BackfillerMonitor, false, /* longLiving */
+	)
+	memMon.StartNoReserved(ctx, cfg.BackfillerMonitor)
+	defer memMon.Stop(ctx)
+	buf := kvevent.NewMemBuffer(memMon.MakeBoundAccount(), &s.execCfg.Settings.SV,
+		&metrics.KVFeedMetrics.AggregatorBufferMetricsWithCompat)
+
+	schemaChange := changefeedbase.SchemaChangeEventClass(changefeedbase.OptSchemaChangeEventClassDefault)
+	kvfeedCfg := kvfeed.Config{
+		Writer:             buf,
+		Settings:           s.execCfg.Settings,
+		DB:                 s.execCfg.DB,
+		Codec:              s.execCfg.Codec,
+		Clock:              s.execCfg.Clock,
+		Spans:              spans,
+		Targets:            targets,
+		Metrics:            &metrics.KVFeedMetrics,
+		MM:                 memMon,
+		InitialHighWater:   from,
+		WithDiff:           true,
+		SchemaChangeEvents: schemaChange,
+		SchemaChangePolicy: changefeedbase.OptSchemaChangePolicyNoBackfill,
+		SchemaFeed: schemafeed.New(ctx, cfg, schemaChange, targets, from,
+			&metrics.SchemaFeedMetrics, changefeedbase.CanHandle{}),
+		ScopedTimers:  sliMetrics.Timers,
+		MonitoringCfg: monitoringCfg,
+	}
+
+	feedCtx, cancelFeed := context.WithCancel(ctx)
+	g := ctxgroup.WithContext(feedCtx)
+	events := make(chan kvevent.Event)
+	feedErr := make(chan error, 1)
+	g.GoCtx(func(ctx context.Context) error {
+		return kvfeed.Run(ctx, kvfeedCfg)
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		for {
+			ev, err := buf.Get(ctx)
+			if err != nil {
+				feedErr <- err
+				return nil
+			}
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+	defer func() {
+		cancelFeed()
+		_ = g.Wait()
+	}()
+
+	var restartBoundary hlc.Timestamp
+	for {
+		select {
+		case ev := <-events:
+			switch ev.Type() {
+			case kvevent.TypeKV:
+				err = s.addChange(ctx, decoder, ev)
+			case kvevent.TypeResolved:
+				resolved := ev.Resolved()
+				if resolved.BoundaryType == jobspb.ResolvedSpan_RESTART {
+					// The primary index of a table changed, so the kvfeed needs to
+					// watch different spans.
+					restartBoundary = resolved.Timestamp
+				}
+				if _, err = frontier.Forward(resolved.Span, resolved.Timestamp); err == nil {
+					err = s.advanceTo(ctx, frontier.Frontier())
+				}
+			}
+			a := ev.DetachAlloc()
+			a.Release(ctx)
+			if err != nil {
+				return hlc.Timestamp{}, err
+			}
+			if !restartBoundary.IsEmpty() && restartBoundary.LessEq(frontier.Frontier()) {
+				return restartBoundary, nil
+			}
+
+		case err := <-feedErr:
+			cancelFeed()
+			if gErr := g.Wait(); gErr != nil && !errors.Is(gErr, context.Canceled) {
+				err = gErr
+			}
+			return hlc.Timestamp{}, err
+
+		case msg := <-msgs:
+			if err := s.handleClientMessage(ctx, msg); err != nil {
+				return hlc.Timestamp{}, err
+			}
+
+		case <-s.keepalive.C:
+			if err := s.sendKeepalive(ctx); err != nil {
+				return hlc.Timestamp{}, err
+			}
+
+		case <-s.checkpoint.C:
+			if err := s.saveCheckpoint(ctx); err != nil {
+				return hlc.Timestamp{}, err
+			}
+			s.checkpoint.Reset(replicationStreamCheckpointInterval.Get(&s.execCfg.Settings.SV))
+			ts := frontier.Frontier()
+			if ts.LessEq(from) {
+				continue
+			}
+			tables, err := s.fetchTables(ctx, ts)
+			if err != nil {
+				return hlc.Timestamp{}, err
+			}
+			for _, t := range tables {
+				if _, ok := watched[t.GetID()]; !ok {
+					return ts, nil
+				}
+			}
+
+		case <-ctx.Done():
+			return hlc.Timestamp{}, ctx.Err()
+		}
+	}
+}
+
+// waitForTables is used while the database has no tables. It waits until some
+// table is created, and returns the time at which it was found.
+func (s *replicationStream) waitForTables(
+	ctx context.Context, msgs <-chan []byte,
+) (hlc.Timestamp, error) {
+	for {
+		select {
+		case msg := <-msgs:
+			if err := s.handleClientMessage(ctx, msg); err != nil {
+				return hlc.Timestamp{}, err
+			}
+
+		case <-s.keepalive.C:
+			if err := s.sendKeepalive(ctx); err != nil {
+				return hlc.Timestamp{}, err
+			}
+
+		case <-s.checkpoint.C:
+			if err := s.saveCheckpoint(ctx); err != nil {
+				return hlc.Timestamp{}, err
+			}
+			s.checkpoint.Reset(replicationStreamCheckpointInterval.Get(&s.execCfg.Settings.SV))
+			now := s.execCfg.Clock.Now()
+			tables, err := s.fetchTables(ctx, now)
+			if err != nil {
+				return hlc.Timestamp{}, err
+			}
+			// There is nothing to send up to now.
+			if err := s.advanceTo(ctx, now); err != nil {
+				return hlc.Timestamp{}, err
+			}
+			if len(tables) > 0 {
+				return now, nil
+			}
+
+		case <-ctx.Done():
+			return hlc.Timestamp{}, ctx.Err()
+		}
+	}
+}
+
+// fetchTables returns the tables of the database of the replication slot as of
+// the given timestamp.
+func (s *replicationStream) fetchTables(
+	ctx context.Context, ts hlc.Timestamp,
+) (tables []catalog.TableDescriptor, _ error) {
+	if err := sql.DescsTxn(ctx, s.execCfg, func(
+		ctx context.Context, txn isql.Txn, descriptors *descs.Collection,
+	) error {
+		tables = tables[:0]
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		db, err := descriptors.ByIDWithoutLeased(txn.KV()).Get().Database(ctx, s.slot.DatabaseID)
+		if err != nil {
+			return err
+		}
+		all, err := descriptors.GetAllInDatabase(ctx, txn.KV(), db)
+		if err != nil {
+			return err
+		}
+		return all.ForEachDescriptor(func(desc catalog.Descriptor) error {
+			switch desc := desc.(type) {
+			case catalog.SchemaDescriptor:
+				s.schemaNames[desc.GetID()] = desc.GetName()
+			case catalog.TableDescriptor:
+				if desc.IsView() || desc.IsSequence() || desc.IsVirtualTable() ||
+					desc.IsTemporary() || !desc.Public() {
+					return nil
+				}
+				if len(desc.GetFamilies()) != 1 {
+					return unimplemented.Newf("pgoutput_column_families",
+						"logical replication of table %q with multiple column families is not supported",
+						desc.GetName())
+				}
+				tables = append(tables, desc)
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// addChange decodes a change and buffers it until it is resolved.
+func (s *replicationStream) addChange(
+	ctx context.Context, decoder cdcevent.Decoder, ev kvevent.Event,
+) error {
+	kv := ev.KV()
+	schemaTS := kv.Value.Timestamp
+	if lsnutil.HLCToLSN(schemaTS) <= s.sentLSN {
+		// The change was already sent.
+		return nil
+	}
+	row, err := decoder.DecodeKV(ctx, kv, cdcevent.CurrentRow, schemaTS, false /* keyOnly */)
+	if err != nil {
+		return err
+	}
+	prevRow, err := decoder.DecodeKV(ctx, ev.PrevKeyValue(), cdcevent.PrevRow, schemaTS, false /* keyOnly */)
+	if err != nil {
+		return err
+	}
+
+	rel := relationKey{id: row.TableID, version: row.Version}
+	if _, ok := s.relations[rel]; !ok {
+		if err := s.addRelation(ctx, rel, row); err != nil {
+			return err
+		}
+	}
+	change := replicationChange{
+		ts:  schemaTS,
+		key: kv.Key,
+		rel: rel,
+	}
+	if prevRow.IsInitialized() && !prevRow.IsDeleted() {
+		if change.old, err = rowDatums(prevRow); err != nil {
+			return err
+		}
+	}
+	if row.IsDeleted() {
+		if change.old == nil {
+			// The previous value of the row is not known, so only its key is
+			// reported.
+			if change.old, err = rowDatums(row); err != nil {
+				return err
+			}
+			for i, col := range s.relations[rel].Columns {
+				if !col.Key {
+					change.old[i] = tree.DNull
+				}
+			}
+			change.keyOnly = true
+		}
+	} else if change.new, err = rowDatums(row); err != nil {
+		return err
+	}
+	s.pending = append(s.pending, change)
+	return nil
+}
+
+// addRelation caches the Relation message describing the version of the table
+// of the given row.
+func (s *replicationStream) addRelation(
+	ctx context.Context, key relationKey, row cdcevent.Row,
+) error {
+	keyCols := make(map[string]struct{})
+	if err := row.ForEachKeyColumn().Col(func(col cdcevent.ResultColumn) error {
+		keyCols[col.Name] = struct{}{}
+		return nil
+	}); err != nil {
+		return err
+	}
+	rel := &pgoutput.Relation{
+		OID:       oid.Oid(row.TableID),
+		Namespace: s.schemaNames[row.TableDescriptor().GetParentSchemaID()],
+		Name:      row.TableName,
+	}
+	if err := row.ForEachColumn().Col(func(col cdcevent.ResultColumn) error {
+		_, isKey := keyCols[col.Name]
+		rel.Columns = append(rel.Columns, pgoutput.Column{Name: col.Name, Type: col.Typ, Key: isKey})
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.relations[key] = rel
+	return nil
+}
+
+func rowDatums(row cdcevent.Row) (tree.Datums, error) {
+	var datums tree.Datums
+	if err := row.ForEachColumn().Datum(func(d tree.Datum, _ cdcevent.ResultColumn) error {
+		datums = append(datums, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return datums, nil
+}
+
+// advanceTo sends the transactions which committed before the wall time of the
+// given resolved timestamp.
+func (s *replicationStream) advanceTo(ctx context.Context, resolved hlc.Timestamp) error {
+	pending := s.pending
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].ts != pending[j].ts {
+			return pending[i].ts.Less(pending[j].ts)
+		}
+		return bytes.Compare(pending[i].key, pending[j].key) < 0
+	})
+	var i int
+	for i < len(pending) && pending[i].ts.WallTime < resolved.WallTime {
+		j := i + 1
+		for j < len(pending) && pending[j].ts.WallTime == pending[i].ts.WallTime {
+			j++
+		}
+		if err := s.sendTxn(ctx, pending[i:j]); err != nil {
+			return err
+		}
+		i = j
+	}
+	s.pending = append(pending[:0], pending[i:]...)
+	// Transactions at the wall time of the resolved timestamp may still be
+	// pending.
+	if l := lsn.LSN(resolved.WallTime - 1); l > s.sentLSN {
+		s.sentLSN = l
+	}
+	return nil
+}
+
+// sendTxn sends the given changes, which all committed at the same wall time,
+// as a single transaction.
+func (s *replicationStream) sendTxn(ctx context.Context, changes []replicationChange) error {
+	txnLSN := lsnutil.HLCToLSN(changes[0].ts)
+	commitTime := changes[0].ts.GoTime()
+	now := timeutil.Now()
+	send := func(msg []byte) error {
+		s.buf = pgoutput.XLogData(s.buf, txnLSN, txnLSN, now, msg)
+		return s.conn.Send(ctx, s.buf)
+	}
+
+	if err := send(s.enc.Begin(txnLSN, commitTime, uint32(txnLSN))); err != nil {
+		return err
+	}
+	for i, c := range changes {
+		if i > 0 && c.ts == changes[i-1].ts && c.key.Equal(changes[i-1].key) {
+			// The rangefeed may emit the same value more than once.
+			continue
+		}
+		rel := s.relations[c.rel]
+		if v, ok := s.sentRelations[c.rel.id]; !ok || v != c.rel.version {
+			if err := send(s.enc.Relation(*rel)); err != nil {
+				return err
+			}
+			s.sentRelations[c.rel.id] = c.rel.version
+		}
+		var msg []byte
+		switch {
+		case c.new == nil:
+			msg = s.enc.Delete(rel.OID, c.old, c.keyOnly)
+		case c.old == nil:
+			msg = s.enc.Insert(rel.OID, c.new)
+		default:
+			msg = s.enc.Update(rel.OID, c.old, c.new)
+		}
+		if err := send(msg); err != nil {
+			return err
+		}
+	}
+	if err := send(s.enc.Commit(txnLSN, txnLSN, commitTime)); err != nil {
+		return err
+	}
+	s.sentLSN = txnLSN
+	return nil
+}
+
+// handleClientMessage processes a message sent by the client.
+func (s *replicationStream) handleClientMessage(ctx context.Context, msg []byte) error {
+	update, ok, err := pgoutput.ParseStandbyStatusUpdate(msg)
+	if err != nil || !ok {
+		return err
+	}
+	// The client cannot have received changes which were not sent.
+	flushed := update.Flushed
+	if flushed > s.sentLSN {
+		flushed = s.sentLSN
+	}
+	if flushed > s.flushedLSN {
+		s.flushedLSN = flushed
+	}
+	if update.ReplyRequested {
+		return s.sendKeepalive(ctx)
+	}
+	return nil
+}
+
+// sendKeepalive sends a keepalive message reporting the position up to which
+// changes have been sent.
+func (s *replicationStream) sendKeepalive(ctx context.Context) error {
+	s.keepalive.Reset(replicationStreamKeepaliveInterval.Get(&s.execCfg.Settings.SV))
+	s.buf = pgoutput.PrimaryKeepalive(s.buf, s.sentLSN, timeutil.Now(), false /* replyRequested */)
+	return s.conn.Send(ctx, s.buf)
+}
+
+// saveCheckpoint saves the position confirmed by the client in the replication
+// slot, which allows the changes before it to be garbage collected.
+func (s *replicationStream) saveCheckpoint(ctx context.Context) error {
+	if s.flushedLSN <= s.checkpointedLSN {
+		return nil
+	}
+	if err := s.execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return replslot.Advance(ctx, txn, s.execCfg.ProtectedTimestampProvider, s.slot.Name, s.flushedLSN)
+	}); err != nil {
+		return err
+	}
+	s.checkpointedLSN = s.flushedLSN
+	return nil
+}
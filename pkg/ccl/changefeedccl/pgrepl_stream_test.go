@@ -0,0 +1,203 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicationStreamPgoutput(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv := serverutils.StartServerOnly(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sysDB := sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t))
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+	sqlDB := sqlutils.MakeSQLRunner(s.SQLConn(t))
+	sqlDB.Exec(t, `SET CLUSTER SETTING changefeed.replication_stream.checkpoint_interval = '100ms'`)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+
+	pgURL, cleanup := s.PGUrl(t, serverutils.CertsDirPrefix("pgrepl_stream_test"), serverutils.User(username.RootUser))
+	defer cleanup()
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	_, err = conn.Exec(ctx, `CREATE_REPLICATION_SLOT s LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 'a')`)
+	sqlDB.Exec(t, `UPDATE t SET v = 'b' WHERE k = 1`)
+	sqlDB.Exec(t, `DELETE FROM t WHERE k = 1`)
+
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{
+		String: `START_REPLICATION SLOT s LOGICAL 0/0 (proto_version '1', publication_names 'p')`,
+	})
+	require.NoError(t, fe.Flush())
+	receive := func() pgproto3.BackendMessage {
+		t.Helper()
+		require.NoError(t, conn.Conn().SetReadDeadline(time.Now().Add(time.Minute)))
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		if errMsg, ok := msg.(*pgproto3.ErrorResponse); ok {
+			t.Fatalf("unexpected error: %s", errMsg.Message)
+		}
+		return msg
+	}
+	_, ok := receive().(*pgproto3.CopyBothResponse)
+	require.True(t, ok)
+
+	var msgs []string
+	var lastCommit lsn.LSN
+	for commits := 0; commits < 3; {
+		data, ok := receive().(*pgproto3.CopyData)
+		require.True(t, ok)
+		if data.Data[0] != 'w' {
+			continue
+		}
+		msg := formatPgoutputMessage(t, data.Data[25:])
+		if strings.HasPrefix(msg, "C") {
+			commits++
+			lastCommit = lsn.LSN(binary.BigEndian.Uint64(data.Data[1:9]))
+		}
+		msgs = append(msgs, msg)
+	}
+	require.Equal(t, []string{
+		"B", "R public.t (k* int8, v text)", "I [1 a]", "C",
+		"B", "U [1 a] -> [1 b]", "C",
+		"B", "D O [1 b]", "C",
+	}, msgs)
+
+	// Confirm the last transaction and end the stream.
+	status := []byte{'r'}
+	for _, v := range []uint64{uint64(lastCommit), uint64(lastCommit), uint64(lastCommit), 0} {
+		status = binary.BigEndian.AppendUint64(status, v)
+	}
+	status = append(status, 0)
+	fe.Send(&pgproto3.CopyData{Data: status})
+	fe.Send(&pgproto3.CopyDone{})
+	require.NoError(t, fe.Flush())
+	for {
+		if _, ok := receive().(*pgproto3.ReadyForQuery); ok {
+			break
+		}
+	}
+
+	sqlDB.CheckQueryResults(t,
+		`SELECT slot_name, confirmed_flush_lsn FROM pg_catalog.pg_replication_slots`,
+		[][]string{{"s", lastCommit.String()}},
+	)
+}
+
+// formatPgoutputMessage formats the pgoutput messages which are expected in
+// TestReplicationStreamPgoutput.
+func formatPgoutputMessage(t *testing.T, msg []byte) string {
+	typ := msg[0]
+	msg = msg[1:]
+	readString := func() string {
+		i := strings.IndexByte(string(msg), 0)
+		s := string(msg[:i])
+		msg = msg[i+1:]
+		return s
+	}
+	readTuple := func() string {
+		n := int(binary.BigEndian.Uint16(msg))
+		msg = msg[2:]
+		vals := make([]string, n)
+		for i := range vals {
+			kind := msg[0]
+			msg = msg[1:]
+			switch kind {
+			case 'n':
+				vals[i] = "NULL"
+			case 't':
+				l := int(binary.BigEndian.Uint32(msg))
+				vals[i] = string(msg[4 : 4+l])
+				msg = msg[4+l:]
+			default:
+				t.Fatalf("unexpected tuple data kind %q", kind)
+			}
+		}
+		return fmt.Sprint(vals)
+	}
+	switch typ {
+	case 'B', 'C':
+		return string(typ)
+	case 'R':
+		msg = msg[4:] // OID
+		namespace := readString()
+		name := readString()
+		msg = msg[1:] // replica identity
+		n := int(binary.BigEndian.Uint16(msg))
+		msg = msg[2:]
+		cols := make([]string, n)
+		for i := range cols {
+			key := msg[0] == 1
+			msg = msg[1:]
+			col := readString()
+			if key {
+				col += "*"
+			}
+			typOid := binary.BigEndian.Uint32(msg)
+			msg = msg[8:]
+			switch typOid {
+			case 20:
+				col += " int8"
+			case 25:
+				col += " text"
+			default:
+				col += fmt.Sprintf(" %d", typOid)
+			}
+			cols[i] = col
+		}
+		return fmt.Sprintf("R %s.%s (%s)", namespace, name, strings.Join(cols, ", "))
+	case 'I':
+		msg = msg[5:] // OID and 'N'
+		return "I " + readTuple()
+	case 'U':
+		msg = msg[4:] // OID
+		var old string
+		if msg[0] == 'O' {
+			msg = msg[1:]
+			old = readTuple() + " -> "
+		}
+		msg = msg[1:] // 'N'
+		return "U " + old + readTuple()
+	case 'D':
+		msg = msg[4:] // OID
+		kind := string(msg[0])
+		msg = msg[1:]
+		return "D " + kind + " " + readTuple()
+	default:
+		t.Fatalf("unexpected pgoutput message %q", typ)
+		return ""
+	}
+}
@@ -33,15 +33,40 @@ type schemaRegistry interface {
 	// available.
 	Ping(ctx context.Context) error
 
-	// RegisterSchemaForSubject registers the given schema for the
-	// given subject. The returned int32 is a schema ID that can
-	// be used in Avro wire messages or in other calls to the
-	// schema registry.
-	RegisterSchemaForSubject(ctx context.Context, subject string, schema string) (int32, error)
+	// RegisterSchemaForSubject registers the given schema of the
+	// given type for the given subject. The returned int32 is a
+	// schema ID that can be used in Avro or Protobuf wire messages
+	// or in other calls to the schema registry.
+	RegisterSchemaForSubject(
+		ctx context.Context, subject string, schema string, schemaType confluentSchemaType,
+	) (int32, error)
+}
+
+// confluentSchemaType is the type of a schema registered with the
+// Confluent schema registry.
+type confluentSchemaType string
+
+const (
+	// confluentSchemaTypeAvro is the default schema type of the registry.
+	// It is left empty so that it is omitted from registration requests,
+	// which keeps them compatible with registries that predate schema
+	// types.
+	confluentSchemaTypeAvro     confluentSchemaType = ``
+	confluentSchemaTypeProtobuf confluentSchemaType = `PROTOBUF`
+)
+
+// String returns the name of the schema type as understood by the
+// registry.
+func (t confluentSchemaType) String() string {
+	if t == confluentSchemaTypeAvro {
+		return "AVRO"
+	}
+	return string(t)
 }
 
 type confluentSchemaVersionRequest struct {
-	Schema string `json:"schema"`
+	Schema     string              `json:"schema"`
+	SchemaType confluentSchemaType `json:"schemaType,omitempty"`
 }
 
 type confluentSchemaVersionResponse struct {
@@ -220,18 +245,18 @@ func (r *confluentSchemaRegistry) Ping(ctx context.Context) error {
 }
 
 // RegisterSchemaForSubject registers the given schema for the given
-// subject. The schema type is assumed to be AVRO.
+// subject. An empty schema type is interpreted by the registry as AVRO.
 //
 //	https://docs.confluent.io/platform/current/schema-registry/develop/api.html#post--subjects-(string-%20subject)-versions
 func (r *confluentSchemaRegistry) RegisterSchemaForSubject(
-	ctx context.Context, subject string, schema string,
+	ctx context.Context, subject string, schema string, schemaType confluentSchemaType,
 ) (int32, error) {
 	u := r.urlForPath(fmt.Sprintf("subjects/%s/versions", subject))
 	if log.V(1) {
-		log.Infof(ctx, "registering avro schema %s %s", u, schema)
+		log.Infof(ctx, "registering %s schema %s %s", schemaType.String(), u, schema)
 	}
 
-	req := confluentSchemaVersionRequest{Schema: schema}
+	req := confluentSchemaVersionRequest{Schema: schema, SchemaType: schemaType}
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(req); err != nil {
 		return 0, err
@@ -313,8 +338,9 @@ func (r *confluentSchemaRegistry) urlForPath(relPath string) string {
 }
 
 type schemaRegistryCacheKey struct {
-	subject string
-	schema  string
+	subject    string
+	schema     string
+	schemaType confluentSchemaType
 }
 
 type schemaRegistryCache struct {
@@ -350,10 +376,10 @@ func (csr *schemaRegistryWithCache) Ping(ctx context.Context) error {
 
 // RegisterSchemaForSubject implements the schemaRegistry interface.
 func (csr *schemaRegistryWithCache) RegisterSchemaForSubject(
-	ctx context.Context, subject string, schema string,
+	ctx context.Context, subject string, schema string, schemaType confluentSchemaType,
 ) (int32, error) {
 	cacheKey := schemaRegistryCacheKey{
-		subject: subject, schema: schema,
+		subject: subject, schema: schema, schemaType: schemaType,
 	}
 	csr.cache.mu.Lock()
 	defer csr.cache.mu.Unlock()
@@ -361,7 +387,7 @@ func (csr *schemaRegistryWithCache) RegisterSchemaForSubject(
 	if ok {
 		return id, nil
 	}
-	id, err := csr.base.RegisterSchemaForSubject(ctx, subject, schema)
+	id, err := csr.base.RegisterSchemaForSubject(ctx, subject, schema, schemaType)
 	if err == nil {
 		csr.cache.Add(cacheKey, id)
 	}
@@ -111,7 +111,7 @@ func TestConfluentSchemaRegistrySharedCache(t *testing.T) {
 		go func() {
 			r, err := newConfluentSchemaRegistry(regServer.URL(), nil, nil)
 			require.NoError(t, err)
-			_, err = r.RegisterSchemaForSubject(context.Background(), "subject1", "schema")
+			_, err = r.RegisterSchemaForSubject(context.Background(), "subject1", "schema", confluentSchemaTypeAvro)
 			require.NoError(t, err)
 			wg.Done()
 
@@ -126,7 +126,7 @@ func TestConfluentSchemaRegistrySharedCache(t *testing.T) {
 		go func(i int) {
 			r, err := newConfluentSchemaRegistry(regServer.URL(), nil, nil)
 			require.NoError(t, err)
-			_, err = r.RegisterSchemaForSubject(context.Background(), "subject1", fmt.Sprintf("schema1%d", i))
+			_, err = r.RegisterSchemaForSubject(context.Background(), "subject1", fmt.Sprintf("schema1%d", i), confluentSchemaTypeAvro)
 			require.NoError(t, err)
 			wg.Done()
 
@@ -178,7 +178,7 @@ func TestConfluentSchemaRegistryRetryMetrics(t *testing.T) {
 		require.NoError(t, err)
 		ctx, cancel := context.WithCancel(context.Background())
 		go func() {
-			_, err = reg.RegisterSchemaForSubject(ctx, "subject1", "schema1")
+			_, err = reg.RegisterSchemaForSubject(ctx, "subject1", "schema1", confluentSchemaTypeAvro)
 		}()
 		require.NoError(t, err)
 		testutils.SucceedsSoon(t, func() error {
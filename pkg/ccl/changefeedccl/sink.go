@@ -7,6 +7,8 @@ package changefeedccl
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"math"
 	"net/url"
@@ -57,6 +59,8 @@ const (
 	sinkTypeCloudstorage
 	sinkTypeSQL
 	sinkTypePulsar
+	sinkTypeNATS
+	sinkTypeAMQP
 )
 
 func (st sinkType) String() string {
@@ -77,6 +81,10 @@ func (st sinkType) String() string {
 		return `sql`
 	case sinkTypePulsar:
 		return `pulsar`
+	case sinkTypeNATS:
+		return `nats`
+	case sinkTypeAMQP:
+		return `amqp`
 	default:
 		return `unknown`
 	}
@@ -132,6 +140,54 @@ type ResolvedTimestampSink interface {
 	EmitResolvedTimestamp(ctx context.Context, encoder Encoder, resolved hlc.Timestamp) error
 }
 
+// resolvedPayload is an encoded resolved message, such as a transaction
+// boundary marker, along with the timestamp it was encoded for.
+type resolvedPayload struct {
+	resolved hlc.Timestamp
+	body     []byte
+}
+
+// resolvedPayloadsSink is implemented by ResolvedTimestampSinks that can emit
+// several resolved messages, in order, with a single flush.
+type resolvedPayloadsSink interface {
+	// EmitResolvedPayloads synchronously emits the payloads on every topic.
+	EmitResolvedPayloads(ctx context.Context, payloads []resolvedPayload) error
+}
+
+// emitResolvedPayloads emits the payloads on every topic of the sink, in
+// order, and returns once they have been delivered.
+func emitResolvedPayloads(
+	ctx context.Context, sink ResolvedTimestampSink, payloads []resolvedPayload,
+) error {
+	if s, ok := sink.(resolvedPayloadsSink); ok {
+		return s.EmitResolvedPayloads(ctx, payloads)
+	}
+	for _, p := range payloads {
+		if err := sink.EmitResolvedTimestamp(ctx, encodedResolvedPayload{body: p.body}, p.resolved); err != nil {
+			return err
+		}
+	}
+	if s, ok := sink.(EventSink); ok {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// encodedResolvedPayload encodes every resolved timestamp as the same,
+// already encoded, payload. ResolvedTimestampSinks only use the
+// EncodeResolvedTimestamp method of their encoder.
+type encodedResolvedPayload struct {
+	Encoder
+	body []byte
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e encodedResolvedPayload) EncodeResolvedTimestamp(
+	context.Context, string, hlc.Timestamp,
+) ([]byte, error) {
+	return e.body, nil
+}
+
 // SinkWithTopics extends the Sink interface to include a method that returns
 // the topics that a changefeed will emit to.
 type SinkWithTopics interface {
@@ -145,9 +201,10 @@ func getEventSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	processorID int32,
 	m metricsRecorder,
 ) (EventSink, error) {
-	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, processorID, m)
 }
 
 func getResolvedTimestampSink(
@@ -157,9 +214,10 @@ func getResolvedTimestampSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	processorID int32,
 	m metricsRecorder,
 ) (ResolvedTimestampSink, error) {
-	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, processorID, m)
 }
 
 func getAndDialSink(
@@ -169,9 +227,10 @@ func getAndDialSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	processorID int32,
 	m metricsRecorder,
 ) (Sink, error) {
-	sink, err := getSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	sink, err := getSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, processorID, m)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +261,7 @@ func getSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	processorID int32,
 	m metricsRecorder,
 ) (Sink, error) {
 	u, err := url.Parse(feedCfg.SinkURI)
@@ -252,7 +312,7 @@ func getSink(
 				if KafkaV2Enabled.Get(&serverCfg.Settings.SV) {
 					return makeKafkaSinkV2(ctx, &changefeedbase.SinkURL{URL: u}, AllTargets(feedCfg), opts.GetKafkaConfigJSON(),
 						numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg), timeutil.DefaultTimeSource{},
-						serverCfg.Settings, metricsBuilder, kafkaSinkV2Knobs{})
+						serverCfg.Settings, metricsBuilder, jobID, processorID, kafkaSinkV2Knobs{})
 				} else {
 					return makeKafkaSink(ctx, &changefeedbase.SinkURL{URL: u}, AllTargets(feedCfg), opts.GetKafkaConfigJSON(), serverCfg.Settings, metricsBuilder)
 				}
@@ -283,6 +343,18 @@ func getSink(
 				opts.IsSet(changefeedbase.OptUnordered), numSinkIOWorkers(serverCfg),
 				newCPUPacerFactory(ctx, serverCfg), timeutil.DefaultTimeSource{},
 				metricsBuilder, serverCfg.Settings, testingKnobs)
+		case isNATSSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.NATSValidOptions, func() (Sink, error) {
+				return makeNATSSink(ctx, &changefeedbase.SinkURL{URL: u}, AllTargets(feedCfg),
+					opts.GetNATSConfigJSON(), numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg),
+					timeutil.DefaultTimeSource{}, metricsBuilder, serverCfg.Settings, natsSinkKnobs{})
+			})
+		case isAMQPSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.AMQPValidOptions, func() (Sink, error) {
+				return makeAMQPSink(ctx, &changefeedbase.SinkURL{URL: u}, encodingOpts, AllTargets(feedCfg),
+					opts.GetAMQPConfigJSON(), numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg),
+					timeutil.DefaultTimeSource{}, metricsBuilder, serverCfg.Settings, amqpSinkKnobs{})
+			})
 		case isCloudStorageSink(u):
 			return validateOptionsAndMakeSink(changefeedbase.CloudStorageValidOptions, func() (Sink, error) {
 				var testingKnobs *TestingKnobs
@@ -308,7 +380,7 @@ func getSink(
 			return validateOptionsAndMakeSink(changefeedbase.ExternalConnectionValidOptions, func() (Sink, error) {
 				return makeExternalConnectionSink(
 					ctx, &changefeedbase.SinkURL{URL: u}, user, makeExternalConnectionProvider(ctx, serverCfg.DB),
-					serverCfg, feedCfg, timestampOracle, jobID, m,
+					serverCfg, feedCfg, timestampOracle, jobID, processorID, m,
 				)
 			})
 		case u.Scheme == "":
@@ -323,6 +395,16 @@ func getSink(
 		return nil, err
 	}
 
+	if bs, ok := sink.(*batchingSink); ok {
+		dlq, err := makeDeadLetterQueue(serverCfg.DB, opts, jobID, user, m)
+		if err != nil {
+			return nil, err
+		}
+		if dlq != nil {
+			bs.setDeadLetterQueue(dlq)
+		}
+	}
+
 	if knobs, ok := serverCfg.TestingKnobs.Changefeed.(*TestingKnobs); ok && knobs.WrapSink != nil {
 		// External connections call getSink recursively and wrap the sink then.
 		if u.Scheme != changefeedbase.SinkSchemeExternalConnection {
@@ -388,6 +470,16 @@ func (s errorWrapperSink) EmitResolvedTimestamp(
 	return nil
 }
 
+// EmitResolvedPayloads implements the resolvedPayloadsSink interface.
+func (s errorWrapperSink) EmitResolvedPayloads(
+	ctx context.Context, payloads []resolvedPayload,
+) error {
+	if err := emitResolvedPayloads(ctx, s.wrapped.(ResolvedTimestampSink), payloads); err != nil {
+		return changefeedbase.MarkRetryableError(err)
+	}
+	return nil
+}
+
 // Flush implements Sink interface.
 func (s errorWrapperSink) Flush(ctx context.Context) error {
 	if err := s.wrapped.(EventSink).Flush(ctx); err != nil {
@@ -790,6 +882,50 @@ func (j *jsonMaxRetries) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// consumeTLSParams consumes the insecure_tls_skip_verify, ca_cert,
+// client_cert and client_key parameters of a sink URL into a TLS config. It
+// also returns whether any of them was set, for sinks that only use TLS when
+// asked to.
+func consumeTLSParams(u *changefeedbase.SinkURL) (_ *tls.Config, set bool, _ error) {
+	var skipVerify bool
+	var caCert, clientCert, clientKey []byte
+	skipVerifySet, err := u.ConsumeBool(changefeedbase.SinkParamSkipTLSVerify, &skipVerify)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := u.DecodeBase64(changefeedbase.SinkParamCACert, &caCert); err != nil {
+		return nil, false, err
+	}
+	if err := u.DecodeBase64(changefeedbase.SinkParamClientCert, &clientCert); err != nil {
+		return nil, false, err
+	}
+	if err := u.DecodeBase64(changefeedbase.SinkParamClientKey, &clientKey); err != nil {
+		return nil, false, err
+	}
+
+	cfg := &tls.Config{InsecureSkipVerify: skipVerify}
+	if caCert != nil {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, false, errors.Errorf("failed to parse certificate data:%s", string(caCert))
+		}
+		cfg.RootCAs = caCertPool
+	}
+	if clientCert != nil && clientKey == nil {
+		return nil, false, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey)
+	} else if clientKey != nil && clientCert == nil {
+		return nil, false, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientKey, changefeedbase.SinkParamClientCert)
+	}
+	if clientCert != nil && clientKey != nil {
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, false, errors.Wrap(err, `invalid client certificate data provided`)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, skipVerifySet || caCert != nil || clientCert != nil, nil
+}
+
 func numSinkIOWorkers(cfg *execinfra.ServerConfig) int {
 	numWorkers := changefeedbase.SinkIOWorkers.Get(&cfg.Settings.SV)
 	if numWorkers > 0 {
@@ -0,0 +1,365 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/cidr"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// amqpKeyHeader is the header that carries the encoded key of a row, as AMQP
+// messages have no key of their own.
+const amqpKeyHeader = `Changefeed-Key`
+
+// isAMQPSink returns true if url contains scheme with valid AMQP sink
+func isAMQPSink(u *url.URL) bool {
+	switch u.Scheme {
+	case changefeedbase.SinkSchemeAMQP, changefeedbase.SinkSchemeAMQPS:
+		return true
+	default:
+		return false
+	}
+}
+
+// amqpPublisher publishes messages to an AMQP 0-9-1 broker such as RabbitMQ.
+type amqpPublisher interface {
+	// publish publishes msgs to exchange with the given routing key, and returns
+	// once the broker confirmed all of them.
+	publish(ctx context.Context, exchange, routingKey string, msgs []amqp.Publishing) error
+	// checkExchange returns an error if exchange does not exist.
+	checkExchange(ctx context.Context, exchange string) error
+	close() error
+}
+
+type amqpSinkKnobs struct {
+	OverrideClient func() amqpPublisher
+}
+
+// amqpSinkClient publishes rows to an exchange of an AMQP broker, using the
+// topic of every row as its routing key. Messages are persistent and only
+// considered delivered once the broker confirmed them. They are published
+// without the mandatory flag, so messages that the exchange cannot route to
+// any queue are dropped by the broker; configure an alternate exchange to
+// keep them.
+type amqpSinkClient struct {
+	publisher   amqpPublisher
+	exchange    string
+	contentType string
+	batchCfg    sinkBatchConfig
+}
+
+var _ SinkClient = (*amqpSinkClient)(nil)
+var _ SinkPayload = (*amqpBatch)(nil)
+
+type amqpBatch struct {
+	routingKey string
+	messages   []amqp.Publishing
+}
+
+// FlushResolvedPayload implements the SinkClient interface.
+func (ac *amqpSinkClient) FlushResolvedPayload(
+	ctx context.Context,
+	body []byte,
+	forEachTopic func(func(topic string) error) error,
+	retryOpts retry.Options,
+) error {
+	return forEachTopic(func(topic string) error {
+		batch := &amqpBatch{routingKey: topic, messages: []amqp.Publishing{ac.makeMessage(nil, body, nil)}}
+		return retry.WithMaxAttempts(ctx, retryOpts, retryOpts.MaxRetries+1, func() error {
+			return ac.Flush(ctx, batch)
+		})
+	})
+}
+
+// Flush implements the SinkClient interface.
+func (ac *amqpSinkClient) Flush(ctx context.Context, payload SinkPayload) error {
+	batch := payload.(*amqpBatch)
+	if err := ac.publisher.publish(ctx, ac.exchange, batch.routingKey, batch.messages); err != nil {
+		return errors.Wrapf(err, "publishing to exchange %q with routing key %s", ac.exchange, batch.routingKey)
+	}
+	return nil
+}
+
+// CheckConnection implements the SinkClient interface.
+func (ac *amqpSinkClient) CheckConnection(ctx context.Context) error {
+	return ac.publisher.checkExchange(ctx, ac.exchange)
+}
+
+// MakeBatchBuffer implements the SinkClient interface.
+func (ac *amqpSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
+	return &amqpBuffer{
+		client:   ac,
+		batchCfg: ac.batchCfg,
+		batch: &amqpBatch{
+			routingKey: topic,
+			messages:   make([]amqp.Publishing, 0, ac.batchCfg.Messages),
+		},
+	}
+}
+
+// Close implements the SinkClient interface.
+func (ac *amqpSinkClient) Close() error {
+	return ac.publisher.close()
+}
+
+func (ac *amqpSinkClient) makeMessage(key, value []byte, headers map[string][]byte) amqp.Publishing {
+	msg := amqp.Publishing{
+		DeliveryMode: amqp.Persistent,
+		ContentType:  ac.contentType,
+		Body:         value,
+	}
+	if len(key) > 0 || len(headers) > 0 {
+		msg.Headers = make(amqp.Table, len(headers)+1)
+		for k, v := range headers {
+			msg.Headers[k] = string(v)
+		}
+		if len(key) > 0 {
+			msg.Headers[amqpKeyHeader] = string(key)
+		}
+	}
+	return msg
+}
+
+type amqpBuffer struct {
+	client   *amqpSinkClient
+	batchCfg sinkBatchConfig
+	batch    *amqpBatch
+	numBytes int
+}
+
+var _ BatchBuffer = (*amqpBuffer)(nil)
+
+// Append implements the BatchBuffer interface.
+func (b *amqpBuffer) Append(key []byte, value []byte, attrs attributes) {
+	b.batch.messages = append(b.batch.messages, b.client.makeMessage(key, value, attrs.headers))
+	b.numBytes += len(key) + len(value)
+}
+
+// Close implements the BatchBuffer interface.
+func (b *amqpBuffer) Close() (SinkPayload, error) {
+	return b.batch, nil
+}
+
+// ShouldFlush implements the BatchBuffer interface.
+func (b *amqpBuffer) ShouldFlush() bool {
+	return shouldFlushBatch(b.numBytes, len(b.batch.messages), b.batchCfg)
+}
+
+// amqpConnPublisher is the amqpPublisher of an AMQP connection. The
+// connection and its channel are (re)opened whenever they are needed, as the
+// broker closes the channel on errors such as publishing to an exchange that
+// does not exist.
+type amqpConnPublisher struct {
+	url    string
+	config amqp.Config
+
+	mu struct {
+		syncutil.Mutex
+		conn *amqp.Connection
+		ch   *amqp.Channel
+	}
+}
+
+var _ amqpPublisher = (*amqpConnPublisher)(nil)
+
+func makeAMQPConnPublisher(
+	ctx context.Context, u *url.URL, tlsCfg *tls.Config, netMetrics *cidr.NetMetrics,
+) *amqpConnPublisher {
+	connURL := *u
+	connURL.RawQuery = ``
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	dial := netMetrics.Wrap(dialer.DialContext, "amqp")
+	return &amqpConnPublisher{
+		url: connURL.String(),
+		config: amqp.Config{
+			TLSClientConfig: tlsCfg,
+			Dial: func(network, addr string) (net.Conn, error) {
+				return dial(ctx, network, addr)
+			},
+			Properties: amqp.Table{"connection_name": "cockroachdb-changefeed"},
+		},
+	}
+}
+
+// channelLocked returns an open channel in confirm mode.
+func (p *amqpConnPublisher) channelLocked() (*amqp.Channel, error) {
+	if p.mu.ch != nil && !p.mu.ch.IsClosed() {
+		return p.mu.ch, nil
+	}
+	if p.mu.conn == nil || p.mu.conn.IsClosed() {
+		conn, err := amqp.DialConfig(p.url, p.config)
+		if err != nil {
+			return nil, errors.Wrap(err, "connecting to amqp broker")
+		}
+		p.mu.conn = conn
+	}
+	ch, err := p.mu.conn.Channel()
+	if err != nil {
+		return nil, errors.Wrap(err, "opening amqp channel")
+	}
+	if err := ch.Confirm(false /* noWait */); err != nil {
+		_ = ch.Close()
+		return nil, errors.Wrap(err, "enabling publisher confirms")
+	}
+	p.mu.ch = ch
+	return ch, nil
+}
+
+// publish implements the amqpPublisher interface.
+func (p *amqpConnPublisher) publish(
+	ctx context.Context, exchange, routingKey string, msgs []amqp.Publishing,
+) error {
+	p.mu.Lock()
+	ch, err := p.channelLocked()
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	confirms := make([]*amqp.DeferredConfirmation, 0, len(msgs))
+	for _, msg := range msgs {
+		confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey,
+			false /* mandatory */, false /* immediate */, msg)
+		if err != nil {
+			return err
+		}
+		confirms = append(confirms, confirm)
+	}
+	for _, confirm := range confirms {
+		acked, err := confirm.WaitContext(ctx)
+		if err != nil {
+			return err
+		}
+		if !acked {
+			// Pending confirmations are also nacked when the broker closes the
+			// channel, for example when the exchange does not exist.
+			if ch.IsClosed() {
+				return errors.New("channel was closed before the broker confirmed the message")
+			}
+			return errors.New("message was not acknowledged by the broker")
+		}
+	}
+	return nil
+}
+
+// checkExchange implements the amqpPublisher interface.
+func (p *amqpConnPublisher) checkExchange(ctx context.Context, exchange string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.channelLocked(); err != nil {
+		return err
+	}
+	if exchange == `` {
+		// The default exchange always exists.
+		return nil
+	}
+	// A failed passive declaration closes the channel, so use a separate one.
+	ch, err := p.mu.conn.Channel()
+	if err != nil {
+		return errors.Wrap(err, "opening amqp channel")
+	}
+	defer func() { _ = ch.Close() }()
+	// The kind of the exchange is ignored by passive declarations.
+	if err := ch.ExchangeDeclarePassive(exchange, amqp.ExchangeDirect,
+		true /* durable */, false /* autoDelete */, false /* internal */, false /* noWait */, nil /* args */); err != nil {
+		return errors.Wrapf(err, "checking exchange %q", exchange)
+	}
+	return nil
+}
+
+// close implements the amqpPublisher interface.
+func (p *amqpConnPublisher) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.mu.conn == nil || p.mu.conn.IsClosed() {
+		return nil
+	}
+	return p.mu.conn.Close()
+}
+
+func makeAMQPSink(
+	ctx context.Context,
+	u *changefeedbase.SinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	targets changefeedbase.Targets,
+	jsonConfig changefeedbase.SinkSpecificJSONConfig,
+	parallelism int,
+	pacerFactory func() *admission.Pacer,
+	source timeutil.TimeSource,
+	mb metricsRecorderBuilder,
+	settings *cluster.Settings,
+	knobs amqpSinkKnobs,
+) (Sink, error) {
+	m := mb(requiresResourceAccounting)
+
+	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{})
+	if err != nil {
+		return nil, err
+	}
+
+	var contentType string
+	switch encodingOpts.Format {
+	case changefeedbase.OptFormatJSON:
+		contentType = `application/json`
+	case changefeedbase.OptFormatCSV:
+		contentType = `text/csv`
+	}
+
+	exchange := u.ConsumeParam(changefeedbase.SinkParamExchange)
+	routingKeyPrefix := u.ConsumeParam(changefeedbase.SinkParamTopicPrefix)
+	routingKey := u.ConsumeParam(changefeedbase.SinkParamTopicName)
+	topicNamer, err := MakeTopicNamer(targets, WithPrefix(routingKeyPrefix), WithSingleName(routingKey))
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCfg, tlsParamsSet, err := consumeTLSParams(u)
+	if err != nil {
+		return nil, err
+	}
+	if tlsParamsSet && u.Scheme != changefeedbase.SinkSchemeAMQPS {
+		return nil, errors.Errorf(`%s, %s, %s and %s require the %s scheme`,
+			changefeedbase.SinkParamSkipTLSVerify, changefeedbase.SinkParamCACert,
+			changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey,
+			changefeedbase.SinkSchemeAMQPS)
+	}
+
+	if unknownParams := u.RemainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown amqp sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	client := &amqpSinkClient{
+		exchange:    exchange,
+		contentType: contentType,
+		batchCfg:    batchCfg,
+	}
+	if knobs.OverrideClient != nil {
+		client.publisher = knobs.OverrideClient()
+	} else {
+		if !tlsParamsSet {
+			tlsCfg = nil
+		}
+		client.publisher = makeAMQPConnPublisher(ctx, u.URL, tlsCfg, m.netMetrics())
+	}
+
+	return makeBatchingSink(ctx, sinkTypeAMQP, client, time.Duration(batchCfg.Frequency), retryOpts,
+		parallelism, topicNamer, pacerFactory, source, m, settings), nil
+}
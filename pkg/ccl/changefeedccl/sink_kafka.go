@@ -204,6 +204,16 @@ type saramaConfig struct {
 	RequiredAcks string `json:",omitempty"`
 
 	Version string `json:",omitempty"`
+
+	// ExactlyOnce makes the sink produce within Kafka transactions that are
+	// committed as the changefeed checkpoints, each containing the rows up to
+	// the highwater of its checkpoint. It is only supported by the sink enabled
+	// by changefeed.new_kafka_sink.enabled. The changefeed then runs on a single
+	// node, holds the rows above the highwater in memory, and does not store
+	// span-level checkpoints. If it fails after committing a transaction but
+	// before persisting the checkpoint, it emits the rows of that transaction
+	// again.
+	ExactlyOnce bool `json:",omitempty"`
 }
 
 func (c saramaConfig) Validate() error {
@@ -803,6 +813,10 @@ func (c *saramaConfig) Apply(kafka *sarama.Config) error {
 	kafka.Producer.Compression = sarama.CompressionCodec(c.Compression)
 	kafka.Producer.CompressionLevel = c.CompressionLevel
 
+	if c.ExactlyOnce {
+		return errors.Errorf(`ExactlyOnce requires %s to be enabled`, KafkaV2Enabled.Name())
+	}
+
 	if c.Version != "" {
 		parsedVersion, err := sarama.ParseKafkaVersion(c.Version)
 		if err != nil {
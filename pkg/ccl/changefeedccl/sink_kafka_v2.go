@@ -10,14 +10,17 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"hash/fnv"
 	"io"
 	"net"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/cidr"
@@ -46,6 +49,17 @@ type kafkaSinkClientV2 struct {
 
 	topicsForConnectionCheck []string
 
+	// transactional is set when the client produces within Kafka transactions,
+	// see CommitTransaction.
+	transactional bool
+	txnMu         struct {
+		syncutil.Mutex
+		open bool
+		// failed is set once a produce within the open transaction has failed,
+		// after which the transaction can only be aborted.
+		failed bool
+	}
+
 	// we need to fetch and keep track of this ourselves since kgo doesnt expose metadata to us
 	metadataMu struct {
 		syncutil.Mutex
@@ -56,12 +70,15 @@ type kafkaSinkClientV2 struct {
 
 // newKafkaSinkClientV2 creates a new kafka sink client. It is a thin wrapper
 // around the kgo client for use by the batching sink. It's not meant to be
-// invoked on its own, but rather through makeKafkaSinkV2.
+// invoked on its own, but rather through makeKafkaSinkV2. If transactionalID
+// is set, the client is an idempotent producer that writes within Kafka
+// transactions.
 func newKafkaSinkClientV2(
 	ctx context.Context,
 	clientOpts []kgo.Opt,
 	batchCfg sinkBatchConfig,
 	bootstrapAddrsStr string,
+	transactionalID string,
 	settings *cluster.Settings,
 	knobs kafkaSinkV2Knobs,
 	mb metricsRecorderBuilder,
@@ -70,9 +87,6 @@ func newKafkaSinkClientV2(
 	bootstrapBrokers := strings.Split(bootstrapAddrsStr, `,`)
 
 	baseOpts := []kgo.Opt{
-		// Disable idempotency to maintain parity with the v1 sink and not add surface area for unknowns.
-		kgo.DisableIdempotentWrite(),
-
 		kgo.SeedBrokers(bootstrapBrokers...),
 		kgo.WithLogger(kgoLogAdapter{ctx: ctx}),
 		kgo.RecordPartitioner(newKgoChangefeedPartitioner()),
@@ -102,6 +116,21 @@ func newKafkaSinkClientV2(
 	}
 
 	clientOpts = append(baseOpts, clientOpts...)
+	if transactionalID == `` {
+		// Disable idempotency to maintain parity with the v1 sink and not add surface area for unknowns.
+		clientOpts = append(clientOpts, kgo.DisableIdempotentWrite())
+	} else {
+		clientOpts = append(clientOpts,
+			kgo.TransactionalID(transactionalID),
+			// Transactions require idempotent writes, which in turn require
+			// acknowledgement from all in-sync replicas.
+			kgo.RequiredAcks(kgo.AllISRAcks()),
+			// A transaction stays open between two changefeed checkpoints, which
+			// can be further apart than kgo's default timeout of 40s. Kafka caps
+			// this at the broker's transaction.max.timeout.ms, 15m by default.
+			kgo.TransactionTimeout(kafkaTransactionTimeout),
+		)
+	}
 
 	var client KafkaClientV2
 	var adminClient KafkaAdminClientV2
@@ -122,28 +151,85 @@ func newKafkaSinkClientV2(
 		adminClient:              adminClient,
 		knobs:                    knobs,
 		batchCfg:                 batchCfg,
-		canTryResizing:           changefeedbase.BatchReductionRetryEnabled.Get(&settings.SV),
 		recordResize:             recordResize,
 		topicsForConnectionCheck: topicsForConnectionCheck,
+		transactional:            transactionalID != ``,
+		// A failed produce dooms the open transaction, so there is no point in
+		// retrying a smaller batch within it.
+		canTryResizing: changefeedbase.BatchReductionRetryEnabled.Get(&settings.SV) && transactionalID == ``,
 	}
 	c.metadataMu.allTopicPartitions = make(map[string][]int32)
 
 	return c, nil
 }
 
+// kafkaTransactionalID returns the transactional ID of the Kafka producer of
+// the given changefeed processor. The ID is stable across restarts of the
+// changefeed, so that the producers of a restarted changefeed fence off those
+// of the previous attempt and abort their open transactions, as Kafka only
+// allows the most recent producer using a given ID to write.
+func kafkaTransactionalID(jobID jobspb.JobID, processorID int32) string {
+	return fmt.Sprintf(`crdb-changefeed-%d-%d`, jobID, processorID)
+}
+
+// isExactlyOnce returns whether the kafka_sink_config of a changefeed enables
+// exactly-once delivery. Invalid configs are reported when the sink is created.
+func isExactlyOnce(opts changefeedbase.StatementOptions) bool {
+	cfg, err := getSaramaConfig(opts.GetKafkaConfigJSON())
+	return err == nil && cfg.ExactlyOnce
+}
+
+// kafkaTransactionTimeout is the timeout after which the broker aborts a Kafka
+// transaction that was neither committed nor aborted by the sink.
+const kafkaTransactionTimeout = 5 * time.Minute
+
 // Close implements SinkClient.
 func (k *kafkaSinkClientV2) Close() error {
+	var err error
+	if k.transactional {
+		// Abort the open transaction, if any, so that read_committed consumers
+		// do not have to wait for it to time out.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		k.txnMu.Lock()
+		if k.txnMu.open {
+			err = k.abortTransactionLocked(ctx)
+		}
+		k.txnMu.Unlock()
+	}
 	k.client.Close()
-	return nil
+	return err
 }
 
 // Flush implements SinkClient. Does not retry -- retries will be handled either by kafka or ParallelIO.
 func (k *kafkaSinkClientV2) Flush(ctx context.Context, payload SinkPayload) (retErr error) {
 	msgs := payload.([]*kgo.Record)
 
-	var flushMsgs func(msgs []*kgo.Record) error
-	flushMsgs = func(msgs []*kgo.Record) error {
-		if err := k.client.ProduceSync(ctx, msgs...).FirstErr(); err != nil {
+	if k.transactional {
+		if err := k.maybeBeginTransaction(); err != nil {
+			return err
+		}
+		defer func() {
+			if retErr != nil {
+				k.txnMu.Lock()
+				defer k.txnMu.Unlock()
+				k.txnMu.failed = true
+			}
+		}()
+	}
+
+	// rejected holds the positions in the payload of the records that the
+	// broker permanently rejected, and rejectedErr the first such rejection.
+	// The other records of the payload were delivered, so only the rejected
+	// ones are reported.
+	var rejected []int
+	var rejectedErr error
+
+	// offset is the position of msgs in the payload.
+	var flushMsgs func(msgs []*kgo.Record, offset int) error
+	flushMsgs = func(msgs []*kgo.Record, offset int) error {
+		results := k.client.ProduceSync(ctx, msgs...)
+		if err := results.FirstErr(); err != nil {
 			if k.shouldTryResizing(err, msgs) {
 				a, b := msgs[0:len(msgs)/2], msgs[len(msgs)/2:]
 				// Recurse. This is a little odd because the client's batch
@@ -155,21 +241,61 @@ func (k *kafkaSinkClientV2) Flush(ctx context.Context, payload SinkPayload) (ret
 				// Ideally users would set kafka-side max bytes appropriately
 				// with respect to their average message sizes.
 				k.recordResize(int64(len(a)))
-				if err := flushMsgs(a); err != nil {
+				if err := flushMsgs(a, offset); err != nil {
 					return err
 				}
 				k.recordResize(int64(len(b)))
-				if err := flushMsgs(b); err != nil {
+				if err := flushMsgs(b, offset+len(a)); err != nil {
 					return err
 				}
 				return nil
+			} else if k.transactional {
+				return err
+			} else if positions, ok := rejectedRecords(results, msgs); ok {
+				for _, i := range positions {
+					rejected = append(rejected, offset+i)
+				}
+				if rejectedErr == nil {
+					rejectedErr = err
+				}
+				return nil
 			} else {
 				return err
 			}
 		}
 		return nil
 	}
-	return flushMsgs(msgs)
+	if err := flushMsgs(msgs, 0); err != nil {
+		return err
+	}
+	if len(rejected) > 0 {
+		return markMessagesRejectedBySink(rejectedErr, rejected)
+	}
+	return nil
+}
+
+// rejectedRecords returns the positions in msgs of the records that failed to
+// be produced. It returns false unless every failure is a permanent rejection
+// of a record of msgs by the broker.
+func rejectedRecords(results kgo.ProduceResults, msgs []*kgo.Record) (_ []int, ok bool) {
+	// The results are ordered by completion rather than by record.
+	positions := make(map[*kgo.Record]int, len(msgs))
+	for i, m := range msgs {
+		positions[m] = i
+	}
+	var rejected []int
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		i, found := positions[r.Record]
+		if !found || !isRejectedByKafka(r.Err) {
+			return nil, false
+		}
+		rejected = append(rejected, i)
+	}
+	slices.Sort(rejected)
+	return rejected, len(rejected) > 0
 }
 
 // FlushResolvedPayload implements SinkClient.
@@ -178,6 +304,17 @@ func (k *kafkaSinkClientV2) FlushResolvedPayload(
 	body []byte,
 	forEachTopic func(func(topic string) error) error,
 	retryOpts retry.Options,
+) error {
+	return k.FlushResolvedPayloads(ctx, [][]byte{body}, forEachTopic, retryOpts)
+}
+
+// FlushResolvedPayloads implements resolvedPayloadsSinkClient. All the
+// payloads are produced to every partition with a single request.
+func (k *kafkaSinkClientV2) FlushResolvedPayloads(
+	ctx context.Context,
+	bodies [][]byte,
+	forEachTopic func(func(topic string) error) error,
+	retryOpts retry.Options,
 ) error {
 	return retryOpts.Do(ctx, func(ctx context.Context) error {
 		if err := k.maybeUpdateTopicPartitions(ctx, forEachTopic); err != nil {
@@ -188,18 +325,20 @@ func (k *kafkaSinkClientV2) FlushResolvedPayload(
 		err := func() error {
 			k.metadataMu.Lock()
 			defer k.metadataMu.Unlock()
-			msgs = make([]*kgo.Record, 0, len(k.metadataMu.allTopicPartitions))
+			msgs = make([]*kgo.Record, 0, len(k.metadataMu.allTopicPartitions)*len(bodies))
 			return forEachTopic(func(topic string) error {
 				if _, ok := k.metadataMu.allTopicPartitions[topic]; !ok {
 					log.Warningf(ctx, `cannot flush resolved timestamp for unknown topic %s`, topic)
 				}
 				for _, partition := range k.metadataMu.allTopicPartitions[topic] {
-					msgs = append(msgs, &kgo.Record{
-						Topic:     topic,
-						Partition: partition,
-						Key:       nil,
-						Value:     body,
-					})
+					for _, body := range bodies {
+						msgs = append(msgs, &kgo.Record{
+							Topic:     topic,
+							Partition: partition,
+							Key:       nil,
+							Value:     body,
+						})
+					}
 				}
 				return nil
 			})
@@ -207,10 +346,64 @@ func (k *kafkaSinkClientV2) FlushResolvedPayload(
 		if err != nil {
 			return err
 		}
-		return k.Flush(ctx, msgs)
+		if err := k.Flush(ctx, msgs); err != nil {
+			return err
+		}
+		// Resolved timestamps are emitted by the change frontier once it has
+		// persisted a checkpoint at them, so they are committed right away.
+		return k.CommitTransaction(ctx)
 	})
 }
 
+// CommitTransaction implements transactionalSinkClient. The change frontier
+// calls it before persisting a checkpoint covering the flushed rows (see
+// transactionalSink). As a result, consumers with read_committed isolation do
+// not see the rows produced by an aggregator that failed before the changefeed
+// checkpointed past them, since those rows are aborted rather than replayed on
+// top of visible copies.
+func (k *kafkaSinkClientV2) CommitTransaction(ctx context.Context) error {
+	if !k.transactional {
+		return nil
+	}
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+	if !k.txnMu.open {
+		return nil
+	}
+	if k.txnMu.failed {
+		return errors.CombineErrors(
+			errors.New(`kafka transaction aborted after a failed produce`),
+			k.abortTransactionLocked(ctx),
+		)
+	}
+	k.txnMu.open = false
+	if err := k.client.EndTransaction(ctx, kgo.TryCommit); err != nil {
+		return errors.Wrap(err, `committing kafka transaction`)
+	}
+	return nil
+}
+
+func (k *kafkaSinkClientV2) maybeBeginTransaction() error {
+	k.txnMu.Lock()
+	defer k.txnMu.Unlock()
+	if k.txnMu.open {
+		return nil
+	}
+	if err := k.client.BeginTransaction(); err != nil {
+		return errors.Wrap(err, `beginning kafka transaction`)
+	}
+	k.txnMu.open = true
+	return nil
+}
+
+func (k *kafkaSinkClientV2) abortTransactionLocked(ctx context.Context) error {
+	k.txnMu.open, k.txnMu.failed = false, false
+	if err := k.client.AbortBufferedRecords(ctx); err != nil {
+		return errors.Wrap(err, `aborting kafka transaction`)
+	}
+	return errors.Wrap(k.client.EndTransaction(ctx, kgo.TryAbort), `aborting kafka transaction`)
+}
+
 func (k *kafkaSinkClientV2) CheckConnection(ctx context.Context) error {
 	return k.maybeUpdateTopicPartitions(ctx, func(cb func(topic string) error) error {
 		for _, topic := range k.topicsForConnectionCheck {
@@ -273,9 +466,22 @@ func (k *kafkaSinkClientV2) shouldTryResizing(err error, msgs []*kgo.Record) boo
 	return errors.Is(err, kerr.MessageTooLarge)
 }
 
+// isRejectedByKafka returns true if err indicates that the broker will never
+// accept the records that were produced, no matter how often they are retried.
+// Records produced in a transaction are never treated as rejected since the
+// failure aborts the whole transaction.
+func isRejectedByKafka(err error) bool {
+	return errors.Is(err, kerr.MessageTooLarge) || errors.Is(err, kerr.InvalidRecord)
+}
+
 // KafkaClientV2 is a small interface restricting the functionality in *kgo.Client
 type KafkaClientV2 interface {
 	ProduceSync(ctx context.Context, msgs ...*kgo.Record) kgo.ProduceResults
+	// BeginTransaction, EndTransaction and AbortBufferedRecords are only used
+	// when the sink is configured for exactly-once delivery.
+	BeginTransaction() error
+	EndTransaction(ctx context.Context, commit kgo.TransactionEndTry) error
+	AbortBufferedRecords(ctx context.Context) error
 	Close()
 }
 
@@ -291,6 +497,7 @@ type kafkaSinkV2Knobs struct {
 }
 
 var _ SinkClient = (*kafkaSinkClientV2)(nil)
+var _ transactionalSinkClient = (*kafkaSinkClientV2)(nil)
 var _ SinkPayload = ([]*kgo.Record)(nil) // NOTE: This doesn't actually assert anything, but it's good documentation.
 
 type kafkaBuffer struct {
@@ -326,6 +533,7 @@ func (b *kafkaBuffer) ShouldFlush() bool {
 }
 
 var _ BatchBuffer = (*kafkaBuffer)(nil)
+var _ resolvedPayloadsSinkClient = (*kafkaSinkClientV2)(nil)
 
 func makeKafkaSinkV2(
 	ctx context.Context,
@@ -337,6 +545,8 @@ func makeKafkaSinkV2(
 	timeSource timeutil.TimeSource,
 	settings *cluster.Settings,
 	mb metricsRecorderBuilder,
+	jobID jobspb.JobID,
+	processorID int32,
 	knobs kafkaSinkV2Knobs,
 ) (Sink, error) {
 	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{
@@ -376,8 +586,18 @@ func makeKafkaSinkV2(
 			`unknown kafka sink query parameters: %s`, strings.Join(unknownParams, ", "))
 	}
 
+	// The config was validated by buildKgoConfig.
+	sinkCfg, err := getSaramaConfig(jsonConfig)
+	if err != nil {
+		return nil, err
+	}
+	var transactionalID string
+	if sinkCfg.ExactlyOnce {
+		transactionalID = kafkaTransactionalID(jobID, processorID)
+	}
+
 	topicsForConnectionCheck := topicNamer.DisplayNamesSlice()
-	client, err := newKafkaSinkClientV2(ctx, clientOpts, batchCfg, u.Host, settings, knobs, mb, topicsForConnectionCheck)
+	client, err := newKafkaSinkClientV2(ctx, clientOpts, batchCfg, u.Host, transactionalID, settings, knobs, mb, topicsForConnectionCheck)
 	if err != nil {
 		return nil, err
 	}
@@ -473,10 +693,16 @@ func buildKgoConfig(
 
 	switch strings.ToUpper(sinkCfg.RequiredAcks) {
 	case ``, `ONE`, `1`: // This is our default.
+		if sinkCfg.ExactlyOnce && sinkCfg.RequiredAcks != `` {
+			return nil, errors.Errorf(`ExactlyOnce requires RequiredAcks to be ALL`)
+		}
 		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()))
 	case `ALL`, `-1`:
 		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
 	case `NONE`, `0`:
+		if sinkCfg.ExactlyOnce {
+			return nil, errors.Errorf(`ExactlyOnce requires RequiredAcks to be ALL`)
+		}
 		opts = append(opts, kgo.RequiredAcks(kgo.NoAck()))
 	default:
 		return nil, errors.Errorf(`unknown required acks value: %s`, sinkCfg.RequiredAcks)
@@ -20,11 +20,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/mocks"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -87,6 +90,24 @@ func TestKafkaSinkClientV2_Resolved(t *testing.T) {
 	fx.kc.EXPECT().ProduceSync(fx.ctx, matchers...)
 
 	require.NoError(t, fx.sink.FlushResolvedPayload(fx.ctx, []byte(`{"resolved" 42}`), forEachTopic, retry.Options{}))
+
+	// Several payloads are produced with a single request, in order on every
+	// partition.
+	bodies := [][]byte{[]byte(`{"begin" 43}`), []byte(`{"commit" 43}`)}
+	multiMatchers := make([]any, 0, 12)
+	for _, topic := range []string{"t1", "t2", "t3"} {
+		for _, partition := range []int32{0, 1} {
+			for _, body := range bodies {
+				multiMatchers = append(multiMatchers, fnMatcher(func(arg any) bool {
+					r := arg.(*kgo.Record)
+					return r.Topic == topic && r.Partition == partition && string(r.Value) == string(body)
+				}))
+			}
+		}
+	}
+	fx.kc.EXPECT().ProduceSync(fx.ctx, multiMatchers...).Times(1)
+
+	require.NoError(t, fx.sink.FlushResolvedPayloads(fx.ctx, bodies, forEachTopic, retry.Options{}))
 }
 
 func TestKafkaSinkClientV2_Basic(t *testing.T) {
@@ -181,6 +202,259 @@ func TestKafkaSinkClientV2_Resize(t *testing.T) {
 		require.NoError(t, fx.sink.Flush(fx.ctx, payload))
 		require.Len(t, gotRecordValues, 100)
 	})
+
+	t.Run("resize enabled and a record is rejected", func(t *testing.T) {
+		fx, payload, _ := setup(t, true)
+		msgs := payload.([]*kgo.Record)
+
+		// Only the 30th record is too large, so splitting the batch delivers all
+		// the others.
+		gotRecordValues := make(map[string]struct{})
+		fx.kc.EXPECT().ProduceSync(fx.ctx, gomock.Any()).AnyTimes().DoAndReturn(func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+			var pr kgo.ProduceResults
+			for _, r := range records {
+				if r == msgs[30] {
+					pr = append(pr, kgo.ProduceResult{Record: r, Err: fmt.Errorf("..: %w", kerr.MessageTooLarge)})
+				} else {
+					pr = append(pr, kgo.ProduceResult{Record: r})
+				}
+			}
+			if pr.FirstErr() == nil {
+				for _, r := range records {
+					gotRecordValues[string(r.Value)] = struct{}{}
+				}
+			}
+			return pr
+		})
+
+		err := fx.sink.Flush(fx.ctx, payload)
+		require.True(t, errors.Is(err, errRejectedBySink))
+		var rejected *rejectedMessagesError
+		require.True(t, errors.As(err, &rejected))
+		require.Equal(t, []int{30}, rejected.messages)
+		require.Len(t, gotRecordValues, 99)
+	})
+}
+
+func TestKafkaSinkClientV2_Rejected(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	setup := func(t *testing.T) (*kafkaSinkV2Fx, []*kgo.Record) {
+		fx := newKafkaSinkV2Fx(t)
+		buf := fx.sink.MakeBatchBuffer("t")
+		for i := range 3 {
+			buf.Append([]byte("k1"), []byte(strconv.Itoa(i)), attributes{})
+		}
+		payload, err := buf.Close()
+		require.NoError(t, err)
+		return fx, payload.([]*kgo.Record)
+	}
+
+	t.Run("only the rejected records are reported", func(t *testing.T) {
+		fx, msgs := setup(t)
+		defer fx.close()
+		// Results are ordered by completion rather than by record.
+		pr := kgo.ProduceResults{
+			{Record: msgs[2], Err: fmt.Errorf("..: %w", kerr.MessageTooLarge)},
+			{Record: msgs[0]},
+			{Record: msgs[1], Err: fmt.Errorf("..: %w", kerr.InvalidRecord)},
+		}
+		fx.kc.EXPECT().ProduceSync(fx.ctx, gomock.Any()).Times(1).Return(pr)
+
+		err := fx.sink.Flush(fx.ctx, msgs)
+		require.True(t, errors.Is(err, errRejectedBySink))
+		var rejected *rejectedMessagesError
+		require.True(t, errors.As(err, &rejected))
+		require.Equal(t, []int{1, 2}, rejected.messages)
+	})
+
+	t.Run("other failures are not rejections", func(t *testing.T) {
+		fx, msgs := setup(t)
+		defer fx.close()
+		pr := kgo.ProduceResults{
+			{Record: msgs[0], Err: fmt.Errorf("..: %w", kerr.MessageTooLarge)},
+			{Record: msgs[1], Err: fmt.Errorf("..: %w", kerr.NotLeaderForPartition)},
+			{Record: msgs[2]},
+		}
+		fx.kc.EXPECT().ProduceSync(fx.ctx, gomock.Any()).Times(1).Return(pr)
+
+		err := fx.sink.Flush(fx.ctx, msgs)
+		require.Error(t, err)
+		require.False(t, errors.Is(err, errRejectedBySink))
+	})
+}
+
+func TestKafkaSinkClientV2_Transactions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	makePayload := func(t *testing.T, fx *kafkaSinkV2Fx) SinkPayload {
+		buf := fx.sink.MakeBatchBuffer("t")
+		buf.Append([]byte("k1"), []byte("v1"), attributes{})
+		payload, err := buf.Close()
+		require.NoError(t, err)
+		return payload
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		fx := newKafkaSinkV2Fx(t, withTransactionalID("txn"))
+		defer fx.close()
+
+		// Both flushes are part of a single transaction, and the next flush
+		// starts a new one.
+		gomock.InOrder(
+			fx.kc.EXPECT().BeginTransaction().Times(1).Return(nil),
+			fx.kc.EXPECT().ProduceSync(fx.ctx, gomock.Any()).Times(2).Return(nil),
+			fx.kc.EXPECT().EndTransaction(fx.ctx, kgo.TryCommit).Times(1).Return(nil),
+			fx.kc.EXPECT().BeginTransaction().Times(1).Return(nil),
+			fx.kc.EXPECT().ProduceSync(fx.ctx, gomock.Any()).Times(1).Return(nil),
+			fx.kc.EXPECT().EndTransaction(fx.ctx, kgo.TryCommit).Times(1).Return(nil),
+		)
+		require.NoError(t, fx.sink.Flush(fx.ctx, makePayload(t, fx)))
+		require.NoError(t, fx.sink.Flush(fx.ctx, makePayload(t, fx)))
+		require.NoError(t, fx.sink.CommitTransaction(fx.ctx))
+		require.NoError(t, fx.sink.Flush(fx.ctx, makePayload(t, fx)))
+		require.NoError(t, fx.sink.CommitTransaction(fx.ctx))
+
+		// Committing without an open transaction is a no-op.
+		require.NoError(t, fx.sink.CommitTransaction(fx.ctx))
+	})
+
+	t.Run("failed produce aborts", func(t *testing.T) {
+		fx := newKafkaSinkV2Fx(t, withTransactionalID("txn"),
+			withSettings(func(settings *cluster.Settings) {
+				changefeedbase.BatchReductionRetryEnabled.Override(context.Background(), &settings.SV, true)
+			}))
+		defer fx.close()
+
+		// Resizing is disabled for transactional clients.
+		pr := kgo.ProduceResults{kgo.ProduceResult{Err: fmt.Errorf("..: %w", kerr.MessageTooLarge)}}
+		gomock.InOrder(
+			fx.kc.EXPECT().BeginTransaction().Times(1).Return(nil),
+			fx.kc.EXPECT().ProduceSync(fx.ctx, gomock.Any(), gomock.Any()).Times(1).Return(pr),
+			fx.kc.EXPECT().AbortBufferedRecords(fx.ctx).Times(1).Return(nil),
+			fx.kc.EXPECT().EndTransaction(fx.ctx, kgo.TryAbort).Times(1).Return(nil),
+		)
+		buf := fx.sink.MakeBatchBuffer("t")
+		buf.Append([]byte("k1"), []byte("v1"), attributes{})
+		buf.Append([]byte("k2"), []byte("v2"), attributes{})
+		payload, err := buf.Close()
+		require.NoError(t, err)
+		require.Error(t, fx.sink.Flush(fx.ctx, payload))
+		require.ErrorContains(t, fx.sink.CommitTransaction(fx.ctx), `kafka transaction aborted after a failed produce`)
+	})
+
+	t.Run("close aborts", func(t *testing.T) {
+		fx := newKafkaSinkV2Fx(t, withTransactionalID("txn"))
+
+		gomock.InOrder(
+			fx.kc.EXPECT().BeginTransaction().Times(1).Return(nil),
+			fx.kc.EXPECT().ProduceSync(fx.ctx, gomock.Any()).Times(1).Return(nil),
+			fx.kc.EXPECT().AbortBufferedRecords(gomock.Any()).Times(1).Return(nil),
+			fx.kc.EXPECT().EndTransaction(gomock.Any(), kgo.TryAbort).Times(1).Return(nil),
+		)
+		require.NoError(t, fx.sink.Flush(fx.ctx, makePayload(t, fx)))
+		fx.close()
+	})
+
+	t.Run("restart from the highwater", func(t *testing.T) {
+		// The mocked broker keeps track of the records visible to consumers with
+		// read_committed isolation.
+		var mu syncutil.Mutex
+		var open, committed []string
+		mockBroker := func(fx *kafkaSinkV2Fx) {
+			fx.kc.EXPECT().BeginTransaction().AnyTimes().Return(nil)
+			fx.kc.EXPECT().ProduceSync(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(_ context.Context, records ...*kgo.Record) kgo.ProduceResults {
+					mu.Lock()
+					defer mu.Unlock()
+					for _, r := range records {
+						open = append(open, string(r.Key))
+					}
+					return nil
+				})
+			fx.kc.EXPECT().EndTransaction(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(_ context.Context, commit kgo.TransactionEndTry) error {
+					mu.Lock()
+					defer mu.Unlock()
+					if commit == kgo.TryCommit {
+						committed = append(committed, open...)
+					}
+					open = nil
+					return nil
+				})
+			fx.kc.EXPECT().AbortBufferedRecords(gomock.Any()).AnyTimes().Return(nil)
+			fx.kc.EXPECT().Close().AnyTimes()
+		}
+		getCommitted := func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), committed...)
+		}
+		ts := func(wallTime int64) hlc.Timestamp {
+			return hlc.Timestamp{WallTime: wallTime}
+		}
+		emit := func(s *transactionalSink, wallTimes ...int64) {
+			for _, wt := range wallTimes {
+				require.NoError(t, s.EmitRow(context.Background(), topic(`t`),
+					[]byte(fmt.Sprintf(`k%d`, wt)), []byte(`v`), ts(wt), ts(wt), zeroAlloc, nil))
+			}
+		}
+
+		fx := newKafkaSinkV2Fx(t, withJSONConfig(`{"ExactlyOnce": true}`))
+		require.True(t, fx.bs.isTransactional())
+		mockBroker(fx)
+		s := newTransactionalSink(fx.bs, ts(0))
+
+		// Rows are emitted ahead of the checkpoints.
+		emit(s, 1, 2, 3, 4)
+		require.NoError(t, s.Flush(fx.ctx))
+		require.Empty(t, getCommitted())
+		// The transaction committed for a checkpoint at 2 only contains the rows
+		// up to 2.
+		require.NoError(t, s.commit(fx.ctx, ts(2)))
+		require.Equal(t, []string{`k1`, `k2`}, getCommitted())
+		// Checkpoints below the last one commit nothing.
+		require.NoError(t, s.commit(fx.ctx, ts(1)))
+		require.Equal(t, []string{`k1`, `k2`}, getCommitted())
+
+		// The changefeed fails before checkpointing past the other rows.
+		emit(s, 5)
+		require.NoError(t, s.Close())
+		fx.bs = nil
+		fx.close()
+
+		// Once restarted from the highwater, the changefeed emits every row above
+		// it again, and each row is committed exactly once.
+		fx = newKafkaSinkV2Fx(t, withJSONConfig(`{"ExactlyOnce": true}`))
+		mockBroker(fx)
+		s = newTransactionalSink(fx.bs, ts(2))
+		emit(s, 3, 4, 5, 6)
+		require.NoError(t, s.commit(fx.ctx, ts(4)))
+		require.Equal(t, []string{`k1`, `k2`, `k3`, `k4`}, getCommitted())
+		require.NoError(t, s.commit(fx.ctx, ts(6)))
+		require.Equal(t, []string{`k1`, `k2`, `k3`, `k4`, `k5`, `k6`}, getCommitted())
+		require.NoError(t, s.Close())
+		fx.bs = nil
+		fx.close()
+	})
+
+	t.Run("transactional id", func(t *testing.T) {
+		// The ID only depends on the job and the processor, so that the producers
+		// of a restarted changefeed fence off those of the previous attempt.
+		require.Equal(t, `crdb-changefeed-123-4`, kafkaTransactionalID(123, 4))
+		require.Equal(t, kafkaTransactionalID(123, 4), kafkaTransactionalID(123, 4))
+		require.NotEqual(t, kafkaTransactionalID(123, 4), kafkaTransactionalID(123, 5))
+	})
+
+	t.Run("requires acks from all replicas", func(t *testing.T) {
+		var createErr error
+		fx := newKafkaSinkV2Fx(t, withJSONConfig(`{"ExactlyOnce": true, "RequiredAcks": "ONE"}`),
+			withCreateClientErrorCb(func(err error) { createErr = err }))
+		defer fx.close()
+		require.ErrorContains(t, createErr, `ExactlyOnce requires RequiredAcks to be ALL`)
+	})
 }
 
 // These are really tests of the TopicNamer and our configuration of it.
@@ -366,6 +640,17 @@ func TestKafkaSinkClientV2_Opts(t *testing.T) {
 			},
 			expectedBatchingSinkMinFreq: 2 * time.Second,
 		},
+		{
+			name: "exactly once",
+			jsonConfig: map[string]any{
+				"ExactlyOnce": true,
+			},
+			expectedOpts: map[string]any{
+				"RequiredAcks":           kgo.AllISRAcks(),
+				"DisableIdempotentWrite": false,
+				"TransactionTimeout":     kafkaTransactionTimeout,
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -614,6 +899,7 @@ type kafkaSinkV2Fx struct {
 	additionalKOpts     []kgo.Opt
 	createClientErrorCb func(error)
 	uri                 string
+	transactionalID     string
 
 	sink *kafkaSinkClientV2
 	bs   *batchingSink
@@ -669,6 +955,12 @@ func withKOptsClient(kOpts []kgo.Opt) fxOpt {
 	}
 }
 
+func withTransactionalID(id string) fxOpt {
+	return func(fx *kafkaSinkV2Fx) {
+		fx.transactionalID = id
+	}
+}
+
 func withCreateClientErrorCb(cb func(error)) fxOpt {
 	return func(fx *kafkaSinkV2Fx) {
 		fx.createClientErrorCb = cb
@@ -711,7 +1003,7 @@ func newKafkaSinkV2Fx(t *testing.T, opts ...fxOpt) *kafkaSinkV2Fx {
 	}
 
 	var err error
-	fx.sink, err = newKafkaSinkClientV2(ctx, fx.additionalKOpts, fx.batchConfig, uri, settings, knobs, nilMetricsRecorderBuilder, nil)
+	fx.sink, err = newKafkaSinkClientV2(ctx, fx.additionalKOpts, fx.batchConfig, uri, fx.transactionalID, settings, knobs, nilMetricsRecorderBuilder, nil)
 	if err != nil && fx.createClientErrorCb != nil {
 		fx.createClientErrorCb(err)
 		return fx
@@ -732,7 +1024,7 @@ func newKafkaSinkV2Fx(t *testing.T, opts ...fxOpt) *kafkaSinkV2Fx {
 	}
 	u.RawQuery = q.Encode()
 
-	bs, err := makeKafkaSinkV2(ctx, &changefeedbase.SinkURL{URL: u}, targets, fx.sinkJSONConfig, 1, nilPacerFactory, timeutil.DefaultTimeSource{}, settings, nilMetricsRecorderBuilder, knobs)
+	bs, err := makeKafkaSinkV2(ctx, &changefeedbase.SinkURL{URL: u}, targets, fx.sinkJSONConfig, 1, nilPacerFactory, timeutil.DefaultTimeSource{}, settings, nilMetricsRecorderBuilder, 0 /* jobID */, 0 /* processorID */, knobs)
 	if err != nil && fx.createClientErrorCb != nil {
 		fx.createClientErrorCb(err)
 		return fx
@@ -0,0 +1,316 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/cidr"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// natsKeyHeader is the header that carries the encoded key of a row, as NATS
+// messages have no key of their own.
+const natsKeyHeader = `Changefeed-Key`
+
+// isNATSSink returns true if url contains scheme with valid NATS sink
+func isNATSSink(u *url.URL) bool {
+	return u.Scheme == changefeedbase.SinkSchemeNATS
+}
+
+// NATSJetStream is the subset of jetstream.JetStream used by the NATS sink.
+type NATSJetStream interface {
+	PublishMsgAsync(msg *nats.Msg, opts ...jetstream.PublishOpt) (jetstream.PubAckFuture, error)
+	StreamNameBySubject(ctx context.Context, subject string) (string, error)
+}
+
+type natsSinkKnobs struct {
+	OverrideClient func() NATSJetStream
+}
+
+// natsSinkClient publishes rows to NATS JetStream. Every table is published to
+// its own subject, which has to be captured by a stream: a message is only
+// considered delivered once the stream acknowledged storing it.
+type natsSinkClient struct {
+	conn     *nats.Conn // nil if the client was overridden by knobs
+	js       NATSJetStream
+	batchCfg sinkBatchConfig
+
+	subjectsForConnectionCheck []string
+}
+
+var _ SinkClient = (*natsSinkClient)(nil)
+var _ SinkPayload = ([]*nats.Msg)(nil)
+
+// FlushResolvedPayload implements the SinkClient interface.
+func (nc *natsSinkClient) FlushResolvedPayload(
+	ctx context.Context,
+	body []byte,
+	forEachTopic func(func(topic string) error) error,
+	retryOpts retry.Options,
+) error {
+	return forEachTopic(func(topic string) error {
+		msgs := []*nats.Msg{{Subject: topic, Data: body}}
+		return retry.WithMaxAttempts(ctx, retryOpts, retryOpts.MaxRetries+1, func() error {
+			return nc.Flush(ctx, msgs)
+		})
+	})
+}
+
+// Flush implements the SinkClient interface. The messages are published
+// asynchronously, and Flush returns once JetStream acknowledged all of them.
+func (nc *natsSinkClient) Flush(ctx context.Context, payload SinkPayload) error {
+	msgs := payload.([]*nats.Msg)
+	acks := make([]jetstream.PubAckFuture, 0, len(msgs))
+	for _, msg := range msgs {
+		ack, err := nc.js.PublishMsgAsync(msg)
+		if err != nil {
+			return natsPublishError(msg.Subject, err)
+		}
+		acks = append(acks, ack)
+	}
+	for _, ack := range acks {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ack.Ok():
+		case err := <-ack.Err():
+			return natsPublishError(ack.Msg().Subject, err)
+		}
+	}
+	return nil
+}
+
+func natsPublishError(subject string, err error) error {
+	switch {
+	case errors.Is(err, nats.ErrMaxPayload):
+		// The server will never accept a message larger than its max_payload.
+		return markRejectedBySink(err)
+	case errors.Is(err, jetstream.ErrNoStreamResponse):
+		return errors.WithHintf(
+			errors.Wrapf(err, "publishing to subject %s", subject),
+			"Create a JetStream stream whose subjects include %s.", subject)
+	default:
+		return errors.Wrapf(err, "publishing to subject %s", subject)
+	}
+}
+
+// CheckConnection implements the SinkClient interface. It verifies that every
+// subject the changefeed publishes to is captured by a stream.
+func (nc *natsSinkClient) CheckConnection(ctx context.Context) error {
+	for _, subject := range nc.subjectsForConnectionCheck {
+		if _, err := nc.js.StreamNameBySubject(ctx, subject); err != nil {
+			return errors.WithHintf(
+				errors.Wrapf(err, "looking up the JetStream stream of subject %s", subject),
+				"Create a JetStream stream whose subjects include %s.", subject)
+		}
+	}
+	return nil
+}
+
+// MakeBatchBuffer implements the SinkClient interface.
+func (nc *natsSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
+	return &natsBuffer{
+		subject:  topic,
+		batchCfg: nc.batchCfg,
+		messages: make([]*nats.Msg, 0, nc.batchCfg.Messages),
+	}
+}
+
+// Close implements the SinkClient interface.
+func (nc *natsSinkClient) Close() error {
+	if nc.conn != nil {
+		nc.conn.Close()
+	}
+	return nil
+}
+
+type natsBuffer struct {
+	subject  string
+	batchCfg sinkBatchConfig
+	messages []*nats.Msg
+	numBytes int
+}
+
+var _ BatchBuffer = (*natsBuffer)(nil)
+
+// Append implements the BatchBuffer interface.
+func (b *natsBuffer) Append(key []byte, value []byte, attrs attributes) {
+	msg := nats.NewMsg(b.subject)
+	msg.Data = value
+	if len(key) > 0 {
+		msg.Header.Set(natsKeyHeader, string(key))
+	}
+	for k, v := range attrs.headers {
+		msg.Header.Add(k, string(v))
+	}
+	b.messages = append(b.messages, msg)
+	b.numBytes += len(key) + len(value)
+}
+
+// Close implements the BatchBuffer interface.
+func (b *natsBuffer) Close() (SinkPayload, error) {
+	return b.messages, nil
+}
+
+// ShouldFlush implements the BatchBuffer interface.
+func (b *natsBuffer) ShouldFlush() bool {
+	return shouldFlushBatch(b.numBytes, len(b.messages), b.batchCfg)
+}
+
+// natsDialer adapts a cidr.DialContext to nats.CustomDialer so that the
+// traffic to the server is accounted for in the network metrics.
+type natsDialer struct {
+	ctx  context.Context
+	dial cidr.DialContext
+}
+
+var _ nats.CustomDialer = natsDialer{}
+
+// Dial implements the nats.CustomDialer interface.
+func (d natsDialer) Dial(network, address string) (net.Conn, error) {
+	return d.dial(d.ctx, network, address)
+}
+
+// natsSubjectsForConnectionCheck returns the subjects that are known before
+// any row is emitted. The subjects of changefeeds on each column family are
+// only known once the family is seen.
+func natsSubjectsForConnectionCheck(tn *TopicNamer) []string {
+	if tn.singleName != "" {
+		return tn.DisplayNamesSlice()
+	}
+	var subjects []string
+	for target, name := range tn.DisplayNames {
+		if target.Type == jobspb.ChangefeedTargetSpecification_EACH_FAMILY {
+			continue
+		}
+		subjects = append(subjects, name)
+	}
+	return subjects
+}
+
+// natsConnectOptions consumes the connection parameters of the sink URL and
+// returns the servers and options to connect to NATS with.
+func natsConnectOptions(
+	ctx context.Context, u *changefeedbase.SinkURL, m metricsRecorder,
+) (servers string, _ []nats.Option, _ error) {
+	var tlsEnabled bool
+	if _, err := u.ConsumeBool(changefeedbase.SinkParamTLSEnabled, &tlsEnabled); err != nil {
+		return "", nil, err
+	}
+	tlsCfg, tlsParamsSet, err := consumeTLSParams(u)
+	if err != nil {
+		return "", nil, err
+	}
+	if tlsParamsSet && !tlsEnabled {
+		return "", nil, errors.Errorf(`%s, %s, %s and %s require %s=true`,
+			changefeedbase.SinkParamSkipTLSVerify, changefeedbase.SinkParamCACert,
+			changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey,
+			changefeedbase.SinkParamTLSEnabled)
+	}
+
+	// The host may be a comma separated list of servers to connect to.
+	var serverURLs []string
+	for _, host := range strings.Split(u.Host, `,`) {
+		serverURLs = append(serverURLs, (&url.URL{Scheme: u.Scheme, Host: host}).String())
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	opts := []nats.Option{
+		nats.Name(`cockroachdb-changefeed`),
+		nats.SetCustomDialer(natsDialer{ctx: ctx, dial: m.netMetrics().Wrap(dialer.DialContext, "nats")}),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				log.Warningf(ctx, "nats sink disconnected: %v", err)
+			}
+		}),
+	}
+	if tlsEnabled {
+		opts = append(opts, nats.Secure(tlsCfg))
+	}
+	// Like other NATS clients, treat a user without a password as a token.
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts = append(opts, nats.UserInfo(u.User.Username(), password))
+		} else {
+			opts = append(opts, nats.Token(u.User.Username()))
+		}
+	}
+	return strings.Join(serverURLs, `,`), opts, nil
+}
+
+func makeNATSSink(
+	ctx context.Context,
+	u *changefeedbase.SinkURL,
+	targets changefeedbase.Targets,
+	jsonConfig changefeedbase.SinkSpecificJSONConfig,
+	parallelism int,
+	pacerFactory func() *admission.Pacer,
+	source timeutil.TimeSource,
+	mb metricsRecorderBuilder,
+	settings *cluster.Settings,
+	knobs natsSinkKnobs,
+) (Sink, error) {
+	m := mb(requiresResourceAccounting)
+
+	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{})
+	if err != nil {
+		return nil, err
+	}
+
+	subjectPrefix := u.ConsumeParam(changefeedbase.SinkParamTopicPrefix)
+	subjectName := u.ConsumeParam(changefeedbase.SinkParamTopicName)
+	topicNamer, err := MakeTopicNamer(targets,
+		WithPrefix(subjectPrefix), WithSingleName(subjectName),
+		WithSanitizeFn(changefeedbase.SQLNameToNATSSubject))
+	if err != nil {
+		return nil, err
+	}
+
+	servers, connectOpts, err := natsConnectOptions(ctx, u, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if unknownParams := u.RemainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown nats sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	client := &natsSinkClient{
+		batchCfg:                   batchCfg,
+		subjectsForConnectionCheck: natsSubjectsForConnectionCheck(topicNamer),
+	}
+	if knobs.OverrideClient != nil {
+		client.js = knobs.OverrideClient()
+	} else {
+		client.conn, err = nats.Connect(servers, connectOpts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "connecting to nats")
+		}
+		client.js, err = jetstream.New(client.conn)
+		if err != nil {
+			client.conn.Close()
+			return nil, errors.Wrap(err, "creating jetstream context")
+		}
+	}
+
+	return makeBatchingSink(ctx, sinkTypeNATS, client, time.Duration(batchCfg.Frequency), retryOpts,
+		parallelism, topicNamer, pacerFactory, source, m, settings), nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJetStream is a NATSJetStream that acknowledges every message it is
+// published, unless ackErr returns an error for it.
+type fakeJetStream struct {
+	ackErr  func(*nats.Msg) error
+	streams map[string]string
+
+	mu struct {
+		syncutil.Mutex
+		published []*nats.Msg
+	}
+}
+
+var _ NATSJetStream = (*fakeJetStream)(nil)
+
+type fakePubAckFuture struct {
+	msg *nats.Msg
+	ok  chan *jetstream.PubAck
+	err chan error
+}
+
+func (f *fakePubAckFuture) Ok() <-chan *jetstream.PubAck { return f.ok }
+func (f *fakePubAckFuture) Err() <-chan error            { return f.err }
+func (f *fakePubAckFuture) Msg() *nats.Msg               { return f.msg }
+
+func (js *fakeJetStream) PublishMsgAsync(
+	msg *nats.Msg, _ ...jetstream.PublishOpt,
+) (jetstream.PubAckFuture, error) {
+	ack := &fakePubAckFuture{msg: msg, ok: make(chan *jetstream.PubAck, 1), err: make(chan error, 1)}
+	if js.ackErr != nil {
+		if err := js.ackErr(msg); err != nil {
+			ack.err <- err
+			return ack, nil
+		}
+	}
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	js.mu.published = append(js.mu.published, msg)
+	ack.ok <- &jetstream.PubAck{Stream: js.streams[msg.Subject]}
+	return ack, nil
+}
+
+func (js *fakeJetStream) StreamNameBySubject(_ context.Context, subject string) (string, error) {
+	if stream, ok := js.streams[subject]; ok {
+		return stream, nil
+	}
+	return "", jetstream.ErrStreamNotFound
+}
+
+func (js *fakeJetStream) published() []*nats.Msg {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	return append([]*nats.Msg(nil), js.mu.published...)
+}
+
+func makeTestNATSSink(
+	t *testing.T, uri string, js *fakeJetStream, targetNames ...string,
+) (Sink, error) {
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	return makeNATSSink(context.Background(), &changefeedbase.SinkURL{URL: u},
+		makeChangefeedTargets(targetNames...), `` /* jsonConfig */, 1 /* parallelism */, nilPacerFactory,
+		timeutil.DefaultTimeSource{}, nilMetricsRecorderBuilder, cluster.MakeTestingClusterSettings(),
+		natsSinkKnobs{OverrideClient: func() NATSJetStream { return js }})
+}
+
+func TestNATSSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+
+	t.Run("emit", func(t *testing.T) {
+		js := &fakeJetStream{streams: map[string]string{`cdc.t1`: `CDC`, `cdc.t2`: `CDC`}}
+		sink, err := makeTestNATSSink(t, `nats://localhost:4222?topic_prefix=cdc.`, js, `t1`, `t2`)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		require.NoError(t, sink.Dial())
+		require.NoError(t, sink.EmitRow(ctx, topic(`t1`), []byte(`[1]`), []byte(`v1`), zeroTS, zeroTS, zeroAlloc, nil))
+		require.NoError(t, sink.EmitRow(ctx, topic(`t2`), nil, []byte(`v2`), zeroTS, zeroTS, zeroAlloc, nil))
+		require.NoError(t, sink.Flush(ctx))
+
+		published := js.published()
+		require.Len(t, published, 2)
+		bySubject := make(map[string]*nats.Msg)
+		for _, msg := range published {
+			bySubject[msg.Subject] = msg
+		}
+		require.Equal(t, `v1`, string(bySubject[`cdc.t1`].Data))
+		require.Equal(t, `[1]`, bySubject[`cdc.t1`].Header.Get(natsKeyHeader))
+		require.Equal(t, `v2`, string(bySubject[`cdc.t2`].Data))
+		require.Empty(t, bySubject[`cdc.t2`].Header.Get(natsKeyHeader))
+	})
+
+	t.Run("escaping", func(t *testing.T) {
+		js := &fakeJetStream{}
+		sink, err := makeTestNATSSink(t, `nats://localhost:4222`, js, `☃`)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		require.NoError(t, sink.EmitRow(ctx, topic(`☃`), []byte(`k`), []byte(`v`), zeroTS, zeroTS, zeroAlloc, nil))
+		require.NoError(t, sink.Flush(ctx))
+		published := js.published()
+		require.Len(t, published, 1)
+		require.Equal(t, `_u2603_`, published[0].Subject)
+	})
+
+	t.Run("resolved", func(t *testing.T) {
+		js := &fakeJetStream{}
+		sink, err := makeTestNATSSink(t, `nats://localhost:4222?topic_name=all`, js, `t1`, `t2`)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		client := sink.(*batchingSink).client
+		forEachTopic := func(cb func(topic string) error) error {
+			return cb(`all`)
+		}
+		require.NoError(t, client.FlushResolvedPayload(ctx, []byte(`{"resolved":"1"}`), forEachTopic, retry.Options{}))
+		published := js.published()
+		require.Len(t, published, 1)
+		require.Equal(t, `all`, published[0].Subject)
+		require.Equal(t, `{"resolved":"1"}`, string(published[0].Data))
+	})
+
+	t.Run("missing stream", func(t *testing.T) {
+		js := &fakeJetStream{}
+		sink, err := makeTestNATSSink(t, `nats://localhost:4222`, js, `t`)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, sink.Close()) }()
+		require.ErrorContains(t, sink.Dial(), `looking up the JetStream stream of subject t`)
+	})
+
+	t.Run("ack errors", func(t *testing.T) {
+		client := &natsSinkClient{js: &fakeJetStream{ackErr: func(msg *nats.Msg) error {
+			if msg.Subject == `too_large` {
+				return nats.ErrMaxPayload
+			}
+			return jetstream.ErrNoStreamResponse
+		}}}
+
+		err := client.Flush(ctx, []*nats.Msg{{Subject: `too_large`}})
+		require.ErrorIs(t, err, nats.ErrMaxPayload)
+		require.True(t, errors.Is(err, errRejectedBySink))
+
+		err = client.Flush(ctx, []*nats.Msg{{Subject: `t`}})
+		require.ErrorContains(t, err, `publishing to subject t`)
+		require.False(t, errors.Is(err, errRejectedBySink))
+		require.Contains(t, errors.FlattenHints(err), `Create a JetStream stream whose subjects include t.`)
+	})
+
+	t.Run("batching", func(t *testing.T) {
+		client := &natsSinkClient{batchCfg: sinkBatchConfig{Messages: 2}}
+		buf := client.MakeBatchBuffer(`t`)
+		buf.Append([]byte(`k1`), []byte(`v1`), attributes{})
+		require.False(t, buf.ShouldFlush())
+		buf.Append([]byte(`k2`), []byte(`v2`), attributes{headers: map[string][]byte{`h`: []byte(`x`)}})
+		require.True(t, buf.ShouldFlush())
+		payload, err := buf.Close()
+		require.NoError(t, err)
+		msgs := payload.([]*nats.Msg)
+		require.Len(t, msgs, 2)
+		require.Equal(t, `x`, msgs[1].Header.Get(`h`))
+	})
+
+	t.Run("params", func(t *testing.T) {
+		for _, tc := range []struct {
+			uri string
+			err string
+		}{
+			{uri: `nats://localhost:4222?foo=bar`, err: `unknown nats sink query parameters: foo`},
+			{uri: `nats://localhost:4222?insecure_tls_skip_verify=true`, err: `require tls_enabled=true`},
+			{uri: `nats://localhost:4222?tls_enabled=true&insecure_tls_skip_verify=true`},
+			{uri: `nats://localhost:4222,localhost:4223?topic_prefix=cdc.`},
+		} {
+			t.Run(tc.uri, func(t *testing.T) {
+				sink, err := makeTestNATSSink(t, tc.uri, &fakeJetStream{}, `t`)
+				if tc.err != `` {
+					require.ErrorContains(t, err, tc.err)
+					return
+				}
+				require.NoError(t, err)
+				require.NoError(t, sink.Close())
+			})
+		}
+	})
+}
@@ -280,7 +280,14 @@ func (sc *webhookSinkClient) Flush(ctx context.Context, batch SinkPayload) error
 		if err != nil {
 			return errors.Wrapf(err, "failed to read body for HTTP response with status: %d", res.StatusCode)
 		}
-		return fmt.Errorf("%s: %s", res.Status, string(resBody))
+		err = fmt.Errorf("%s: %s", res.Status, string(resBody))
+		switch res.StatusCode {
+		case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+			// The endpoint rejected the content of the batch, rather than being
+			// unavailable or misconfigured, so retrying it cannot succeed.
+			return markRejectedBySink(err)
+		}
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,218 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// Changefeeds with the transaction_boundaries option group the rows they emit
+// by commit timestamp. The aggregators count the rows they emitted for each
+// table at each commit timestamp and send the counts to the change frontier
+// along with their resolved spans. Once the frontier is resolved past a commit
+// timestamp, every row of that transaction has been delivered to the sink and
+// the frontier emits a BEGIN and a COMMIT marker for it. The markers go to
+// every topic, the same way resolved timestamps do, and the markers of all the
+// transactions resolved by an advance of the frontier are emitted with a single
+// flush of the sink.
+//
+// Rows committed at the same timestamp by different transactions are grouped
+// together. Rows of backfills, including the initial scan, are not part of any
+// transaction. The counts only exist in memory, so a restarted changefeed
+// counts the rows it emits again. The spans of a span-level checkpoint resume
+// from their checkpointed timestamp rather than the highwater, and their rows
+// up to that timestamp are not emitted again. The row counts of the
+// transactions committed at or before the highest checkpointed timestamp may
+// therefore be incomplete, and no markers are emitted for them.
+
+// txnRows maps a commit timestamp to the number of rows emitted for each table
+// at that timestamp.
+type txnRows map[hlc.Timestamp]map[string]uint64
+
+// add records n rows of the given table committed at ts.
+func (r txnRows) add(ts hlc.Timestamp, table string, n uint64) {
+	tables, ok := r[ts]
+	if !ok {
+		tables = make(map[string]uint64)
+		r[ts] = tables
+	}
+	tables[table] += n
+}
+
+// addAll records the rows an aggregator reported to the frontier.
+func (r txnRows) addAll(txns []jobspb.ResolvedSpans_TransactionRows) {
+	for _, txn := range txns {
+		for _, table := range txn.Tables {
+			r.add(txn.CommitTimestamp, table.Name, table.RowCount)
+		}
+	}
+}
+
+// popResolved removes and returns the transactions committed at or before
+// resolved, ordered by commit timestamp.
+func (r txnRows) popResolved(resolved hlc.Timestamp) []jobspb.ResolvedSpans_TransactionRows {
+	var txns []jobspb.ResolvedSpans_TransactionRows
+	for ts, tables := range r {
+		if resolved.Less(ts) {
+			continue
+		}
+		txn := jobspb.ResolvedSpans_TransactionRows{CommitTimestamp: ts}
+		for name, rowCount := range tables {
+			txn.Tables = append(txn.Tables,
+				jobspb.ResolvedSpans_TransactionRows_Table{Name: name, RowCount: rowCount})
+		}
+		slices.SortFunc(txn.Tables, func(a, b jobspb.ResolvedSpans_TransactionRows_Table) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		txns = append(txns, txn)
+		delete(r, ts)
+	}
+	slices.SortFunc(txns, func(a, b jobspb.ResolvedSpans_TransactionRows) int {
+		return a.CommitTimestamp.Compare(b.CommitTimestamp)
+	})
+	return txns
+}
+
+// maxCheckpointTimestamp returns the highest timestamp of the given span-level
+// checkpoint, or the empty timestamp if there is none.
+func maxCheckpointTimestamp(cp *jobspb.TimestampSpansMap) hlc.Timestamp {
+	var maxTS hlc.Timestamp
+	for ts := range cp.All() {
+		maxTS.Forward(ts)
+	}
+	return maxTS
+}
+
+// skipIncompleteTxns removes from txns the transactions committed at or before
+// checkpointTS, the highest timestamp of the span-level checkpoint the
+// changefeed was resumed from. Rows of those transactions may have been
+// emitted before the restart and not counted again.
+func skipIncompleteTxns(
+	txns []jobspb.ResolvedSpans_TransactionRows, checkpointTS hlc.Timestamp,
+) []jobspb.ResolvedSpans_TransactionRows {
+	if checkpointTS.IsEmpty() {
+		return txns
+	}
+	return slices.DeleteFunc(txns, func(txn jobspb.ResolvedSpans_TransactionRows) bool {
+		return txn.CommitTimestamp.LessEq(checkpointTS)
+	})
+}
+
+// txnRowCounter counts the rows emitted by the event consumers of an
+// aggregator. It is safe for concurrent use since a parallelEventConsumer runs
+// several consumers.
+type txnRowCounter struct {
+	mu struct {
+		syncutil.Mutex
+		rows txnRows
+	}
+}
+
+func makeTxnRowCounter() *txnRowCounter {
+	c := &txnRowCounter{}
+	c.mu.rows = make(txnRows)
+	return c
+}
+
+// add records a row of the given table emitted at its commit timestamp.
+func (c *txnRowCounter) add(ts hlc.Timestamp, table string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.rows.add(ts, table, 1)
+}
+
+// drain removes and returns all the rows counted so far.
+func (c *txnRowCounter) drain() []jobspb.ResolvedSpans_TransactionRows {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mu.rows.popResolved(hlc.MaxTimestamp)
+}
+
+const (
+	txnMarkerBegin  = `BEGIN`
+	txnMarkerCommit = `COMMIT`
+)
+
+type txnMarkerTable struct {
+	Table    string `json:"table"`
+	RowCount uint64 `json:"row_count"`
+}
+
+// txnMarker is the JSON body of a transaction boundary marker. The commit
+// timestamp is formatted like the mvcc_timestamp of the rows.
+type txnMarker struct {
+	Status          string           `json:"status"`
+	CommitTimestamp string           `json:"commit_timestamp"`
+	RowCount        uint64           `json:"row_count,omitempty"`
+	Tables          []txnMarkerTable `json:"tables,omitempty"`
+}
+
+// makeTxnMarkers returns the BEGIN and COMMIT markers of a transaction. Only
+// the COMMIT marker carries the row counts.
+func makeTxnMarkers(txn jobspb.ResolvedSpans_TransactionRows) (begin, commit txnMarker) {
+	ts := eval.TimestampToDecimalDatum(txn.CommitTimestamp).Decimal.String()
+	begin = txnMarker{Status: txnMarkerBegin, CommitTimestamp: ts}
+	commit = txnMarker{Status: txnMarkerCommit, CommitTimestamp: ts}
+	for _, table := range txn.Tables {
+		commit.RowCount += table.RowCount
+		commit.Tables = append(commit.Tables, txnMarkerTable{Table: table.Name, RowCount: table.RowCount})
+	}
+	return begin, commit
+}
+
+// txnMarkerEncoder encodes a transaction boundary marker in place of a
+// resolved timestamp, which lets the markers be emitted through any
+// ResolvedTimestampSink. Like resolved timestamps, the markers are nested
+// under the metadata key unless the envelope is wrapped or enriched.
+type txnMarkerEncoder struct {
+	Encoder
+	envelope changefeedbase.EnvelopeType
+	marker   txnMarker
+}
+
+var _ Encoder = (*txnMarkerEncoder)(nil)
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *txnMarkerEncoder) EncodeResolvedTimestamp(
+	_ context.Context, _ string, _ hlc.Timestamp,
+) ([]byte, error) {
+	meta := map[string]interface{}{`transaction`: e.marker}
+	switch e.envelope {
+	case changefeedbase.OptEnvelopeWrapped, changefeedbase.OptEnvelopeEnriched:
+		return json.Marshal(meta)
+	default:
+		return json.Marshal(map[string]interface{}{metaSentinel: meta})
+	}
+}
+
+// encodeTxnMarkers encodes the BEGIN and COMMIT markers of txns, in order, so
+// that they can all be emitted with a single flush.
+func (e *txnMarkerEncoder) encodeTxnMarkers(
+	ctx context.Context, txns []jobspb.ResolvedSpans_TransactionRows,
+) ([]resolvedPayload, error) {
+	payloads := make([]resolvedPayload, 0, 2*len(txns))
+	for _, txn := range txns {
+		begin, commit := makeTxnMarkers(txn)
+		for _, marker := range []txnMarker{begin, commit} {
+			e.marker = marker
+			body, err := e.EncodeResolvedTimestamp(ctx, ``, txn.CommitTimestamp)
+			if err != nil {
+				return nil, err
+			}
+			payloads = append(payloads, resolvedPayload{resolved: txn.CommitTimestamp, body: body})
+		}
+	}
+	return payloads, nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionBoundaries(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ts := func(wallTime int64) hlc.Timestamp {
+		return hlc.Timestamp{WallTime: wallTime, Logical: 1}
+	}
+	type table = jobspb.ResolvedSpans_TransactionRows_Table
+
+	t.Run("counting", func(t *testing.T) {
+		// Two aggregators emit rows of the same transactions.
+		agg1, agg2 := makeTxnRowCounter(), makeTxnRowCounter()
+		agg1.add(ts(1), `foo`)
+		agg1.add(ts(1), `foo`)
+		agg1.add(ts(2), `foo`)
+		agg2.add(ts(1), `bar`)
+		agg2.add(ts(3), `bar`)
+
+		rows := make(txnRows)
+		rows.addAll(agg1.drain())
+		require.Empty(t, agg1.drain())
+		rows.addAll(agg2.drain())
+
+		require.Empty(t, rows.popResolved(ts(0)))
+		require.Equal(t, []jobspb.ResolvedSpans_TransactionRows{
+			{CommitTimestamp: ts(1), Tables: []table{{Name: `bar`, RowCount: 1}, {Name: `foo`, RowCount: 2}}},
+			{CommitTimestamp: ts(2), Tables: []table{{Name: `foo`, RowCount: 1}}},
+		}, rows.popResolved(ts(2)))
+		// Transactions are only popped once.
+		require.Empty(t, rows.popResolved(ts(2)))
+
+		agg1.add(ts(3), `foo`)
+		rows.addAll(agg1.drain())
+		require.Equal(t, []jobspb.ResolvedSpans_TransactionRows{
+			{CommitTimestamp: ts(3), Tables: []table{{Name: `bar`, RowCount: 1}, {Name: `foo`, RowCount: 1}}},
+		}, rows.popResolved(hlc.MaxTimestamp))
+		require.Empty(t, rows)
+	})
+
+	t.Run("markers", func(t *testing.T) {
+		ctx := context.Background()
+		txn := jobspb.ResolvedSpans_TransactionRows{
+			CommitTimestamp: hlc.Timestamp{WallTime: 1, Logical: 2},
+			Tables:          []table{{Name: `bar`, RowCount: 1}, {Name: `foo`, RowCount: 2}},
+		}
+		begin, commit := makeTxnMarkers(txn)
+
+		e := &txnMarkerEncoder{envelope: changefeedbase.OptEnvelopeWrapped, marker: begin}
+		payload, err := e.EncodeResolvedTimestamp(ctx, `foo`, txn.CommitTimestamp)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"transaction":{"status":"BEGIN","commit_timestamp":"1.0000000002"}}`,
+			string(payload))
+
+		e.marker = commit
+		payload, err = e.EncodeResolvedTimestamp(ctx, `foo`, txn.CommitTimestamp)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"transaction":{"status":"COMMIT","commit_timestamp":"1.0000000002","row_count":3,`+
+			`"tables":[{"table":"bar","row_count":1},{"table":"foo","row_count":2}]}}`, string(payload))
+
+		e.envelope = changefeedbase.OptEnvelopeBare
+		payload, err = e.EncodeResolvedTimestamp(ctx, `foo`, txn.CommitTimestamp)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"__crdb__":{"transaction":{"status":"COMMIT","commit_timestamp":"1.0000000002",`+
+			`"row_count":3,"tables":[{"table":"bar","row_count":1},{"table":"foo","row_count":2}]}}}`,
+			string(payload))
+	})
+
+	t.Run("multiple transactions per resolved interval", func(t *testing.T) {
+		ctx := context.Background()
+		agg := makeTxnRowCounter()
+		agg.add(ts(1), `foo`)
+		agg.add(ts(2), `foo`)
+		agg.add(ts(2), `bar`)
+		agg.add(ts(3), `foo`)
+		rows := make(txnRows)
+		rows.addAll(agg.drain())
+
+		// A single advance of the frontier resolves the first two transactions.
+		txns := rows.popResolved(ts(2))
+		require.Len(t, txns, 2)
+		e := &txnMarkerEncoder{envelope: changefeedbase.OptEnvelopeWrapped}
+		payloads, err := e.encodeTxnMarkers(ctx, txns)
+		require.NoError(t, err)
+
+		type marker struct {
+			resolved hlc.Timestamp
+			status   string
+			rowCount uint64
+		}
+		decode := func(t *testing.T, resolved hlc.Timestamp, body []byte) marker {
+			var m struct {
+				Transaction txnMarker `json:"transaction"`
+			}
+			require.NoError(t, json.Unmarshal(body, &m))
+			return marker{resolved: resolved, status: m.Transaction.Status, rowCount: m.Transaction.RowCount}
+		}
+		expected := []marker{
+			{resolved: ts(1), status: txnMarkerBegin},
+			{resolved: ts(1), status: txnMarkerCommit, rowCount: 1},
+			{resolved: ts(2), status: txnMarkerBegin},
+			{resolved: ts(2), status: txnMarkerCommit, rowCount: 2},
+		}
+
+		t.Run("single flush", func(t *testing.T) {
+			sink := &batchRecordingResolvedSink{}
+			require.NoError(t, emitResolvedPayloads(ctx, &errorWrapperSink{wrapped: sink}, payloads))
+			// All the markers are emitted at once, in order.
+			require.Len(t, sink.batches, 1)
+			var got []marker
+			for _, p := range sink.batches[0] {
+				got = append(got, decode(t, p.resolved, p.body))
+			}
+			require.Equal(t, expected, got)
+			require.Empty(t, sink.emitted)
+		})
+
+		t.Run("one message at a time", func(t *testing.T) {
+			// Sinks that cannot emit several payloads at once emit them one by one.
+			sink := &recordingResolvedSink{}
+			require.NoError(t, emitResolvedPayloads(ctx, &errorWrapperSink{wrapped: sink}, payloads))
+			var got []marker
+			for _, p := range sink.emitted {
+				got = append(got, decode(t, p.resolved, p.body))
+			}
+			require.Equal(t, expected, got)
+		})
+
+		// The last transaction is resolved by the next advance.
+		require.Equal(t, []jobspb.ResolvedSpans_TransactionRows{
+			{CommitTimestamp: ts(3), Tables: []table{{Name: `foo`, RowCount: 1}}},
+		}, rows.popResolved(ts(3)))
+	})
+
+	t.Run("resumed from checkpoint", func(t *testing.T) {
+		span := func(key string) roachpb.Span {
+			return roachpb.Span{Key: roachpb.Key(key), EndKey: roachpb.Key(key + "\x00")}
+		}
+		require.Empty(t, maxCheckpointTimestamp(nil))
+		cp := jobspb.NewTimestampSpansMap(map[hlc.Timestamp]roachpb.Spans{
+			ts(2): {span(`a`)},
+			ts(4): {span(`b`)},
+		})
+		require.Equal(t, ts(4), maxCheckpointTimestamp(cp))
+
+		txns := func() []jobspb.ResolvedSpans_TransactionRows {
+			var txns []jobspb.ResolvedSpans_TransactionRows
+			for i := int64(3); i <= 5; i++ {
+				txns = append(txns, jobspb.ResolvedSpans_TransactionRows{
+					CommitTimestamp: ts(i), Tables: []table{{Name: `foo`, RowCount: 1}},
+				})
+			}
+			return txns
+		}
+		// Without a checkpoint every transaction is complete.
+		require.Len(t, skipIncompleteTxns(txns(), hlc.Timestamp{}), 3)
+		// Rows of the checkpointed spans committed at or before their timestamp
+		// are not emitted again, so only the transaction above it is complete.
+		require.Equal(t, []jobspb.ResolvedSpans_TransactionRows{
+			{CommitTimestamp: ts(5), Tables: []table{{Name: `foo`, RowCount: 1}}},
+		}, skipIncompleteTxns(txns(), maxCheckpointTimestamp(cp)))
+	})
+}
+
+// recordingResolvedSink is a ResolvedTimestampSink that records the resolved
+// messages it emits.
+type recordingResolvedSink struct {
+	emitted []resolvedPayload
+}
+
+var _ ResolvedTimestampSink = (*recordingResolvedSink)(nil)
+
+func (s *recordingResolvedSink) Dial() error               { return nil }
+func (s *recordingResolvedSink) Close() error              { return nil }
+func (s *recordingResolvedSink) getConcreteType() sinkType { return sinkTypeNull }
+
+func (s *recordingResolvedSink) EmitResolvedTimestamp(
+	ctx context.Context, encoder Encoder, resolved hlc.Timestamp,
+) error {
+	body, err := encoder.EncodeResolvedTimestamp(ctx, ``, resolved)
+	if err != nil {
+		return err
+	}
+	s.emitted = append(s.emitted, resolvedPayload{resolved: resolved, body: body})
+	return nil
+}
+
+// batchRecordingResolvedSink is a recordingResolvedSink that can emit several
+// resolved messages at once.
+type batchRecordingResolvedSink struct {
+	recordingResolvedSink
+	batches [][]resolvedPayload
+}
+
+var _ resolvedPayloadsSink = (*batchRecordingResolvedSink)(nil)
+
+func (s *batchRecordingResolvedSink) EmitResolvedPayloads(
+	_ context.Context, payloads []resolvedPayload,
+) error {
+	s.batches = append(s.batches, payloads)
+	return nil
+}
@@ -271,6 +271,20 @@ func TestCCLLogic_triggers(
 	runCCLLogicTest(t, "triggers")
 }
 
+func TestCCLLogic_triggers_instead_of(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "triggers_instead_of")
+}
+
+func TestCCLLogic_triggers_statement_level(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "triggers_statement_level")
+}
+
 func TestCCLLogic_udf_params(
 	t *testing.T,
 ) {
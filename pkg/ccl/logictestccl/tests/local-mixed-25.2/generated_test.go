@@ -264,6 +264,13 @@ func TestCCLLogic_triggers(
 	runCCLLogicTest(t, "triggers")
 }
 
+func TestCCLLogic_triggers_mixed_version(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "triggers_mixed_version")
+}
+
 func TestCCLLogic_udf_params(
 	t *testing.T,
 ) {
@@ -238,6 +238,14 @@ const (
 
 	V25_3_AddEventLogColumnAndIndex
 
+	// V25_3_NotificationsTable adds the system.notifications table, which backs
+	// LISTEN and NOTIFY.
+	V25_3_NotificationsTable
+
+	// V25_3_ReplicationSlotsTable adds the system.replication_slots table, which
+	// stores logical replication slots.
+	V25_3_ReplicationSlotsTable
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -299,6 +307,8 @@ var versionTable = [numKeys]roachpb.Version{
 	V25_3_Start: {Major: 25, Minor: 2, Internal: 2},
 
 	V25_3_AddEventLogColumnAndIndex: {Major: 25, Minor: 2, Internal: 4},
+	V25_3_NotificationsTable:        {Major: 25, Minor: 2, Internal: 6},
+	V25_3_ReplicationSlotsTable:     {Major: 25, Minor: 2, Internal: 8},
 
 	// *************************************************
 	// Step (2): Add new versions above this comment.
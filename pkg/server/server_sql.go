@@ -85,6 +85,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/gcjob/gcjobnotifier"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/listennotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
@@ -1050,6 +1051,17 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		execCfg.VirtualClusterName = catconstants.SystemTenantName
 	}
 
+	execCfg.NotificationRegistry = listennotify.NewRegistry(
+		cfg.AmbientCtx,
+		codec,
+		cfg.clock,
+		cfg.rangeFeedFactory,
+		cfg.stopper,
+		cfg.Settings,
+		cfg.internalDB,
+		execCfg.SystemTableIDResolver,
+	)
+
 	if sqlSchemaChangerTestingKnobs := cfg.TestingKnobs.SQLSchemaChanger; sqlSchemaChangerTestingKnobs != nil {
 		execCfg.SchemaChangerTestingKnobs = sqlSchemaChangerTestingKnobs.(*sql.SchemaChangerTestingKnobs)
 	} else {
@@ -1625,6 +1637,9 @@ func (s *SQLServer) preStart(
 	s.sqlInstanceReader.Start(ctx, instance)
 
 	s.execCfg.GCJobNotifier.Start(ctx)
+	if err := s.execCfg.NotificationRegistry.Start(ctx); err != nil {
+		return err
+	}
 	s.temporaryObjectCleaner.Start(ctx, stopper)
 	s.distSQLServer.Start()
 	s.pgServer.Start(ctx, stopper)
@@ -78,6 +78,9 @@ func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	// referenced by other objects. This is needed when want to allow function
 	// references. Need to think about in what condition a function can be altered
 	// or not.
+	if err := checkAggregateRoutineKind(fnDesc, false /* aggregateStmt */, "ALTER"); err != nil {
+		return err
+	}
 	if err := tree.ValidateRoutineOptions(n.n.Options, fnDesc.IsProcedure()); err != nil {
 		return err
 	}
@@ -173,6 +176,9 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkAggregateRoutineKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	oldFnName, err := params.p.getQualifiedFunctionName(params.ctx, fnDesc)
 	if err != nil {
 		return err
@@ -218,8 +224,9 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
-		_, ok := desc.(catalog.FunctionDescriptor)
-		if !ok {
+		// Aggregate functions reference their support functions by ID, so they
+		// are unaffected.
+		if fn, ok := desc.(catalog.FunctionDescriptor); !ok || fn.IsAggregate() {
 			continue
 		}
 		fullyResolvedName, err := params.p.GetQualifiedFunctionNameByID(params.ctx, int64(dep.ID))
@@ -295,6 +302,9 @@ func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkAggregateRoutineKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	newOwner, err := decodeusername.FromRoleSpec(
 		params.p.SessionData(), username.PurposeValidation, n.n.NewOwner,
 	)
@@ -369,6 +379,9 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkAggregateRoutineKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	oldFnName, err := params.p.getQualifiedFunctionName(params.ctx, fnDesc)
 	if err != nil {
 		return err
@@ -391,8 +404,9 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
-		_, ok := desc.(catalog.FunctionDescriptor)
-		if !ok {
+		// Aggregate functions reference their support functions by ID, so they
+		// are unaffected.
+		if fn, ok := desc.(catalog.FunctionDescriptor); !ok || fn.IsAggregate() {
 			continue
 		}
 		fullyResolvedName, err := params.p.GetQualifiedFunctionNameByID(params.ctx, int64(dep.ID))
@@ -525,6 +539,25 @@ func (p *planner) mustGetMutableFunctionForAlter(
 	return mut, nil
 }
 
+// checkAggregateRoutineKind returns an error if an aggregate function is the
+// target of a statement for plain functions, or if a plain function is the
+// target of a statement for aggregate functions. verb is the statement's verb,
+// e.g. "ALTER" or "DROP".
+func checkAggregateRoutineKind(
+	fnDesc catalog.FunctionDescriptor, aggregateStmt bool, verb string,
+) error {
+	if fnDesc.IsAggregate() && !aggregateStmt {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function", fnDesc.GetName()),
+			"Use %s AGGREGATE to %s aggregate functions.", verb, strings.ToLower(verb),
+		)
+	}
+	if !fnDesc.IsAggregate() && aggregateStmt {
+		return pgerror.Newf(pgcode.WrongObjectType, "function %s is not an aggregate", fnDesc.GetName())
+	}
+	return nil
+}
+
 func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionSignature {
 	ret := descpb.SchemaDescriptor_FunctionSignature{
 		ID:          fnDesc.GetID(),
@@ -532,6 +565,8 @@ func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor
 		ReturnType:  fnDesc.ReturnType.Type,
 		ReturnSet:   fnDesc.ReturnType.ReturnSet,
 		IsProcedure: fnDesc.IsProcedure(),
+		IsAggregate: fnDesc.IsAggregate(),
+		IsVariadic:  fnDesc.IsVariadic(),
 	}
 	for paramIdx, param := range fnDesc.Params {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
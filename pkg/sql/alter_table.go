@@ -94,6 +94,11 @@ func (p *planner) AlterTable(ctx context.Context, n *tree.AlterTable) (planNode,
 		return newZeroNode(nil /* columns */), nil
 	}
 
+	if tableDesc.IsForeignTable() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"ALTER TABLE is not supported on foreign table %q", tableDesc.GetName())
+	}
+
 	// This check for CREATE privilege is kept for backwards compatibility.
 	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
 		return nil, pgerror.Wrapf(err, pgcode.InsufficientPrivilege,
@@ -365,6 +370,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExclusionConstraintTableDef:
+				if err := addExclusionConstraintTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -853,6 +872,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 		case *tree.AlterTableSetRLSMode:
 			return pgerror.New(pgcode.FeatureNotSupported,
 				"ALTER TABLE ... ROW LEVEL SECURITY is only implemented in the declarative schema changer")
+		case *tree.AlterTableInherit:
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"ALTER TABLE ... INHERIT is only implemented in the declarative schema changer")
 		default:
 			return errors.AssertionFailedf("unsupported alter command: %T", cmd)
 		}
@@ -1707,6 +1729,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
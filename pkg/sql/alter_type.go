@@ -54,6 +54,22 @@ func (p *planner) AlterType(ctx context.Context, n *tree.AlterType) (planNode, e
 		return nil, err
 	}
 
+	// The ALTER DOMAIN commands can only be used on domains, and ALTER DOMAIN
+	// cannot be used on other types.
+	isDomainCmd := n.Domain
+	switch n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault, *tree.AlterDomainSetNotNull,
+		*tree.AlterDomainAddConstraint, *tree.AlterDomainDropConstraint:
+		isDomainCmd = true
+	}
+	if isDomainCmd && desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(
+			pgcode.WrongObjectType,
+			"%q is not a domain",
+			tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations),
+		)
+	}
+
 	switch desc.Kind {
 	case descpb.TypeDescriptor_ALIAS:
 		// The implicit array types are not modifiable.
@@ -126,6 +142,14 @@ func (n *alterTypeNode) startExec(params runParams) error {
 		eventLogDone = true // done inside alterTypeOwner().
 	case *tree.AlterTypeDropValue:
 		err = params.p.dropEnumValue(params.ctx, n.desc, t.Val)
+	case *tree.AlterDomainSetDefault:
+		err = params.p.setDomainDefault(params.ctx, n.desc, t, tree.AsStringWithFQNames(n.n, params.p.Ann()))
+	case *tree.AlterDomainSetNotNull:
+		err = params.p.setDomainNotNull(params.ctx, n.desc, t, tree.AsStringWithFQNames(n.n, params.p.Ann()))
+	case *tree.AlterDomainAddConstraint:
+		err = params.p.addDomainConstraint(params.ctx, n.desc, t, tree.AsStringWithFQNames(n.n, params.p.Ann()))
+	case *tree.AlterDomainDropConstraint:
+		err = params.p.dropDomainConstraint(params.ctx, n.desc, t, tree.AsStringWithFQNames(n.n, params.p.Ann()))
 	default:
 		err = errors.AssertionFailedf("unknown alter type cmd %s", t)
 	}
@@ -213,6 +237,73 @@ func (p *planner) dropEnumValue(
 	return p.writeTypeSchemaChange(ctx, desc, desc.Name)
 }
 
+func (p *planner) setDomainDefault(
+	ctx context.Context, desc *typedesc.Mutable, node *tree.AlterDomainSetDefault, jobDesc string,
+) error {
+	if node.Default == nil {
+		desc.Domain.DefaultExpr = nil
+		return p.writeTypeSchemaChange(ctx, desc, jobDesc)
+	}
+	defaultExpr, err := p.validateDomainDefaultExpr(ctx, node.Default, desc.Domain.BaseType)
+	if err != nil {
+		return err
+	}
+	desc.Domain.DefaultExpr = &defaultExpr
+	return p.writeTypeSchemaChange(ctx, desc, jobDesc)
+}
+
+func (p *planner) setDomainNotNull(
+	ctx context.Context, desc *typedesc.Mutable, node *tree.AlterDomainSetNotNull, jobDesc string,
+) error {
+	if desc.Domain.NotNull == node.NotNull {
+		return nil
+	}
+	if node.NotNull {
+		// Existing values of the domain must satisfy the new constraint.
+		if err := p.validateDomainConstraintOnColumns(ctx, desc, "" /* constraintName */, "" /* exprStr */); err != nil {
+			return err
+		}
+	}
+	desc.Domain.NotNull = node.NotNull
+	return p.writeTypeSchemaChange(ctx, desc, jobDesc)
+}
+
+func (p *planner) addDomainConstraint(
+	ctx context.Context, desc *typedesc.Mutable, node *tree.AlterDomainAddConstraint, jobDesc string,
+) error {
+	check, err := p.makeDomainCheckConstraint(ctx, desc.Name, desc.Domain, &node.Constraint)
+	if err != nil {
+		return err
+	}
+	// Existing values of the domain must satisfy the new constraint.
+	if err := p.validateDomainConstraintOnColumns(ctx, desc, check.Name, check.Expr); err != nil {
+		return err
+	}
+	desc.Domain.Checks = append(desc.Domain.Checks, check)
+	return p.writeTypeSchemaChange(ctx, desc, jobDesc)
+}
+
+func (p *planner) dropDomainConstraint(
+	ctx context.Context, desc *typedesc.Mutable, node *tree.AlterDomainDropConstraint, jobDesc string,
+) error {
+	for i := range desc.Domain.Checks {
+		if desc.Domain.Checks[i].Name != string(node.Constraint) {
+			continue
+		}
+		desc.Domain.Checks = append(desc.Domain.Checks[:i], desc.Domain.Checks[i+1:]...)
+		return p.writeTypeSchemaChange(ctx, desc, jobDesc)
+	}
+	if node.IfExists {
+		p.BufferClientNotice(
+			ctx,
+			pgnotice.Newf("constraint %q of domain %q does not exist, skipping", node.Constraint, desc.Name),
+		)
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"constraint %q of domain %q does not exist", node.Constraint, desc.Name)
+}
+
 func (p *planner) renameType(ctx context.Context, n *alterTypeNode, newName string) error {
 	err := descs.CheckObjectNameCollision(
 		ctx,
@@ -196,3 +196,79 @@ func (i *rowContainerIterator) Next() (tree.Datums, error) {
 func (i *rowContainerIterator) Close() {
 	i.iter.Close()
 }
+
+// indexedRowContainerHelper is a variant of rowContainerHelper that allows the
+// buffered rows to be retrieved by their ordinal position. It is used by
+// components that need random access to the buffered data (e.g. SCROLL
+// cursors). InitWithParentMon must be called before the first use.
+type indexedRowContainerHelper struct {
+	memMonitor          *mon.BytesMonitor
+	unlimitedMemMonitor *mon.BytesMonitor
+	diskMonitor         *mon.BytesMonitor
+	rows                *rowcontainer.DiskBackedIndexedRowContainer
+	scratch             rowenc.EncDatumRow
+	numCols             int
+}
+
+// InitWithParentMon initializes the helper to store rows of the given types,
+// using the given parent memory monitor.
+func (c *indexedRowContainerHelper) InitWithParentMon(
+	ctx context.Context,
+	typs []*types.T,
+	parent *mon.BytesMonitor,
+	evalContext *extendedEvalContext,
+	opName redact.SafeString,
+) {
+	distSQLCfg := &evalContext.DistSQLPlanner.distSQLSrv.ServerConfig
+	c.memMonitor = execinfra.NewLimitedMonitorNoFlowCtx(
+		ctx, parent, distSQLCfg, evalContext.SessionData(),
+		mon.MakeName(opName).Limited(),
+	)
+	c.unlimitedMemMonitor = execinfra.NewMonitor(
+		ctx, parent, mon.MakeName(opName).Unlimited(),
+	)
+	c.diskMonitor = execinfra.NewMonitor(
+		ctx, distSQLCfg.ParentDiskMonitor, mon.MakeName(opName).Disk(),
+	)
+	c.rows = rowcontainer.NewDiskBackedIndexedRowContainer(
+		colinfo.NoOrdering, typs, &evalContext.Context, distSQLCfg.TempStorage,
+		c.memMonitor, c.unlimitedMemMonitor, c.diskMonitor,
+	)
+	c.scratch = make(rowenc.EncDatumRow, len(typs))
+	c.numCols = len(typs)
+}
+
+// AddRow adds the given row to the container.
+func (c *indexedRowContainerHelper) AddRow(ctx context.Context, row tree.Datums) error {
+	for i := range row {
+		c.scratch[i].Datum = row[i]
+	}
+	return c.rows.AddRow(ctx, c.scratch)
+}
+
+// GetRow returns the row at the given (zero-based) ordinal position. The
+// returned row is safe to hold on to after subsequent calls.
+func (c *indexedRowContainerHelper) GetRow(ctx context.Context, idx int) (tree.Datums, error) {
+	row, err := c.rows.GetRow(ctx, idx)
+	if err != nil {
+		return nil, err
+	}
+	return row.GetDatums(0, c.numCols)
+}
+
+// Len returns the number of rows buffered so far.
+func (c *indexedRowContainerHelper) Len() int {
+	return c.rows.Len()
+}
+
+// Close must be called once the helper is no longer needed to clean up any
+// resources.
+func (c *indexedRowContainerHelper) Close(ctx context.Context) {
+	if c.rows != nil {
+		c.rows.Close(ctx)
+		c.memMonitor.Stop(ctx)
+		c.unlimitedMemMonitor.Stop(ctx)
+		c.diskMonitor.Stop(ctx)
+		c.rows = nil
+	}
+}
@@ -27,6 +27,8 @@ var (
 		catconstants.StatementActivityTableName,
 		catconstants.TransactionActivityTableName,
 		catconstants.PreparedTransactionsTableName,
+		catconstants.NotificationsTableName,
+		catconstants.ReplicationSlotsTableName,
 	}
 
 	readWriteSystemTables = []catconstants.SystemTableName{
@@ -111,7 +111,11 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 		types.TimestampFamily, types.TimestampTZFamily, types.UuidFamily, types.TimeTZFamily,
 		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.Box2DFamily,
 		types.TSQueryFamily, types.TSVectorFamily, types.PGLSNFamily, types.PGVectorFamily, types.RefCursorFamily:
-	// These types are OK.
+		// These types are OK, except for the ANYENUM pseudo-type.
+		if t.Oid() == oid.T_anyenum {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"value type %s cannot be used for table columns", t.String())
+		}
 
 	case types.JsonpathFamily:
 		return unimplemented.NewWithIssueDetailf(144910, t.String(),
@@ -9,6 +9,7 @@ package dbdesc
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -200,6 +201,33 @@ func (desc *immutable) GetSchemaID(name string) descpb.ID {
 	return info.ID
 }
 
+// GetForeignServer implements the DatabaseDescriptor interface.
+func (desc *immutable) GetForeignServer(name string) *descpb.DatabaseDescriptor_ForeignServer {
+	server, ok := desc.ForeignServers[name]
+	if !ok {
+		return nil
+	}
+	return &server
+}
+
+// ForEachForeignServer implements the DatabaseDescriptor interface.
+func (desc *immutable) ForEachForeignServer(
+	f func(name string, server *descpb.DatabaseDescriptor_ForeignServer) error,
+) error {
+	names := make([]string, 0, len(desc.ForeignServers))
+	for name := range desc.ForeignServers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		server := desc.ForeignServers[name]
+		if err := f(name, &server); err != nil {
+			return iterutil.Map(err)
+		}
+	}
+	return nil
+}
+
 // HasPublicSchemaWithDescriptor returns if the database has a public schema
 // with a descriptor.
 // If descs.Schemas has an explicit entry for "public", then it has a descriptor
@@ -566,6 +594,23 @@ func (desc *Mutable) AddSchemaToDatabase(
 	desc.Schemas[schemaName] = schemaInfo
 }
 
+// AddForeignServer adds a foreign server to the database's ForeignServers
+// map, overriding any existing server with the same name.
+func (desc *Mutable) AddForeignServer(
+	name string, server descpb.DatabaseDescriptor_ForeignServer,
+) {
+	if desc.ForeignServers == nil {
+		desc.ForeignServers = make(map[string]descpb.DatabaseDescriptor_ForeignServer)
+	}
+	desc.ForeignServers[name] = server
+}
+
+// RemoveForeignServer removes the foreign server with the given name from the
+// database's ForeignServers map.
+func (desc *Mutable) RemoveForeignServer(name string) {
+	delete(desc.ForeignServers, name)
+}
+
 // GetDeclarativeSchemaChangerState is part of the catalog.MutableDescriptor
 // interface.
 func (desc *immutable) GetDeclarativeSchemaChangerState() *scpb.DescriptorState {
@@ -233,7 +233,12 @@ func (desc *TableDescriptor) MaterializedView() bool {
 
 // IsReadOnly implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsReadOnly() bool {
-	return desc.IsMaterializedView || desc.GetExternal() != nil
+	return desc.IsMaterializedView || desc.GetExternal() != nil || desc.IsForeignTable()
+}
+
+// IsForeignTable implements the TableDescriptor interface.
+func (desc *TableDescriptor) IsForeignTable() bool {
+	return desc.ForeignTable != nil
 }
 
 // IsPhysicalTable implements the TableDescriptor interface.
@@ -333,6 +338,11 @@ func (u *UniqueWithoutIndexConstraint) IsPartial() bool {
 	return u.Predicate != ""
 }
 
+// IsExclusion returns true if the constraint is an exclusion constraint.
+func (u *UniqueWithoutIndexConstraint) IsExclusion() bool {
+	return len(u.ExclusionOperators) > 0
+}
+
 // GetParentID implements the catalog.NameKeyHaver interface.
 func (ni NameInfo) GetParentID() ID {
 	return ni.ParentID
@@ -320,6 +320,12 @@ type DatabaseDescriptor interface {
 	// GetSchemaID returns the ID in the schema mapping entry for the
 	// given name, 0 otherwise.
 	GetSchemaID(name string) descpb.ID
+	// GetForeignServer returns the foreign server with the given name, or nil
+	// if no such server exists in the database.
+	GetForeignServer(name string) *descpb.DatabaseDescriptor_ForeignServer
+	// ForEachForeignServer iterates f over the foreign servers of the database
+	// in name order. iterutil.StopIteration is supported.
+	ForEachForeignServer(f func(name string, server *descpb.DatabaseDescriptor_ForeignServer) error) error
 	// GetNonDroppedSchemaName returns the name in the schema mapping entry for the
 	// given ID, if it's not marked as dropped, empty string otherwise.
 	GetNonDroppedSchemaName(schemaID descpb.ID) string
@@ -369,6 +375,12 @@ type TableDescriptor interface {
 	// IsReadOnly returns if this table descriptor has external data, and cannot
 	// be written to.
 	IsReadOnly() bool
+	// IsForeignTable returns true if the TableDescriptor describes a foreign
+	// table, whose rows are read through a foreign-data wrapper.
+	IsForeignTable() bool
+	// GetForeignTable returns the foreign table definition. It is only non-nil
+	// if IsForeignTable is true.
+	GetForeignTable() *descpb.TableDescriptor_ForeignTable
 	// IsAs returns true if the TableDescriptor describes a Table that was created
 	// with a CREATE TABLE AS command.
 	IsAs() bool
@@ -694,6 +706,11 @@ type TableDescriptor interface {
 	// GetDependsOnFunctions returns the IDs of all functions that this view
 	// depends on. It's only non-nil if IsView is true.
 	GetDependsOnFunctions() []descpb.ID
+	// GetInherits returns the IDs of the tables this table inherits from.
+	GetInherits() []descpb.ID
+	// GetInheritedBy returns the IDs of the tables which inherit from this
+	// table.
+	GetInheritedBy() []descpb.ID
 
 	// AllConstraints returns all constraints in this table, regardless if
 	// they're enforced yet or not. The ordering of the constraints within this
@@ -915,6 +932,10 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type, nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -1002,6 +1023,29 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domain types, which
+// are base types with optional constraints.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// BaseType returns the underlying type of the domain.
+	BaseType() *types.T
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// GetDefaultExpr returns the serialized default expression of the domain,
+	// and whether it has one.
+	GetDefaultExpr() (expr string, ok bool)
+
+	// NumChecks returns the number of CHECK constraints of the domain.
+	NumChecks() int
+
+	// GetCheck returns the name and the serialized expression of the CHECK
+	// constraint at the given ordinal.
+	GetCheck(ordinal int) (name, expr string)
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -1087,8 +1131,31 @@ type FunctionDescriptor interface {
 	// returns false if the descriptor represents a user-defined function.
 	IsProcedure() bool
 
+	// IsAggregate returns true if the descriptor represents a user-defined
+	// aggregate function.
+	IsAggregate() bool
+
+	// IsVariadic returns true if the last input parameter of the routine is
+	// declared VARIADIC.
+	IsVariadic() bool
+
 	// GetSecurity returns the security specification of this function.
 	GetSecurity() catpb.Function_Security
+
+	// GetCost returns the estimated execution cost of this function, or zero
+	// if it was not specified.
+	GetCost() float64
+
+	// GetRows returns the estimated number of rows returned by this function,
+	// or zero if it was not specified.
+	GetRows() float64
+
+	// GetParallel returns the parallel safety of this function.
+	GetParallel() catpb.Function_Parallel
+
+	// GetConfig returns the session variable overrides that are applied while
+	// this function executes.
+	GetConfig() []descpb.FunctionDescriptor_ConfigSetting
 }
 
 // FilterDroppedDescriptor returns an error if the descriptor state is DROP.
@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
@@ -230,6 +231,36 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	if agg := desc.Aggregate; agg != nil {
+		if desc.IsProcedure() || desc.ReturnType.ReturnSet {
+			vea.Report(errors.AssertionFailedf("aggregate function cannot be a procedure or return a set"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate state type not set"))
+		}
+		dependsOn := catalog.MakeDescriptorIDSet(desc.DependsOnFunctions...)
+		for _, id := range []descpb.ID{agg.TransitionFunctionID, agg.FinalFunctionID} {
+			if id != descpb.InvalidID && !dependsOn.Contains(id) {
+				vea.Report(errors.AssertionFailedf("aggregate support function %d is missing from depends-on-functions references", id))
+			}
+		}
+		if agg.TransitionFunctionID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("aggregate transition function not set"))
+		}
+	}
+
+	if desc.Cost < 0 {
+		vea.Report(errors.AssertionFailedf("invalid cost %v", desc.Cost))
+	}
+	if desc.Rows < 0 || (desc.Rows > 0 && !desc.ReturnType.ReturnSet) {
+		vea.Report(errors.AssertionFailedf("invalid rows %v", desc.Rows))
+	}
+	for i, c := range desc.Config {
+		if c.Name == "" {
+			vea.Report(errors.AssertionFailedf("config setting #%d has no name", i))
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -497,6 +528,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if agg := desc.Aggregate; agg != nil && catid.IsOIDUserDefined(agg.StateType.Oid()) {
+		if err := fn(agg.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -508,6 +544,9 @@ func (desc *immutable) MaybeRequiresTypeHydration() bool {
 	if catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return true
 	}
+	if agg := desc.Aggregate; agg != nil && catid.IsOIDUserDefined(agg.StateType.Oid()) {
+		return true
+	}
 	for i := range desc.Params {
 		if catid.IsOIDUserDefined(desc.Params[i].Type.Oid()) {
 			return true
@@ -633,6 +672,49 @@ func (desc *Mutable) SetSecurity(v catpb.Function_Security) {
 	desc.Security = v
 }
 
+// SetCost sets the estimated execution cost of the function.
+func (desc *Mutable) SetCost(v float64) {
+	desc.Cost = v
+}
+
+// SetRows sets the estimated number of rows returned by the function.
+func (desc *Mutable) SetRows(v float64) {
+	desc.Rows = v
+}
+
+// SetParallel sets the Parallel attribute.
+func (desc *Mutable) SetParallel(v catpb.Function_Parallel) {
+	desc.Parallel = v
+}
+
+// SetConfig adds a session variable override to the function, replacing any
+// existing override of the same session variable.
+func (desc *Mutable) SetConfig(name, value string) {
+	for i := range desc.Config {
+		if desc.Config[i].Name == name {
+			desc.Config[i].Value = value
+			return
+		}
+	}
+	desc.Config = append(desc.Config, descpb.FunctionDescriptor_ConfigSetting{Name: name, Value: value})
+}
+
+// ResetConfig removes the override of the given session variable from the
+// function, if it exists.
+func (desc *Mutable) ResetConfig(name string) {
+	for i := range desc.Config {
+		if desc.Config[i].Name == name {
+			desc.Config = append(desc.Config[:i], desc.Config[i+1:]...)
+			return
+		}
+	}
+}
+
+// ResetAllConfig removes all session variable overrides from the function.
+func (desc *Mutable) ResetAllConfig() {
+	desc.Config = nil
+}
+
 // SetName sets the function name.
 func (desc *Mutable) SetName(n string) {
 	desc.Name = n
@@ -928,6 +1010,26 @@ func (desc *immutable) GetSecurity() catpb.Function_Security {
 	return desc.Security
 }
 
+// GetCost implements the FunctionDescriptor interface.
+func (desc *immutable) GetCost() float64 {
+	return desc.Cost
+}
+
+// GetRows implements the FunctionDescriptor interface.
+func (desc *immutable) GetRows() float64 {
+	return desc.Rows
+}
+
+// GetParallel implements the FunctionDescriptor interface.
+func (desc *immutable) GetParallel() catpb.Function_Parallel {
+	return desc.Parallel
+}
+
+// GetConfig implements the FunctionDescriptor interface.
+func (desc *immutable) GetConfig() []descpb.FunctionDescriptor_ConfigSetting {
+	return desc.Config
+}
+
 func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	routineType := tree.UDFRoutine
 	if desc.IsProcedure() {
@@ -964,6 +1066,7 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	ret.ReturnType = tree.FixedReturnType(desc.ReturnType.Type)
 	ret.ReturnsRecordType = !desc.IsProcedure() && desc.ReturnType.Type.Identical(types.AnyTuple)
 	ret.Types = signatureTypes
+	ret.Variadic = desc.IsVariadic()
 	ret.Volatility, err = desc.getOverloadVolatility()
 	if err != nil {
 		return nil, err
@@ -975,7 +1078,26 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.UDFAggregate = &tree.UDFAggregate{
+			TransitionFunc: catid.FuncIDToOID(agg.TransitionFunctionID),
+			StateType:      agg.StateType,
+			InitCond:       agg.InitCond,
+		}
+		if agg.FinalFunctionID != descpb.InvalidID {
+			ret.UDFAggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFunctionID)
+		}
+	}
 	ret.SecurityMode = desc.getCreateExprSecurity()
+	ret.Cost = desc.Cost
+	ret.Rows = desc.Rows
+	if len(desc.Config) > 0 {
+		ret.Config = make(tree.RoutineConfig, len(desc.Config))
+		for i, c := range desc.Config {
+			ret.Config[i] = tree.RoutineConfigSetting{Name: c.Name, Value: c.Value}
+		}
+	}
 
 	return ret, nil
 }
@@ -1039,15 +1161,41 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateRoutine, err error) {
 			}
 		}
 	}
-	// We only store 6 function attributes at the moment. We may extend the
-	// pre-allocated capacity in the future.
-	ret.Options = make(tree.RoutineOptions, 0, 6)
+	// We always store 6 function attributes, plus any of the optional PARALLEL,
+	// COST, ROWS, and SET attributes that were specified.
+	ret.Options = make(tree.RoutineOptions, 0, 9+len(desc.Config))
 	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
 	ret.Options = append(ret.Options, tree.RoutineLeakproof(desc.LeakProof))
 	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
 	ret.Options = append(ret.Options, tree.RoutineBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	ret.Options = append(ret.Options, desc.getCreateExprSecurity())
+	if desc.Parallel != catpb.Function_PARALLEL_UNSAFE {
+		ret.Options = append(ret.Options, desc.getCreateExprParallel())
+	}
+	if desc.Cost != 0 {
+		ret.Options = append(ret.Options, tree.RoutineCost(desc.Cost))
+	}
+	if desc.Rows != 0 {
+		ret.Options = append(ret.Options, tree.RoutineRows(desc.Rows))
+	}
+	for _, c := range desc.Config {
+		set := &tree.RoutineSet{Name: c.Name}
+		if c.Name == "search_path" {
+			// The search path is stored as a comma-separated list of schemas,
+			// which must be formatted as separate values to round-trip.
+			paths, err := sessiondata.ParseSearchPath(c.Value)
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range paths {
+				set.Values = append(set.Values, tree.NewStrVal(path))
+			}
+		} else {
+			set.Values = tree.Exprs{tree.NewStrVal(c.Value)}
+		}
+		ret.Options = append(ret.Options, set)
+	}
 	return ret, nil
 }
 
@@ -1056,6 +1204,21 @@ func (desc *immutable) IsProcedure() bool {
 	return desc.FunctionDescriptor.IsProcedure
 }
 
+// IsAggregate implements the FunctionDescriptor interface.
+func (desc *immutable) IsAggregate() bool {
+	return desc.Aggregate != nil
+}
+
+// IsVariadic implements the FunctionDescriptor interface.
+func (desc *immutable) IsVariadic() bool {
+	for i := range desc.Params {
+		if desc.Params[i].Class == catpb.Function_Param_VARIADIC {
+			return true
+		}
+	}
+	return false
+}
+
 func (desc *immutable) getCreateExprLang() tree.RoutineLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
@@ -1090,6 +1253,18 @@ func (desc *immutable) getCreateExprNullInputBehavior() tree.RoutineNullInputBeh
 	return 0
 }
 
+func (desc *immutable) getCreateExprParallel() tree.RoutineParallel {
+	switch desc.Parallel {
+	case catpb.Function_PARALLEL_UNSAFE:
+		return tree.RoutineParallelUnsafe
+	case catpb.Function_PARALLEL_RESTRICTED:
+		return tree.RoutineParallelRestricted
+	case catpb.Function_PARALLEL_SAFE:
+		return tree.RoutineParallelSafe
+	}
+	return 0
+}
+
 func (desc *immutable) getCreateExprSecurity() tree.RoutineSecurity {
 	switch desc.Security {
 	case catpb.Function_INVOKER:
@@ -141,3 +141,17 @@ func SecurityToProto(v tree.RoutineSecurity) (catpb.Function_Security, error) {
 	}
 	return -1, errors.AssertionFailedf("unknown function security class %q", v)
 }
+
+// ParallelToProto converts sql statement input parallel safety to protobuf
+// type.
+func ParallelToProto(v tree.RoutineParallel) (catpb.Function_Parallel, error) {
+	switch v {
+	case tree.RoutineParallelUnsafe:
+		return catpb.Function_PARALLEL_UNSAFE, nil
+	case tree.RoutineParallelRestricted:
+		return catpb.Function_PARALLEL_RESTRICTED, nil
+	case tree.RoutineParallelSafe:
+		return catpb.Function_PARALLEL_SAFE, nil
+	}
+	return -1, errors.AssertionFailedf("unknown function parallel class %q", v)
+}
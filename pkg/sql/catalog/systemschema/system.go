@@ -1326,6 +1326,37 @@ CREATE TABLE system.prepared_transactions (
   CONSTRAINT "primary" PRIMARY KEY (global_id),
   FAMILY "primary" (global_id, transaction_id, transaction_key, prepared, owner, database, heuristic)
 );`
+
+	// NotificationsTableSchema stores the notifications sent with NOTIFY and
+	// pg_notify(). Rows are delivered to the listening sessions of every SQL
+	// instance through a rangefeed, and are deleted shortly after they are
+	// written.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+  created     TIMESTAMPTZ  NOT NULL DEFAULT now(),
+  id          INT8         NOT NULL DEFAULT unique_rowid(),
+  channel     STRING       NOT NULL,
+  payload     STRING       NOT NULL,
+  sender_pid  INT8         NOT NULL,
+  CONSTRAINT "primary" PRIMARY KEY (created, id),
+  FAMILY "primary" (created, id, channel, payload, sender_pid)
+);`
+
+	// ReplicationSlotsTableSchema stores the logical replication slots created
+	// with CREATE_REPLICATION_SLOT over a replication connection. Each slot
+	// holds a protected timestamp record on its database so that changes after
+	// confirmed_flush_lsn remain available to the consumer.
+	ReplicationSlotsTableSchema = `
+CREATE TABLE system.replication_slots (
+  slot_name                   STRING       NOT NULL,
+  plugin                      STRING       NOT NULL,
+  database_id                 INT8         NOT NULL,
+  created                     TIMESTAMPTZ  NOT NULL DEFAULT now(),
+  confirmed_flush_lsn         PG_LSN       NOT NULL,
+  protected_timestamp_record  UUID         NULL,
+  CONSTRAINT "primary" PRIMARY KEY (slot_name),
+  FAMILY "primary" (slot_name, plugin, database_id, created, confirmed_flush_lsn, protected_timestamp_record)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1378,7 +1409,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V25_3_AddEventLogColumnAndIndex.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V25_3_ReplicationSlotsTable.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1575,6 +1606,8 @@ func MakeSystemTables() []SystemTable {
 		SystemJobStatusTable,
 		SystemJobMessageTable,
 		PreparedTransactionsTable,
+		NotificationsTable,
+		ReplicationSlotsTable,
 	}
 }
 
@@ -5216,6 +5249,60 @@ var (
 			pk("global_id"),
 		),
 	)
+
+	NotificationsTable = makeSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "created", ID: 1, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+				{Name: "id", ID: 2, Type: types.Int, DefaultExpr: &uniqueRowIDString},
+				{Name: "channel", ID: 3, Type: types.String},
+				{Name: "payload", ID: 4, Type: types.String},
+				{Name: "sender_pid", ID: 5, Type: types.Int},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ColumnNames: []string{"created", "id", "channel", "payload", "sender_pid"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                tabledesc.LegacyPrimaryKeyIndexName,
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"created", "id"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC},
+				KeyColumnIDs:        []descpb.ColumnID{1, 2},
+			},
+		),
+	)
+
+	ReplicationSlotsTable = makeSystemTable(
+		ReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "slot_name", ID: 1, Type: types.String},
+				{Name: "plugin", ID: 2, Type: types.String},
+				{Name: "database_id", ID: 3, Type: types.Int},
+				{Name: "created", ID: 4, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+				{Name: "confirmed_flush_lsn", ID: 5, Type: types.PGLSN},
+				{Name: "protected_timestamp_record", ID: 6, Type: types.Uuid, Nullable: true},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ColumnNames: []string{"slot_name", "plugin", "database_id", "created", "confirmed_flush_lsn", "protected_timestamp_record"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			pk("slot_name"),
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
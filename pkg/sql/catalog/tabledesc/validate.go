@@ -6,6 +6,8 @@
 package tabledesc
 
 import (
+	"slices"
+
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -143,6 +145,13 @@ func (desc *wrapper) GetReferencedDescIDs(
 			ids.Add(id)
 		}
 	}
+	// Add inheritance references.
+	for _, id := range desc.GetInherits() {
+		ids.Add(id)
+	}
+	for _, id := range desc.GetInheritedBy() {
+		ids.Add(id)
+	}
 	return ids, nil
 }
 
@@ -270,6 +279,11 @@ func (desc *wrapper) ValidateForwardReferences(
 			vea.Report(catalog.ValidateOutboundFunctionRef(id, vdg))
 		}
 	}
+
+	// Check that the tables this table inherits from exist.
+	for _, id := range desc.Inherits {
+		vea.Report(catalog.ValidateOutboundTableRef(id, vdg))
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -399,6 +413,31 @@ func (desc *wrapper) ValidateBackReferences(
 			vea.Report(catalog.ValidateOutboundTableRefBackReference(desc.GetID(), ref))
 		}
 	}
+
+	// Check that inheritance references have matching back-references.
+	for _, id := range desc.Inherits {
+		parent, _ := vdg.GetTableDescriptor(id)
+		if parent == nil {
+			continue
+		}
+		if !slices.Contains(parent.GetInheritedBy(), desc.GetID()) {
+			vea.Report(errors.AssertionFailedf(
+				"inherited table %q (%d) has no corresponding inherited-by back reference",
+				parent.GetName(), parent.GetID()))
+		}
+	}
+	for _, id := range desc.InheritedBy {
+		child, err := vdg.GetTableDescriptor(id)
+		if err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid inherited-by back reference"))
+			continue
+		}
+		if !slices.Contains(child.GetInherits(), desc.GetID()) {
+			vea.Report(errors.AssertionFailedf(
+				"inherited-by table %q (%d) has no corresponding inherits forward reference",
+				child.GetName(), child.GetID()))
+		}
+	}
 }
 
 func (desc *wrapper) validateOutboundTypeRef(id descpb.ID, vdg catalog.ValidationDescGetter) error {
@@ -1559,6 +1598,22 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		// Verify that an exclusion constraint has a valid operator for each
+		// column.
+		if uc := c.UniqueWithoutIndexDesc(); uc.IsExclusion() {
+			if len(uc.ExclusionOperators) != c.NumKeyColumns() {
+				return errors.Newf(
+					"exclusion constraint %q has %d operators for %d columns",
+					c.GetName(), len(uc.ExclusionOperators), c.NumKeyColumns(),
+				)
+			}
+			for _, op := range uc.ExclusionOperators {
+				if _, err := tree.ExclusionOperator(op); err != nil {
+					return errors.Wrapf(err, "exclusion constraint %q", c.GetName())
+				}
+			}
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
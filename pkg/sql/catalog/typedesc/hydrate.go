@@ -131,6 +131,20 @@ func ensureTypeMetadataIsHydrated(
 		tm.ImplicitRecordType = true
 		return
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		n := d.NumChecks()
+		tm.DomainData = &types.DomainMetadata{
+			BaseType:   d.BaseType(),
+			NotNull:    d.IsNotNull(),
+			CheckNames: make([]string, n),
+			CheckExprs: make([]string, n),
+		}
+		tm.DomainData.DefaultExpr, _ = d.GetDefaultExpr()
+		for i := 0; i < n; i++ {
+			tm.DomainData.CheckNames[i], tm.DomainData.CheckExprs[i] = d.GetCheck(i)
+		}
+		return
+	}
 	if e := maybeDesc.AsEnumTypeDescriptor(); e != nil {
 		if imm, ok := e.(*immutable); ok {
 			// Fast-path for immutable enum descriptors. We can use a pointer into the
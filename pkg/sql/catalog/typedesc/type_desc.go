@@ -38,6 +38,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -502,6 +503,24 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.RegionConfig != nil {
+			vea.Report(errors.AssertionFailedf("found region config on %s type desc", desc.Kind.String()))
+		}
+		if desc.Domain == nil || desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+			break
+		}
+		checkNames := make(map[string]struct{}, len(desc.Domain.Checks))
+		for _, c := range desc.Domain.Checks {
+			if c.Name == "" {
+				vea.Report(errors.AssertionFailedf("DOMAIN type desc has unnamed check constraint"))
+			}
+			if _, ok := checkNames[c.Name]; ok {
+				vea.Report(errors.AssertionFailedf("duplicate domain check constraint %q", c.Name))
+			}
+			checkNames[c.Name] = struct{}{}
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -610,6 +629,14 @@ func (desc *immutable) ValidateForwardReferences(
 		}
 	}
 
+	if d := desc.AsDomainTypeDescriptor(); d != nil && d.BaseType().UserDefined() {
+		// User-defined base types are currently not supported, but this should
+		// be validated elsewhere.
+		vea.Report(errors.AssertionFailedf("invalid reference to user-defined type %q from domain type %q",
+			d.BaseType().String(), desc.GetName(),
+		))
+	}
+
 	if c := desc.AsCompositeTypeDescriptor(); c != nil {
 		for i := 0; i < c.NumElements(); i++ {
 			t := c.GetElementType(i)
@@ -771,6 +798,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			desc.Domain.BaseType,
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -908,6 +941,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if desc.Domain != nil && catid.IsOIDUserDefined(desc.Domain.BaseType.Oid()) {
+		if err := fn(desc.Domain.BaseType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if desc.Composite == nil {
 		return nil
 	}
@@ -927,6 +965,9 @@ func (desc *immutable) MaybeRequiresTypeHydration() bool {
 	if desc.Alias != nil && catid.IsOIDUserDefined(desc.Alias.Oid()) {
 		return true
 	}
+	if desc.Domain != nil && catid.IsOIDUserDefined(desc.Domain.BaseType.Oid()) {
+		return true
+	}
 	if desc.Composite == nil {
 		return false
 	}
@@ -951,6 +992,9 @@ func (desc *immutable) GetIDClosure() (ret catalog.DescriptorIDSet) {
 		for _, e := range desc.Composite.Elements {
 			GetTypeDescriptorClosure(e.ElementType).ForEach(ret.Add)
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		ret.Add(desc.ArrayTypeID)
+		GetTypeDescriptorClosure(desc.Domain.BaseType).ForEach(ret.Add)
 	default:
 		// Otherwise, take the array type ID.
 		ret.Add(desc.ArrayTypeID)
@@ -1025,6 +1069,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1051,6 +1103,35 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// BaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) BaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// IsNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) IsNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// GetDefaultExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDefaultExpr() (expr string, ok bool) {
+	if desc.Domain.DefaultExpr == nil {
+		return "", false
+	}
+	return *desc.Domain.DefaultExpr, true
+}
+
+// NumChecks implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumChecks() int {
+	return len(desc.Domain.Checks)
+}
+
+// GetCheck implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheck(ordinal int) (name, expr string) {
+	c := &desc.Domain.Checks[ordinal]
+	return c.Name, c.Expr
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
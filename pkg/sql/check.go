@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
 	pbtypes "github.com/gogo/protobuf/types"
 )
 
@@ -500,6 +501,17 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
+			if uc.UniqueWithoutIndexDesc().IsExclusion() {
+				return validateExclusionConstraint(
+					ctx,
+					tableDesc,
+					uc.UniqueWithoutIndexDesc(),
+					0, /* indexIDForValidation */
+					p.InternalSQLTxn(),
+					p.User(),
+					true, /* preExisting */
+				)
+			}
 			return validateUniqueConstraint(
 				ctx,
 				tableDesc,
@@ -580,17 +592,31 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
-				ctx,
-				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
-				0, /* indexIDForValidation */
-				txn,
-				user,
-				true, /* preExisting */
-			); err != nil {
+			var err error
+			if uc.UniqueWithoutIndexDesc().IsExclusion() {
+				err = validateExclusionConstraint(
+					ctx,
+					tableDesc,
+					uc.UniqueWithoutIndexDesc(),
+					0, /* indexIDForValidation */
+					txn,
+					user,
+					true, /* preExisting */
+				)
+			} else {
+				err = validateUniqueConstraint(
+					ctx,
+					tableDesc,
+					uc.GetName(),
+					uc.CollectKeyColumnIDs().Ordered(),
+					uc.GetPredicate(),
+					0, /* indexIDForValidation */
+					txn,
+					user,
+					true, /* preExisting */
+				)
+			}
+			if err != nil {
 				log.Errorf(ctx, "validation of unique constraints failed for table %s: %s", tableDesc.GetName(), err)
 				return errors.Wrapf(err, "for table %s", tableDesc.GetName())
 			}
@@ -639,6 +665,45 @@ func validateUniqueConstraint(
 		query,
 	)
 
+	values, err := queryRowForValidation(ctx, txn, "validate unique constraint", user, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add a unique index due to duplicated keys.
+		errMsg := "could not create unique constraint"
+		if preExisting {
+			errMsg = "failed to validate unique constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
+				),
+				constraintName,
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+			),
+		)
+	}
+	return nil
+}
+
+// queryRowForValidation runs the given constraint validation query as the
+// given user and returns its first row, if any.
+func queryRowForValidation(
+	ctx context.Context,
+	txn isql.Txn,
+	opName redact.RedactableString,
+	user username.SQLUsername,
+	query string,
+) (values tree.Datums, err error) {
 	sessionDataOverride := sessiondata.NoSessionDataOverride
 	sessionDataOverride.User = user
 	// We are likely to have performed a lot of work before getting here (e.g.
@@ -651,14 +716,13 @@ func validateUniqueConstraint(
 	// error as "job retryable" and relying on the jobs framework to do the
 	// retries in order to not waste (a lot of) work that was performed before
 	// we got here.
-	var values tree.Datums
 	retryOptions := retry.Options{
 		InitialBackoff: 20 * time.Millisecond,
 		Multiplier:     1.5,
 		MaxRetries:     5,
 	}
 	for r := retry.StartWithCtx(ctx, retryOptions); r.Next(); {
-		values, err = txn.QueryRowEx(ctx, "validate unique constraint", txn.KV(), sessionDataOverride, query)
+		values, err = txn.QueryRowEx(ctx, opName, txn.KV(), sessionDataOverride, query)
 		if err == nil {
 			break
 		}
@@ -669,6 +733,120 @@ func validateUniqueConstraint(
 			log.Infof(ctx, "retrying the validation query because of %v", err)
 			continue
 		}
+		return nil, err
+	}
+	return values, err
+}
+
+// conflictingRowQuery generates and returns a query for column values that
+// violate the specified exclusion constraint. A violation is a pair of
+// distinct rows for which every operator of the constraint returns true.
+//
+// For example, the constraint EXCLUDE (a WITH =, b WITH &&) on the table
+// "tbl" with primary key k would require the following query:
+//
+// SELECT l.a, l.b, r.a, r.b
+// FROM (SELECT k, a, b FROM tbl) AS l, (SELECT k, a, b FROM tbl) AS r
+// WHERE (l.k) != (r.k) AND l.a = r.a AND l.b && r.b
+// LIMIT 1
+//
+// If the constraint is partial, both sides of the join are filtered by its
+// predicate. `indexIDForValidation`, if non-zero, will be used to force the
+// sql query to use this particular index by hinting the query.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+) (sql string, colNames []string, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, uc.ColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColIDs := srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs
+	pkColNames, err := catalog.ColumnNamesForIDs(srcTbl, pkColIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	projColIDs := make([]descpb.ColumnID, 0, len(pkColIDs)+len(uc.ColumnIDs))
+	projColIDs = append(projColIDs, pkColIDs...)
+	projColIDs = append(projColIDs, uc.ColumnIDs...)
+
+	var projCols intsets.Fast
+	var proj, leftCols, rightCols, leftPK, rightPK, where []string
+	for _, id := range projColIDs {
+		if projCols.Contains(int(id)) {
+			continue
+		}
+		projCols.Add(int(id))
+		col, err := catalog.MustFindColumnByID(srcTbl, id)
+		if err != nil {
+			return "", nil, err
+		}
+		proj = append(proj, tree.NameString(col.GetName()))
+	}
+	for _, n := range pkColNames {
+		leftPK = append(leftPK, "l."+tree.NameString(n))
+		rightPK = append(rightPK, "r."+tree.NameString(n))
+	}
+	where = append(where, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(leftPK, ", "), strings.Join(rightPK, ", "),
+	))
+	for i, n := range colNames {
+		op, err := tree.ExclusionOperator(uc.ExclusionOperators[i])
+		if err != nil {
+			return "", nil, err
+		}
+		leftCols = append(leftCols, "l."+tree.NameString(n))
+		rightCols = append(rightCols, "r."+tree.NameString(n))
+		where = append(where, fmt.Sprintf("%s %s %s", leftCols[i], op, rightCols[i]))
+	}
+
+	src := fmt.Sprintf("[%d AS tbl]", srcTbl.GetID())
+	if indexIDForValidation != 0 {
+		src = fmt.Sprintf("%s@[%d]", src, indexIDForValidation)
+	}
+	side := fmt.Sprintf("SELECT %s FROM %s", strings.Join(proj, ", "), src)
+	if uc.Predicate != "" {
+		side = fmt.Sprintf("%s WHERE (%s)", side, uc.Predicate)
+	}
+	query := fmt.Sprintf(
+		`SELECT %[1]s, %[2]s FROM (%[3]s) AS l, (%[3]s) AS r WHERE %[4]s LIMIT 1`,
+		strings.Join(leftCols, ", "),  // 1
+		strings.Join(rightCols, ", "), // 2
+		side,                          // 3
+		strings.Join(where, " AND "),  // 4
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two distinct rows in the
+// srcTable conflict according to the given exclusion constraint.
+//
+// `indexIDForValidation` and preExisting have the same meaning as they do for
+// validateUniqueConstraint.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := conflictingRowQuery(srcTable, uc, indexIDForValidation)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.Name,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := queryRowForValidation(ctx, txn, "validate exclusion constraint", user, query)
+	if err != nil {
 		return err
 	}
 	if values.Len() > 0 {
@@ -676,21 +854,24 @@ func validateUniqueConstraint(
 		for i := range values {
 			valuesStr[i] = values[i].String()
 		}
+		n := len(colNames)
 		// Note: this error message mirrors the message produced by Postgres
-		// when it fails to add a unique index due to duplicated keys.
-		errMsg := "could not create unique constraint"
+		// when it fails to add an exclusion constraint due to conflicting keys.
+		errMsg := "could not create exclusion constraint"
 		if preExisting {
-			errMsg = "failed to validate unique constraint"
+			errMsg = "failed to validate exclusion constraint"
 		}
+		cols := strings.Join(colNames, ", ")
 		return errors.WithDetail(
 			pgerror.WithConstraintName(
 				pgerror.Newf(
-					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.Name,
 				),
-				constraintName,
+				uc.Name,
 			),
 			fmt.Sprintf(
-				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+				"Key (%s)=(%s) conflicts with key (%s)=(%s).",
+				cols, strings.Join(valuesStr[:n], ", "), cols, strings.Join(valuesStr[n:], ", "),
 			),
 		)
 	}
@@ -895,6 +895,11 @@ func NewColOperator(
 					if flowCtx.TraceKV {
 						return false
 					}
+					// Row-level sampling happens while decoding the KVs,
+					// which the KV server doesn't know how to do.
+					if core.TableReader.Sample != nil {
+						return false
+					}
 					// The current implementation of non-default locking
 					// strength as well as of SKIP LOCKED wait policy require
 					// being able to access to the full keys after the
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execreleasable"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
@@ -205,6 +206,10 @@ type cFetcherArgs struct {
 	alwaysReallocate bool
 	// Txn is the txn for the fetch. It might be nil.
 	txn *kv.Txn
+	// sample, if set, indicates that only the rows that are part of the
+	// sample should be emitted, and all other rows are skipped without being
+	// decoded.
+	sample *execinfrapb.TableSampleSpec
 }
 
 // noOutputColumn is a sentinel value to denote that a system column is not
@@ -322,6 +327,9 @@ type cFetcher struct {
 		decoding       []byte
 		nextKVKey      []byte
 		nextKVRawBytes []byte
+		// skippedRowPrefix is the row prefix of the row that is currently
+		// being skipped in stateSkipRow.
+		skippedRowPrefix []byte
 	}
 
 	accountingHelper colmem.SetAccountingHelper
@@ -653,6 +661,16 @@ const (
 	//   7. -> fetchNextKVWithUnfinishedRow
 	stateFetchNextKVWithUnfinishedRow
 
+	// stateSkipRow skips the remaining keys of a row that is not part of the
+	// sample of a TABLESAMPLE BERNOULLI scan.
+	//   1. fetch next kv into nextKV buffer
+	//   2. check whether it has the prefix of the skipped row
+	//   3. yes?
+	//     -> skipRow
+	//   4. no?
+	//     -> decodeFirstKVOfRow
+	stateSkipRow
+
 	// stateFinalizeRow is the state of finalizing a row. It assumes that no more
 	// keys for the current row are present.
 	// state[1] must be set, and stateFinalizeRow will transition to that state
@@ -772,6 +790,21 @@ func (cf *cFetcher) NextBatch(ctx context.Context) (coldata.Batch, error) {
 			cf.resetBatch()
 			cf.shiftState()
 		case stateDecodeFirstKVOfRow:
+			if cf.sample != nil {
+				prefixLen, err := keys.GetRowPrefixLength(cf.machine.nextKV.Key)
+				if err != nil {
+					return nil, err
+				}
+				if !cf.sample.KeepRow(cf.machine.nextKV.Key[cf.table.spec.KeyPrefixLength:prefixLen]) {
+					if cf.table.spec.MaxKeysPerRow == 1 {
+						cf.machine.state[0] = stateInitFetch
+						continue
+					}
+					cf.scratch.skippedRowPrefix = append(cf.scratch.skippedRowPrefix[:0], cf.machine.nextKV.Key[:prefixLen]...)
+					cf.machine.state[0] = stateSkipRow
+					continue
+				}
+			}
 			// Reset MVCC metadata for the table, since this is the first KV of a row.
 			cf.table.rowLastModified = hlc.Timestamp{}
 			cf.table.rowLastOriginID = 0
@@ -975,6 +1008,24 @@ func (cf *cFetcher) NextBatch(ctx context.Context) (coldata.Batch, error) {
 				cf.machine.state[0] = stateFetchNextKVWithUnfinishedRow
 			}
 
+		case stateSkipRow:
+			cf.cpuStopWatch.Start()
+			moreKVs, _, kv, err := cf.nextKVer.NextKV(ctx, cf.mvccDecodeStrategy)
+			cf.cpuStopWatch.Stop()
+			if err != nil {
+				return nil, convertFetchError(&cf.table.spec, err)
+			}
+			if !moreKVs {
+				cf.machine.state[0] = stateEmitLastBatch
+				continue
+			}
+			if bytes.HasPrefix(kv.Key, cf.scratch.skippedRowPrefix) {
+				// The kv belongs to the skipped row.
+				continue
+			}
+			cf.setNextKV(kv)
+			cf.machine.state[0] = stateDecodeFirstKVOfRow
+
 		case stateFinalizeRow:
 			// Populate the timestamp system column if needed. We have to do it
 			// on a per row basis since each row can be modified at a different
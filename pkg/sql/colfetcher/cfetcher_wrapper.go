@@ -233,6 +233,7 @@ func newCFetcherWrapper(
 		collectStats,
 		alwaysReallocate,
 		nil, /* txn */
+		nil, /* sample */
 	}
 
 	// This memory monitor is not connected to the memory accounting system
@@ -18,9 +18,10 @@ func _() {
 	_ = x[stateResetBatch-2]
 	_ = x[stateDecodeFirstKVOfRow-3]
 	_ = x[stateFetchNextKVWithUnfinishedRow-4]
-	_ = x[stateFinalizeRow-5]
-	_ = x[stateEmitLastBatch-6]
-	_ = x[stateFinished-7]
+	_ = x[stateSkipRow-5]
+	_ = x[stateFinalizeRow-6]
+	_ = x[stateEmitLastBatch-7]
+	_ = x[stateFinished-8]
 }
 
 func (i fetcherState) String() string {
@@ -35,6 +36,8 @@ func (i fetcherState) String() string {
 		return "stateDecodeFirstKVOfRow"
 	case stateFetchNextKVWithUnfinishedRow:
 		return "stateFetchNextKVWithUnfinishedRow"
+	case stateSkipRow:
+		return "stateSkipRow"
 	case stateFinalizeRow:
 		return "stateFinalizeRow"
 	case stateEmitLastBatch:
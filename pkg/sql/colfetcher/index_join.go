@@ -603,6 +603,7 @@ func NewColIndexJoin(
 		shouldCollectStats,
 		false, /* alwaysReallocate */
 		txn,
+		nil, /* sample */
 	}
 	if err = fetcher.Init(
 		fetcherAllocator, kvFetcher, tableArgs,
@@ -45,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxrecommendations"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
+	"github.com/cockroachdb/cockroach/pkg/sql/listennotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
@@ -927,6 +928,9 @@ func (s *Server) SetupConn(
 		false, /* underOuterTxn */
 		nil,   /* postSetupFn */
 	)
+	if s.cfg.NotificationRegistry != nil {
+		ex.notificationListener = s.cfg.NotificationRegistry.NewListener()
+	}
 	return ConnectionHandler{ex}, nil
 }
 
@@ -1032,6 +1036,17 @@ func (h ConnectionHandler) GetQueryCancelKey() pgwirecancel.BackendKeyData {
 	return h.ex.queryCancelKey
 }
 
+// NotificationsReady returns a channel which is signaled when notifications are
+// queued for the channels the session is listening on. The connection should
+// push a DeliverNotifications command when it is signaled. Nil is returned if
+// the server does not support LISTEN.
+func (h ConnectionHandler) NotificationsReady() <-chan struct{} {
+	if h.ex.notificationListener == nil {
+		return nil
+	}
+	return h.ex.notificationListener.Ready()
+}
+
 // ServeConn serves a client connection by reading commands from the stmtBuf
 // embedded in the ConnHandler.
 //
@@ -1275,6 +1290,7 @@ func (s *Server) newConnExecutor(
 		ctx, descs.WithDescriptorSessionDataProvider(dsdp), descs.WithMonitor(ex.sessionMon),
 	)
 	ex.extraTxnState.jobs = newTxnJobsCollection()
+	ex.extraTxnState.deferredConstraints = newTxnDeferredConstraints()
 	ex.extraTxnState.txnRewindPos = -1
 	ex.extraTxnState.schemaChangerState = &SchemaChangerState{
 		mode:   ex.sessionData().NewSchemaChangerMode,
@@ -1369,6 +1385,10 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	// Free any memory used by the stats collector.
 	ex.statsCollector.Close(ctx, ex.planner.extendedEvalCtx.SessionID)
 
+	if ex.notificationListener != nil {
+		ex.notificationListener.Close()
+	}
+
 	var payloadErr error
 	if closeType == normalClose {
 		// We'll cleanup the SQL txn by creating a non-retriable (commit:true) event.
@@ -1546,6 +1566,9 @@ type connExecutor struct {
 
 		jobs *txnJobsCollection
 
+		// deferredConstraints tracks the checking of DEFERRABLE constraints.
+		deferredConstraints *txnDeferredConstraints
+
 		// firstStmtExecuted indicates that the first statement inside this
 		// transaction has been executed.
 		firstStmtExecuted bool
@@ -1650,6 +1673,13 @@ type connExecutor struct {
 			resumeProc *memo.Memo
 		}
 
+		// chainedTxnModes, if non-nil, contains the characteristics of an
+		// explicit transaction that was just finished by COMMIT AND CHAIN or
+		// ROLLBACK AND CHAIN. The statement is executed again in the NoTxn state,
+		// where it starts a new explicit transaction with these modes. It is reset
+		// by the connExecutor in execCmd once the statement buffer advances.
+		chainedTxnModes *tree.TransactionModes
+
 		// shouldExecuteOnTxnRestart indicates that ex.onTxnRestart will be
 		// called when txn is being retried. It is true when txn is started but
 		// can remain false when txn is executed within another higher-level
@@ -1844,6 +1874,11 @@ type connExecutor struct {
 	// going to find a suitable time to close the connection.
 	draining bool
 
+	// notificationListener tracks the channels the session is listening on
+	// and queues the notifications received on them. It is nil for internal
+	// executors.
+	notificationListener *listennotify.Listener
+
 	// executorType is set to whether this executor is an ordinary executor which
 	// responds to user queries or an internal one.
 	executorType executorType
@@ -2091,6 +2126,7 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 	ex.extraTxnState.upgradedToSerializable = false
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
 	ex.extraTxnState.createdSequences = nil
+	ex.extraTxnState.deferredConstraints.reset()
 
 	if ex.extraTxnState.skipResettingSchemaObjects {
 		if ex.extraTxnState.shouldResetSyntheticDescriptors {
@@ -2554,6 +2590,15 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, crtime.NowMono())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		// Replication streams do not run in a transaction, so no event is
+		// generated; errors are reported on the result.
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		ex.execStartReplication(ctx, tcmd, replRes)
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2567,6 +2612,10 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case DeliverNotifications:
+		// The notifications are buffered below, if no transaction is open, and
+		// closing the res will flush them to the client.
+		res = ex.clientComm.CreateDeliverNotificationsResult(pos)
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2656,6 +2705,9 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		if notificationRes, ok := res.(NotificationResult); ok {
+			ex.bufferNotifications(notificationRes)
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2715,6 +2767,11 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		ex.extraTxnState.storedProcTxnState.resumeProc = nil
 		ex.extraTxnState.storedProcTxnState.txnModes = nil
 	}
+	if advInfo.code != stayInPlace {
+		// The chained transaction has either been started, or the statement that
+		// requested it failed.
+		ex.extraTxnState.chainedTxnModes = nil
+	}
 
 	if err := ex.updateTxnRewindPosMaybe(ctx, cmd, pos, advInfo); err != nil {
 		return err
@@ -2734,6 +2791,18 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	return nil
 }
 
+// bufferNotifications buffers the notifications queued for the session on the
+// given result. Like in Postgres, notifications are only delivered between
+// transactions.
+func (ex *connExecutor) bufferNotifications(res NotificationResult) {
+	if ex.notificationListener == nil || !ex.idleConn() {
+		return
+	}
+	for _, n := range ex.notificationListener.Pending() {
+		res.BufferNotification(n)
+	}
+}
+
 func (ex *connExecutor) idleConn() bool {
 	switch ex.machine.CurState().(type) {
 	case stateNoTxn:
@@ -2832,10 +2901,14 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				canAdvance = true
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -3762,6 +3835,7 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		Descs:                ex.extraTxnState.descCollection,
 		TxnModesSetter:       ex,
 		jobs:                 ex.extraTxnState.jobs,
+		deferredConstraints:  ex.deferredConstraints(),
 		validateDbZoneConfig: &ex.extraTxnState.validateDbZoneConfig,
 		statsProvider:        ex.server.sqlStats,
 		localStatsProvider:   ex.server.localSqlStats,
@@ -3885,6 +3959,7 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.sqlCursors = ex.getCursorAccessor()
 	p.storedProcTxnState = ex.getStoredProcTxnStateAccessor()
 	p.createdSequences = ex.getCreatedSequencesAccessor()
+	p.notificationListener = ex.notificationListener
 
 	p.queryCacheSession.Init()
 	p.optPlanningCtx.init(p)
@@ -4466,6 +4541,16 @@ func (ex *connExecutor) getPrepStmtsAccessor() preparedStatementsAccessor {
 	}
 }
 
+// deferredConstraints returns the state of the deferred constraints of the
+// current transaction, or nil if the connExecutor does not own the transaction
+// (in which case all constraints are checked immediately).
+func (ex *connExecutor) deferredConstraints() *txnDeferredConstraints {
+	if ex.extraTxnState.underOuterTxn {
+		return nil
+	}
+	return ex.extraTxnState.deferredConstraints
+}
+
 func (ex *connExecutor) getCursorAccessor() sqlCursors {
 	return connExCursorAccessor{
 		ex: ex,
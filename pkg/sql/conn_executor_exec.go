@@ -830,13 +830,23 @@ func (ex *connExecutor) execStmtInOpenState(
 		return makeErrEvent(errTransactionInProgress)
 
 	case *tree.CommitTransaction:
+		if s.Chain && os.ImplicitTxn.Get() {
+			return makeErrEvent(errTxnChainOutsideTxnBlock(s))
+		}
 		// CommitTransaction is executed fully here; there's no plan for it.
-		ev, payload := ex.commitSQLTransaction(ctx, ast, ex.commitSQLTransactionInternal)
+		ev, payload := ex.maybeChainSQLTransaction(s.Chain, func() (fsm.Event, fsm.EventPayload) {
+			return ex.commitSQLTransaction(ctx, ast, ex.commitSQLTransactionInternal)
+		})
 		return ev, payload, nil
 
 	case *tree.RollbackTransaction:
+		if s.Chain && os.ImplicitTxn.Get() {
+			return makeErrEvent(errTxnChainOutsideTxnBlock(s))
+		}
 		// RollbackTransaction is executed fully here; there's no plan for it.
-		ev, payload := ex.rollbackSQLTransaction(ctx, s)
+		ev, payload := ex.maybeChainSQLTransaction(s.Chain, func() (fsm.Event, fsm.EventPayload) {
+			return ex.rollbackSQLTransaction(ctx, s)
+		})
 		return ev, payload, nil
 
 	case *tree.Savepoint:
@@ -1864,13 +1874,23 @@ func (ex *connExecutor) execStmtInOpenStateWithPausablePortal(
 		return makeErrEvent(errTransactionInProgress)
 
 	case *tree.CommitTransaction:
+		if s.Chain && os.ImplicitTxn.Get() {
+			return makeErrEvent(errTxnChainOutsideTxnBlock(s))
+		}
 		// CommitTransaction is executed fully here; there's no plan for it.
-		ev, payload := ex.commitSQLTransaction(ctx, vars.ast, ex.commitSQLTransactionInternal)
+		ev, payload := ex.maybeChainSQLTransaction(s.Chain, func() (fsm.Event, fsm.EventPayload) {
+			return ex.commitSQLTransaction(ctx, vars.ast, ex.commitSQLTransactionInternal)
+		})
 		return ev, payload, nil
 
 	case *tree.RollbackTransaction:
+		if s.Chain && os.ImplicitTxn.Get() {
+			return makeErrEvent(errTxnChainOutsideTxnBlock(s))
+		}
 		// RollbackTransaction is executed fully here; there's no plan for it.
-		ev, payload := ex.rollbackSQLTransaction(ctx, s)
+		ev, payload := ex.maybeChainSQLTransaction(s.Chain, func() (fsm.Event, fsm.EventPayload) {
+			return ex.rollbackSQLTransaction(ctx, s)
+		})
 		return ev, payload, nil
 
 	case *tree.Savepoint:
@@ -2561,6 +2581,12 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) (retEr
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	// Check the constraints whose checking was deferred until the end of the
+	// transaction.
+	if err := ex.planner.validateDeferredConstraints(ctx, nil /* include */); err != nil {
+		return err
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -2656,6 +2682,48 @@ func (ex *connExecutor) createJobs(ctx context.Context) error {
 	return nil
 }
 
+// maybeChainSQLTransaction finishes the current explicit transaction using
+// finishFn. If chain is true and the transaction finished successfully, the
+// characteristics of the transaction are recorded and an event is returned
+// which causes the statement to be executed again in the NoTxn state, where
+// the chained transaction is started.
+func (ex *connExecutor) maybeChainSQLTransaction(
+	chain bool, finishFn func() (fsm.Event, fsm.EventPayload),
+) (fsm.Event, fsm.EventPayload) {
+	if !chain {
+		return finishFn()
+	}
+	// The characteristics must be retrieved before the transaction finishes.
+	modes := ex.state.chainedTxnModes()
+	ev, payload := finishFn()
+	switch ev.(type) {
+	case eventTxnFinishCommitted:
+		ex.extraTxnState.chainedTxnModes = &modes
+		return eventTxnFinishCommittedChain{}, payload
+	case eventTxnFinishAborted:
+		ex.extraTxnState.chainedTxnModes = &modes
+		return eventTxnFinishAbortedChain{}, payload
+	}
+	return ev, payload
+}
+
+// isTxnChain returns true if the given statement is a COMMIT AND CHAIN or
+// ROLLBACK AND CHAIN statement.
+func isTxnChain(stmt tree.Statement) bool {
+	switch t := stmt.(type) {
+	case *tree.CommitTransaction:
+		return t.Chain
+	case *tree.RollbackTransaction:
+		return t.Chain
+	}
+	return false
+}
+
+func errTxnChainOutsideTxnBlock(stmt tree.Statement) error {
+	return pgerror.Newf(pgcode.NoActiveSQLTransaction,
+		"%s AND CHAIN can only be used in transaction blocks", stmt.StatementTag())
+}
+
 // rollbackSQLTransaction executes a ROLLBACK statement: the KV transaction is
 // rolled-back and an event is produced.
 func (ex *connExecutor) rollbackSQLTransaction(
@@ -3551,6 +3619,30 @@ func (ex *connExecutor) beginTransactionTimestampsAndReadMode(
 var eventStartImplicitTxn fsm.Event = eventTxnStart{ImplicitTxn: fsm.True}
 var eventStartExplicitTxn fsm.Event = eventTxnStart{ImplicitTxn: fsm.False}
 
+// beginExplicitTxn returns the event that starts an explicit transaction with
+// the modes of the given BEGIN statement.
+func (ex *connExecutor) beginExplicitTxn(
+	ctx context.Context, s *tree.BeginTransaction,
+) (fsm.Event, fsm.EventPayload) {
+	mode, sqlTs, historicalTs, err := ex.beginTransactionTimestampsAndReadMode(ctx, s)
+	if err != nil {
+		return ex.makeErrEvent(err, s)
+	}
+	ex.sessionDataStack.PushTopClone()
+	return eventStartExplicitTxn,
+		makeEventTxnStartPayload(
+			ex.txnPriorityWithSessionDefault(s.Modes.UserPriority),
+			mode,
+			sqlTs,
+			historicalTs,
+			ex.transitionCtx,
+			ex.QualityOfService(),
+			ex.txnIsolationLevelToKV(ctx, s.Modes.Isolation),
+			ex.omitInRangefeeds(),
+			ex.bufferedWritesEnabled(ctx),
+		)
+}
+
 // execStmtInNoTxnState "executes" a statement when no transaction is in scope.
 // For anything but BEGIN, this method doesn't actually execute the statement;
 // it just returns an Event that will generate a transaction. The statement will
@@ -3613,27 +3705,23 @@ func (ex *connExecutor) execStmtInNoTxnState(
 				ex.incrementExecutedStmtCounter(ast)
 			}
 		}()
-		mode, sqlTs, historicalTs, err := ex.beginTransactionTimestampsAndReadMode(ctx, s)
-		if err != nil {
-			return ex.makeErrEvent(err, s)
-		}
-		ex.sessionDataStack.PushTopClone()
-		return eventStartExplicitTxn,
-			makeEventTxnStartPayload(
-				ex.txnPriorityWithSessionDefault(s.Modes.UserPriority),
-				mode,
-				sqlTs,
-				historicalTs,
-				ex.transitionCtx,
-				ex.QualityOfService(),
-				ex.txnIsolationLevelToKV(ctx, s.Modes.Isolation),
-				ex.omitInRangefeeds(),
-				ex.bufferedWritesEnabled(ctx),
-			)
+		return ex.beginExplicitTxn(ctx, s)
 	case *tree.ShowCommitTimestamp:
 		return ex.execShowCommitTimestampInNoTxnState(ctx, s, res)
 	case *tree.CommitTransaction, *tree.RollbackTransaction, *tree.PrepareTransaction,
 		*tree.SetTransaction, *tree.Savepoint, *tree.ReleaseSavepoint:
+		if modes := ex.extraTxnState.chainedTxnModes; modes != nil {
+			// The previous explicit transaction was just finished by this COMMIT
+			// AND CHAIN or ROLLBACK AND CHAIN statement. Start the chained
+			// transaction. The statement was already logged when it finished the
+			// previous transaction.
+			shouldLogToExecAndAudit = false
+			ex.extraTxnState.chainedTxnModes = nil
+			return ex.beginExplicitTxn(ctx, &tree.BeginTransaction{Modes: *modes})
+		}
+		if isTxnChain(s) {
+			return ex.makeErrEvent(errTxnChainOutsideTxnBlock(s), ast)
+		}
 		if ex.sessionData().AutoCommitBeforeDDL {
 			// If autocommit_before_ddl is set, we allow these statements to be
 			// executed, and send a warning rather than an error.
@@ -3730,7 +3818,9 @@ func (ex *connExecutor) execStmtInAbortedState(
 			// transactions with "ROLLBACK" too.
 			res.ResetStmtType((*tree.RollbackTransaction)(nil))
 		}
-		return ex.rollbackSQLTransaction(ctx, s)
+		return ex.maybeChainSQLTransaction(isTxnChain(s), func() (fsm.Event, fsm.EventPayload) {
+			return ex.rollbackSQLTransaction(ctx, s)
+		})
 
 	case *tree.RollbackToSavepoint:
 		return ex.execRollbackToSavepointInAbortedState(ctx, s)
@@ -3782,14 +3872,16 @@ func (ex *connExecutor) execStmtInCommitWaitState(
 		// Reply to a rollback with the COMMIT tag, by analogy to what we do when we
 		// get a COMMIT in state Aborted.
 		res.ResetStmtType((*tree.CommitTransaction)(nil))
-		return ex.commitSQLTransaction(
-			ctx,
-			ast,
-			func(ctx context.Context) error {
-				// COMMIT while in the CommitWait state is a no-op.
-				return nil
-			},
-		)
+		return ex.maybeChainSQLTransaction(isTxnChain(s), func() (fsm.Event, fsm.EventPayload) {
+			return ex.commitSQLTransaction(
+				ctx,
+				ast,
+				func(ctx context.Context) error {
+					// COMMIT while in the CommitWait state is a no-op.
+					return nil
+				},
+			)
+		})
 	}
 	return eventNonRetriableErr{IsCommit: fsm.False},
 		eventNonRetriableErrPayload{
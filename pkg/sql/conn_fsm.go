@@ -160,6 +160,14 @@ type eventTxnFinishAborted struct{}
 type eventTxnFinishCommittedPLpgSQL struct{}
 type eventTxnFinishAbortedPLpgSQL struct{}
 
+// eventTxnFinishCommittedChain and eventTxnFinishAbortedChain are generated
+// when an explicit transaction is finished by COMMIT AND CHAIN or ROLLBACK AND
+// CHAIN. The current transaction is finished, but the statement buffer is not
+// advanced; the statement is executed again in the NoTxn state, where it starts
+// a new explicit transaction with the characteristics of the finished one.
+type eventTxnFinishCommittedChain struct{}
+type eventTxnFinishAbortedChain struct{}
+
 // eventSavepointRollback is generated when we want to move from Aborted to Open
 // through a ROLLBACK TO SAVEPOINT <not cockroach_restart>. Note that it is not
 // generated when such a savepoint is rolled back to from the Open state. In
@@ -253,6 +261,8 @@ func (eventTxnFinishAborted) Event()                    {}
 func (eventTxnFinishPrepared) Event()                   {}
 func (eventTxnFinishCommittedPLpgSQL) Event()           {}
 func (eventTxnFinishAbortedPLpgSQL) Event()             {}
+func (eventTxnFinishCommittedChain) Event()             {}
+func (eventTxnFinishAbortedChain) Event()               {}
 func (eventSavepointRollback) Event()                   {}
 func (eventNonRetriableErr) Event()                     {}
 func (eventRetriableErr) Event()                        {}
@@ -407,6 +417,25 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 				return args.Extended.(*txnState).finishTxn(txnPrepare, advanceOne)
 			},
 		},
+		// Handle COMMIT AND CHAIN and ROLLBACK AND CHAIN. These are only valid in
+		// the context of an explicit transaction.
+		//
+		// Use stayInPlace so that the statement is executed again in the NoTxn
+		// state, which will start the chained transaction.
+		eventTxnFinishCommittedChain{}: {
+			Description: "COMMIT AND CHAIN",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				return args.Extended.(*txnState).finishTxn(txnCommit, stayInPlace)
+			},
+		},
+		eventTxnFinishAbortedChain{}: {
+			Description: "ROLLBACK AND CHAIN",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				return args.Extended.(*txnState).finishTxn(txnRollback, stayInPlace)
+			},
+		},
 		// Handle the errors in explicit txns.
 		eventNonRetriableErr{IsCommit: fsm.False}: {
 			Next: stateAborted{WasUpgraded: fsm.Var("wasUpgraded")},
@@ -481,6 +510,15 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 				return ts.finishTxn(txnRollback, advanceOne)
 			},
 		},
+		eventTxnFinishAbortedChain{}: {
+			Description: "ROLLBACK AND CHAIN",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				ts := args.Extended.(*txnState)
+				ts.txnAbortCount.Inc(1)
+				return ts.finishTxn(txnRollback, stayInPlace)
+			},
+		},
 		// Any statement.
 		eventNonRetriableErr{IsCommit: fsm.False}: {
 			// This event doesn't change state, but it returns a skipBatch code.
@@ -552,6 +590,13 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 				return args.Extended.(*txnState).finishTxn(noEvent, advanceOne)
 			},
 		},
+		eventTxnFinishCommittedChain{}: {
+			Description: "COMMIT AND CHAIN",
+			Next:        stateNoTxn{},
+			Action: func(args fsm.Args) error {
+				return args.Extended.(*txnState).finishTxn(noEvent, stayInPlace)
+			},
+		},
 		eventNonRetriableErr{IsCommit: fsm.Any}: {
 			// This event doesn't change state, but it returns a skipBatch code.
 			//
@@ -3627,6 +3627,7 @@ func writeCreateTypeDescRow(
 	var typeVariety tree.CreateTypeVariety
 	var typeList []tree.CompositeTypeElem
 	var enumLabels tree.EnumValueList
+	var domain *tree.DomainTypeDef
 	enumLabelsDatum := tree.NewDArray(types.String)
 	resolver := p.semaCtx.TypeResolver
 	descriptors := p.descCollection
@@ -3664,6 +3665,22 @@ func writeCreateTypeDescRow(
 			typeList[i].Label = tree.Name(c.GetElementLabel(i))
 		}
 		typeVariety = tree.Composite
+	} else if d := typeDesc.AsDomainTypeDescriptor(); d != nil {
+		domain = &tree.DomainTypeDef{BaseType: d.BaseType(), NotNull: d.IsNotNull()}
+		if defaultExpr, ok := d.GetDefaultExpr(); ok {
+			if domain.DefaultExpr, err = parser.ParseExpr(defaultExpr); err != nil {
+				return false, err
+			}
+		}
+		domain.Checks = make([]tree.DomainCheckConstraint, d.NumChecks())
+		for i := range domain.Checks {
+			checkName, checkExpr := d.GetCheck(i)
+			domain.Checks[i].Name = tree.Name(checkName)
+			if domain.Checks[i].Expr, err = parser.ParseExpr(checkExpr); err != nil {
+				return false, err
+			}
+		}
+		typeVariety = tree.Domain
 	} else {
 		return false, errors.AssertionFailedf("unknown type descriptor kind %s", typeDesc.GetKind())
 	}
@@ -3677,6 +3694,7 @@ func writeCreateTypeDescRow(
 		TypeName:          name,
 		CompositeTypeList: typeList,
 		EnumLabels:        enumLabels,
+		Domain:            domain,
 	}
 
 	createStatement := tree.AsString(node)
@@ -3694,7 +3712,7 @@ func writeCreateTypeDescRow(
 		tree.NewDInt(tree.DInt(typeDesc.GetID())), // descriptor_id
 		tree.NewDString(typeDesc.GetName()),       // descriptor_name
 		tree.NewDString(createStatement),          // create_statement
-		enumLabelsDatum,                           // empty for composite types
+		enumLabelsDatum,                           // empty for composite types and domains
 	)
 }
 
@@ -3870,28 +3888,38 @@ func createRoutinePopulate(
 			if err != nil {
 				return err
 			}
-			for i := range treeNode.Options {
-				if body, ok := treeNode.Options[i].(tree.RoutineBodyStr); ok {
-					bodyStr := string(body)
-					bodyStr, err = formatFunctionQueryTypesForDisplay(ctx, p.EvalContext(), &p.semaCtx, p.SessionData(), bodyStr, fnDesc.GetLanguage())
-					if err != nil {
-						return err
-					}
-					bodyStr, err = formatQuerySequencesForDisplay(ctx, &p.semaCtx, bodyStr, true /* multiStmt */, fnDesc.GetLanguage())
-					if err != nil {
-						return err
-					}
-					bodyStr = strings.TrimSpace(bodyStr)
-					stmtStrs := strings.Split(bodyStr, "\n")
-					for i := range stmtStrs {
-						if stmtStrs[i] != "" {
-							stmtStrs[i] = "\t" + stmtStrs[i]
+			var createStmt string
+			if fnDesc.IsAggregate() {
+				aggNode, err := p.makeCreateAggregateExpr(ctx, fnDesc, treeNode)
+				if err != nil {
+					return err
+				}
+				createStmt = tree.AsString(aggNode)
+			} else {
+				for i := range treeNode.Options {
+					if body, ok := treeNode.Options[i].(tree.RoutineBodyStr); ok {
+						bodyStr := string(body)
+						bodyStr, err = formatFunctionQueryTypesForDisplay(ctx, p.EvalContext(), &p.semaCtx, p.SessionData(), bodyStr, fnDesc.GetLanguage())
+						if err != nil {
+							return err
 						}
+						bodyStr, err = formatQuerySequencesForDisplay(ctx, &p.semaCtx, bodyStr, true /* multiStmt */, fnDesc.GetLanguage())
+						if err != nil {
+							return err
+						}
+						bodyStr = strings.TrimSpace(bodyStr)
+						stmtStrs := strings.Split(bodyStr, "\n")
+						for i := range stmtStrs {
+							if stmtStrs[i] != "" {
+								stmtStrs[i] = "\t" + stmtStrs[i]
+							}
+						}
+						p := &treeNode.Options[i]
+						// Add two new lines just for better formatting.
+						*p = tree.RoutineBodyStr("\n" + strings.Join(stmtStrs, "\n") + "\n")
 					}
-					p := &treeNode.Options[i]
-					// Add two new lines just for better formatting.
-					*p = tree.RoutineBodyStr("\n" + strings.Join(stmtStrs, "\n") + "\n")
 				}
+				createStmt = tree.AsString(treeNode)
 			}
 
 			err = addRow(
@@ -3901,7 +3929,7 @@ func createRoutinePopulate(
 				tree.NewDString(fnIDToScName[fnDesc.GetID()]),       // schema_name
 				tree.NewDInt(tree.DInt(fnDesc.GetID())),             // function_id
 				tree.NewDString(fnDesc.GetName()),                   // function_name
-				tree.NewDString(tree.AsString(treeNode)),            // create_statement
+				tree.NewDString(createStmt),                         // create_statement
 			)
 			if err != nil {
 				return err
@@ -3911,6 +3939,40 @@ func createRoutinePopulate(
 	}
 }
 
+// makeCreateAggregateExpr returns a CreateAggregate statement for the given
+// user-defined aggregate, using the name and parameters of the given
+// CreateRoutine statement decompiled from its descriptor.
+func (p *planner) makeCreateAggregateExpr(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor, routine *tree.CreateRoutine,
+) (*tree.CreateAggregate, error) {
+	agg := fnDesc.FuncDesc().Aggregate
+	supportFuncName := func(id descpb.ID) (*tree.RoutineName, error) {
+		name, err := p.GetQualifiedFunctionNameByID(ctx, int64(id))
+		if err != nil {
+			return nil, err
+		}
+		name.ExplicitCatalog = false
+		return name, nil
+	}
+	stateFunc, err := supportFuncName(agg.TransitionFunctionID)
+	if err != nil {
+		return nil, err
+	}
+	n := &tree.CreateAggregate{
+		Name:      routine.Name,
+		Params:    routine.Params,
+		StateFunc: *stateFunc,
+		StateType: agg.StateType,
+		InitCond:  agg.InitCond,
+	}
+	if agg.FinalFunctionID != descpb.InvalidID {
+		if n.FinalFunc, err = supportFuncName(agg.FinalFunctionID); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
 var crdbInternalCreateFunctionStmtsTable = virtualSchemaTable{
 	comment: "CREATE statements for all user-defined functions",
 	schema: `
@@ -0,0 +1,467 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	zeroInputPlanNode
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// CreateAggregate creates a user-defined aggregate function that is computed
+// by invoking user-defined support functions.
+// Privileges: CREATE on the schema and EXECUTE on the support functions.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+	if !p.IsActive(ctx, clusterversion.V25_3_Start) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE AGGREGATE is not supported until the cluster version is finalized")
+	}
+	if n.Name.ExplicitSchema && strings.HasPrefix(n.Name.Schema(), catconstants.PgTempSchemaName) {
+		return nil, unimplemented.NewWithIssue(104687,
+			"cannot create user-defined functions under a temporary schema")
+	}
+	db, sc, _, err := p.ResolveTargetObject(ctx, n.Name.ToUnresolvedObjectName())
+	if err != nil {
+		return nil, err
+	}
+	if sc.SchemaKind() == catalog.SchemaTemporary {
+		return nil, unimplemented.NewWithIssue(104687,
+			"cannot create user-defined functions under a temporary schema")
+	}
+	for _, param := range n.Params {
+		switch param.Class {
+		case tree.RoutineParamDefault, tree.RoutineParamIn:
+		default:
+			return nil, unimplemented.NewWithIssue(74775,
+				"OUT, INOUT, and VARIADIC parameters are not supported in user-defined aggregates")
+		}
+		if param.DefaultVal != nil {
+			return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates cannot have default arguments")
+		}
+	}
+	return &createAggregateNode{n: n, dbDesc: db, scDesc: sc}, nil
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(
+		params.ctx, n.dbDesc, n.scDesc.GetName(),
+	)
+	if err != nil {
+		return err
+	}
+
+	var retErr error
+	params.p.runWithOptions(resolveFlags{contextDatabaseID: n.dbDesc.GetID()}, func() {
+		retErr = func() error {
+			aggDesc, existing, err := n.getMutableAggregateDesc(mutScDesc, params)
+			if err != nil {
+				return err
+			}
+			fnName := tree.MakeQualifiedRoutineName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.String())
+			event := eventpb.CreateFunction{
+				FunctionName: fnName.FQString(),
+				IsReplace:    existing != nil,
+			}
+			if existing == nil {
+				err = n.createNewAggregate(aggDesc, mutScDesc, params)
+			} else {
+				err = n.replaceAggregate(aggDesc, params)
+			}
+			if err != nil {
+				return err
+			}
+			return params.p.logEvent(params.ctx, aggDesc.GetID(), &event)
+		}()
+	})
+	return retErr
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+// aggregateDefinition is the resolved definition of a user-defined aggregate.
+type aggregateDefinition struct {
+	agg        descpb.FunctionDescriptor_Aggregate
+	returnType *types.T
+}
+
+// resolveAggregateDefinition resolves and validates the state type and the
+// support functions of the aggregate.
+func (n *createAggregateNode) resolveAggregateDefinition(
+	params runParams, argTypes []*types.T,
+) (aggregateDefinition, error) {
+	var def aggregateDefinition
+	if n.n.CombineFunc != nil {
+		// Aggregations that use user-defined aggregates are never distributed,
+		// so a combine function would never be called.
+		return def, unimplemented.NewWithIssue(74775,
+			"COMBINEFUNC is not supported in user-defined aggregates")
+	}
+	stateType, err := tree.ResolveType(params.ctx, n.n.StateType, params.p)
+	if err != nil {
+		return def, err
+	}
+	if stateType.IsPolymorphicType() {
+		return def, unimplemented.NewWithIssue(74775,
+			"polymorphic state types are not supported in user-defined aggregates")
+	}
+	def.agg.StateType = stateType
+	def.returnType = stateType
+
+	// The transition function takes the state value followed by the
+	// aggregate's arguments, and returns the new state value.
+	transitionArgs := make([]*types.T, 0, len(argTypes)+1)
+	transitionArgs = append(transitionArgs, stateType)
+	transitionArgs = append(transitionArgs, argTypes...)
+	transition, err := n.resolveSupportFunction(params, &n.n.StateFunc, transitionArgs)
+	if err != nil {
+		return def, err
+	}
+	if rt := transition.GetReturnType().Type; rt.Oid() != stateType.Oid() {
+		return def, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of transition function %s is not %s", n.n.StateFunc.Object(), stateType.SQLString())
+	}
+	def.agg.TransitionFunctionID = transition.GetID()
+
+	if n.n.FinalFunc != nil {
+		final, err := n.resolveSupportFunction(params, n.n.FinalFunc, []*types.T{stateType})
+		if err != nil {
+			return def, err
+		}
+		def.agg.FinalFunctionID = final.GetID()
+		def.returnType = final.GetReturnType().Type
+	}
+
+	if n.n.InitCond != nil {
+		// Validate the initial value by converting it to the state type.
+		if _, err := eval.PerformCast(
+			params.ctx, params.EvalContext(), tree.NewDString(*n.n.InitCond), stateType,
+		); err != nil {
+			return def, err
+		}
+		initCond := *n.n.InitCond
+		def.agg.InitCond = &initCond
+	} else if transition.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT &&
+		(len(argTypes) == 0 || argTypes[0].Oid() != stateType.Oid()) {
+		// A strict transition function with no initial value takes the first
+		// input value as the initial state, so it must have the state type.
+		return def, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and transition type is not compatible with input type")
+	}
+	return def, nil
+}
+
+// resolveSupportFunction resolves a support function of the aggregate with the
+// given argument types, and checks that the current user can execute it.
+func (n *createAggregateNode) resolveSupportFunction(
+	params runParams, name *tree.RoutineName, argTypes []*types.T,
+) (catalog.FunctionDescriptor, error) {
+	p := params.p
+	path := p.CurrentSearchPath()
+	unresolvedName := name.ToUnresolvedObjectName().ToUnresolvedName()
+	fnDef, err := p.ResolveFunction(params.ctx, tree.MakeUnresolvedFunctionName(unresolvedName), &path)
+	if err != nil {
+		return nil, err
+	}
+	routineObj := tree.RoutineObj{
+		FuncName: *name,
+		Params:   make(tree.RoutineParams, len(argTypes)),
+	}
+	for i := range argTypes {
+		routineObj.Params[i] = tree.RoutineParam{Type: argTypes[i], Class: tree.RoutineParamIn}
+	}
+	ol, err := fnDef.MatchOverload(
+		params.ctx, p, &routineObj, &path, tree.UDFRoutine|tree.BuiltinRoutine,
+		false /* inDropContext */, false, /* tryDefaultExprs */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ol.Type == tree.BuiltinRoutine {
+		return nil, unimplemented.NewWithIssuef(74775,
+			"built-in function %s cannot be used as an aggregate support function", fnDef.Name)
+	}
+	fnDesc, err := p.Descriptors().ByIDWithLeased(p.Txn()).Get().Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.IsAggregate() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%s is an aggregate function, not a support function", fnDesc.GetName())
+	}
+	if fnDesc.GetReturnType().ReturnSet {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"aggregate support function %s must not return a set", fnDesc.GetName())
+	}
+	if dbID := fnDesc.GetParentID(); dbID != n.dbDesc.GetID() && dbID != keys.SystemDatabaseID {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"dependent function %s cannot be from another database", fnDesc.GetName())
+	}
+	if err := p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+func (n *createAggregateNode) getMutableAggregateDesc(
+	scDesc catalog.SchemaDescriptor, params runParams,
+) (aggDesc *funcdesc.Mutable, existing *tree.QualifiedOverload, err error) {
+	pbParams := make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	for i, param := range n.n.Params {
+		pbParams[i], err = makeFunctionParam(params.ctx, params.p.SemaCtx(), param, params.p)
+		if err != nil {
+			return nil, nil, err
+		}
+		if pbParams[i].Type.IsPolymorphicType() {
+			return nil, nil, unimplemented.NewWithIssue(74775,
+				"polymorphic parameters are not supported in user-defined aggregates")
+		}
+	}
+
+	// Try to look up an existing function.
+	routineObj := tree.RoutineObj{
+		FuncName: n.n.Name,
+		Params:   n.n.Params,
+	}
+	existing, err = params.p.matchRoutine(
+		params.ctx, &routineObj, false, /* required */
+		tree.UDFRoutine|tree.ProcedureRoutine, false, /* inDropContext */
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil {
+		if !n.n.Replace {
+			return nil, nil, pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.Name.Object(),
+			)
+		}
+		fnID := funcdesc.UserDefinedFunctionOIDToID(existing.Oid)
+		aggDesc, err = params.p.checkPrivilegesForDropFunction(params.ctx, fnID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !aggDesc.IsAggregate() {
+			formatStr := "%q is a function"
+			if aggDesc.IsProcedure() {
+				formatStr = "%q is a procedure"
+			}
+			return nil, nil, errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				formatStr,
+				aggDesc.Name,
+			)
+		}
+		return aggDesc, existing, nil
+	}
+
+	funcDescID, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Routines,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	newDesc := funcdesc.NewMutableFunctionDescriptor(
+		funcDescID,
+		n.dbDesc.GetID(),
+		scDesc.GetID(),
+		string(n.n.Name.ObjectName),
+		pbParams,
+		nil,   /* returnType */
+		false, /* returnSet */
+		false, /* isProcedure */
+		privileges,
+	)
+	return &newDesc, nil, nil
+}
+
+func (n *createAggregateNode) createNewAggregate(
+	aggDesc *funcdesc.Mutable, scDesc *schemadesc.Mutable, params runParams,
+) error {
+	argTypes := make([]*types.T, len(aggDesc.Params))
+	for i := range aggDesc.Params {
+		argTypes[i] = aggDesc.Params[i].Type
+	}
+	def, err := n.resolveAggregateDefinition(params, argTypes)
+	if err != nil {
+		return err
+	}
+	aggDesc.ReturnType.Type = def.returnType
+	aggDesc.Aggregate = &def.agg
+	if err := n.addAggregateReferences(aggDesc, params); err != nil {
+		return err
+	}
+	if err := params.p.createDescriptor(
+		params.ctx,
+		aggDesc,
+		tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return err
+	}
+	scDesc.AddFunction(
+		aggDesc.GetName(),
+		descpb.SchemaDescriptor_FunctionSignature{
+			ID:          aggDesc.GetID(),
+			ArgTypes:    argTypes,
+			ReturnType:  def.returnType,
+			IsAggregate: true,
+		},
+	)
+	return params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Aggregate")
+}
+
+func (n *createAggregateNode) replaceAggregate(aggDesc *funcdesc.Mutable, params runParams) error {
+	argTypes := make([]*types.T, len(aggDesc.Params))
+	for i := range aggDesc.Params {
+		argTypes[i] = aggDesc.Params[i].Type
+	}
+	def, err := n.resolveAggregateDefinition(params, argTypes)
+	if err != nil {
+		return err
+	}
+	if def.returnType.Oid() != aggDesc.ReturnType.Type.Oid() {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"cannot change return type of existing function")
+	}
+
+	// Remove the existing references before adding the new ones.
+	for _, id := range aggDesc.DependsOnFunctions {
+		backRefMutable, err := params.p.Descriptors().MutableByID(params.p.txn).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := backRefMutable.RemoveFunctionReference(aggDesc.ID); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefMutable); err != nil {
+			return err
+		}
+	}
+	jobDesc := fmt.Sprintf("updating type back reference %d for function %d", aggDesc.DependsOnTypes, aggDesc.ID)
+	if err := params.p.removeTypeBackReferences(params.ctx, aggDesc.DependsOnTypes, aggDesc.ID, jobDesc); err != nil {
+		return err
+	}
+	aggDesc.Aggregate = &def.agg
+	if err := n.addAggregateReferences(aggDesc, params); err != nil {
+		return err
+	}
+	return params.p.writeFuncSchemaChange(params.ctx, aggDesc)
+}
+
+// addAggregateReferences adds references from the aggregate to its support
+// functions and to the user-defined types it uses, along with the
+// corresponding back references.
+func (n *createAggregateNode) addAggregateReferences(
+	aggDesc *funcdesc.Mutable, params runParams,
+) error {
+	var funcIDs catalog.DescriptorIDSet
+	for _, id := range []descpb.ID{
+		aggDesc.Aggregate.TransitionFunctionID,
+		aggDesc.Aggregate.FinalFunctionID,
+	} {
+		if id != descpb.InvalidID {
+			funcIDs.Add(id)
+		}
+	}
+	aggDesc.DependsOnFunctions = funcIDs.Ordered()
+	for _, id := range aggDesc.DependsOnFunctions {
+		backRefDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := backRefDesc.AddFunctionReference(aggDesc.ID); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefDesc); err != nil {
+			return err
+		}
+	}
+
+	var typeIDs catalog.DescriptorIDSet
+	addTypeDeps := func(typ *types.T) {
+		if typ.UserDefined() {
+			typedesc.GetTypeDescriptorClosure(typ).ForEach(typeIDs.Add)
+		}
+	}
+	for i := range aggDesc.Params {
+		addTypeDeps(aggDesc.Params[i].Type)
+	}
+	addTypeDeps(aggDesc.Aggregate.StateType)
+	addTypeDeps(aggDesc.ReturnType.Type)
+	for _, id := range typeIDs.Ordered() {
+		if isTable, err := params.p.descIsTable(params.ctx, id); err != nil {
+			return err
+		} else if isTable {
+			return unimplemented.NewWithIssue(74775,
+				"table record types are not supported in user-defined aggregates")
+		}
+		jobDesc := fmt.Sprintf("updating type back reference %d for function %d", id, aggDesc.ID)
+		if err := params.p.addTypeBackReference(params.ctx, id, aggDesc.ID, jobDesc); err != nil {
+			return err
+		}
+	}
+	aggDesc.DependsOnTypes = typeIDs.Ordered()
+	return nil
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// domainValueName is the name that refers to the value being checked in the
+// CHECK constraints of a domain.
+const domainValueName = tree.Name("value")
+
+func (p *planner) createDomainWithID(
+	params runParams,
+	id descpb.ID,
+	def *tree.DomainTypeDef,
+	dbDesc catalog.DatabaseDescriptor,
+	typeName *tree.TypeName,
+) error {
+	// Generate a key in the namespace table and a new id for this type.
+	schema, err := getCreateTypeParams(params.ctx, p, typeName, dbDesc)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := createDomainTypeDesc(params, id, def, dbDesc, schema, typeName)
+	if err != nil {
+		return err
+	}
+
+	return p.finishCreateType(params.ctx, params.EvalContext(), typeName, typeDesc, dbDesc, schema)
+}
+
+// createDomainTypeDesc creates a new domain type descriptor.
+func createDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	def *tree.DomainTypeDef,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	baseType, err := tree.ResolveType(params.ctx, def.BaseType, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDomainBaseType(params.ctx, params.p, baseType); err != nil {
+		return nil, err
+	}
+
+	domain := &descpb.TypeDescriptor_Domain{
+		BaseType: baseType,
+		NotNull:  def.NotNull,
+	}
+	if def.DefaultExpr != nil {
+		defaultExpr, err := params.p.validateDomainDefaultExpr(params.ctx, def.DefaultExpr, baseType)
+		if err != nil {
+			return nil, err
+		}
+		domain.DefaultExpr = &defaultExpr
+	}
+	for i := range def.Checks {
+		check, err := params.p.makeDomainCheckConstraint(
+			params.ctx, typeName.Type(), domain, &def.Checks[i],
+		)
+		if err != nil {
+			return nil, err
+		}
+		domain.Checks = append(domain.Checks, check)
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
+// validateDomainBaseType returns an error if the given type cannot be used as
+// the base type of a domain.
+func validateDomainBaseType(ctx context.Context, p *planner, typ *types.T) error {
+	if typ.Identical(types.Trigger) {
+		return tree.CannotAcceptTriggerErr
+	}
+	if typ.Oid() == oidext.T_jsonpath || typ.Oid() == oidext.T__jsonpath {
+		return unimplemented.NewWithIssueDetailf(27796, "jsonpath",
+			"jsonpath cannot be used as the base type of a domain")
+	}
+	if err := tree.CheckUnsupportedType(ctx, &p.semaCtx, typ); err != nil {
+		return err
+	}
+	if typ.UserDefined() {
+		return unimplemented.NewWithIssueDetailf(27796, "user-defined",
+			"domains over user-defined types are not yet supported")
+	}
+	switch typ.Family() {
+	case types.ArrayFamily:
+		return unimplemented.NewWithIssueDetailf(27796, "array",
+			"domains over array types are not yet supported")
+	case types.TupleFamily, types.AnyFamily, types.VoidFamily:
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"%s is not a valid base type for a domain", typ.SQLString())
+	}
+	return nil
+}
+
+// validateDomainDefaultExpr type-checks the default expression of a domain
+// against the base type of the domain, and returns the serialized expression.
+func (p *planner) validateDomainDefaultExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T,
+) (string, error) {
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, &p.semaCtx,
+		volatility.Volatile, true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := funcdesc.MaybeFailOnUDFUsage(
+		typedExpr, tree.DomainDefaultExpr, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+	); err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// makeDomainCheckConstraint validates the given CHECK constraint for the
+// given domain, and returns its descriptor representation. If the constraint
+// is not named, a name that does not conflict with the existing constraints of
+// the domain is generated, following Postgres.
+func (p *planner) makeDomainCheckConstraint(
+	ctx context.Context,
+	domainName string,
+	domain *descpb.TypeDescriptor_Domain,
+	check *tree.DomainCheckConstraint,
+) (descpb.TypeDescriptor_Domain_CheckConstraint, error) {
+	inUse := func(name string) bool {
+		for i := range domain.Checks {
+			if domain.Checks[i].Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	name := string(check.Name)
+	if name == "" {
+		name = domainName + "_check"
+		for i := 1; inUse(name); i++ {
+			name = fmt.Sprintf("%s_check%d", domainName, i)
+		}
+	} else if inUse(name) {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, domainName)
+	}
+
+	// Subqueries are rejected explicitly, since the expression is not built by
+	// the optimizer here.
+	if _, err := tree.SimpleVisit(check.Expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if _, ok := expr.(*tree.Subquery); ok {
+			return false, nil, pgerror.New(pgcode.FeatureNotSupported,
+				"cannot use subquery in check constraint")
+		}
+		return true, expr, nil
+	}); err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+
+	// Replace the VALUE keyword with a variable of the base type so that the
+	// expression can be type-checked.
+	replacedExpr, _, err := schemaexpr.ReplaceColumnVars(
+		check.Expr,
+		func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+			if columnName != domainValueName {
+				return false, false, 0, nil
+			}
+			return true, true, 0, domain.BaseType
+		},
+	)
+	if err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, replacedExpr, types.Bool, tree.DomainCheckExpr, &p.semaCtx,
+		volatility.Volatile, false, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+	if err := funcdesc.MaybeFailOnUDFUsage(
+		typedExpr, tree.DomainCheckExpr, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+	); err != nil {
+		return descpb.TypeDescriptor_Domain_CheckConstraint{}, err
+	}
+	return descpb.TypeDescriptor_Domain_CheckConstraint{
+		Name: name,
+		Expr: tree.Serialize(typedExpr),
+	}, nil
+}
+
+// validateDomainConstraintOnColumns verifies that the values stored in all
+// table columns of the given domain type satisfy the given condition, which
+// must be either a NOT NULL constraint (exprStr is empty) or a CHECK
+// constraint expression that refers to the value with the VALUE keyword.
+func (p *planner) validateDomainConstraintOnColumns(
+	ctx context.Context, typeDesc *typedesc.Mutable, constraintName string, exprStr string,
+) error {
+	var expr tree.Expr
+	if exprStr != "" {
+		var err error
+		if expr, err = parser.ParseExpr(exprStr); err != nil {
+			return err
+		}
+	}
+	domainOID := catid.TypeIDToOID(typeDesc.GetID())
+	for _, id := range typeDesc.ReferencingDescriptorIDs {
+		desc, err := p.Descriptors().ByIDWithoutLeased(p.txn).WithoutNonPublic().Get().Desc(ctx, id)
+		if err != nil {
+			return err
+		}
+		// The domain may also be referenced by views and functions, which do
+		// not store values.
+		tableDesc, ok := desc.(catalog.TableDescriptor)
+		if !ok || !tableDesc.IsTable() {
+			continue
+		}
+		for _, col := range tableDesc.PublicColumns() {
+			if col.GetType().Oid() != domainOID || col.IsVirtual() {
+				continue
+			}
+			colName := tree.NewUnresolvedName(col.GetName())
+			var cond tree.Expr
+			if expr == nil {
+				cond = &tree.IsNullExpr{Expr: colName}
+			} else {
+				// Replace the VALUE keyword with the column.
+				cond, err = tree.SimpleVisit(expr, func(e tree.Expr) (bool, tree.Expr, error) {
+					if n, ok := e.(*tree.UnresolvedName); ok && n.NumParts == 1 &&
+						tree.Name(n.Parts[0]) == domainValueName {
+						return false, colName, nil
+					}
+					return true, e, nil
+				})
+				if err != nil {
+					return err
+				}
+				cond = &tree.AndExpr{
+					Left:  &tree.NotExpr{Expr: &tree.ParenExpr{Expr: cond}},
+					Right: &tree.IsNotNullExpr{Expr: colName},
+				}
+			}
+			queryStr := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE %s LIMIT 1`,
+				tableDesc.GetID(), tree.AsStringWithFlags(cond, tree.FmtSerializable))
+			log.Infof(ctx, "validating domain constraint with query %q", queryStr)
+			row, err := p.InternalSQLTxn().QueryRowEx(
+				ctx,
+				"validate domain constraint",
+				p.txn,
+				sessiondata.NodeUserSessionDataOverride,
+				queryStr,
+			)
+			if err != nil {
+				return err
+			}
+			if row == nil {
+				continue
+			}
+			if expr == nil {
+				return pgerror.Newf(pgcode.NotNullViolation,
+					"column %q of table %q contains null values", col.GetName(), tableDesc.GetName())
+			}
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint %q",
+				col.GetName(), tableDesc.GetName(), constraintName)
+		}
+	}
+	return nil
+}
@@ -8,7 +8,9 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -19,10 +21,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
@@ -179,6 +183,7 @@ func (n *createFunctionNode) createNewFunction(
 			OutParamOrdinals: outParamOrdinals,
 			OutParamTypes:    outParamTypes,
 			DefaultExprs:     defaultExprs,
+			IsVariadic:       udfDesc.IsVariadic(),
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -195,6 +200,13 @@ func (n *createFunctionNode) replaceFunction(
 	existing *tree.QualifiedOverload,
 ) error {
 
+	if udfDesc.IsAggregate() {
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			"%q is an aggregate function",
+			udfDesc.Name,
+		)
+	}
 	if n.cf.IsProcedure != udfDesc.IsProcedure() {
 		formatStr := "%q is a function"
 		if udfDesc.IsProcedure() {
@@ -310,7 +322,8 @@ func (n *createFunctionNode) replaceFunction(
 	}
 
 	signatureChanged := len(existing.OutParamOrdinals) != len(outParamOrdinals) ||
-		len(existing.DefaultExprs) != len(defaultExprs)
+		len(existing.DefaultExprs) != len(defaultExprs) ||
+		existing.Variadic != udfDesc.IsVariadic()
 	for i := 0; !signatureChanged && i < len(outParamOrdinals); i++ {
 		signatureChanged = existing.OutParamOrdinals[i] != outParamOrdinals[i] ||
 			!existing.OutParamTypes.GetAt(i).Equivalent(outParamTypes[i])
@@ -338,6 +351,7 @@ func (n *createFunctionNode) replaceFunction(
 				OutParamOrdinals: outParamOrdinals,
 				OutParamTypes:    outParamTypes,
 				DefaultExprs:     defaultExprs,
+				IsVariadic:       udfDesc.IsVariadic(),
 			},
 		); err != nil {
 			return err
@@ -544,6 +558,13 @@ func setFuncOptions(
 	var body string
 	var lang catpb.Function_Language
 	for _, option := range options {
+		switch option.(type) {
+		case tree.RoutineCost, tree.RoutineRows, tree.RoutineParallel, tree.RoutineSupport, *tree.RoutineSet:
+			if !params.p.IsActive(params.ctx, clusterversion.V25_3_Start) {
+				return unimplemented.Newf("routine options",
+					"%s is only supported in v25.3 and later", tree.AsString(option))
+			}
+		}
 		switch t := option.(type) {
 		case tree.RoutineVolatility:
 			vol, err := funcinfo.VolatilityToProto(t)
@@ -575,6 +596,39 @@ func setFuncOptions(
 				return err
 			}
 			udfDesc.SetSecurity(sec)
+		case tree.RoutineCost:
+			udfDesc.SetCost(float64(t))
+		case tree.RoutineRows:
+			if !udfDesc.ReturnType.ReturnSet {
+				return pgerror.New(pgcode.InvalidParameterValue,
+					"ROWS is not applicable when function does not return a set")
+			}
+			udfDesc.SetRows(float64(t))
+		case tree.RoutineParallel:
+			parallel, err := funcinfo.ParallelToProto(t)
+			if err != nil {
+				return err
+			}
+			udfDesc.SetParallel(parallel)
+		case tree.RoutineSupport:
+			// Support functions must accept and return the internal type, which
+			// cannot be used by user-defined functions, so a support function can
+			// never be found.
+			hasAdmin, err := params.p.HasAdminRole(params.ctx)
+			if err != nil {
+				return err
+			}
+			if !hasAdmin {
+				return pgerror.New(pgcode.InsufficientPrivilege,
+					"must be superuser to specify a support function")
+			}
+			name := tree.Name(t)
+			return pgerror.Newf(pgcode.UndefinedFunction,
+				"function %s(internal) does not exist", tree.AsString(&name))
+		case *tree.RoutineSet:
+			if err := setFuncConfig(params, udfDesc, t); err != nil {
+				return err
+			}
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function option %q", t)
 		}
@@ -604,11 +658,92 @@ func setFuncOptions(
 	return nil
 }
 
+// setFuncConfig applies a SET or RESET clause of a routine definition to the
+// session variable overrides of the function. The value of the session
+// variable is computed in the same way as for a SET statement, and is
+// validated by applying it to a copy of the current session data.
+func setFuncConfig(params runParams, udfDesc *funcdesc.Mutable, set *tree.RoutineSet) error {
+	if set.ResetAll {
+		udfDesc.ResetAllConfig()
+		return nil
+	}
+	name := strings.ToLower(set.Name)
+	_, v, err := getSessionVar(name, false /* missingOk */)
+	if err != nil {
+		return err
+	}
+	if v.Set == nil {
+		if v.RuntimeSet == nil && v.SetWithPlanner == nil {
+			return newCannotChangeParameterError(name)
+		}
+		// Variables that need access to the planner or the connection in
+		// order to be set cannot be overridden for the duration of a routine.
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"parameter %q cannot be set in a routine definition", name)
+	}
+	if set.Reset {
+		udfDesc.ResetConfig(name)
+		return nil
+	}
+
+	var strVal string
+	if set.FromCurrent {
+		if strVal, err = v.Get(params.extendedEvalCtx, params.p.Txn()); err != nil {
+			return err
+		}
+	} else {
+		if len(set.Values) == 1 {
+			if _, ok := set.Values[0].(tree.DefaultVal); ok {
+				// "SET var = DEFAULT" removes the override.
+				udfDesc.ResetConfig(name)
+				return nil
+			}
+		}
+		typedValues := make([]tree.TypedExpr, len(set.Values))
+		for i, expr := range set.Values {
+			expr = paramparse.UnresolvedNameToStrVal(expr)
+			var dummyHelper tree.IndexedVarHelper
+			typedValue, err := params.p.analyzeExpr(
+				params.ctx, expr, dummyHelper, types.String, false, "SET "+name)
+			if err != nil {
+				return wrapSetVarError(err, name, expr.String())
+			}
+			d, err := eval.Expr(params.ctx, params.EvalContext(), typedValue)
+			if err != nil {
+				return err
+			}
+			typedValues[i] = d
+		}
+		if v.GetStringVal != nil {
+			strVal, err = v.GetStringVal(params.ctx, params.extendedEvalCtx, typedValues, params.p.Txn())
+		} else {
+			strVal, err = getStringVal(params.ctx, params.EvalContext(), name, typedValues)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Make sure that the value can be applied when the routine is executed.
+	sd := params.p.SessionData().Clone()
+	if err := v.Set(
+		params.ctx, params.p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, sd), strVal,
+	); err != nil {
+		return err
+	}
+	udfDesc.SetConfig(name, strVal)
+	return nil
+}
+
 // resetFuncOption sets all function options to default values.
 func resetFuncOption(udfDesc *funcdesc.Mutable) {
 	udfDesc.SetVolatility(catpb.Function_VOLATILE)
 	udfDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
 	udfDesc.SetLeakProof(false)
+	udfDesc.SetCost(0)
+	udfDesc.SetRows(0)
+	udfDesc.SetParallel(catpb.Function_PARALLEL_UNSAFE)
+	udfDesc.ResetAllConfig()
 }
 
 func makeFunctionParam(
@@ -0,0 +1,189 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
+)
+
+type createServerNode struct {
+	zeroInputPlanNode
+	n      *tree.CreateServer
+	dbDesc *dbdesc.Mutable
+}
+
+// CreateServer defines a foreign server in the current database, which
+// foreign tables use to read data from outside of the cluster.
+// Privileges: admin.
+func (p *planner) CreateServer(ctx context.Context, n *tree.CreateServer) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE SERVER",
+	); err != nil {
+		return nil, err
+	}
+	if !p.IsActive(ctx, clusterversion.V25_3_Start) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE SERVER is not supported until the cluster version is finalized")
+	}
+	if err := p.checkForeignDataPrivilege(ctx, "create foreign servers"); err != nil {
+		return nil, err
+	}
+	db, err := p.getForeignServerDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &createServerNode{n: n, dbDesc: db}, nil
+}
+
+func (n *createServerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("server"))
+
+	name := string(n.n.Name)
+	if n.dbDesc.GetForeignServer(name) != nil {
+		if n.n.IfNotExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.DuplicateObject, "server %q already exists", name)
+	}
+	w, err := getForeignDataWrapper(string(n.n.Wrapper))
+	if err != nil {
+		return err
+	}
+	options, err := makeForeignOptions(n.n.Options)
+	if err != nil {
+		return err
+	}
+	if err := w.ValidateServerOptions(foreignOptionsMap(options)); err != nil {
+		return err
+	}
+	n.dbDesc.AddForeignServer(name, descpb.DatabaseDescriptor_ForeignServer{
+		Wrapper: string(n.n.Wrapper),
+		Options: options,
+	})
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*createServerNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createServerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createServerNode) Close(ctx context.Context)           {}
+
+type dropServerNode struct {
+	zeroInputPlanNode
+	n      *tree.DropServer
+	dbDesc *dbdesc.Mutable
+}
+
+// DropServer drops foreign servers of the current database.
+// Privileges: admin.
+func (p *planner) DropServer(ctx context.Context, n *tree.DropServer) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP SERVER",
+	); err != nil {
+		return nil, err
+	}
+	if err := p.checkForeignDataPrivilege(ctx, "drop foreign servers"); err != nil {
+		return nil, err
+	}
+	db, err := p.getForeignServerDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dropServerNode{n: n, dbDesc: db}, nil
+}
+
+func (n *dropServerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("server"))
+
+	ctx, p := params.ctx, params.p
+	var toDrop []string
+	for _, name := range n.n.Names {
+		if n.dbDesc.GetForeignServer(string(name)) == nil {
+			if n.n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", name)
+		}
+		toDrop = append(toDrop, string(name))
+	}
+	if len(toDrop) == 0 {
+		return nil
+	}
+
+	// Find the foreign tables which read from the dropped servers.
+	tables, err := p.Descriptors().GetAllTablesInDatabase(ctx, p.txn, n.dbDesc)
+	if err != nil {
+		return err
+	}
+	dependents := make(map[string][]descpb.ID)
+	if err := tables.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok || !tbl.IsForeignTable() || tbl.Dropped() {
+			return nil
+		}
+		server := tbl.GetForeignTable().Server
+		dependents[server] = append(dependents[server], tbl.GetID())
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	jobDesc := tree.AsStringWithFQNames(n.n, params.Ann())
+	for _, name := range toDrop {
+		for _, id := range dependents[name] {
+			tableDesc, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+			if err != nil {
+				return err
+			}
+			if n.n.DropBehavior != tree.DropCascade {
+				return errors.WithHintf(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop server %q because foreign table %q depends on it",
+						name, tableDesc.GetName()),
+					"use DROP SERVER %s CASCADE to drop the dependent foreign tables too",
+					tree.NameString(name),
+				)
+			}
+			if _, err := p.dropTableImpl(
+				ctx, tableDesc, false /* droppingParent */, jobDesc, tree.DropCascade,
+			); err != nil {
+				return err
+			}
+		}
+		n.dbDesc.RemoveForeignServer(name)
+	}
+	return p.writeNonDropDatabaseChange(ctx, n.dbDesc, jobDesc)
+}
+
+func (*dropServerNode) Next(params runParams) (bool, error) { return false, nil }
+func (*dropServerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*dropServerNode) Close(ctx context.Context)           {}
+
+// getForeignServerDatabase returns the current database, which holds the
+// foreign servers that statements refer to.
+func (p *planner) getForeignServerDatabase(ctx context.Context) (*dbdesc.Mutable, error) {
+	if p.CurrentDatabase() == "" {
+		return nil, pgerror.New(pgcode.UndefinedDatabase,
+			"foreign servers require a current database; use SET database = <dbname>")
+	}
+	return p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+}
@@ -706,6 +706,9 @@ func addUniqueWithoutIndexColumnTableDef(
 			"unique constraints without an index are not yet supported",
 		)
 	}
+	if err := checkDeferrableConstraintsActive(ctx, evalCtx.Settings, d.Unique.Deferrability); err != nil {
+		return err
+	}
 	// Add a unique constraint.
 	if err := ResolveUniqueWithoutIndexConstraint(
 		ctx,
@@ -713,6 +716,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		d.Unique.Deferrability,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -745,6 +749,9 @@ func addUniqueWithoutIndexTableDef(
 			"unique constraints without an index cannot store columns",
 		)
 	}
+	if err := checkDeferrableConstraintsActive(ctx, evalCtx.Settings, d.Deferrability); err != nil {
+		return err
+	}
 	if d.PartitionByIndex.ContainsPartitions() {
 		return pgerror.New(pgcode.FeatureNotSupported,
 			"partitioned unique constraints without an index are not supported",
@@ -776,7 +783,7 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
@@ -796,6 +803,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -844,12 +852,14 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:              constraintName,
+		TableID:           tbl.ID,
+		ColumnIDs:         columnIDs,
+		Predicate:         predicate,
+		Validity:          validity,
+		ConstraintID:      tbl.NextConstraintID,
+		Deferrable:        deferrability.IsDeferrable(),
+		InitiallyDeferred: deferrability.IsInitiallyDeferred(),
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -861,6 +871,118 @@ func ResolveUniqueWithoutIndexConstraint(
 	return nil
 }
 
+// addExclusionConstraintTableDef adds an EXCLUDE constraint to the table
+// descriptor. Exclusion constraints are stored as UNIQUE WITHOUT INDEX
+// constraints with a comparison operator for each column, and are enforced by
+// the optimizer with checks that are similar to uniqueness checks.
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExclusionConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V25_3_Start) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"exclusion constraints are not supported until the cluster is upgraded to 25.3",
+		)
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		var err error
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx, evalCtx.Settings.Version.ActiveVersionOrEmpty(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	var colSet catalog.TableColSet
+	colNames := make([]string, len(d.Elems))
+	columnIDs := make(descpb.ColumnIDs, len(d.Elems))
+	operators := make([]string, len(d.Elems))
+	for i, elem := range d.Elems {
+		col, err := desc.FindActiveOrNewColumnByName(elem.Column)
+		if err != nil {
+			return err
+		}
+		if colSet.Contains(col.GetID()) {
+			return pgerror.Newf(pgcode.DuplicateColumn,
+				"column %q appears twice in exclusion constraint", col.GetName())
+		}
+		colSet.Add(col.GetID())
+		op, err := tree.ExclusionOperator(elem.Operator.Symbol.String())
+		if err != nil {
+			return err
+		}
+		// NE is evaluated as the negation of EQ, so it has no overloads of its
+		// own.
+		sym := op.Symbol
+		if sym == treecmp.NE {
+			sym = treecmp.EQ
+		}
+		if _, ok := tree.CmpOps[sym].LookupImpl(col.GetType(), col.GetType()); !ok {
+			return pgerror.Newf(pgcode.UndefinedFunction,
+				"operator does not exist: %s %s %s", col.GetType().SQLString(), op, col.GetType().SQLString())
+		}
+		colNames[i] = col.GetName()
+		columnIDs[i] = col.GetID()
+		operators[i] = op.String()
+	}
+
+	constraintName := string(d.Name)
+	if constraintName == "" {
+		constraintName = tabledesc.GenerateUniqueName(
+			fmt.Sprintf("%s_%s_excl", desc.GetName(), strings.Join(colNames, "_")),
+			func(p string) bool {
+				return catalog.FindConstraintByName(desc, p) != nil
+			},
+		)
+	} else if c := catalog.FindConstraintByName(desc, constraintName); c != nil {
+		return pgerror.Newf(pgcode.DuplicateObject, "duplicate constraint name: %q", constraintName)
+	}
+
+	validity := descpb.ConstraintValidity_Validated
+	if ts != NewTable {
+		if validationBehavior == tree.ValidationSkip {
+			validity = descpb.ConstraintValidity_Unvalidated
+		} else {
+			validity = descpb.ConstraintValidity_Validating
+		}
+	}
+
+	// Postgres uses btree when the access method is omitted.
+	method := d.Using
+	if method == "" {
+		method = "btree"
+	}
+	uc := descpb.UniqueWithoutIndexConstraint{
+		Name:               constraintName,
+		TableID:            desc.ID,
+		ColumnIDs:          columnIDs,
+		Predicate:          predicate,
+		Validity:           validity,
+		ConstraintID:       desc.NextConstraintID,
+		Deferrable:         d.Deferrability.IsDeferrable(),
+		InitiallyDeferred:  d.Deferrability.IsInitiallyDeferred(),
+		ExclusionOperators: operators,
+		ExclusionMethod:    method,
+	}
+	desc.NextConstraintID++
+	if ts == NewTable {
+		desc.UniqueWithoutIndexConstraints = append(desc.UniqueWithoutIndexConstraints, uc)
+	} else {
+		desc.AddUniqueWithoutIndexMutation(&uc, descpb.DescriptorMutation_ADD)
+	}
+	return nil
+}
+
 // ResolveFK looks up the tables and columns mentioned in a `REFERENCES`
 // constraint and adds metadata representing that constraint to the descriptor.
 // It may, in doing so, add to or alter descriptors in the passed in `backrefs`
@@ -899,6 +1021,9 @@ func ResolveFK(
 	validationBehavior tree.ValidationBehavior,
 	evalCtx *eval.Context,
 ) error {
+	if err := checkDeferrableConstraintsActive(ctx, evalCtx.Settings, d.Deferrability); err != nil {
+		return err
+	}
 	var originColSet catalog.TableColSet
 	originCols := make([]catalog.Column, len(d.FromCols))
 	for i, fromCol := range d.FromCols {
@@ -932,6 +1057,10 @@ func ResolveFK(
 			)
 		}
 	}
+	if target.IsForeignTable() {
+		return pgerror.Newf(pgcode.WrongObjectType,
+			"referenced relation %q is a foreign table", target.GetName())
+	}
 	if tbl.Temporary != target.Temporary {
 		persistenceType := "permanent"
 		if tbl.Temporary {
@@ -1123,6 +1252,8 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrable:          d.Deferrability.IsDeferrable(),
+		InitiallyDeferred:   d.Deferrability.IsInitiallyDeferred(),
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -2168,7 +2299,8 @@ func NewTableDesc(
 			); err != nil {
 				return nil, err
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExclusionConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2342,6 +2474,13 @@ func NewTableDesc(
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
+		case *tree.ExclusionConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, evalCtx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.CheckConstraintTableDef:
 			ck, err := ckBuilder.Build(d, version)
 			if err != nil {
@@ -2493,6 +2632,15 @@ func newTableDesc(
 		n.Defs = newDefs
 	}
 
+	parents, err := resolveInheritedTables(n, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateForeignTableDefs(n, params); err != nil {
+		return nil, err
+	}
+
 	// Process any SERIAL columns to remove the SERIAL type, as required by
 	// NewTableDesc.
 	colNameToOwnedSeq, err := createSequencesForSerialColumns(
@@ -2550,6 +2698,22 @@ func newTableDesc(
 		}
 	}
 
+	if n.Foreign != nil {
+		if ret.ForeignTable, err = makeForeignTable(n.Foreign, db, ret); err != nil {
+			return nil, err
+		}
+	}
+
+	// Link the new table to the tables it inherits from.
+	for _, parent := range parents {
+		ret.Inherits = append(ret.Inherits, parent.GetID())
+		if affectedParent, ok := affected[parent.GetID()]; ok {
+			parent = affectedParent
+		}
+		parent.InheritedBy = append(parent.InheritedBy, ret.GetID())
+		affected[parent.GetID()] = parent
+	}
+
 	// Row level TTL tables require a scheduled job to be created as well.
 	if ret.HasRowLevelTTL() {
 		ttl := ret.GetRowLevelTTL()
@@ -2577,7 +2741,7 @@ func newTableDesc(
 
 	// For tables set schema_locked by default if it hasn't been set, and we
 	// aren't running under an internal executor.
-	if !ret.IsView() && !ret.IsSequence() && !ret.IsTemporary() &&
+	if !ret.IsView() && !ret.IsSequence() && !ret.IsTemporary() && !ret.IsForeignTable() &&
 		n.StorageParams.GetVal("schema_locked") == nil &&
 		!params.p.SessionData().Internal &&
 		params.p.SessionData().CreateTableWithSchemaLocked &&
@@ -2889,6 +3053,232 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 	return newDefs, nil
 }
 
+// validateForeignTableDefs checks that the definition of a foreign table
+// only uses what foreign tables support: plain columns which can be declared
+// NOT NULL. Foreign tables store no data, so they have no indexes,
+// constraints or column defaults.
+func validateForeignTableDefs(n *tree.CreateTable, params runParams) error {
+	if n.Foreign == nil {
+		return nil
+	}
+	if !params.p.IsActive(params.ctx, clusterversion.V25_3_Start) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"foreign tables are not supported until the cluster version is finalized")
+	}
+	if err := params.p.checkForeignDataPrivilege(params.ctx, "create foreign tables"); err != nil {
+		return err
+	}
+	for _, def := range n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"foreign tables only support column definitions")
+		}
+		var unsupported string
+		switch {
+		case d.IsSerial:
+			unsupported = "serial columns"
+		case d.GeneratedIdentity.IsGeneratedAsIdentity:
+			unsupported = "identity columns"
+		case d.Hidden:
+			unsupported = "hidden columns"
+		case d.PrimaryKey.IsPrimaryKey:
+			unsupported = "primary keys"
+		case d.Unique.IsUnique:
+			unsupported = "unique constraints"
+		case d.DefaultExpr.Expr != nil:
+			unsupported = "column defaults"
+		case d.OnUpdateExpr.Expr != nil:
+			unsupported = "ON UPDATE expressions"
+		case len(d.CheckExprs) > 0:
+			unsupported = "check constraints"
+		case d.References.Table != nil:
+			unsupported = "foreign keys"
+		case d.Computed.Computed:
+			unsupported = "computed columns"
+		case d.Family.Name != "" || d.Family.Create:
+			unsupported = "column families"
+		default:
+			continue
+		}
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s are not supported on foreign tables", unsupported)
+	}
+	return nil
+}
+
+// makeForeignTable returns the foreign table definition of a new foreign
+// table, validating its options with the foreign-data wrapper of its server.
+func makeForeignTable(
+	def *tree.ForeignTableDef, db catalog.DatabaseDescriptor, desc *tabledesc.Mutable,
+) (*descpb.TableDescriptor_ForeignTable, error) {
+	for _, col := range desc.VisibleColumns() {
+		if col.GetType().UserDefined() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q: user-defined types are not supported on foreign tables", col.GetName())
+		}
+	}
+	server := db.GetForeignServer(string(def.Server))
+	if server == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", def.Server)
+	}
+	w, err := getForeignDataWrapper(server.Wrapper)
+	if err != nil {
+		return nil, err
+	}
+	options, err := makeForeignOptions(def.Options)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.ValidateTableOptions(foreignOptionsMap(options)); err != nil {
+		return nil, err
+	}
+	return &descpb.TableDescriptor_ForeignTable{
+		Server:  string(def.Server),
+		Options: options,
+	}, nil
+}
+
+// resolveInheritedTables resolves the parent tables named in the INHERITS
+// clause of the input CreateTable node and rewrites n.Defs so that the new
+// table gets the columns and check constraints of its parents. Inherited
+// columns come first, in the order of the parents, followed by the columns
+// which are only defined locally. A locally defined column with the same
+// name as an inherited column is merged into it, as are inherited columns
+// with the same name from different parents.
+func resolveInheritedTables(n *tree.CreateTable, params runParams) ([]*tabledesc.Mutable, error) {
+	if len(n.Inherits) == 0 {
+		return nil, nil
+	}
+	if !params.p.IsActive(params.ctx, clusterversion.V25_3_Start) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"table inheritance is not supported until the cluster version is finalized")
+	}
+	parents := make([]*tabledesc.Mutable, 0, len(n.Inherits))
+	for i := range n.Inherits {
+		_, parent, err := params.p.ResolveMutableTableDescriptor(
+			params.ctx, &n.Inherits[i], true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := params.p.CheckPrivilege(params.ctx, parent, privilege.CREATE); err != nil {
+			return nil, err
+		}
+		for _, other := range parents {
+			if other.GetID() == parent.GetID() {
+				return nil, pgerror.Newf(pgcode.DuplicateRelation,
+					"relation %q would be inherited from more than once", parent.GetName())
+			}
+		}
+		if parent.IsForeignTable() {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"cannot inherit from foreign table %q", parent.GetName())
+		}
+		if parent.IsTemporary() && n.Persistence != tree.PersistenceTemporary {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"cannot inherit from temporary relation %q", parent.GetName())
+		}
+		parents = append(parents, parent)
+	}
+
+	// Collect the locally defined columns, which are merged into the inherited
+	// columns of the same name.
+	localCols := make(map[tree.Name]*tree.ColumnTableDef)
+	for _, def := range n.Defs {
+		if d, ok := def.(*tree.ColumnTableDef); ok {
+			localCols[d.Name] = d
+		}
+	}
+	inheritedCols := make(map[tree.Name]*tree.ColumnTableDef)
+	inheritedChecks := make(map[tree.Name]struct{})
+	var defs tree.TableDefs
+	for _, parent := range parents {
+		for i := range parent.Columns {
+			c := &parent.Columns[i]
+			implicit, err := isImplicitlyCreatedBySystem(parent, c)
+			if err != nil {
+				return nil, err
+			}
+			if implicit {
+				continue
+			}
+			def := inheritedCols[tree.Name(c.Name)]
+			if def == nil {
+				def = localCols[tree.Name(c.Name)]
+				if def != nil {
+					params.p.BufferClientNotice(params.ctx,
+						pgnotice.Newf("merging column %q with inherited definition", c.Name))
+					delete(localCols, def.Name)
+				} else {
+					def = &tree.ColumnTableDef{Name: tree.Name(c.Name), Type: c.Type, Hidden: c.Hidden}
+				}
+				inheritedCols[def.Name] = def
+				defs = append(defs, def)
+			} else {
+				params.p.BufferClientNotice(params.ctx,
+					pgnotice.Newf("merging multiple inherited definitions of column %q", c.Name))
+			}
+			typ, err := tree.ResolveType(params.ctx, def.Type, params.p.semaCtx.GetTypeResolver())
+			if err != nil {
+				return nil, err
+			}
+			if !typ.Identical(c.Type) {
+				return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+					"column %q has a type conflict", c.Name)
+			}
+			if !c.Nullable {
+				def.Nullable.Nullability = tree.NotNull
+			}
+			if c.DefaultExpr != nil && def.DefaultExpr.Expr == nil {
+				def.DefaultExpr.Expr, err = parser.ParseExpr(*c.DefaultExpr)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if c.ComputeExpr != nil && !def.Computed.Computed {
+				def.Computed.Computed = true
+				def.Computed.Virtual = c.Virtual
+				def.Computed.Expr, err = parser.ParseExpr(*c.ComputeExpr)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, c := range parent.Checks {
+			if c.FromHashShardedColumn {
+				continue
+			}
+			if _, ok := inheritedChecks[tree.Name(c.Name)]; ok {
+				continue
+			}
+			inheritedChecks[tree.Name(c.Name)] = struct{}{}
+			def := &tree.CheckConstraintTableDef{Name: tree.Name(c.Name)}
+			var err error
+			def.Expr, err = parser.ParseExpr(c.Expr)
+			if err != nil {
+				return nil, err
+			}
+			defs = append(defs, def)
+		}
+	}
+	for _, def := range n.Defs {
+		if d, ok := def.(*tree.ColumnTableDef); ok && inheritedCols[d.Name] == d {
+			continue
+		}
+		if d, ok := def.(*tree.CheckConstraintTableDef); ok && d.Name != "" {
+			if _, ok := inheritedChecks[d.Name]; ok {
+				// PostgreSQL merges a local check constraint into the inherited
+				// constraint of the same name.
+				continue
+			}
+		}
+		defs = append(defs, def)
+	}
+	n.Defs = defs
+	return parents, nil
+}
+
 // makeShardColumnDesc returns a new column descriptor for a hidden computed shard column
 // based on all the `colNames` and the bucket count. It delegates to one of
 // makeHashShardComputeExpr.
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -65,6 +66,10 @@ func (p *planner) CreateType(ctx context.Context, n *tree.CreateType) (planNode,
 	); err != nil {
 		return nil, err
 	}
+	if n.Variety == tree.Domain && !p.IsActive(ctx, clusterversion.V25_3_Start) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE DOMAIN is not supported until the cluster version is finalized")
+	}
 
 	// Resolve the desired new type name.
 	typeName, db, err := resolveNewTypeName(ctx, p, n.TypeName)
@@ -243,6 +248,8 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(typDesc.Domain.BaseType, catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id))
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -324,6 +331,8 @@ func (p *planner) createUserDefinedType(params runParams, n *createTypeNode) err
 		return params.p.createCompositeWithID(
 			params, id, n.n.CompositeTypeList, n.dbDesc, n.typeName,
 		)
+	case tree.Domain:
+		return params.p.createDomainWithID(params, id, n.n.Domain, n.dbDesc, n.typeName)
 	}
 	return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 }
@@ -0,0 +1,250 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// deferredConstraintKey identifies a DEFERRABLE constraint. Foreign keys are
+// identified by their origin (referencing) table.
+type deferredConstraintKey struct {
+	tableID descpb.ID
+	name    string
+}
+
+// constraintDeferrability returns the deferrability of the given constraint.
+// Only foreign key and UNIQUE WITHOUT INDEX constraints can be deferrable.
+func constraintDeferrability(c catalog.Constraint) tree.ConstraintDeferrability {
+	if fk := c.AsForeignKey(); fk != nil {
+		d := fk.ForeignKeyDesc()
+		return tree.MakeConstraintDeferrability(d.Deferrable, d.InitiallyDeferred)
+	}
+	if uc := c.AsUniqueWithoutIndex(); uc != nil {
+		d := uc.UniqueWithoutIndexDesc()
+		return tree.MakeConstraintDeferrability(d.Deferrable, d.InitiallyDeferred)
+	}
+	return tree.ConstraintNotDeferrable
+}
+
+// checkDeferrableConstraintsActive returns an error if a constraint is declared
+// DEFERRABLE before the cluster is upgraded to 25.3, since nodes running older
+// versions would check it immediately.
+func checkDeferrableConstraintsActive(
+	ctx context.Context, st *cluster.Settings, d tree.ConstraintDeferrability,
+) error {
+	if d.IsDeferrable() && !st.Version.IsActive(ctx, clusterversion.V25_3_Start) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"deferrable constraints are not supported until the cluster is upgraded to 25.3",
+		)
+	}
+	return nil
+}
+
+// txnDeferredConstraints tracks the checking of DEFERRABLE constraints in a
+// transaction. When the check of a deferred constraint fails at the end of a
+// statement, the constraint is recorded as pending and is validated against
+// the whole table when the transaction commits, or when the constraint is made
+// IMMEDIATE with SET CONSTRAINTS.
+//
+// The struct is protected by a mutex since checks may be run in parallel.
+type txnDeferredConstraints struct {
+	mu struct {
+		syncutil.Mutex
+		// allSet is true if SET CONSTRAINTS ALL was used in the transaction,
+		// in which case allDeferred overrides the initial mode of all
+		// constraints which are not in modes.
+		allSet      bool
+		allDeferred bool
+		// modes contains the modes of the constraints which were named in SET
+		// CONSTRAINTS, after the last SET CONSTRAINTS ALL.
+		modes map[deferredConstraintKey]bool
+		// pending contains the constraints which were violated by a statement
+		// while they were deferred.
+		pending map[deferredConstraintKey]struct{}
+	}
+}
+
+func newTxnDeferredConstraints() *txnDeferredConstraints {
+	ret := &txnDeferredConstraints{}
+	ret.mu.modes = make(map[deferredConstraintKey]bool)
+	ret.mu.pending = make(map[deferredConstraintKey]struct{})
+	return ret
+}
+
+func (d *txnDeferredConstraints) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.allSet = false
+	d.mu.allDeferred = false
+	for k := range d.mu.modes {
+		delete(d.mu.modes, k)
+	}
+	for k := range d.mu.pending {
+		delete(d.mu.pending, k)
+	}
+}
+
+// maybeDefer records the violated constraint as pending and returns true if
+// the constraint is currently deferred.
+func (d *txnDeferredConstraints) maybeDefer(v *exec.DeferrableConstraintViolation) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := deferredConstraintKey{tableID: descpb.ID(v.TableID), name: v.ConstraintName}
+	deferred, ok := d.mu.modes[key]
+	if !ok {
+		deferred = v.InitiallyDeferred
+		if d.mu.allSet {
+			deferred = d.mu.allDeferred
+		}
+	}
+	if deferred {
+		d.mu.pending[key] = struct{}{}
+	}
+	return deferred
+}
+
+// setAllModes implements SET CONSTRAINTS ALL.
+func (d *txnDeferredConstraints) setAllModes(deferred bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.allSet = true
+	d.mu.allDeferred = deferred
+	for k := range d.mu.modes {
+		delete(d.mu.modes, k)
+	}
+}
+
+// setModes implements SET CONSTRAINTS for a list of constraints.
+func (d *txnDeferredConstraints) setModes(keys []deferredConstraintKey, deferred bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, k := range keys {
+		d.mu.modes[k] = deferred
+	}
+}
+
+// takePending removes and returns the pending constraints for which include
+// returns true, in a deterministic order.
+func (d *txnDeferredConstraints) takePending(
+	include func(deferredConstraintKey) bool,
+) []deferredConstraintKey {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var ret []deferredConstraintKey
+	for k := range d.mu.pending {
+		if include(k) {
+			ret = append(ret, k)
+			delete(d.mu.pending, k)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].tableID != ret[j].tableID {
+			return ret[i].tableID < ret[j].tableID
+		}
+		return ret[i].name < ret[j].name
+	})
+	return ret
+}
+
+// maybeDeferConstraintViolation returns nil if err is the violation of a
+// DEFERRABLE constraint which is currently deferred, in which case the
+// constraint is validated again before the transaction commits. Otherwise, err
+// is returned unchanged.
+//
+// Constraints are never deferred when the planner does not own the
+// transaction, e.g. in an internal executor running in an outer transaction.
+func (p *planner) maybeDeferConstraintViolation(err error) error {
+	if err == nil || p.extendedEvalCtx.deferredConstraints == nil {
+		return err
+	}
+	var v *exec.DeferrableConstraintViolation
+	if !errors.As(err, &v) {
+		return err
+	}
+	if p.extendedEvalCtx.deferredConstraints.maybeDefer(v) {
+		return nil
+	}
+	return err
+}
+
+// validateDeferredConstraints validates the pending deferred constraints for
+// which include returns true. A nil include function validates all of them.
+func (p *planner) validateDeferredConstraints(
+	ctx context.Context, include func(deferredConstraintKey) bool,
+) error {
+	if p.extendedEvalCtx.deferredConstraints == nil {
+		return nil
+	}
+	if include == nil {
+		include = func(deferredConstraintKey) bool { return true }
+	}
+	for _, key := range p.extendedEvalCtx.deferredConstraints.takePending(include) {
+		if err := p.validateDeferredConstraint(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *planner) validateDeferredConstraint(ctx context.Context, key deferredConstraintKey) error {
+	tbl, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Table(ctx, key.tableID)
+	if err != nil {
+		return err
+	}
+	if tbl.Dropped() {
+		return nil
+	}
+	c := catalog.FindConstraintByName(tbl, key.name)
+	if c == nil {
+		// The constraint was dropped later in the transaction.
+		return nil
+	}
+	log.VEventf(ctx, 2, "validating deferred constraint %q on table %q", key.name, tbl.GetName())
+	if fk := c.AsForeignKey(); fk != nil {
+		mut := tabledesc.NewBuilder(tbl.TableDesc()).BuildExistingMutableTable()
+		return validateFkInTxn(ctx, p.InternalSQLTxn(), mut, key.name)
+	}
+	if uc := c.AsUniqueWithoutIndex(); uc != nil {
+		if uc.UniqueWithoutIndexDesc().IsExclusion() {
+			return validateExclusionConstraint(
+				ctx,
+				tbl,
+				uc.UniqueWithoutIndexDesc(),
+				0, /* indexIDForValidation */
+				p.InternalSQLTxn(),
+				p.User(),
+				true, /* preExisting */
+			)
+		}
+		return validateUniqueConstraint(
+			ctx,
+			tbl,
+			uc.GetName(),
+			uc.CollectKeyColumnIDs().Ordered(),
+			uc.GetPredicate(),
+			0, /* indexIDForValidation */
+			p.InternalSQLTxn(),
+			p.User(),
+			true, /* preExisting */
+		)
+	}
+	return errors.AssertionFailedf("constraint %q is not deferrable", key.name)
+}
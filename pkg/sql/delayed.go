@@ -21,6 +21,9 @@ type delayedNode struct {
 	columns     colinfo.ResultColumns
 	constructor nodeConstructor
 	input       planNode
+	// foreignScan is set if the node scans a foreign table, in which case
+	// filters on the output can be pushed down into the scan.
+	foreignScan *foreignScan
 }
 
 type nodeConstructor func(context.Context, *planner) (planNode, error)
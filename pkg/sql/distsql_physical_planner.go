@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 
@@ -37,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -2186,6 +2188,10 @@ func initTableReaderSpecTemplate(
 		return nil, execinfrapb.PostProcessSpec{}, err
 	}
 
+	if n.tableSample.Set && n.tableSample.Method == tree.TableSampleBernoulli {
+		s.Sample = makeTableSampleSpec(n.tableSample)
+	}
+
 	var post execinfrapb.PostProcessSpec
 	if n.hardLimit != 0 {
 		post.Limit = uint64(n.hardLimit)
@@ -2195,6 +2201,56 @@ func initTableReaderSpecTemplate(
 	return s, post, nil
 }
 
+// makeTableSampleSpec returns the TableSampleSpec for the given sample. Unless
+// REPEATABLE was specified, a new seed is chosen every time the scan is
+// planned.
+func makeTableSampleSpec(sample opt.TableSample) *execinfrapb.TableSampleSpec {
+	seed := uint64(randutil.FastInt63())
+	if sample.Repeatable {
+		seed = math.Float64bits(sample.Seed)
+	}
+	return &execinfrapb.TableSampleSpec{Probability: sample.Probability, Seed: seed}
+}
+
+// sampleSpansByRange implements TABLESAMPLE SYSTEM by splitting the spans at
+// range boundaries and keeping each of the resulting pieces with the sample
+// probability. Whether a piece is kept only depends on the seed and on the
+// start key of its range. If no piece is kept, the first piece is returned and
+// empty is true.
+func (dsp *DistSQLPlanner) sampleSpansByRange(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	spans roachpb.Spans,
+	sample *execinfrapb.TableSampleSpec,
+) (_ roachpb.Spans, empty bool, _ error) {
+	var sampled roachpb.Spans
+	var first roachpb.Span
+	it := planCtx.spanIter
+	for _, sp := range spans {
+		for it.Seek(ctx, sp, kvcoord.Ascending); ; it.Next(ctx) {
+			if !it.Valid() {
+				return nil, false, it.Error()
+			}
+			desc := it.Desc()
+			if piece := sp.Intersect(desc.RSpan().AsRawSpanWithNoLocals()); piece.Valid() {
+				if !first.Valid() {
+					first = piece
+				}
+				if sample.KeepRow(desc.StartKey) {
+					sampled = append(sampled, piece)
+				}
+			}
+			if sp.EndKey == nil || !it.NeedAnother() {
+				break
+			}
+		}
+	}
+	if len(sampled) == 0 && first.Valid() {
+		return roachpb.Spans{first}, true, nil
+	}
+	return sampled, false, nil
+}
+
 // createTableReaders generates a plan consisting of table reader processors,
 // one for each node that has spans that we are reading.
 func (dsp *DistSQLPlanner) createTableReaders(
@@ -2205,6 +2261,27 @@ func (dsp *DistSQLPlanner) createTableReaders(
 		return nil, err
 	}
 
+	spans := n.spans
+	if n.tableSample.Set && n.tableSample.Method == tree.TableSampleSystem {
+		sample := makeTableSampleSpec(n.tableSample)
+		if planCtx.spanIter != nil { // This condition can only be false in tests.
+			var empty bool
+			if spans, empty, err = dsp.sampleSpansByRange(ctx, planCtx, n.spans, sample); err != nil {
+				return nil, err
+			}
+			if empty {
+				// No range was sampled, so the result is empty. Scan a single
+				// range and discard all of its rows, which keeps the plan shape
+				// intact.
+				spec.Sample = &execinfrapb.TableSampleSpec{Seed: sample.Seed}
+			}
+		} else {
+			// Without the range boundaries, fall back to sampling individual
+			// rows.
+			spec.Sample = sample
+		}
+	}
+
 	p := planCtx.NewPhysicalPlan()
 	err = dsp.planTableReaders(
 		ctx,
@@ -2214,7 +2291,7 @@ func (dsp *DistSQLPlanner) createTableReaders(
 			spec:                spec,
 			post:                post,
 			desc:                n.desc,
-			spans:               n.spans,
+			spans:               spans,
 			reverse:             n.reverse,
 			parallelize:         n.parallelize,
 			estimatedRowCount:   n.estimatedRowCount,
@@ -2606,11 +2683,22 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		var ef physicalplan.ExprFactory
+		ef.Init(ctx, planCtx, nil /* indexVarMap */)
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			udAgg, err := makeUserDefinedAggregateSpec(&ef, fholder)
+			if err != nil {
+				return err
+			}
+			aggregations[i].UserDefined = udAgg
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2621,8 +2709,6 @@ func (dsp *DistSQLPlanner) addAggregators(
 		}
 		aggregations[i].Arguments = make([]execinfrapb.Expression, len(fholder.arguments))
 		argumentsColumnTypes[i] = make([]*types.T, len(fholder.arguments))
-		var ef physicalplan.ExprFactory
-		ef.Init(ctx, planCtx, nil /* indexVarMap */)
 		for j, argument := range fholder.arguments {
 			var err error
 			aggregations[i].Arguments[j], err = ef.Make(argument)
@@ -2646,6 +2732,29 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// makeUserDefinedAggregateSpec creates the specification of the routines that
+// compute the given user-defined aggregate function.
+func makeUserDefinedAggregateSpec(
+	ef *physicalplan.ExprFactory, fholder *aggregateFuncHolder,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregate, error) {
+	udAgg := &execinfrapb.AggregatorSpec_UserDefinedAggregate{
+		ResultType: fholder.resultType,
+	}
+	var err error
+	if udAgg.Transition, err = ef.Make(fholder.userDefined.Transition); err != nil {
+		return nil, err
+	}
+	if fholder.userDefined.Final != nil {
+		if udAgg.Final, err = ef.Make(fholder.userDefined.Final); err != nil {
+			return nil, err
+		}
+	}
+	if udAgg.InitValue, err = ef.Make(fholder.userDefined.InitValue); err != nil {
+		return nil, err
+	}
+	return udAgg, nil
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -3193,6 +3302,10 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes = append(argTypes, inputTypes[c])
 		}
 		argTypes = append(argTypes, info.argumentsColumnTypes[i]...)
+		if agg.UserDefined != nil {
+			finalOutTypes[i] = agg.UserDefined.ResultType
+			continue
+		}
 		returnTyp, err := execagg.GetAggregateOutputType(agg.Func, argTypes)
 		if err != nil {
 			return err
@@ -2300,7 +2300,7 @@ func (dsp *DistSQLPlanner) PlanAndRunPostQueries(
 				}
 				for i := checksIdx; i < len(plan.checkPlans); i++ {
 					log.VEventf(ctx, 2, "executing check query %d out of %d", i+1, len(plan.checkPlans))
-					if err := dsp.planAndRunPostquery(
+					if err := planner.maybeDeferConstraintViolation(dsp.planAndRunPostquery(
 						ctx,
 						plan.checkPlans[i].plan,
 						planner,
@@ -2310,7 +2310,7 @@ func (dsp *DistSQLPlanner) PlanAndRunPostQueries(
 						defaultGetSaveFlowsFunc,
 						planner.instrumentation.getAssociateNodeWithComponentsFn(),
 						recv.stats.add,
-					); err != nil {
+					)); err != nil {
 						recv.SetError(err)
 						return false
 					}
@@ -2327,8 +2327,9 @@ func (dsp *DistSQLPlanner) PlanAndRunPostQueries(
 		for ; triggersIdx < numTriggers; triggersIdx++ {
 			trigger := &plan.triggers[triggersIdx]
 			hasBuffer, numBufferedRows := checkPostQueryBuffer(plan.triggers[triggersIdx])
-			if hasBuffer && numBufferedRows == 0 {
-				// No rows were actually modified.
+			if hasBuffer && numBufferedRows == 0 && !hasStatementLevelTriggers(trigger) {
+				// No rows were actually modified. Statement-level triggers fire even
+				// if no rows were modified.
 				continue
 			}
 			if log.ExpensiveLogEnabled(ctx, 2) {
@@ -2369,6 +2370,17 @@ func checkPostQueryBuffer(postQuery postQueryMetadata) (hasBuffer bool, numBuffe
 	return true, buf.(*bufferNode).rows.rows.Len()
 }
 
+// hasStatementLevelTriggers returns true if any of the triggers of the given
+// post-query are statement-level triggers.
+func hasStatementLevelTriggers(postQuery *postQueryMetadata) bool {
+	for _, trigger := range postQuery.Triggers {
+		if !trigger.ForEachRow() {
+			return true
+		}
+	}
+	return false
+}
+
 // planAndRunCascadeOrTrigger builds the plan for a cascade or trigger query and
 // runs it. It returns false if an error was encountered and sets that error in
 // the provided receiver. It also returns checksContainLocking, which is true if
@@ -2399,15 +2411,40 @@ func (dsp *DistSQLPlanner) planAndRunCascadeOrTrigger(
 	}
 	cp := cascadePlan.(*planComponents)
 	pq.plan = cp.main
-	if len(cp.subqueryPlans) > 0 {
-		recv.SetError(errors.AssertionFailedf("post-query should not have subqueries"))
-		return false, false
+	pq.subqueryPlans = cp.subqueryPlans
+	for i := range cp.subqueryPlans {
+		// The only subqueries allowed in a post-query are materialized With
+		// bindings built for triggers, e.g. the transition relations of AFTER
+		// triggers, or the statement-level BEFORE triggers of a cascade.
+		if cp.subqueryPlans[i].execMode != rowexec.SubqueryExecModeDiscardAllRows {
+			recv.SetError(errors.AssertionFailedf("post-query should not have subqueries"))
+			return false, false
+		}
 	}
 	checksContainLocking = cp.flags.IsSet(planFlagCheckContainsLocking)
 
 	// add any newly generated post-queries to the queue.
 	addPostQueriesFromPlan(evalCtx, recv, cp, plan)
 
+	if len(cp.subqueryPlans) > 0 {
+		// The subqueries don't produce results, so the memory account is not
+		// needed once they have been run.
+		subqueryResultMemAcc := planner.Mon().MakeBoundAccount()
+		defer subqueryResultMemAcc.Close(ctx)
+		if !dsp.PlanAndRunSubqueries(
+			ctx,
+			planner,
+			func() *extendedEvalContext { return evalCtx },
+			cp.subqueryPlans,
+			recv,
+			&subqueryResultMemAcc,
+			false, /* skipDistSQLDiagramGeneration */
+			false, /* mustUseLeafTxn */
+		) {
+			return false, false
+		}
+	}
+
 	if err := dsp.planAndRunPostquery(
 		ctx,
 		cp.main,
@@ -2627,7 +2664,7 @@ func (dsp *DistSQLPlanner) planAndRunChecksInParallel(
 	errs := make([]error, len(checkPlans))
 	runCheck := func(ctx context.Context, checkPlanIdx int) {
 		log.VEventf(ctx, 3, "begin check %d", checkPlanIdx)
-		errs[checkPlanIdx] = dsp.planAndRunPostquery(
+		errs[checkPlanIdx] = planner.maybeDeferConstraintViolation(dsp.planAndRunPostquery(
 			ctx, checkPlans[checkPlanIdx].plan,
 			planner,
 			evalCtxFactory(true /* usedConcurrently */),
@@ -2636,7 +2673,7 @@ func (dsp *DistSQLPlanner) planAndRunChecksInParallel(
 			getSaveFlowsFunc,
 			associateNodeWithComponents,
 			addTopLevelQueryStats,
-		)
+		))
 		log.VEventf(ctx, 3, "end check %d", checkPlanIdx)
 	}
 
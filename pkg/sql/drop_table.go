@@ -8,6 +8,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -18,6 +19,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -66,6 +69,14 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		if droppedDesc == nil {
 			continue
 		}
+		if n.Foreign && !droppedDesc.IsForeignTable() {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"%q is not a foreign table", droppedDesc.Name)
+		} else if !n.Foreign && droppedDesc.IsForeignTable() {
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%q is a foreign table", droppedDesc.Name),
+				"use DROP FOREIGN TABLE to remove a foreign table")
+		}
 
 		td[droppedDesc.ID] = toDelete{tn, droppedDesc}
 	}
@@ -93,6 +104,14 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		if err := p.canRemoveAllTableOwnedSequences(ctx, droppedDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
+		for _, childID := range droppedDesc.InheritedBy {
+			if _, ok := td[childID]; !ok && n.DropBehavior != tree.DropCascade {
+				return nil, errors.WithHint(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop table %s because other objects depend on it", droppedDesc.Name),
+					"use DROP ... CASCADE to drop the dependent objects too")
+			}
+		}
 
 	}
 
@@ -284,6 +303,33 @@ func (p *planner) dropTableImpl(
 		}
 	}
 
+	// Remove the inherited-by back references from the tables this table
+	// inherits from, and drop the tables which inherit from this one, assuming
+	// that we wouldn't have made it to this point if `cascade` wasn't enabled.
+	for _, parentID := range tableDesc.Inherits {
+		if err := p.removeInheritanceBackReference(ctx, tableDesc, parentID); err != nil {
+			return droppedViews, err
+		}
+	}
+	inheritedBy := append([]descpb.ID(nil), tableDesc.InheritedBy...)
+	for _, childID := range inheritedBy {
+		childDesc, err := p.Descriptors().MutableByID(p.txn).Table(ctx, childID)
+		if err != nil {
+			return droppedViews, err
+		}
+		if childDesc.Dropped() {
+			continue
+		}
+		cascadedViews, err := p.dropTableImpl(
+			ctx, childDesc, droppingParent, "dropping inheriting table", tree.DropCascade,
+		)
+		if err != nil {
+			return droppedViews, err
+		}
+		droppedViews = append(droppedViews, cascadedViews...)
+	}
+	tableDesc.InheritedBy = nil
+
 	// Remove function dependencies
 	fnIDs, err := tableDesc.GetAllReferencedFunctionIDs()
 	if err != nil {
@@ -671,6 +717,35 @@ func removeFKBackReferenceFromTable(
 
 // removeTriggerBackReference removes the trigger back reference for the
 // referenced table with the given ID.
+// removeInheritanceBackReference removes the inherited-by back reference to
+// tableDesc from the table with the given parent ID.
+func (p *planner) removeInheritanceBackReference(
+	ctx context.Context, tableDesc *tabledesc.Mutable, parentID descpb.ID,
+) error {
+	parentDesc, err := p.Descriptors().MutableByID(p.txn).Table(ctx, parentID)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving inherited table ID %d", parentID)
+	}
+	if parentDesc.Dropped() {
+		// The parent table is being dropped. No need to modify it further.
+		return nil
+	}
+	parentDesc.InheritedBy = slices.DeleteFunc(parentDesc.InheritedBy, func(id descpb.ID) bool {
+		return id == tableDesc.ID
+	})
+	name, err := p.getQualifiedTableName(ctx, tableDesc)
+	if err != nil {
+		return err
+	}
+	parentName, err := p.getQualifiedTableName(ctx, parentDesc)
+	if err != nil {
+		return err
+	}
+	jobDesc := fmt.Sprintf("updating table %q after removing inheriting table %q",
+		parentName.FQString(), name.FQString())
+	return p.writeSchemaChange(ctx, parentDesc, descpb.InvalidMutationID, jobDesc)
+}
+
 func (p *planner) removeTriggerBackReference(
 	ctx context.Context, tableDesc *tabledesc.Mutable, refID descpb.ID, triggerName string,
 ) error {
@@ -259,25 +259,40 @@ func constructVirtualScan(
 	// that varies by exec.Factory implementations.
 	delayedNodeCallback func(*delayedNode) (exec.Node, error),
 ) (exec.Node, error) {
-	tn := &table.(*optVirtualTable).name
-	virtual, err := p.getVirtualTabler().getVirtualTableEntry(tn, p)
-	if err != nil {
-		return nil, err
-	}
-	if !canQueryVirtualTable(p.EvalContext(), virtual) {
-		return nil, newUnimplementedVirtualTableError(tn.Schema(), tn.Table())
+	var columns colinfo.ResultColumns
+	var constructor nodeConstructor
+	var fs *foreignScan
+	if table.IsForeignTable() {
+		// Foreign tables are scanned through their foreign-data wrapper, which
+		// only produces the needed columns.
+		fs, columns = newForeignScan(table.(*optVirtualTable), params.NeededCols)
+		constructor = func(ctx context.Context, p *planner) (planNode, error) {
+			return fs.newPlanNode(ctx, p, columns)
+		}
+	} else {
+		tn := &table.(*optVirtualTable).name
+		virtual, err := p.getVirtualTabler().getVirtualTableEntry(tn, p)
+		if err != nil {
+			return nil, err
+		}
+		if !canQueryVirtualTable(p.EvalContext(), virtual) {
+			return nil, newUnimplementedVirtualTableError(tn.Schema(), tn.Table())
+		}
+		idx := index.(*optVirtualIndex).idx
+		var virtualConstructor virtualTableConstructor
+		columns, virtualConstructor = virtual.getPlanInfo(
+			table.(*optVirtualTable).desc, idx, params.IndexConstraint, p.execCfg.Stopper,
+		)
+		constructor = func(ctx context.Context, p *planner) (planNode, error) {
+			return virtualConstructor(ctx, p, tn.Catalog())
+		}
 	}
-	idx := index.(*optVirtualIndex).idx
-	columns, constructor := virtual.getPlanInfo(
-		table.(*optVirtualTable).desc, idx, params.IndexConstraint, p.execCfg.Stopper,
-	)
 
 	n, err := delayedNodeCallback(&delayedNode{
-		name:    fmt.Sprintf("%s@%s", table.Name(), index.Name()),
-		columns: columns,
-		constructor: func(ctx context.Context, p *planner) (planNode, error) {
-			return constructor(ctx, p, tn.Catalog())
-		},
+		name:        fmt.Sprintf("%s@%s", table.Name(), index.Name()),
+		columns:     columns,
+		constructor: constructor,
+		foreignScan: fs,
 	})
 	if err != nil {
 		return nil, err
@@ -70,6 +70,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/listennotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
@@ -1603,6 +1604,10 @@ type ExecutorConfig struct {
 	// SystemTableIDResolver is used to obtain dynamic IDs for system tables.
 	SystemTableIDResolver catalog.SystemTableIDResolver
 
+	// NotificationRegistry delivers the notifications sent by NOTIFY to the
+	// sessions listening on their channels.
+	NotificationRegistry *listennotify.Registry
+
 	// SpanConfigReconciler is used to drive the span config reconciliation job
 	// and related upgrades.
 	SpanConfigReconciler spanconfig.Reconciler
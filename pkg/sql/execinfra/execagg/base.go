@@ -91,6 +91,10 @@ func GetAggregateConstructor(
 		}
 		paramTypes[j] = inputTypes[c]
 	}
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = getUserDefinedAggregateInfo(ctx, aggInfo.UserDefined)
+		return constructor, nil /* arguments */, outputType, err
+	}
 	arguments = make(tree.Datums, len(aggInfo.Arguments))
 	var d tree.Datum
 	for j, argument := range aggInfo.Arguments {
@@ -0,0 +1,154 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package execagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// getUserDefinedAggregateInfo returns the aggregate constructor and the return
+// type for the given user-defined aggregate function.
+func getUserDefinedAggregateInfo(
+	ctx context.Context, udAgg *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+) (aggregateConstructor AggregateConstructor, returnType *types.T, err error) {
+	if udAgg == nil {
+		return nil, nil, errors.AssertionFailedf("missing user-defined aggregate specification")
+	}
+	transition, ok := udAgg.Transition.LocalExpr.(*tree.RoutineExpr)
+	if !ok {
+		return nil, nil, errors.AssertionFailedf(
+			"user-defined aggregates can only be evaluated locally",
+		)
+	}
+	var final *tree.RoutineExpr
+	if udAgg.Final.LocalExpr != nil {
+		if final, ok = udAgg.Final.LocalExpr.(*tree.RoutineExpr); !ok {
+			return nil, nil, errors.AssertionFailedf(
+				"expected routine for final function, found %T", udAgg.Final.LocalExpr,
+			)
+		}
+	}
+	initValue, ok := udAgg.InitValue.LocalExpr.(tree.Datum)
+	if !ok {
+		return nil, nil, errors.AssertionFailedf(
+			"expected datum for initial value, found %T", udAgg.InitValue.LocalExpr,
+		)
+	}
+	constructAgg := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		agg := &userDefinedAggregate{
+			ctx:        ctx,
+			evalCtx:    evalCtx,
+			transition: transition,
+			final:      final,
+			initValue:  initValue,
+		}
+		agg.Reset(ctx)
+		return agg
+	}
+	return constructAgg, udAgg.ResultType, nil
+}
+
+// userDefinedAggregate computes a user-defined aggregate function by invoking
+// its state transition function for each input row and its final function, if
+// any, on the final state value. The arguments of the aggregate are passed as a
+// single tuple.
+type userDefinedAggregate struct {
+	// ctx is the context used to evaluate the routines in Result, which is not
+	// passed a context.
+	ctx        context.Context
+	evalCtx    *eval.Context
+	transition *tree.RoutineExpr
+	final      *tree.RoutineExpr
+	initValue  tree.Datum
+
+	// state is the current state value.
+	state tree.Datum
+	// noState is true if the transition function is strict, there is no
+	// initial value, and no non-NULL input has been seen yet. In this case, the
+	// first non-NULL input becomes the state value, following Postgres.
+	noState bool
+	// args is scratch space for the arguments of the transition function.
+	args tree.Datums
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+
+// Add is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	a.ctx = ctx
+	var inputs tree.Datums
+	if tuple, ok := tree.AsDTuple(firstArg); ok {
+		inputs = tuple.D
+	} else if firstArg != tree.DNull {
+		return errors.AssertionFailedf("expected tuple argument, found %T", firstArg)
+	}
+	if !a.transition.CalledOnNullInput {
+		// A strict transition function is not invoked for rows with NULL
+		// inputs, and the state is left unchanged.
+		for _, d := range inputs {
+			if d == tree.DNull {
+				return nil
+			}
+		}
+		if firstArg == tree.DNull {
+			return nil
+		}
+		if a.noState {
+			if len(inputs) == 0 {
+				return errors.AssertionFailedf("expected at least one aggregate argument")
+			}
+			a.state = inputs[0]
+			a.noState = false
+			return nil
+		}
+		if a.state == tree.DNull {
+			// Postgres does not invoke a strict transition function with a NULL
+			// state value, so the state remains NULL.
+			return nil
+		}
+	}
+	a.args = append(a.args[:0], a.state)
+	a.args = append(a.args, inputs...)
+	state, err := a.evalCtx.Planner.EvalRoutineExpr(ctx, a.transition, a.args)
+	if err != nil {
+		return err
+	}
+	a.state = state
+	return nil
+}
+
+// Result is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.final == nil {
+		return a.state, nil
+	}
+	return a.evalCtx.Planner.EvalRoutineExpr(a.ctx, a.final, tree.Datums{a.state})
+}
+
+// Reset is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(context.Context) {
+	a.state = a.initValue
+	a.noState = !a.transition.CalledOnNullInput && a.initValue == tree.DNull
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(context.Context) {}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
+
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
@@ -223,6 +223,10 @@ func (tr *TableReaderSpec) summary() (string, []string) {
 		))
 	}
 
+	if tr.Sample != nil {
+		details = append(details, fmt.Sprintf("Sample: %g%%", tr.Sample.Probability*100))
+	}
+
 	return "TableReader", details
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/errors"
 )
@@ -42,6 +43,11 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 	if a.Func != b.Func || a.Distinct != b.Distinct {
 		return false
 	}
+	if a.UserDefined != nil || b.UserDefined != nil {
+		// User-defined aggregates with the same arguments may still differ in
+		// their routines, so they are never considered equal.
+		return false
+	}
 	if a.FilterColIdx == nil {
 		if b.FilterColIdx != nil {
 			return false
@@ -381,6 +387,25 @@ func (spec *JoinReaderSpec) IsIndexJoin() bool {
 	return len(spec.LookupColumns) == 0 && spec.LookupExpr.Empty()
 }
 
+// KeepRow returns whether the row identified by the given key prefix is part
+// of the sample. The decision is a pure function of the seed and the key.
+func (spec *TableSampleSpec) KeepRow(rowPrefix []byte) bool {
+	h := util.MakeFNV64()
+	h.Add(spec.Seed)
+	for _, c := range rowPrefix {
+		h.Add(uint64(c))
+	}
+	// FNV does not mix the high bits of the hash well for short keys, so run
+	// the sum through a finalizer before mapping it onto [0, 1).
+	x := h.Sum()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return float64(x>>11)/(1<<53) < spec.Probability
+}
+
 // init performs some sanity checks for the invariants required by the
 // upperBuffer type.
 func init() {
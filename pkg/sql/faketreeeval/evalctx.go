@@ -348,6 +348,20 @@ func (*DummyEvalPlanner) PLpgSQLFetchCursor(
 	return nil, errors.WithStack(errEvalPlanner)
 }
 
+// PLpgSQLOpenDynamicCursor is part of the eval.Planner interface.
+func (*DummyEvalPlanner) PLpgSQLOpenDynamicCursor(
+	context.Context, tree.Name, string, tree.Datums, bool, bool,
+) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
+// PLpgSQLExecuteDynamic is part of the eval.Planner interface.
+func (*DummyEvalPlanner) PLpgSQLExecuteDynamic(
+	context.Context, string, tree.Datums,
+) ([]tree.Datums, *types.T, error) {
+	return nil, nil, errors.WithStack(errEvalPlanner)
+}
+
 func (p *DummyEvalPlanner) StartHistoryRetentionJob(
 	ctx context.Context, desc string, protectTS hlc.Timestamp, expiration time.Duration,
 ) (jobspb.JobID, error) {
@@ -572,6 +586,11 @@ func (ep *DummyEvalPlanner) ClearQueryPlanCache() {}
 // ClearTableStatsCache is part of the eval.Planner interface.
 func (ep *DummyEvalPlanner) ClearTableStatsCache() {}
 
+// SendNotification is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) SendNotification(ctx context.Context, channel, payload string) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
 // DummyPrivilegedAccessor implements the tree.PrivilegedAccessor interface by returning errors.
 type DummyPrivilegedAccessor struct{}
 
@@ -0,0 +1,299 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// ForeignDataWrapper implements access to data stored outside of the cluster
+// for foreign tables. A foreign server names a wrapper along with the options
+// used to connect to the external source, and a foreign table adds the options
+// that locate its rows within that source.
+type ForeignDataWrapper interface {
+	// ValidateServerOptions checks the options of a CREATE SERVER statement.
+	ValidateServerOptions(options map[string]string) error
+	// ValidateTableOptions checks the options of a CREATE FOREIGN TABLE
+	// statement.
+	ValidateTableOptions(options map[string]string) error
+	// Scan reads the rows of a foreign table, calling push with the values of
+	// params.Columns for each row. Filters which the wrapper does not evaluate
+	// remotely can be ignored, since they are also applied to the returned
+	// rows.
+	Scan(ctx context.Context, params ForeignScanParams, push func(tree.Datums) error) error
+}
+
+// ForeignScanParams are the parameters of a scan of a foreign table.
+type ForeignScanParams struct {
+	ExecCfg *ExecutorConfig
+	EvalCtx *eval.Context
+	User    username.SQLUsername
+	// Table is the descriptor of the foreign table being scanned.
+	Table         catalog.TableDescriptor
+	ServerOptions map[string]string
+	TableOptions  map[string]string
+	// Columns are the columns to return, in order.
+	Columns []catalog.Column
+	// Filters are simple conditions on the columns which every returned row
+	// must satisfy.
+	Filters []ForeignFilter
+}
+
+// ForeignFilter is a comparison between a column and a constant which can be
+// pushed down into a foreign scan. IS NULL and IS NOT NULL are represented by
+// the IsNotDistinctFrom and IsDistinctFrom operators with a NULL value.
+type ForeignFilter struct {
+	Column catalog.Column
+	Op     treecmp.ComparisonOperatorSymbol
+	Value  tree.Datum
+}
+
+var foreignDataWrappers struct {
+	syncutil.Mutex
+	m map[string]ForeignDataWrapper
+}
+
+// RegisterForeignDataWrapper registers a foreign-data wrapper under the given
+// name, which can then be used in CREATE SERVER statements. It is meant to be
+// called from init functions.
+func RegisterForeignDataWrapper(name string, w ForeignDataWrapper) {
+	foreignDataWrappers.Lock()
+	defer foreignDataWrappers.Unlock()
+	if foreignDataWrappers.m == nil {
+		foreignDataWrappers.m = make(map[string]ForeignDataWrapper)
+	}
+	if _, ok := foreignDataWrappers.m[name]; ok {
+		panic(errors.AssertionFailedf("foreign-data wrapper %q is already registered", name))
+	}
+	foreignDataWrappers.m[name] = w
+}
+
+func getForeignDataWrapper(name string) (ForeignDataWrapper, error) {
+	foreignDataWrappers.Lock()
+	defer foreignDataWrappers.Unlock()
+	w, ok := foreignDataWrappers.m[name]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "foreign-data wrapper %q does not exist", name)
+	}
+	return w, nil
+}
+
+// foreignDataWrapperNames returns the names of the registered foreign-data
+// wrappers in sorted order.
+func foreignDataWrapperNames() []string {
+	foreignDataWrappers.Lock()
+	defer foreignDataWrappers.Unlock()
+	names := make([]string, 0, len(foreignDataWrappers.m))
+	for name := range foreignDataWrappers.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkForeignDataPrivilege ensures that the current user is allowed to define
+// foreign servers and foreign tables, which give access to resources outside
+// of the cluster.
+func (p *planner) checkForeignDataPrivilege(ctx context.Context, action string) error {
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasAdmin {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to %s", action)
+	}
+	return nil
+}
+
+// makeForeignOptions converts the options of an OPTIONS clause into their
+// descriptor representation.
+func makeForeignOptions(opts tree.ForeignOptions) ([]descpb.ForeignOption, error) {
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	ret := make([]descpb.ForeignOption, 0, len(opts))
+	seen := make(map[tree.Name]struct{}, len(opts))
+	for _, opt := range opts {
+		if _, ok := seen[opt.Name]; ok {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"option %q provided more than once", opt.Name)
+		}
+		seen[opt.Name] = struct{}{}
+		ret = append(ret, descpb.ForeignOption{Name: string(opt.Name), Value: opt.Value})
+	}
+	return ret, nil
+}
+
+// CheckForeignOptionNames returns an error if one of the given options of a
+// foreign server or foreign table is not one of the valid option names.
+func CheckForeignOptionNames(options map[string]string, valid ...string) error {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !slices.Contains(valid, name) {
+			return errors.WithHintf(
+				pgerror.Newf(pgcode.FdwInvalidOptionName, "invalid option %q", name),
+				"Valid options in this context are: %s", strings.Join(valid, ", "),
+			)
+		}
+	}
+	return nil
+}
+
+// foreignOptionsMap returns the options as a map from name to value.
+func foreignOptionsMap(opts []descpb.ForeignOption) map[string]string {
+	ret := make(map[string]string, len(opts))
+	for _, opt := range opts {
+		ret[opt.Name] = opt.Value
+	}
+	return ret
+}
+
+// foreignScan is the state of a scan over a foreign table, which is planned
+// like a scan over a virtual table.
+type foreignScan struct {
+	table   catalog.TableDescriptor
+	columns []catalog.Column
+	filters []ForeignFilter
+}
+
+// newForeignScan returns a foreignScan of the needed columns of the given
+// foreign table, along with the result columns of the scan.
+func newForeignScan(
+	table *optVirtualTable, needed exec.TableColumnOrdinalSet,
+) (*foreignScan, colinfo.ResultColumns) {
+	fs := &foreignScan{table: table.desc}
+	columns := make(colinfo.ResultColumns, 0, needed.Len())
+	// Ordinal 0 is the dummy primary key column of virtual tables, which
+	// cannot be used.
+	for ord, ok := needed.Next(1); ok; ord, ok = needed.Next(ord + 1) {
+		col := table.getCol(ord)
+		fs.columns = append(fs.columns, col)
+		columns = append(columns, colinfo.ResultColumn{
+			Name:           col.GetName(),
+			Typ:            col.GetType(),
+			TableID:        table.desc.GetID(),
+			PGAttributeNum: uint32(col.GetPGAttributeNum()),
+		})
+	}
+	return fs, columns
+}
+
+// pushFilter records the conjuncts of the given filter which can be evaluated
+// by the foreign-data wrapper. The indexed variables of the filter refer to
+// the columns of the scan.
+func (fs *foreignScan) pushFilter(filter tree.TypedExpr) {
+	switch t := filter.(type) {
+	case *tree.AndExpr:
+		fs.pushFilter(t.TypedLeft())
+		fs.pushFilter(t.TypedRight())
+	case *tree.ComparisonExpr:
+		op := t.Operator.Symbol
+		left, right := t.TypedLeft(), t.TypedRight()
+		if _, ok := left.(tree.Datum); ok {
+			// Normalize comparisons of the form `constant op column`.
+			switch op {
+			case treecmp.EQ, treecmp.NE:
+			case treecmp.LT:
+				op = treecmp.GT
+			case treecmp.LE:
+				op = treecmp.GE
+			case treecmp.GT:
+				op = treecmp.LT
+			case treecmp.GE:
+				op = treecmp.LE
+			default:
+				return
+			}
+			left, right = right, left
+		}
+		v, ok := left.(*tree.IndexedVar)
+		if !ok || v.Idx < 0 || v.Idx >= len(fs.columns) {
+			return
+		}
+		d, ok := right.(tree.Datum)
+		if !ok {
+			return
+		}
+		switch op {
+		case treecmp.EQ, treecmp.NE, treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE:
+			if d == tree.DNull {
+				return
+			}
+		case treecmp.IsDistinctFrom, treecmp.IsNotDistinctFrom:
+			if d != tree.DNull {
+				return
+			}
+		default:
+			return
+		}
+		fs.filters = append(fs.filters, ForeignFilter{Column: fs.columns[v.Idx], Op: op, Value: d})
+	}
+}
+
+// newPlanNode returns a planNode that reads the rows of the foreign table from
+// its foreign server.
+func (fs *foreignScan) newPlanNode(
+	ctx context.Context, p *planner, columns colinfo.ResultColumns,
+) (planNode, error) {
+	db, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Database(ctx, fs.table.GetParentID())
+	if err != nil {
+		return nil, err
+	}
+	ft := fs.table.GetForeignTable()
+	server := db.GetForeignServer(ft.Server)
+	if server == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", ft.Server)
+	}
+	w, err := getForeignDataWrapper(server.Wrapper)
+	if err != nil {
+		return nil, err
+	}
+	params := ForeignScanParams{
+		ExecCfg:       p.ExecCfg(),
+		EvalCtx:       p.EvalContext(),
+		User:          p.User(),
+		Table:         fs.table,
+		ServerOptions: foreignOptionsMap(server.Options),
+		TableOptions:  foreignOptionsMap(ft.Options),
+		Columns:       fs.columns,
+		Filters:       fs.filters,
+	}
+	next, cleanup, err := setupGenerator(ctx, func(ctx context.Context, pusher rowPusher) error {
+		return w.Scan(ctx, params, func(row tree.Datums) error {
+			if len(row) != len(columns) {
+				return errors.AssertionFailedf(
+					"foreign-data wrapper %q returned %d values, expected %d",
+					server.Wrapper, len(row), len(columns))
+			}
+			return pusher.pushRow(row...)
+		})
+	}, p.execCfg.Stopper)
+	if err != nil {
+		return nil, err
+	}
+	return p.newVirtualTableNode(columns, next, cleanup), nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
 // A groupNode implements the planNode interface and handles the grouping logic.
@@ -80,6 +81,12 @@ type aggregateFuncHolder struct {
 	// distsqlBlocklist is set when this function cannot be evaluated in
 	// distributed fashion.
 	distsqlBlocklist bool
+	// userDefined is set if this is a user-defined aggregate function. It
+	// contains the routines that compute the aggregate.
+	userDefined *exec.UserDefinedAggInfo
+	// resultType is the type of the aggregate's result. It is only set for
+	// user-defined aggregate functions.
+	resultType *types.T
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
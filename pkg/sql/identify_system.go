@@ -54,7 +54,6 @@ func (p *planner) IdentifySystem(
 	ctx context.Context, n *pgrepltree.IdentifySystem,
 ) (planNode, error) {
 	return &identifySystemNode{
-		// TODO(#105130): correctly populate this field.
 		lsn:       lsnutil.HLCToLSN(p.Txn().ReadTimestamp()),
 		clusterID: p.ExecCfg().NodeInfo.LogicalClusterID().String(),
 		database:  p.SessionData().Database,
@@ -0,0 +1,274 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/errors"
+	"github.com/linkedin/goavro/v2"
+)
+
+// fileFDW is a foreign-data wrapper which reads the rows of foreign tables
+// from CSV, Avro or Parquet files in external storage, using the same readers
+// as IMPORT. The uri option of the server is the location of the files, and
+// the filename option of a foreign table names a file relative to it. If the
+// filename ends with a slash, all of the files with that prefix are read.
+//
+// The columns of the foreign table correspond to the fields of CSV records
+// and the columns of Parquet files by position, and to the fields of Avro
+// records by name.
+type fileFDW struct{}
+
+var _ sql.ForeignDataWrapper = fileFDW{}
+
+func init() {
+	sql.RegisterForeignDataWrapper("file_fdw", fileFDW{})
+}
+
+// fileFDWOptions are the parsed options of a foreign table read through
+// file_fdw.
+type fileFDWOptions struct {
+	uri      string
+	filename string
+	format   roachpb.IOFileFormat_FileFormat
+	csv      roachpb.CSVOptions
+	header   bool
+}
+
+// ValidateServerOptions is part of the sql.ForeignDataWrapper interface.
+func (fileFDW) ValidateServerOptions(options map[string]string) error {
+	if err := sql.CheckForeignOptionNames(options, "uri"); err != nil {
+		return err
+	}
+	if _, ok := options["uri"]; !ok {
+		return pgerror.New(pgcode.FdwOptionNameNotFound, `option "uri" is required`)
+	}
+	// The user is only used to open the storage, so it doesn't matter here.
+	_, err := cloud.ExternalStorageConfFromURI(options["uri"], username.SQLUsername{})
+	return err
+}
+
+// ValidateTableOptions is part of the sql.ForeignDataWrapper interface.
+func (fileFDW) ValidateTableOptions(options map[string]string) error {
+	_, err := parseFileFDWOptions(nil /* serverOptions */, options)
+	return err
+}
+
+func parseFileFDWOptions(serverOptions, tableOptions map[string]string) (fileFDWOptions, error) {
+	if err := sql.CheckForeignOptionNames(
+		tableOptions, "delimiter", "filename", "format", "header", "null",
+	); err != nil {
+		return fileFDWOptions{}, err
+	}
+	opts := fileFDWOptions{
+		uri:      serverOptions["uri"],
+		filename: tableOptions["filename"],
+		format:   roachpb.IOFileFormat_CSV,
+	}
+	if opts.filename == "" {
+		return fileFDWOptions{}, pgerror.New(pgcode.FdwOptionNameNotFound,
+			`option "filename" is required`)
+	}
+	if format, ok := tableOptions["format"]; ok {
+		switch strings.ToLower(format) {
+		case "csv":
+		case "avro":
+			opts.format = roachpb.IOFileFormat_Avro
+		case "parquet":
+			opts.format = roachpb.IOFileFormat_Parquet
+		default:
+			return fileFDWOptions{}, pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+				"format %q not recognized; expected csv, avro or parquet", format)
+		}
+	}
+	for _, name := range []string{"delimiter", "header", "null"} {
+		if _, ok := tableOptions[name]; ok && opts.format != roachpb.IOFileFormat_CSV {
+			return fileFDWOptions{}, pgerror.Newf(pgcode.FdwInvalidOptionName,
+				"option %q is only supported with format csv", name)
+		}
+	}
+	if delimiter, ok := tableOptions["delimiter"]; ok {
+		r, size := utf8.DecodeRuneInString(delimiter)
+		if size == 0 || size != len(delimiter) {
+			return fileFDWOptions{}, pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+				"delimiter must be a single character, got %q", delimiter)
+		}
+		opts.csv.Comma = r
+	}
+	if null, ok := tableOptions["null"]; ok {
+		opts.csv.NullEncoding = &null
+	}
+	if header, ok := tableOptions["header"]; ok {
+		var err error
+		if opts.header, err = strconv.ParseBool(header); err != nil {
+			return fileFDWOptions{}, pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+				"header must be a boolean, got %q", header)
+		}
+	}
+	return opts, nil
+}
+
+// Scan is part of the sql.ForeignDataWrapper interface.
+func (fileFDW) Scan(
+	ctx context.Context, params sql.ForeignScanParams, push func(tree.Datums) error,
+) error {
+	opts, err := parseFileFDWOptions(params.ServerOptions, params.TableOptions)
+	if err != nil {
+		return err
+	}
+	store, err := params.ExecCfg.DistSQLSrv.ExternalStorageFromURI(ctx, opts.uri, params.User)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	files := []string{opts.filename}
+	if strings.HasSuffix(opts.filename, "/") {
+		files = files[:0]
+		if err := store.List(ctx, opts.filename, "" /* delimiter */, func(name string) error {
+			files = append(files, opts.filename+strings.TrimPrefix(name, "/"))
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The file readers fill in the values of all of the visible columns of the
+	// table, from which the requested columns are returned.
+	visibleCols := params.Table.VisibleColumns()
+	conv := &row.DatumRowConverter{
+		Datums:          make(tree.Datums, len(visibleCols)),
+		EvalCtx:         params.EvalCtx,
+		SemaCtx:         &tree.SemaContext{},
+		VisibleCols:     visibleCols,
+		VisibleColTypes: make([]*types.T, len(visibleCols)),
+	}
+	visibleIdx := make(map[descpb.ColumnID]int, len(visibleCols))
+	for i, col := range visibleCols {
+		conv.VisibleColTypes[i] = col.GetType()
+		conv.TargetColOrds.Add(i)
+		visibleIdx[col.GetID()] = i
+	}
+	emit := func(datums tree.Datums) error {
+		out := make(tree.Datums, len(params.Columns))
+		for i, col := range params.Columns {
+			out[i] = datums[visibleIdx[col.GetID()]]
+		}
+		return push(out)
+	}
+
+	for _, name := range files {
+		if err := readFileFDWFile(ctx, store, name, opts, conv, emit); err != nil {
+			return errors.Wrapf(err, "reading %s", name)
+		}
+	}
+	return nil
+}
+
+// readFileFDWFile reads the rows of a single file, calling emit with the values
+// of the visible columns of each row.
+func readFileFDWFile(
+	ctx context.Context,
+	store cloud.ExternalStorage,
+	name string,
+	opts fileFDWOptions,
+	conv *row.DatumRowConverter,
+	emit func(tree.Datums) error,
+) error {
+	raw, _, err := store.ReadFile(ctx, name, cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return err
+	}
+	defer raw.Close(ctx)
+	input, err := decompressingReader(ioctx.ReaderCtxAdapter(ctx, raw), name, roachpb.IOFileFormat_Auto)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	if opts.format == roachpb.IOFileFormat_Parquet {
+		// Parquet files are read from their footer, so they are buffered in
+		// memory.
+		buf, err := io.ReadAll(input)
+		if err != nil {
+			return err
+		}
+		return parquet.ReadRows(bytes.NewReader(buf), conv.VisibleColTypes, emit)
+	}
+
+	var producer importRowProducer
+	var consumer importRowConsumer
+	switch opts.format {
+	case roachpb.IOFileFormat_CSV:
+		cr := csv.NewReader(input)
+		if opts.csv.Comma != 0 {
+			cr.Comma = opts.csv.Comma
+		}
+		cr.FieldsPerRecord = -1
+		cr.LazyQuotes = true
+		producer = &csvRowProducer{
+			opts:               &opts.csv,
+			csv:                cr,
+			progress:           func() float32 { return 0 },
+			numExpectedColumns: len(conv.VisibleCols),
+		}
+		consumer = &csvRowConsumer{opts: &opts.csv}
+		// Scanning the first record skips the header.
+		if opts.header && !producer.Scan() {
+			return producer.Err()
+		}
+	case roachpb.IOFileFormat_Avro:
+		ocf, err := goavro.NewOCFReader(bufio.NewReaderSize(input, 64<<10))
+		if err != nil {
+			return err
+		}
+		fieldIdxByName := make(map[string]int, len(conv.VisibleCols))
+		for idx, col := range conv.VisibleCols {
+			fieldIdxByName[col.GetName()] = idx
+		}
+		producer = &ocfStream{ocf: ocf}
+		consumer = &avroConsumer{fieldNameToIdx: fieldIdxByName}
+	default:
+		return errors.AssertionFailedf("unexpected file format %s", opts.format)
+	}
+
+	for rowNum := int64(1); producer.Scan(); rowNum++ {
+		data, err := producer.Row()
+		if err != nil {
+			return err
+		}
+		for i := range conv.Datums {
+			conv.Datums[i] = nil
+		}
+		if err := consumer.FillDatums(ctx, data, rowNum, conv); err != nil {
+			return err
+		}
+		if err := emit(conv.Datums); err != nil {
+			return err
+		}
+	}
+	return producer.Err()
+}
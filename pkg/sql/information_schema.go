@@ -818,7 +818,7 @@ https://www.postgresql.org/docs/9.5/infoschema-constraint-column-usage.html`,
 					cols = refTable.ForeignKeyReferencedColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.UniqueWithoutIndexDesc().IsExclusion() {
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for _, col := range cols {
@@ -862,7 +862,7 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 					cols = table.ForeignKeyOriginColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.UniqueWithoutIndexDesc().IsExclusion() {
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for pos, col := range cols {
@@ -1458,6 +1458,10 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for _, c := range table.AllConstraints() {
+					// Like Postgres, exclusion constraints are omitted.
+					if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && uwoi.UniqueWithoutIndexDesc().IsExclusion() {
+						continue
+					}
 					kind := catconstants.ConstraintTypeUnique
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
@@ -1466,6 +1470,9 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
 					}
+					deferrability := constraintDeferrability(c)
+					isDeferrable := yesOrNoDatum(deferrability.IsDeferrable())
+					initiallyDeferred := yesOrNoDatum(deferrability.IsInitiallyDeferred())
 					if err := addRow(
 						dbNameStr,                     // constraint_catalog
 						scNameStr,                     // constraint_schema
@@ -1474,8 +1481,8 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 						scNameStr,                     // table_schema
 						tbNameStr,                     // table_name
 						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						isDeferrable,                  // is_deferrable
+						initiallyDeferred,             // initially_deferred
 					); err != nil {
 						return err
 					}
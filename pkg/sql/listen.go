@@ -0,0 +1,39 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+//
+// Unlike in Postgres, the session starts listening on the channel immediately
+// rather than when the transaction commits.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if p.notificationListener == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "LISTEN is not supported in this session")
+	}
+	return &listenNode{channel: string(n.ChannelName)}, nil
+}
+
+type listenNode struct {
+	zeroInputPlanNode
+	channel string
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	return params.p.notificationListener.Listen(params.ctx, n.channel)
+}
+
+func (n *listenNode) Next(runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums          { return nil }
+func (n *listenNode) Close(context.Context)        {}
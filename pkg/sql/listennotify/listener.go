@@ -0,0 +1,150 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package listennotify
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// maxPendingNotifications is the maximum number of notifications that are
+// queued for a single session before new notifications are dropped. Postgres
+// bounds its notification queue in a similar fashion, although it is shared
+// between all sessions.
+const maxPendingNotifications = 10000
+
+// Listener tracks the channels a single session is listening on and queues the
+// notifications delivered to it until the session is able to send them to its
+// client.
+//
+// A Listener is not registered with its Registry until its first call to
+// Listen. Close must be called when the session is closed.
+type Listener struct {
+	registry *Registry
+
+	// readyCh is signaled (without blocking) whenever a notification is queued.
+	readyCh chan struct{}
+
+	mu struct {
+		syncutil.Mutex
+		// channels is the set of channels the session is listening on.
+		channels map[string]struct{}
+		pending  []Notification
+		// dropped is set when notifications were dropped because the queue was
+		// full, so that the condition is logged once per occurrence.
+		dropped bool
+	}
+}
+
+// Listen registers the session as a listener on the given channel. Listening
+// on a channel more than once is a no-op.
+func (l *Listener) Listen(ctx context.Context, channel string) error {
+	if err := validateChannel(channel); err != nil {
+		return err
+	}
+	if err := l.registry.ensureStarted(ctx); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	if l.mu.channels == nil {
+		l.mu.channels = make(map[string]struct{})
+	}
+	l.mu.channels[channel] = struct{}{}
+	l.mu.Unlock()
+	l.registry.addListener(channel, l)
+	return nil
+}
+
+// Unlisten stops listening on the given channel. Unlistening from a channel
+// which the session is not listening on is a no-op.
+func (l *Listener) Unlisten(channel string) {
+	l.mu.Lock()
+	_, ok := l.mu.channels[channel]
+	delete(l.mu.channels, channel)
+	l.mu.Unlock()
+	if ok {
+		l.registry.removeListener(channel, l)
+	}
+}
+
+// UnlistenAll stops listening on all channels.
+func (l *Listener) UnlistenAll() {
+	l.mu.Lock()
+	channels := l.mu.channels
+	l.mu.channels = nil
+	l.mu.Unlock()
+	for channel := range channels {
+		l.registry.removeListener(channel, l)
+	}
+}
+
+// Channels returns the sorted list of channels the session is listening on.
+func (l *Listener) Channels() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	channels := make([]string, 0, len(l.mu.channels))
+	for channel := range l.mu.channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Ready returns a channel which is signaled when notifications are queued for
+// the session. A signal may be spurious; callers should use Pending to
+// retrieve the queued notifications.
+func (l *Listener) Ready() <-chan struct{} {
+	return l.readyCh
+}
+
+// Pending returns and clears the notifications queued for the session, in the
+// order in which they were committed.
+func (l *Listener) Pending() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending := l.mu.pending
+	l.mu.pending = nil
+	l.mu.dropped = false
+	return pending
+}
+
+// HasPending returns whether any notifications are queued for the session.
+func (l *Listener) HasPending() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.mu.pending) > 0
+}
+
+// Close unregisters the listener from all channels.
+func (l *Listener) Close() {
+	l.UnlistenAll()
+}
+
+// deliver queues the notification for the session if it is still listening on
+// the notification's channel.
+func (l *Listener) deliver(ctx context.Context, n Notification) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.mu.channels[n.Channel]; !ok {
+		return
+	}
+	if len(l.mu.pending) >= maxPendingNotifications {
+		if !l.mu.dropped {
+			log.Warningf(ctx, "dropping notifications for session: "+
+				"more than %d notifications are pending", maxPendingNotifications)
+			l.mu.dropped = true
+		}
+		return
+	}
+	l.mu.pending = append(l.mu.pending, n)
+	select {
+	case l.readyCh <- struct{}{}:
+	default:
+	}
+}
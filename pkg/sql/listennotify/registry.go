@@ -0,0 +1,402 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package listennotify implements the cluster-wide delivery of notifications
+// for the LISTEN and NOTIFY statements.
+//
+// NOTIFY inserts a row into the system.notifications table as part of the
+// notifying transaction, so that notifications are only delivered if the
+// transaction commits. Each SQL server which has at least one listening
+// session runs a rangefeed over the table and fans the committed notifications
+// out to its listening sessions. Notifications are delivered in the order of
+// their commit timestamps once the rangefeed frontier has passed them, which
+// also guarantees that the notifications of a single transaction are delivered
+// together.
+package listennotify
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// MaxPayloadLen is the maximum length of a notification payload. Taken from
+// Postgres, see NOTIFY_PAYLOAD_MAX_LENGTH.
+const MaxPayloadLen = 8000 - 1
+
+// maxChannelLen is the maximum length of a channel name. Taken from Postgres,
+// see NAMEDATALEN.
+const maxChannelLen = 63
+
+// CleanupInterval is the interval at which expired notifications are deleted
+// from the system.notifications table.
+var CleanupInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.notifications.cleanup.interval",
+	"duration between attempts to delete expired rows from system.notifications",
+	time.Minute,
+	settings.PositiveDuration,
+)
+
+// Retention is the duration for which notifications are kept in the
+// system.notifications table before they are deleted.
+var Retention = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.notifications.retention",
+	"duration for which rows in system.notifications are retained after the notification was sent",
+	time.Hour,
+	settings.PositiveDuration,
+)
+
+// cleanupBatchSize is the maximum number of rows deleted by a single
+// statement during cleanup.
+const cleanupBatchSize = 1000
+
+// Notification is a message sent by NOTIFY or pg_notify().
+type Notification struct {
+	// Channel is the name of the channel the notification was sent on.
+	Channel string
+	// Payload is the (possibly empty) payload of the notification.
+	Payload string
+	// SenderPID is the pg_backend_pid() of the sending session.
+	SenderPID int32
+}
+
+// bufferedNotification is a notification received by the rangefeed which has
+// not yet been delivered, because the rangefeed frontier has not yet passed
+// its timestamp.
+type bufferedNotification struct {
+	ts hlc.Timestamp
+	id int64
+	Notification
+}
+
+// Registry manages the rangefeed over the system.notifications table on a SQL
+// server and delivers the notifications to the listening sessions.
+type Registry struct {
+	ambientCtx log.AmbientContext
+	codec      keys.SQLCodec
+	clock      *hlc.Clock
+	f          *rangefeed.Factory
+	stopper    *stop.Stopper
+	st         *cluster.Settings
+	db         isql.DB
+	resolver   catalog.SystemTableIDResolver
+
+	mu struct {
+		syncutil.Mutex
+		// rf is the rangefeed over the notifications table. It is started
+		// lazily when the first session listens on a channel.
+		rf *rangefeed.RangeFeed
+		// stopped is set once the stopper has closed the rangefeed, after which
+		// no new rangefeed may be started.
+		stopped bool
+		// listeners maps channel names to the set of listening sessions.
+		listeners map[string]map[*Listener]struct{}
+	}
+
+	// buffer is only accessed by the rangefeed callbacks, which are not
+	// invoked concurrently.
+	buffer struct {
+		dec     rowDecoder
+		pending []bufferedNotification
+		// frontier is the timestamp up to which all notifications have been
+		// delivered. Notifications at or below it are duplicates.
+		frontier hlc.Timestamp
+	}
+}
+
+// NewRegistry constructs a new Registry.
+func NewRegistry(
+	ambientCtx log.AmbientContext,
+	codec keys.SQLCodec,
+	clock *hlc.Clock,
+	f *rangefeed.Factory,
+	stopper *stop.Stopper,
+	st *cluster.Settings,
+	db isql.DB,
+	resolver catalog.SystemTableIDResolver,
+) *Registry {
+	r := &Registry{
+		ambientCtx: ambientCtx,
+		codec:      codec,
+		clock:      clock,
+		f:          f,
+		stopper:    stopper,
+		st:         st,
+		db:         db,
+		resolver:   resolver,
+	}
+	r.mu.listeners = make(map[string]map[*Listener]struct{})
+	r.buffer.dec = makeRowDecoder(codec)
+	return r
+}
+
+// Start runs the loop which deletes expired notifications, and arranges for
+// the rangefeed to be closed when the server stops.
+func (r *Registry) Start(ctx context.Context) error {
+	r.stopper.AddCloser(stop.CloserFn(r.close))
+	return r.stopper.RunAsyncTask(ctx, "notifications-cleanup", r.cleanupLoop)
+}
+
+// close closes the rangefeed over the notifications table, if it was started.
+func (r *Registry) close() {
+	r.mu.Lock()
+	r.mu.stopped = true
+	rf := r.mu.rf
+	r.mu.rf = nil
+	r.mu.Unlock()
+	// The rangefeed callbacks acquire the mutex, so the rangefeed must be
+	// closed without holding it.
+	if rf != nil {
+		rf.Close()
+	}
+}
+
+// NewListener returns a new Listener for a session.
+func (r *Registry) NewListener() *Listener {
+	return &Listener{
+		registry: r,
+		readyCh:  make(chan struct{}, 1),
+	}
+}
+
+// Notify sends a notification on the given channel as part of the given
+// transaction. The notification is delivered to the listening sessions if and
+// when the transaction commits.
+func (r *Registry) Notify(
+	ctx context.Context, txn isql.Txn, channel, payload string, senderPID int32,
+) error {
+	if err := r.checkVersion(ctx); err != nil {
+		return err
+	}
+	if err := validateChannel(channel); err != nil {
+		return err
+	}
+	if len(payload) > MaxPayloadLen {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	if _, err := txn.ExecEx(
+		ctx,
+		"insert-notification",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.notifications (channel, payload, sender_pid) VALUES ($1, $2, $3)`,
+		channel,
+		payload,
+		senderPID,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *Registry) checkVersion(ctx context.Context) error {
+	// The system.notifications table does not exist until the cluster is
+	// upgraded.
+	if !r.st.Version.IsActive(ctx, clusterversion.V25_3_NotificationsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"LISTEN and NOTIFY are unsupported in mixed-version cluster")
+	}
+	return nil
+}
+
+func validateChannel(channel string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxChannelLen {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	return nil
+}
+
+// ensureStarted starts the rangefeed over the notifications table if it has
+// not been started yet.
+func (r *Registry) ensureStarted(ctx context.Context) error {
+	if err := r.checkVersion(ctx); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mu.stopped {
+		return stop.ErrUnavailable
+	}
+	if r.mu.rf != nil {
+		return nil
+	}
+	tableID, err := r.resolver.LookupSystemTableID(ctx, systemschema.NotificationsTable.GetName())
+	if err != nil {
+		return err
+	}
+	prefix := r.codec.TablePrefix(uint32(tableID))
+	span := roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+
+	// The rangefeed outlives the session which started it, so it must not
+	// inherit its context.
+	rfCtx := r.ambientCtx.AnnotateCtx(context.Background())
+	rf, err := r.f.RangeFeed(
+		rfCtx,
+		"notifications",
+		[]roachpb.Span{span},
+		r.clock.Now(),
+		r.onValue,
+		rangefeed.WithSystemTablePriority(),
+		rangefeed.WithOnFrontierAdvance(r.onFrontierAdvance),
+	)
+	if err != nil {
+		return err
+	}
+	r.mu.rf = rf
+	return nil
+}
+
+func (r *Registry) addListener(channel string, l *Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners, ok := r.mu.listeners[channel]
+	if !ok {
+		listeners = make(map[*Listener]struct{})
+		r.mu.listeners[channel] = listeners
+	}
+	listeners[l] = struct{}{}
+}
+
+func (r *Registry) removeListener(channel string, l *Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners := r.mu.listeners[channel]
+	delete(listeners, l)
+	if len(listeners) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// onValue buffers the notification until the rangefeed frontier passes its
+// timestamp.
+func (r *Registry) onValue(ctx context.Context, kv *kvpb.RangeFeedValue) {
+	if kv.Value.Timestamp.LessEq(r.buffer.frontier) {
+		// The rangefeed was restarted and is replaying notifications which have
+		// already been delivered.
+		return
+	}
+	n, id, tombstone, err := r.buffer.dec.decodeRow(roachpb.KeyValue{
+		Key:   kv.Key,
+		Value: kv.Value,
+	})
+	if err != nil {
+		log.Warningf(ctx, "failed to decode notification row %v: %v", kv.Key, err)
+		return
+	}
+	if tombstone {
+		// Rows are only deleted once they have expired.
+		return
+	}
+	r.buffer.pending = append(r.buffer.pending, bufferedNotification{
+		ts:           kv.Value.Timestamp,
+		id:           id,
+		Notification: n,
+	})
+}
+
+// onFrontierAdvance delivers all buffered notifications at or below the new
+// frontier in commit order.
+func (r *Registry) onFrontierAdvance(ctx context.Context, frontier hlc.Timestamp) {
+	r.buffer.frontier.Forward(frontier)
+	pending := r.buffer.pending
+	if len(pending) == 0 {
+		return
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].ts != pending[j].ts {
+			return pending[i].ts.Less(pending[j].ts)
+		}
+		return pending[i].id < pending[j].id
+	})
+	var i int
+	for ; i < len(pending) && pending[i].ts.LessEq(frontier); i++ {
+		if i > 0 && pending[i].ts == pending[i-1].ts && pending[i].id == pending[i-1].id {
+			// The rangefeed may emit the same value more than once.
+			continue
+		}
+		r.dispatch(ctx, pending[i].Notification)
+	}
+	r.buffer.pending = append(pending[:0], pending[i:]...)
+}
+
+func (r *Registry) dispatch(ctx context.Context, n Notification) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for l := range r.mu.listeners[n.Channel] {
+		l.deliver(ctx, n)
+	}
+}
+
+func (r *Registry) cleanupLoop(ctx context.Context) {
+	ctx, cancel := r.stopper.WithCancelOnQuiesce(ctx)
+	defer cancel()
+	var t timeutil.Timer
+	defer t.Stop()
+	t.Reset(CleanupInterval.Get(&r.st.SV))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := r.deleteExpiredNotifications(ctx); err != nil && ctx.Err() == nil {
+				log.Warningf(ctx, "failed to delete expired notifications: %v", err)
+			}
+			t.Reset(CleanupInterval.Get(&r.st.SV))
+		}
+	}
+}
+
+// deleteExpiredNotifications deletes the notifications which are older than
+// the retention period. Every server performs the cleanup, regardless of
+// whether it sent any notifications, since the rows of a server which has
+// stopped must be deleted as well. The notifications table is ordered by its
+// creation timestamp, so that the deletion only scans the expired rows.
+func (r *Registry) deleteExpiredNotifications(ctx context.Context) error {
+	if !r.st.Version.IsActive(ctx, clusterversion.V25_3_NotificationsTable) {
+		return nil
+	}
+	cutoff := timeutil.Now().Add(-Retention.Get(&r.st.SV))
+	for {
+		n, err := r.db.Executor().ExecEx(
+			ctx,
+			"delete-expired-notifications",
+			nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.notifications WHERE created < $1 LIMIT $2`,
+			cutoff,
+			cleanupBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+		if n < cleanupBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package listennotify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegistryDelivery tests that notifications received by the rangefeed are
+// delivered to the listening sessions in commit order once the frontier has
+// passed them, and that duplicates and deletions are ignored.
+func TestRegistryDelivery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	r := NewRegistry(
+		log.MakeTestingAmbientCtxWithNewTracer(), keys.SystemSQLCodec,
+		nil /* clock */, nil /* f */, nil /* stopper */, nil /* st */, nil /* db */, nil, /* resolver */
+	)
+	listen := func(l *Listener, channels ...string) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.mu.channels = make(map[string]struct{})
+		for _, channel := range channels {
+			l.mu.channels[channel] = struct{}{}
+			r.addListener(channel, l)
+		}
+	}
+	a, b := r.NewListener(), r.NewListener()
+	listen(a, "foo")
+	listen(b, "foo", "bar")
+
+	table := systemschema.NotificationsTable
+	var colMap catalog.TableColMap
+	for i, col := range table.PublicColumns() {
+		colMap.Set(col.GetID(), i)
+	}
+	value := func(wallTime int64, id int64, channel, payload string, deleted bool) *kvpb.RangeFeedValue {
+		datums := []tree.Datum{
+			tree.MustMakeDTimestampTZ(time.Unix(0, wallTime), time.Microsecond),
+			tree.NewDInt(tree.DInt(id)),
+			tree.NewDString(channel),
+			tree.NewDString(payload),
+			tree.NewDInt(1),
+		}
+		entries, err := rowenc.EncodePrimaryIndex(
+			keys.SystemSQLCodec, table, table.GetPrimaryIndex(), colMap, datums, true, /* includeEmpty */
+		)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		v := &kvpb.RangeFeedValue{Key: entries[0].Key, Value: entries[0].Value}
+		if deleted {
+			v.Value.RawBytes = nil
+		}
+		v.Value.Timestamp = hlc.Timestamp{WallTime: wallTime}
+		return v
+	}
+
+	r.onValue(ctx, value(20, 2, "foo", "second", false))
+	r.onValue(ctx, value(10, 1, "foo", "first", false))
+	r.onValue(ctx, value(20, 3, "bar", "third", false))
+	r.onValue(ctx, value(30, 4, "foo", "fourth", false))
+	r.onValue(ctx, value(25, 1, "foo", "", true /* deleted */))
+	// The rangefeed may emit duplicates.
+	r.onValue(ctx, value(20, 2, "foo", "second", false))
+
+	// Nothing is delivered until the frontier advances.
+	require.False(t, a.HasPending())
+	require.False(t, b.HasPending())
+
+	r.onFrontierAdvance(ctx, hlc.Timestamp{WallTime: 25})
+	payloads := func(l *Listener) []string {
+		var res []string
+		for _, n := range l.Pending() {
+			res = append(res, n.Payload)
+		}
+		return res
+	}
+	select {
+	case <-a.Ready():
+	default:
+		t.Fatal("expected listener to be signaled")
+	}
+	require.Equal(t, []string{"first", "second"}, payloads(a))
+	require.Equal(t, []string{"first", "second", "third"}, payloads(b))
+
+	// Values at or below the frontier are replays and are ignored.
+	r.onValue(ctx, value(20, 2, "foo", "second", false))
+	b.Unlisten("foo")
+	r.onFrontierAdvance(ctx, hlc.Timestamp{WallTime: 30})
+	require.Equal(t, []string{"fourth"}, payloads(a))
+	require.Empty(t, payloads(b))
+	require.Equal(t, []string{"bar"}, b.Channels())
+
+	a.Close()
+	b.Close()
+	require.Empty(t, r.mu.listeners)
+}
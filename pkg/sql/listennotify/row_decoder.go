@@ -0,0 +1,77 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package listennotify
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// rowDecoder decodes rows from the system.notifications table.
+type rowDecoder struct {
+	codec   keys.SQLCodec
+	alloc   tree.DatumAlloc
+	columns []catalog.Column
+	decoder valueside.Decoder
+}
+
+func makeRowDecoder(codec keys.SQLCodec) rowDecoder {
+	columns := systemschema.NotificationsTable.PublicColumns()
+	return rowDecoder{
+		codec:   codec,
+		columns: columns,
+		decoder: valueside.MakeDecoder(columns),
+	}
+}
+
+// decodeRow decodes a row of the system.notifications table. The returned id
+// is the row's unique_rowid() primary key column, which is used to break ties
+// between notifications written at the same MVCC timestamp. If the value is
+// not present (i.e. the row was deleted), tombstone is set.
+func (d *rowDecoder) decodeRow(
+	kv roachpb.KeyValue,
+) (_ Notification, id int64, tombstone bool, _ error) {
+	// The primary key is (created, id).
+	keyVals := make([]rowenc.EncDatum, 2)
+	if _, err := rowenc.DecodeIndexKey(d.codec, keyVals, nil, kv.Key); err != nil {
+		return Notification{}, 0, false, errors.Wrap(err, "failed to decode key")
+	}
+	if err := keyVals[1].EnsureDecoded(d.columns[1].GetType(), &d.alloc); err != nil {
+		return Notification{}, 0, false, err
+	}
+	id = int64(tree.MustBeDInt(keyVals[1].Datum))
+	if !kv.Value.IsPresent() {
+		return Notification{}, id, true, nil
+	}
+
+	// The rest of the columns are stored as a family.
+	bytes, err := kv.Value.GetTuple()
+	if err != nil {
+		return Notification{}, 0, false, err
+	}
+	datums, err := d.decoder.Decode(&d.alloc, bytes)
+	if err != nil {
+		return Notification{}, 0, false, err
+	}
+
+	var n Notification
+	if channel := datums[2]; channel != tree.DNull {
+		n.Channel = string(tree.MustBeDString(channel))
+	}
+	if payload := datums[3]; payload != tree.DNull {
+		n.Payload = string(tree.MustBeDString(payload))
+	}
+	if pid := datums[4]; pid != tree.DNull {
+		n.SenderPID = int32(tree.MustBeDInt(pid))
+	}
+	return n, id, false, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	return &notifyNode{channel: string(n.ChannelName), payload: n.Payload}, nil
+}
+
+type notifyNode struct {
+	zeroInputPlanNode
+	channel string
+	payload string
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.SendNotification(params.ctx, n.channel, n.payload)
+}
+
+func (n *notifyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums          { return nil }
+func (n *notifyNode) Close(context.Context)        {}
+
+// SendNotification is part of the eval.Planner interface.
+func (p *planner) SendNotification(ctx context.Context, channel, payload string) error {
+	registry := p.execCfg.NotificationRegistry
+	if registry == nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "NOTIFY is not supported by this server")
+	}
+	pid := int32(p.extendedEvalCtx.QueryCancelKey.GetPGBackendPID())
+	return registry.Notify(ctx, p.InternalSQLTxn(), channel, payload, pid)
+}
@@ -38,6 +38,10 @@ const (
 const (
 	T_jsonpath  = oid.Oid(4072)
 	T__jsonpath = oid.Oid(4073)
+
+	T_anycompatible         = oid.Oid(5077)
+	T_anycompatiblearray    = oid.Oid(5078)
+	T_anycompatiblenonarray = oid.Oid(5079)
 )
 
 // ExtensionTypeName returns a mapping from extension oids
@@ -53,6 +57,10 @@ var ExtensionTypeName = map[oid.Oid]string{
 	T__pgvector:  "_VECTOR",
 	T_jsonpath:   "JSONPATH",
 	T__jsonpath:  "_JSONPATH",
+
+	T_anycompatible:         "ANYCOMPATIBLE",
+	T_anycompatiblearray:    "ANYCOMPATIBLEARRAY",
+	T_anycompatiblenonarray: "ANYCOMPATIBLENONARRAY",
 }
 
 // TypeName checks the name for a given type by first looking up oid.TypeName
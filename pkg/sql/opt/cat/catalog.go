@@ -284,6 +284,11 @@ type Catalog interface {
 	// (specified by routineOid) owner.
 	GetRoutineOwner(ctx context.Context, routineOid oid.Oid) (username.SQLUsername, error)
 
+	// PushRoutineConfig applies the given session variable overrides for the
+	// duration of a routine's planning and execution. The returned pop function
+	// must be called to restore the previous session settings.
+	PushRoutineConfig(ctx context.Context, config tree.RoutineConfig) (pop func() error, err error)
+
 	// IsOwner returns true if user is the owner of the object o
 	IsOwner(ctx context.Context, o Object, user username.SQLUsername) (bool, error)
 }
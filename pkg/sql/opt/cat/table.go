@@ -10,6 +10,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -46,6 +47,11 @@ type Table interface {
 	// that they cannot be mutated.
 	IsMaterializedView() bool
 
+	// IsForeignTable returns true if this table is a foreign table, whose rows
+	// are read from an external source through a foreign-data wrapper. Foreign
+	// tables are also virtual tables, and cannot be mutated.
+	IsForeignTable() bool
+
 	// LookupColumnOrdinal returns the ordinal of the column with the given ID.
 	LookupColumnOrdinal(colID descpb.ColumnID) (int, error)
 
@@ -137,6 +143,14 @@ type Table interface {
 	// i < UniqueCount.
 	Unique(i UniqueOrdinal) UniqueConstraint
 
+	// ExclusionCount returns the number of exclusion constraints defined on
+	// this table.
+	ExclusionCount() int
+
+	// Exclusion returns the ith exclusion constraint defined on this table,
+	// where i < ExclusionCount.
+	Exclusion(i ExclusionOrdinal) ExclusionConstraint
+
 	// Zone returns a table's zone.
 	Zone() Zone
 
@@ -189,6 +203,15 @@ type Table interface {
 	// Trigger returns the ith trigger, where i < TriggerCount.
 	Trigger(i int) Trigger
 
+	// InheritedByCount returns the number of tables which directly inherit
+	// from this table. Scans of this table include the rows of those tables,
+	// unless the scan is qualified with ONLY.
+	InheritedByCount() int
+
+	// InheritedBy returns the ID of the ith table which inherits from this
+	// table, where i < InheritedByCount.
+	InheritedBy(i int) StableID
+
 	// IsRowLevelSecurityEnabled is true if policies should be applied during the query.
 	IsRowLevelSecurityEnabled() bool
 
@@ -350,6 +373,12 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrability returns whether the checking of the constraint can be
+	// deferred until the end of the transaction. The optimizer cannot assume
+	// that the data satisfies a deferrable constraint, since it may be
+	// violated in the middle of a transaction.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -403,6 +432,11 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Deferrability returns whether the checking of the constraint can be
+	// deferred until the end of the transaction. See
+	// ForeignKeyConstraint.Deferrability.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
@@ -411,3 +445,52 @@ type UniqueOrdinal = int
 // UniqueOrdinals identifies a list of unique constraints (in the context of
 // a Table).
 type UniqueOrdinals = []UniqueOrdinal
+
+// ExclusionConstraint represents an exclusion constraint, which guarantees
+// that no two rows of the table compare true using all of the constraint's
+// operators. For example, the following statement creates an exclusion
+// constraint that prevents two rows with the same room from having
+// overlapping bounding boxes:
+//
+//	CREATE TABLE t (room INT, box GEOMETRY, EXCLUDE USING gist (room WITH =, box WITH &&));
+//
+// Exclusion constraints are not backed by an index, so the optimizer must add
+// an exclusion check as a postquery to any query that inserts into or updates
+// the constraint's columns.
+type ExclusionConstraint interface {
+	// Name of the exclusion constraint.
+	Name() string
+
+	// TableID returns the stable identifier of the table on which this
+	// exclusion constraint is defined.
+	TableID() StableID
+
+	// ColumnCount returns the number of columns in this constraint.
+	ColumnCount() int
+
+	// ColumnOrdinal returns the table column ordinal of the ith column in this
+	// constraint.
+	ColumnOrdinal(tab Table, i int) int
+
+	// Operator returns the comparison operator used for the ith column in this
+	// constraint.
+	Operator(i int) treecmp.ComparisonOperator
+
+	// Predicate returns the partial predicate expression and true if the
+	// constraint is a partial exclusion constraint. If it is not, the empty
+	// string and false are returned.
+	Predicate() (string, bool)
+
+	// Validated is true if the constraint is validated (i.e. we know that the
+	// existing data satisfies the constraint).
+	Validated() bool
+
+	// Deferrability returns whether the checking of the constraint can be
+	// deferred until the end of the transaction. See
+	// ForeignKeyConstraint.Deferrability.
+	Deferrability() tree.ConstraintDeferrability
+}
+
+// ExclusionOrdinal identifies an exclusion constraint (in the context of a
+// Table).
+type ExclusionOrdinal = int
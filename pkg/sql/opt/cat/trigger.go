@@ -237,11 +237,46 @@ func HasRowLevelTriggers(
 // the order in which they should be executed.
 func GetRowLevelTriggers(
 	tab Table, actionTime tree.TriggerActionTime, eventsToMatch tree.TriggerEventTypeSet,
+) []Trigger {
+	return getTriggers(tab, actionTime, true /* forEachRow */, eventsToMatch)
+}
+
+// GetStatementLevelTriggers returns the set of statement-level triggers for
+// the given table and given trigger event type and timing. The triggers are
+// returned in the order in which they should be executed.
+func GetStatementLevelTriggers(
+	tab Table, actionTime tree.TriggerActionTime, eventsToMatch tree.TriggerEventTypeSet,
+) []Trigger {
+	return getTriggers(tab, actionTime, false /* forEachRow */, eventsToMatch)
+}
+
+// GetInsteadOfTriggers returns the set of INSTEAD OF triggers for the given
+// view and trigger event type. The triggers are returned in the order in which
+// they should be executed.
+func GetInsteadOfTriggers(view View, eventToMatch tree.TriggerEventType) []Trigger {
+	var eventsToMatch tree.TriggerEventTypeSet
+	eventsToMatch.Add(eventToMatch)
+	return getTriggers(
+		view, tree.TriggerActionTimeInsteadOf, true /* forEachRow */, eventsToMatch,
+	)
+}
+
+// triggerSource is a table or view, which can have triggers.
+type triggerSource interface {
+	TriggerCount() int
+	Trigger(i int) Trigger
+}
+
+func getTriggers(
+	tab triggerSource,
+	actionTime tree.TriggerActionTime,
+	forEachRow bool,
+	eventsToMatch tree.TriggerEventTypeSet,
 ) []Trigger {
 	var neededTriggers intsets.Fast
 	for i := 0; i < tab.TriggerCount(); i++ {
 		trigger := tab.Trigger(i)
-		if !trigger.Enabled() || !trigger.ForEachRow() ||
+		if !trigger.Enabled() || trigger.ForEachRow() != forEachRow ||
 			trigger.ActionTime() != actionTime {
 			continue
 		}
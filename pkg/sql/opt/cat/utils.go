@@ -117,6 +117,24 @@ func FormatTable(
 		}
 	}
 
+	for i := 0; i < tab.ExclusionCount(); i++ {
+		excl := tab.Exclusion(i)
+		buf.Reset()
+		buf.WriteString("EXCLUDE (")
+		for j := 0; j < excl.ColumnCount(); j++ {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(&buf, "%s WITH %s",
+				tab.Column(excl.ColumnOrdinal(tab, j)).ColName(), excl.Operator(j))
+		}
+		buf.WriteString(")")
+		c := child.Child(buf.String())
+		if pred, isPartial := excl.Predicate(); isPartial {
+			c.Childf("WHERE %s", MaybeMarkRedactable(pred, redactableValues))
+		}
+	}
+
 	// TODO(radu): show stats.
 }
 
@@ -125,6 +125,14 @@ type Builder struct {
 	// subqueries for statements inside a UDF.
 	planLazySubqueries bool
 
+	// allowRoutineOuterWithRefs is true if the bodies of routines built by the
+	// builder can reference With expressions that are built outside of the
+	// routine. This is the case for routines built as part of a post-query, since
+	// the functions of AFTER triggers can reference transition relations, which
+	// are built as With expressions by the post-query. It is inherited by the
+	// builders of nested routines.
+	allowRoutineOuterWithRefs bool
+
 	// tailCalls is used when building the last body statement of a routine. It
 	// identifies nested routines that are in tail-call position. This information
 	// is used to determine whether tail-call optimization is applicable.
@@ -196,6 +196,11 @@ func (b *Builder) tryBuildFastPathInsert(
 	if ins.VectorInsert {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
+	// Do not attempt the fast path if any of the checks are for deferrable
+	// constraints, since the fast path reports violations directly.
+	if hasDeferrableChecks(b.mem.Metadata(), ins.UniqueChecks, ins.FKChecks) {
+		return execPlan{}, colOrdMap{}, false, nil
+	}
 
 	insInput := ins.Input
 	values, ok := insInput.(*memo.ValuesExpr)
@@ -868,7 +873,10 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
-			return mkUniqueCheckErr(md, c, keyVals)
+			if c.Exclusion {
+				return maybeWrapDeferrableUniqueCheckErr(md, c, mkExclusionCheckErr(md, c, keyVals))
+			}
+			return maybeWrapDeferrableUniqueCheckErr(md, c, mkUniqueCheckErr(md, c, keyVals))
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
 		if err != nil {
@@ -900,7 +908,7 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
-			return mkFKCheckErr(md, c, keyVals)
+			return maybeWrapDeferrableFKCheckErr(md, c, mkFKCheckErr(md, c, keyVals))
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
 		if err != nil {
@@ -911,6 +919,69 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// checkedForeignKey returns the foreign key constraint which is enforced by the
+// given FK check.
+func checkedForeignKey(md *opt.Metadata, c *memo.FKChecksItem) cat.ForeignKeyConstraint {
+	if c.FKOutbound {
+		return md.Table(c.OriginTable).OutboundForeignKey(c.FKOrdinal)
+	}
+	return md.Table(c.ReferencedTable).InboundForeignKey(c.FKOrdinal)
+}
+
+// hasDeferrableChecks returns true if any of the given checks enforce a
+// DEFERRABLE constraint.
+func hasDeferrableChecks(
+	md *opt.Metadata, uniqueChecks memo.UniqueChecksExpr, fkChecks memo.FKChecksExpr,
+) bool {
+	for i := range uniqueChecks {
+		c := &uniqueChecks[i]
+		if c.Exclusion {
+			if md.Table(c.Table).Exclusion(c.CheckOrdinal).Deferrability().IsDeferrable() {
+				return true
+			}
+			continue
+		}
+		if md.Table(c.Table).Unique(c.CheckOrdinal).Deferrability().IsDeferrable() {
+			return true
+		}
+	}
+	for i := range fkChecks {
+		if checkedForeignKey(md, &fkChecks[i]).Deferrability().IsDeferrable() {
+			return true
+		}
+	}
+	return false
+}
+
+// maybeWrapDeferrableUniqueCheckErr wraps the given uniqueness violation in an
+// exec.DeferrableConstraintViolation if the checked constraint is DEFERRABLE.
+func maybeWrapDeferrableUniqueCheckErr(
+	md *opt.Metadata, c *memo.UniqueChecksItem, err error,
+) error {
+	if c.Exclusion {
+		ec := md.Table(c.Table).Exclusion(c.CheckOrdinal)
+		if d := ec.Deferrability(); d.IsDeferrable() {
+			return exec.NewDeferrableConstraintViolation(err, ec.TableID(), ec.Name(), d.IsInitiallyDeferred())
+		}
+		return err
+	}
+	uc := md.Table(c.Table).Unique(c.CheckOrdinal)
+	if d := uc.Deferrability(); d.IsDeferrable() {
+		return exec.NewDeferrableConstraintViolation(err, uc.TableID(), uc.Name(), d.IsInitiallyDeferred())
+	}
+	return err
+}
+
+// maybeWrapDeferrableFKCheckErr wraps the given foreign key violation in an
+// exec.DeferrableConstraintViolation if the checked constraint is DEFERRABLE.
+func maybeWrapDeferrableFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, err error) error {
+	fk := checkedForeignKey(md, c)
+	if d := fk.Deferrability(); d.IsDeferrable() {
+		return exec.NewDeferrableConstraintViolation(err, fk.OriginTableID(), fk.Name(), d.IsInitiallyDeferred())
+	}
+	return err
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
@@ -953,6 +1024,48 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	)
 }
 
+// mkExclusionCheckErr generates a user-friendly error describing an exclusion
+// constraint violation. The keyVals are the values that correspond to the
+// cat.ExclusionConstraint columns.
+func mkExclusionCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	tabMeta := md.TableMeta(c.Table)
+	ec := tabMeta.Table.Exclusion(c.CheckOrdinal)
+	constraintName := ec.Name()
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k)=(2) conflicts with existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
+
+	details.WriteString("Key (")
+	for i := 0; i < ec.ColumnCount(); i++ {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		col := tabMeta.Table.Column(ec.ColumnOrdinal(tabMeta.Table, i))
+		details.WriteString(string(col.ColName()))
+	}
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+
+	details.WriteString(") conflicts with existing key.")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			constraintName,
+		),
+		details.String(),
+	)
+}
+
 // mkUniqueCheckErrWithoutColNames is a simpler version of mkUniqueCheckErr that
 // omits column names from the error details.
 func mkUniqueCheckErrWithoutColNames(
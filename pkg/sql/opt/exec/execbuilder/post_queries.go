@@ -316,6 +316,9 @@ func (cb *postQueryBuilder) planPostQuery(
 		// Set up the With binding.
 		eb.addBuiltWithExpr(postQueryInputWithID, bufferColMap, bufferRef)
 	}
+	// The functions of AFTER triggers can reference transition relations, which
+	// are built as With expressions in the post-query.
+	eb.allowRoutineOuterWithRefs = true
 	plan, err := eb.Build()
 	if err != nil {
 		return nil, errors.Wrapf(err, "while building %s plan", actionName)
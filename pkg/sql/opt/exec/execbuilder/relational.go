@@ -816,6 +816,7 @@ func (b *Builder) scanParams(
 		Locking:            locking,
 		EstimatedRowCount:  rowCount,
 		LocalityOptimized:  scan.LocalityOptimized,
+		TableSample:        scan.TableSample,
 	}, outputMap, nil
 }
 
@@ -1787,7 +1788,19 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			agg = aggDistinct.Input
 		}
 
-		name, overload := memo.FindAggregateOverload(agg)
+		var name string
+		var distsqlBlocklist bool
+		var userDefined *exec.UserDefinedAggInfo
+		if udAgg, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			// The routines of a user-defined aggregate cannot be serialized, so
+			// the aggregation cannot be distributed.
+			name, distsqlBlocklist = udAgg.Name, true
+			userDefined = b.buildUserDefinedAgg(udAgg)
+		} else {
+			var overload *tree.Overload
+			name, overload = memo.FindAggregateOverload(agg)
+			distsqlBlocklist = overload.DistsqlBlocklist
+		}
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
@@ -1821,7 +1834,8 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			ArgCols:          argCols[:len(argCols):len(argCols)],
 			ConstArgs:        constArgs[:len(constArgs):len(constArgs)],
 			Filter:           filterOrd,
-			DistsqlBlocklist: overload.DistsqlBlocklist,
+			DistsqlBlocklist: distsqlBlocklist,
+			UserDefined:      userDefined,
 		}
 		outputCols.Set(item.Col, len(groupingColIdx)+i)
 		// Slice argCols and constArgs so the rest of their capacity can be
@@ -3586,6 +3600,7 @@ func (b *Builder) buildCall(c *memo.CallExpr) (_ execPlan, outputCols colOrdMap,
 		nil,   /* cursorDeclaration */
 		nil,   /* firstStmtResultWriter */
 	)
+	r.Config = udf.Def.Config
 
 	var ep execPlan
 	ep.root, err = b.factory.ConstructCall(r)
@@ -871,6 +871,7 @@ func (b *Builder) buildSubquery(
 			eb.routineResultBuffers = b.routineResultBuffers
 			eb.disableTelemetry = true
 			eb.planLazySubqueries = true
+			eb.allowRoutineOuterWithRefs = b.allowRoutineOuterWithRefs
 			eb.tailCalls = tailCalls
 			ePlan, _, err := eb.buildRelational(input)
 			if err != nil {
@@ -1012,8 +1013,8 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.Body,
 		udf.Def.BodyProps,
 		udf.Def.BodyStmts,
-		false, /* allowOuterWithRefs */
-		nil,   /* wrapRootExpr */
+		b.allowRoutineOuterWithRefs,
+		nil, /* wrapRootExpr */
 		udf.Def.ResultBufferID,
 	)
 
@@ -1031,7 +1032,7 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	// routine is in tail-call position.
 	_, tailCall := b.tailCalls[udf]
 
-	return tree.NewTypedRoutineExpr(
+	r := tree.NewTypedRoutineExpr(
 		udf.Def.Name,
 		args,
 		planGen,
@@ -1048,7 +1049,9 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		blockState,
 		firstStmtOut.CursorDeclaration,
 		firstStmtResultWriter,
-	), nil
+	)
+	r.Config = udf.Def.Config
+	return r, nil
 }
 
 func (b *Builder) buildRoutineArgs(
@@ -1067,6 +1070,62 @@ func (b *Builder) buildRoutineArgs(
 	return args, nil
 }
 
+// buildUserDefinedAgg builds the routines that compute the given user-defined
+// aggregate function.
+func (b *Builder) buildUserDefinedAgg(agg *memo.UserDefinedAggExpr) *exec.UserDefinedAggInfo {
+	info := &exec.UserDefinedAggInfo{
+		Transition: b.buildAggregateSupportRoutine(agg.Transition),
+		InitValue:  agg.InitValue,
+	}
+	if agg.Final != nil {
+		info.Final = b.buildAggregateSupportRoutine(agg.Final)
+	}
+	return info
+}
+
+// buildAggregateSupportRoutine builds a routine with no arguments for a support
+// function of a user-defined aggregate. The actual arguments are supplied by
+// the aggregator each time the routine is invoked.
+func (b *Builder) buildAggregateSupportRoutine(def *memo.UDFDefinition) *tree.RoutineExpr {
+	for _, s := range def.Body {
+		if s.Relational().CanMutate {
+			b.setMutationFlags(s)
+		}
+	}
+	blockState := def.BlockState
+	if blockState != nil {
+		blockState.VariableCount = len(def.Params)
+		b.initRoutineExceptionHandler(blockState, def.ExceptionBlock)
+	}
+	planGen := b.buildRoutinePlanGenerator(
+		def.Params,
+		def.Body,
+		def.BodyProps,
+		def.BodyStmts,
+		b.allowRoutineOuterWithRefs,
+		nil, /* wrapRootExpr */
+		0,   /* resultBufferID */
+	)
+	return tree.NewTypedRoutineExpr(
+		def.Name,
+		nil, /* args */
+		planGen,
+		def.Typ,
+		def.Volatility == volatility.Volatile, /* enableStepping */
+		def.CalledOnNullInput,
+		false, /* multiColOutput */
+		false, /* generator */
+		false, /* discardLastStmtResult */
+		false, /* tailCall */
+		false, /* procedure */
+		false, /* triggerFunc */
+		def.BlockStart,
+		blockState,
+		nil, /* cursorDeclaration */
+		nil, /* firstStmtResultWriter */
+	)
+}
+
 // initRoutineExceptionHandler initializes the exception handler (if any) for
 // the shared BlockState of a group of sub-routines within a PLpgSQL block.
 func (b *Builder) initRoutineExceptionHandler(
@@ -1085,9 +1144,9 @@ func (b *Builder) initRoutineExceptionHandler(
 			action.Body,
 			action.BodyProps,
 			action.BodyStmts,
-			false, /* allowOuterWithRefs */
-			nil,   /* wrapRootExpr */
-			0,     /* resultBufferID */
+			b.allowRoutineOuterWithRefs,
+			nil, /* wrapRootExpr */
+			0,   /* resultBufferID */
 		)
 		// Build a routine with no arguments for the exception handler. The actual
 		// arguments will be supplied when (if) the handler is invoked.
@@ -1245,6 +1304,16 @@ func (b *Builder) buildRoutinePlanGenerator(
 				return f.CopyAndReplaceDefault(e, replaceFn)
 			}
 			f.CopyAndReplace(originalMemo, stmt, props, replaceFn)
+			if allowOuterWithRefs {
+				// Nested routines can also refer to "outer" With expressions, so add
+				// the With expressions that were not referenced directly by the
+				// statement to the new metadata as well.
+				b.mem.Metadata().ForEachWithBinding(func(id opt.WithID, expr opt.Expr) {
+					if !f.Metadata().HasWithBinding(id) {
+						f.Metadata().AddWithBinding(id, expr)
+					}
+				})
+			}
 
 			if wrapRootExpr != nil {
 				wrapped := wrapRootExpr(f, f.Memo().RootExpr().(memo.RelExpr)).(memo.RelExpr)
@@ -1278,6 +1347,7 @@ func (b *Builder) buildRoutinePlanGenerator(
 			eb.withExprs = withExprs
 			eb.disableTelemetry = true
 			eb.planLazySubqueries = true
+			eb.allowRoutineOuterWithRefs = b.allowRoutineOuterWithRefs
 			eb.tailCalls = tailCalls
 			eb.routineResultBuffers = b.routineResultBuffers
 			if resultBufferID != 0 {
@@ -1390,6 +1460,7 @@ func (b *Builder) buildTxnControl(
 		return f.DetachMemo(), nil
 	}
 	return tree.NewTxnControlExpr(
-		txnExpr.TxnOp, txnExpr.TxnModes, args, gen, txnExpr.Def.Name, txnExpr.Def.Typ,
+		txnExpr.TxnOp, txnExpr.TxnModes, txnExpr.TxnChain, args, gen,
+		txnExpr.Def.Name, txnExpr.Def.Typ,
 	), nil
 }
@@ -610,6 +610,13 @@ func TestExecBuild_subquery_correlated(
 	runExecBuildLogicTest(t, "subquery_correlated")
 }
 
+func TestExecBuild_tablesample(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "tablesample")
+}
+
 func TestExecBuild_topk(
 	t *testing.T,
 ) {
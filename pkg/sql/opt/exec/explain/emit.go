@@ -712,6 +712,10 @@ func (e *emitter) emitNodeAttributes(ctx context.Context, evalCtx *eval.Context,
 			ob.Attr("limit", "")
 		}
 
+		if a.Params.TableSample.Set {
+			ob.Attr("table sample", a.Params.TableSample.String())
+		}
+
 		if a.Params.Parallelize {
 			ob.VAttr("parallel", "")
 		}
@@ -532,6 +532,10 @@ func (u *unknownTable) IsMaterializedView() bool {
 	return false
 }
 
+func (u *unknownTable) IsForeignTable() bool {
+	return false
+}
+
 func (u *unknownTable) LookupColumnOrdinal(descpb.ColumnID) (int, error) {
 	panic(errors.AssertionFailedf("not implemented"))
 }
@@ -608,6 +612,14 @@ func (u *unknownTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("not implemented"))
 }
 
+func (u *unknownTable) ExclusionCount() int {
+	return 0
+}
+
+func (u *unknownTable) Exclusion(i cat.ExclusionOrdinal) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 func (u *unknownTable) Zone() cat.Zone {
 	return cat.EmptyZone()
 }
@@ -670,6 +682,16 @@ func (u *unknownTable) Trigger(i int) cat.Trigger {
 	panic(errors.AssertionFailedf("not implemented"))
 }
 
+// InheritedByCount is part of the cat.Table interface.
+func (u *unknownTable) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (u *unknownTable) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 // IsRowLevelSecurityEnabled is part of the cat.Table interface
 func (u *unknownTable) IsRowLevelSecurityEnabled() bool { return false }
 
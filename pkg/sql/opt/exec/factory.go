@@ -144,6 +144,9 @@ type ScanParams struct {
 	// to work correctly, the execution engine must create a local DistSQL plan
 	// for the main query (subqueries and postqueries need not be local).
 	LocalityOptimized bool
+
+	// If set, the scan only returns a random sample of the rows of the table.
+	TableSample opt.TableSample
 }
 
 // OutputOrdering indicates the required output ordering on a Node that is being
@@ -234,6 +237,23 @@ type AggInfo struct {
 	// DistsqlBlocklist is set to true when this aggregate function cannot be
 	// evaluated in distributed fashion.
 	DistsqlBlocklist bool
+
+	// UserDefined is set if this is a user-defined aggregate function. In that
+	// case, ArgCols contains a single tuple column that packs the arguments of
+	// the aggregate.
+	UserDefined *UserDefinedAggInfo
+}
+
+// UserDefinedAggInfo contains the routines that compute a user-defined
+// aggregate function.
+type UserDefinedAggInfo struct {
+	// Transition is the state transition function. It is invoked with the
+	// current state value followed by the arguments of the aggregate.
+	Transition *tree.RoutineExpr
+	// Final is the final function, which is nil if the aggregate has none.
+	Final *tree.RoutineExpr
+	// InitValue is the initial state value.
+	InitValue tree.Datum
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -382,6 +402,53 @@ type InsertFastPathCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableConstraintViolation wraps the error generated by the check of a
+// DEFERRABLE constraint. It allows the execution engine to decide whether the
+// violation should be reported right away, or whether the constraint should be
+// checked again when the transaction commits.
+type DeferrableConstraintViolation struct {
+	cause error
+
+	// TableID is the ID of the table on which the constraint is defined. For
+	// foreign keys, this is the origin (referencing) table.
+	TableID cat.StableID
+
+	// ConstraintName is the name of the violated constraint.
+	ConstraintName string
+
+	// InitiallyDeferred is true if the checking of the constraint is deferred
+	// unless it was made immediate with SET CONSTRAINTS.
+	InitiallyDeferred bool
+}
+
+// NewDeferrableConstraintViolation wraps the given constraint violation error
+// in a DeferrableConstraintViolation.
+func NewDeferrableConstraintViolation(
+	cause error, tableID cat.StableID, constraintName string, initiallyDeferred bool,
+) error {
+	return &DeferrableConstraintViolation{
+		cause:             cause,
+		TableID:           tableID,
+		ConstraintName:    constraintName,
+		InitiallyDeferred: initiallyDeferred,
+	}
+}
+
+// Error implements the error interface.
+func (e *DeferrableConstraintViolation) Error() string {
+	return e.cause.Error()
+}
+
+// Cause implements the causer interface.
+func (e *DeferrableConstraintViolation) Cause() error {
+	return e.cause
+}
+
+// Unwrap implements the wrapper interface.
+func (e *DeferrableConstraintViolation) Unwrap() error {
+	return e.cause
+}
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
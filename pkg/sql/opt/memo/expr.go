@@ -727,6 +727,19 @@ type UDFDefinition struct {
 	// RoutineLang indicates the language of the routine (SQL or PL/pgSQL).
 	RoutineLang tree.RoutineLanguage
 
+	// Cost is the user-provided estimated execution cost of the routine, in
+	// units of cpu_operator_cost. It is zero if it was not specified.
+	Cost float64
+
+	// Rows is the user-provided estimated number of rows returned by a
+	// set-returning routine. It is zero if it was not specified.
+	Rows float64
+
+	// Config contains the session variable overrides that are applied while the
+	// routine executes. Like TriggerFunc, it is only set for the outermost
+	// routine, and not for any sub-routines that implement the PL/pgSQL body.
+	Config tree.RoutineConfig
+
 	// Params is the list of columns representing parameters of the function. The
 	// i-th column in the list corresponds to the i-th parameter of the function.
 	// During execution of the UDF, these columns are replaced with the arguments
@@ -860,15 +873,21 @@ func (f *WindowFrame) String() string {
 // primary index Scan operator (i.e. unconstrained and not limited).
 // s.InvertedConstraint is implicitly nil because a primary index cannot
 // be inverted.
+//
+// Sampled scans are not canonical, so exploration rules never replace them with
+// scans over other indexes, or constrain or limit them.
 func (s *ScanPrivate) IsCanonical() bool {
 	return s.Index == cat.PrimaryIndex &&
 		s.Constraint == nil &&
 		s.HardLimit == 0 &&
-		!s.LocalityOptimized
+		!s.LocalityOptimized &&
+		!s.TableSample.Set
 }
 
 // IsUnfiltered returns true if the ScanPrivate will produce all rows in the
-// table.
+// table. Sampled scans are treated as unfiltered since they may read the whole
+// table, so callers which rely on all rows being produced must also check
+// TableSample.
 func (s *ScanPrivate) IsUnfiltered(md *opt.Metadata) bool {
 	return (s.Constraint == nil || s.Constraint.IsUnconstrained()) &&
 		s.InvertedConstraint == nil &&
@@ -1392,7 +1411,9 @@ type AfterTriggers struct {
 	Builder PostQueryBuilder
 
 	// WithID identifies the buffer for the mutation input in the original
-	// expression tree. It is always nonzero.
+	// expression tree. It is zero if the triggers don't need the modified rows,
+	// which is only the case for statement-level triggers that don't reference
+	// transition relations.
 	WithID opt.WithID
 }
 
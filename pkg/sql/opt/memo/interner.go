@@ -526,6 +526,14 @@ func (h *hasher) HashScanFlags(val ScanFlags) {
 	}
 }
 
+func (h *hasher) HashTableSample(val opt.TableSample) {
+	h.HashBool(val.Set)
+	h.HashInt(int(val.Method))
+	h.HashFloat64(val.Probability)
+	h.HashBool(val.Repeatable)
+	h.HashFloat64(val.Seed)
+}
+
 func (h *hasher) HashJoinFlags(val JoinFlags) {
 	h.HashUint64(uint64(val))
 }
@@ -1006,6 +1014,10 @@ func (h *hasher) IsScanFlagsEqual(l, r ScanFlags) bool {
 	return l == r
 }
 
+func (h *hasher) IsTableSampleEqual(l, r opt.TableSample) bool {
+	return l == r
+}
+
 func (h *hasher) IsJoinFlagsEqual(l, r JoinFlags) bool {
 	return l == r
 }
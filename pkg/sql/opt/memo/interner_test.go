@@ -448,6 +448,28 @@ func TestInterner(t *testing.T) {
 			},
 		}},
 
+		{hashFn: in.hasher.HashTableSample, eqFn: in.hasher.IsTableSampleEqual, variations: []testVariation{
+			{val1: opt.TableSample{}, val2: opt.TableSample{}, equal: true},
+			{val1: opt.TableSample{Set: true, Probability: 0.1}, val2: opt.TableSample{Set: true, Probability: 0.1}, equal: true},
+			{val1: opt.TableSample{Set: true, Probability: 0.1}, val2: opt.TableSample{Set: true, Probability: 0.2}, equal: false},
+			{val1: opt.TableSample{Set: true, Probability: 0.1}, val2: opt.TableSample{Probability: 0.1}, equal: false},
+			{
+				val1:  opt.TableSample{Set: true, Method: tree.TableSampleBernoulli, Probability: 0.1},
+				val2:  opt.TableSample{Set: true, Method: tree.TableSampleSystem, Probability: 0.1},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{Set: true, Probability: 0.1, Repeatable: true, Seed: 1},
+				val2:  opt.TableSample{Set: true, Probability: 0.1, Repeatable: true, Seed: 2},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{Set: true, Probability: 0.1, Repeatable: true, Seed: 1},
+				val2:  opt.TableSample{Set: true, Probability: 0.1, Repeatable: true, Seed: 1},
+				equal: true,
+			},
+		}},
+
 		{hashFn: in.hasher.HashTransactionModes, eqFn: in.hasher.IsTransactionModesEqual, variations: []testVariation{
 			{
 				val1:  tree.TransactionModes{},
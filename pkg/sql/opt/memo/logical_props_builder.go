@@ -1905,6 +1905,13 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Def.Volatility)
 
+	case *UserDefinedAggExpr:
+		shared.HasUDF = true
+		shared.VolatilitySet.Add(t.Transition.Volatility)
+		if t.Final != nil {
+			shared.VolatilitySet.Add(t.Final.Volatility)
+		}
+
 	default:
 		if opt.IsUnaryOp(e) {
 			inputType := e.Child(0).(opt.ScalarExpr).DataType()
@@ -2097,9 +2104,9 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
-		if !unique.Validated() {
-			// This unique constraint has not been validated, so we cannot use it
-			// as a key.
+		if !unique.Validated() || unique.Deferrability().IsDeferrable() {
+			// This unique constraint has not been validated, or may be violated
+			// in the middle of a transaction, so we cannot use it as a key.
 			continue
 		}
 
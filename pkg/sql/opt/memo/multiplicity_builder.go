@@ -101,7 +101,7 @@ func deriveUnfilteredCols(mem *Memo, in RelExpr) opt.ColSet {
 		// is the case we must bubble up non-output columns.
 		md := mem.Metadata()
 		baseTable := md.Table(t.Table)
-		if t.IsUnfiltered(md) {
+		if t.IsUnfiltered(md) && !t.TableSample.Set {
 			for i, cnt := 0, baseTable.ColumnCount(); i < cnt; i++ {
 				unfilteredCols.Add(t.Table.ColumnID(i))
 			}
@@ -512,7 +512,7 @@ func checkForeignKeyCase(
 		leftBaseTable := md.Table(leftTableID)
 		for i, cnt := 0, leftBaseTable.OutboundForeignKeyCount(); i < cnt; i++ {
 			fk := leftBaseTable.OutboundForeignKey(i)
-			if !fk.Validated() {
+			if !fk.Validated() || fk.Deferrability().IsDeferrable() {
 				// The data is not guaranteed to follow the foreign key constraint.
 				continue
 			}
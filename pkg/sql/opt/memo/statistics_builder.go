@@ -853,6 +853,14 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 	s.VirtualCols.UnionWith(inputStats.VirtualCols)
 	pred := scan.PartialIndexPredicate(sb.md)
 
+	// A sampled scan is an unconstrained scan on the primary index which only
+	// returns the sampled fraction of the rows.
+	if scan.TableSample.Set {
+		s.ApplySelectivity(props.MakeSelectivity(scan.TableSample.Probability))
+		sb.finalizeFromCardinality(relProps)
+		return
+	}
+
 	// If the constraints and pred are nil, then this scan is an unconstrained
 	// scan on a non-partial index. The stats of the scan are the same as the
 	// underlying table stats.
@@ -2633,6 +2641,14 @@ func (sb *statisticsBuilder) buildProjectSet(
 				break
 			}
 		}
+		if udf, ok := projectSet.Zip[i].Fn.(*UDFCallExpr); ok {
+			if udf.Def.SetReturning && udf.Def.Rows > 0 {
+				// Use the estimate specified with the ROWS option of the
+				// set-returning function.
+				zipRowCount = udf.Def.Rows
+				break
+			}
+		}
 
 		// A scalar function generates one row.
 		zipRowCount = 1
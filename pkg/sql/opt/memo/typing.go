@@ -190,6 +190,7 @@ func init() {
 	typingFuncMap[opt.CollateOp] = typeCollate
 	typingFuncMap[opt.IfErrOp] = typeIfErr
 	typingFuncMap[opt.UDFCallOp] = typeUDFCall
+	typingFuncMap[opt.UserDefinedAggOp] = typeUserDefinedAgg
 	typingFuncMap[opt.TxnControlOp] = typeTxnControl
 
 	// Override default typeAsAggregate behavior for aggregate functions with
@@ -398,6 +399,11 @@ func typeUDFCall(e opt.ScalarExpr) *types.T {
 	return e.(*UDFCallExpr).Def.Typ
 }
 
+// typeUserDefinedAgg returns the type of a UserDefinedAggExpr operator.
+func typeUserDefinedAgg(e opt.ScalarExpr) *types.T {
+	return e.(*UserDefinedAggExpr).Typ
+}
+
 // typeTxnControl returns the type of a TxnControlExpr operator
 func typeTxnControl(e opt.ScalarExpr) *types.T {
 	return e.(*TxnControlExpr).Def.Typ
@@ -385,6 +385,17 @@ func (f *Factory) AssignPlaceholders(from *memo.Memo) (err error) {
 			for i := range t.Def.Body {
 				t.Def.Body[i] = f.CopyAndReplaceDefault(t.Def.Body[i], replaceFn).(memo.RelExpr)
 			}
+		case *memo.UserDefinedAggExpr:
+			// As with UDFCall, the statements in the bodies of the routines which
+			// implement the aggregate must be copied to the new memo.
+			for _, def := range []*memo.UDFDefinition{t.Transition, t.Final} {
+				if def == nil {
+					continue
+				}
+				for i := range def.Body {
+					def.Body[i] = f.CopyAndReplaceDefault(def.Body[i], replaceFn).(memo.RelExpr)
+				}
+			}
 		case *memo.RecursiveCTEExpr:
 			// A recursive CTE may have the stats change on its Initial expression
 			// after placeholder assignment, if that happens we need to
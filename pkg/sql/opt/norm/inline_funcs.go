@@ -447,6 +447,11 @@ func (c *CustomFuncs) IsInlinableUDF(args memo.ScalarListExpr, udfp *memo.UDFCal
 		len(udfp.Def.Body) != 1 || udfp.Def.SetReturning || udfp.Def.MultiColDataSource {
 		return false
 	}
+	if len(udfp.Def.Config) > 0 {
+		// Session variable overrides must be applied while the body executes,
+		// which is not possible if the body is inlined into the calling query.
+		return false
+	}
 	if !args.IsConstantsAndPlaceholdersAndVariables() {
 		return false
 	}
@@ -701,7 +701,7 @@ func (c *CustomFuncs) ForeignKeyConstraintFilters(
 
 		for i := 0; i < fkChildTable.OutboundForeignKeyCount(); i++ {
 			fk := fkChildTable.OutboundForeignKey(i)
-			if !fk.Validated() {
+			if !fk.Validated() || fk.Deferrability().IsDeferrable() {
 				// The data is not guaranteed to follow the foreign key constraint.
 				continue
 			}
@@ -153,6 +153,11 @@ type Builder struct {
 	// insideDataSource is true when we are processing a data source.
 	insideDataSource bool
 
+	// onlyDataSource is true when the table name of the data source being
+	// processed was qualified with ONLY, in which case the rows of tables which
+	// inherit from it are not included in its scan.
+	onlyDataSource bool
+
 	// insideNestedPLpgSQLCall is true when we are processing a nested PLpgSQL
 	// CALL statement.
 	insideNestedPLpgSQLCall bool
@@ -84,11 +84,16 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	languageFound := false
 	var funcBodyStr string
 	var language tree.RoutineLanguage
+	var isSecurityDefiner, hasConfig bool
 	for _, option := range cf.Options {
 		switch opt := option.(type) {
 		case tree.RoutineBodyStr:
 			funcBodyFound = true
 			funcBodyStr = string(opt)
+		case tree.RoutineSecurity:
+			isSecurityDefiner = opt == tree.RoutineDefiner
+		case *tree.RoutineSet:
+			hasConfig = !opt.Reset
 		case tree.RoutineLanguage:
 			languageFound = true
 			language = opt
@@ -159,7 +164,8 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// When multiple OUT parameters are present, parameter names become the
 	// labels in the output RECORD type.
 	var outParamNames []string
-	var sawDefaultExpr, sawPolymorphicInParam, sawPolymorphicOutParam bool
+	var sawDefaultExpr, sawPolymorphicInParam, sawPolymorphicOutParam, sawVariadic bool
+	var sawAnyCompatibleInParam bool
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
@@ -186,11 +192,31 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		if param.Class == tree.RoutineParamInOut && param.Name == "" {
 			panic(unimplemented.NewWithIssue(121251, "unnamed INOUT parameters are not yet supported"))
 		}
+		if sawVariadic {
+			// NOTE: These are the same errors as returned by Postgres.
+			if param.IsInParam() {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be the last input parameter"))
+			}
+			if cf.IsProcedure {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be the last parameter"))
+			}
+		}
+		if param.Class == tree.RoutineParamVariadic {
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be an array"))
+			}
+			sawVariadic = true
+		}
 		if param.IsInParam() {
 			if typ.Family() == types.VoidFamily {
 				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition, "SQL functions cannot have arguments of type VOID"))
 			}
-			if typ.IsPolymorphicType() {
+			if typ.IsAnyCompatibleType() {
+				sawAnyCompatibleInParam = true
+			} else if typ.IsPolymorphicType() {
 				sawPolymorphicInParam = true
 			}
 		}
@@ -322,9 +348,19 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	if b.evalCtx.SessionData().OptimizerUsePolymorphicParameterFix &&
 		(funcReturnType.IsPolymorphicType() || sawPolymorphicOutParam) {
 		// The routine return type has or contains a polymorphic type. Validate that
-		// there is at least one polymorphic IN parameter.
-		if !sawPolymorphicInParam {
-			makeErr := func(polyTyp *types.T) {
+		// there is at least one polymorphic IN parameter of the same family.
+		checkResultType := func(polyTyp *types.T) {
+			if polyTyp.IsAnyCompatibleType() {
+				if !sawAnyCompatibleInParam {
+					panic(errors.WithDetailf(
+						pgerror.New(pgcode.InvalidFunctionDefinition, "cannot determine result data type"),
+						"A result of type %s requires at least one input of type "+
+							"anycompatible, anycompatiblearray, anycompatiblenonarray, anycompatiblerange, "+
+							"or anycompatiblemultirange.",
+						polyTyp.Name(),
+					))
+				}
+			} else if !sawPolymorphicInParam {
 				panic(errors.WithDetailf(
 					pgerror.New(pgcode.InvalidFunctionDefinition, "cannot determine result data type"),
 					"A result of type %s requires at least one input of type "+
@@ -332,13 +368,13 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 					polyTyp.Name(),
 				))
 			}
-			if funcReturnType.IsPolymorphicType() {
-				makeErr(funcReturnType)
-			} else {
-				for _, tc := range funcReturnType.TupleContents() {
-					if tc.IsPolymorphicType() {
-						makeErr(tc)
-					}
+		}
+		if funcReturnType.IsPolymorphicType() {
+			checkResultType(funcReturnType)
+		} else {
+			for _, tc := range funcReturnType.TupleContents() {
+				if tc.IsPolymorphicType() {
+					checkResultType(tc)
 				}
 			}
 		}
@@ -465,6 +501,8 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			SetIsSetReturning(isSetReturning).
 			SetIsProcedure(cf.IsProcedure).
 			SetIsTriggerFn(isTriggerFn).
+			SetIsSecurityDefiner(isSecurityDefiner).
+			SetHasConfig(hasConfig).
 			SetSkipSQL(skipSQL)
 		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
 			plBuilder := newPLpgSQLBuilder(
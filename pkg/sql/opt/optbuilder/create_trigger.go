@@ -6,6 +6,7 @@
 package optbuilder
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -75,7 +76,7 @@ func (b *Builder) buildCreateTrigger(ct *tree.CreateTrigger, inScope *scope) (ou
 	}
 
 	// Check for unsupported CREATE TRIGGER statements.
-	checkUnsupportedCreateTrigger(ct, ds)
+	b.checkUnsupportedCreateTrigger(ct, ds)
 
 	// Lookup the implicit table type. This must happen after the above checks,
 	// since virtual/system tables do not have an implicit type.
@@ -342,15 +343,19 @@ var triggerArgvColIdx = len(triggerFuncStaticParams) + 1
 const triggerColNew = "new"
 const triggerColOld = "old"
 
-func checkUnsupportedCreateTrigger(ct *tree.CreateTrigger, ds cat.DataSource) {
-	if ct.ForEach == tree.TriggerForEachStatement {
-		panic(unimplementedStatementLevelErr)
-	}
-	if ct.ActionTime == tree.TriggerActionTimeInsteadOf {
-		panic(unimplementedInsteadOfErr)
-	}
-	if len(ct.Transitions) > 0 {
-		panic(unimplementedReferencingErr)
+func (b *Builder) checkUnsupportedCreateTrigger(ct *tree.CreateTrigger, ds cat.DataSource) {
+	// Nodes running older versions would not fire statement-level or INSTEAD
+	// OF triggers, or populate transition tables.
+	if !b.evalCtx.Settings.Version.IsActive(b.ctx, clusterversion.V25_3_Start) {
+		if ct.ForEach == tree.TriggerForEachStatement {
+			panic(unimplementedStatementLevelErr)
+		}
+		if ct.ActionTime == tree.TriggerActionTimeInsteadOf {
+			panic(unimplementedInsteadOfErr)
+		}
+		if len(ct.Transitions) > 0 {
+			panic(unimplementedReferencingErr)
+		}
 	}
 	for _, event := range ct.Events {
 		if event.EventType == tree.TriggerEventTruncate {
@@ -360,7 +365,7 @@ func checkUnsupportedCreateTrigger(ct *tree.CreateTrigger, ds cat.DataSource) {
 			panic(unimplementedColumnListErr)
 		}
 	}
-	if _, ok := ds.(cat.View); ok {
+	if _, ok := ds.(cat.View); ok && ct.ActionTime != tree.TriggerActionTimeInsteadOf {
 		panic(unimplementedViewTriggerErr)
 	}
 }
@@ -377,7 +382,7 @@ var (
 	unimplementedColumnListErr = unimplemented.NewWithIssue(135656,
 		"column lists are not yet supported for triggers")
 	unimplementedViewTriggerErr = unimplemented.NewWithIssue(135658,
-		"triggers on views are not yet supported")
+		"statement-level triggers on views are not yet supported")
 	unimplementedArgvErr = unimplemented.NewWithIssue(135311,
 		"referencing the TG_ARGV trigger function parameter is not yet supported")
 )
@@ -57,7 +57,20 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	}
 
 	// Find which table we're working on, check the permissions.
-	tab, depName, alias, refColumns := b.resolveTableForMutation(del.Table, privilege.DELETE)
+	tab, view, depName, alias, refColumns := b.resolveTableForMutation(
+		del.Table, privilege.DELETE, tree.TriggerEventDelete,
+	)
+
+	// A view with INSTEAD OF triggers is mutated by invoking the triggers.
+	if view != nil {
+		return b.buildInsteadOfDelete(del, inScope, view, depName, alias)
+	}
+
+	if tab.IsForeignTable() {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot delete from foreign table \"%s\"", tab.Name(),
+		))
+	}
 
 	if tab.IsVirtualTable() {
 		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
@@ -106,7 +119,7 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 func (mb *mutationBuilder) buildDelete(returning *tree.ReturningExprs) {
 	mb.buildFKChecksAndCascadesForDelete()
 
-	mb.buildRowLevelAfterTriggers(opt.DeleteOp)
+	mb.buildAfterTriggers(opt.DeleteOp)
 
 	// Project partial index DEL boolean columns.
 	mb.projectPartialIndexDelCols()
@@ -124,6 +137,8 @@ func (mb *mutationBuilder) buildDelete(returning *tree.ReturningExprs) {
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
 	)
 
+	mb.buildStatementLevelBeforeTriggers(opt.DeleteOp)
+
 	returningInScope, returningOutScope := mb.buildReturningScopes(returning, nil /* colRefs */)
 	mb.buildReturning(returning, returningInScope, returningOutScope)
 }
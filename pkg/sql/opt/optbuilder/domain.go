@@ -0,0 +1,135 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// domainValueColName is the name of the parameter that refers to the value
+// being checked in the CHECK constraints of a domain.
+const domainValueColName = "value"
+
+// buildDomainCheck wraps the given scalar expression, which must be of the
+// given type, with a check of the NOT NULL and CHECK constraints of the domain
+// type typ. If typ is not a domain type, or the domain has no constraints, the
+// input is returned unchanged.
+//
+// The constraints are checked by a synthesized routine with a single parameter
+// named "value", so that the input expression is evaluated only once. Each
+// constraint is checked by a CASE expression that raises an error when the
+// constraint is violated, in the same way as PL/pgSQL runtime checks.
+func (b *Builder) buildDomainCheck(input opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+	if !typ.IsDomain() {
+		return input
+	}
+	domain := typ.TypeMeta.DomainData
+	if domain == nil {
+		panic(errors.AssertionFailedf("domain type %s is not hydrated", typ.SQLString()))
+	}
+	if !domain.NotNull && len(domain.CheckExprs) == 0 {
+		return input
+	}
+	// Track the domain so that cached plans are invalidated if its constraints
+	// are altered.
+	b.factory.Metadata().AddUserDefinedType(typ, nil /* name */)
+
+	// Build a scope with the "value" parameter. The CHECK expressions are typed
+	// against the base type of the domain, as in Postgres.
+	bodyScope := b.allocScope()
+	valueCol := b.synthesizeColumn(
+		bodyScope, scopeColName(domainValueColName), domain.BaseType, nil /* expr */, nil, /* scalar */
+	)
+	valueCol.setParamOrd(0)
+	bodyScope.expr = b.factory.ConstructNoColsRow()
+	value := b.factory.ConstructVariable(valueCol.id)
+
+	typName := typ.Name()
+	var branches memo.ScalarListExpr
+	var raiseArgs []memo.ScalarListExpr
+	if domain.NotNull {
+		branches = append(branches, b.factory.ConstructIs(value, memo.NullSingleton))
+		raiseArgs = append(raiseArgs, b.makeConstRaiseArgs(
+			"ERROR", /* severity */
+			fmt.Sprintf("domain %s does not allow null values", typName), /* message */
+			"",                               /* detail */
+			"",                               /* hint */
+			pgcode.NotNullViolation.String(), /* code */
+		))
+	}
+	for i, exprStr := range domain.CheckExprs {
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			panic(err)
+		}
+		texpr := bodyScope.resolveAndRequireType(expr, types.Bool)
+		check := b.buildScalar(texpr, bodyScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		// A CHECK constraint is only violated if it evaluates to false.
+		branches = append(branches, b.factory.ConstructIsNot(check, memo.TrueSingleton))
+		raiseArgs = append(raiseArgs, b.makeConstRaiseArgs(
+			"ERROR", /* severity */
+			fmt.Sprintf(
+				"value for domain %s violates check constraint %q", typName, domain.CheckNames[i],
+			), /* message */
+			"",                             /* detail */
+			"",                             /* hint */
+			pgcode.CheckViolation.String(), /* code */
+		))
+		if domain.NotNull {
+			continue
+		}
+		// NULL values always satisfy the CHECK constraints of a domain.
+		branches[len(branches)-1] = b.factory.ConstructAnd(
+			b.factory.ConstructIsNot(value, memo.NullSingleton), branches[len(branches)-1],
+		)
+	}
+
+	// The first body statement performs the checks. An optimization barrier
+	// ensures that the checks are not eliminated by column pruning.
+	whens := make(memo.ScalarListExpr, len(branches))
+	for i := range branches {
+		whens[i] = b.factory.ConstructWhen(branches[i], b.makePLpgSQLRaiseFn(raiseArgs[i]))
+	}
+	checkExpr := b.factory.ConstructCase(memo.TrueSingleton, whens, b.factory.ConstructNull(types.Int))
+	checkScope := bodyScope.push()
+	checkScope.expr = bodyScope.expr
+	b.projectColWithMetadataName(checkScope, "domain_check", types.Int, checkExpr)
+	b.addBarrier(checkScope)
+
+	// The second body statement returns the value.
+	resultScope := bodyScope.push()
+	b.synthesizeColumn(resultScope, scopeColName(""), typ, nil /* expr */, value)
+	b.constructProjectForScope(bodyScope, resultScope)
+
+	vol := volatility.Immutable
+	for _, s := range []*scope{checkScope, resultScope} {
+		if v := s.expr.Relational().VolatilitySet.ToVolatility(); vol < v {
+			vol = v
+		}
+	}
+	def := &memo.UDFDefinition{
+		Params:            opt.ColList{valueCol.id},
+		Name:              fmt.Sprintf("check_domain_%s", typName),
+		Typ:               typ,
+		CalledOnNullInput: true,
+		RoutineType:       tree.UDFRoutine,
+		RoutineLang:       tree.RoutineLangSQL,
+		Body:              []memo.RelExpr{checkScope.expr, resultScope.expr},
+		BodyProps:         []*physical.Required{checkScope.makePhysicalProps(), resultScope.makePhysicalProps()},
+		Volatility:        vol,
+	}
+	return b.factory.ConstructUDFCall(memo.ScalarListExpr{input}, &memo.UDFCallPrivate{Def: def})
+}
@@ -323,6 +323,7 @@ func (cb *onDeleteFastCascadeBuilder) Build(
 					includeInverted:  false,
 				}),
 				indexFlags,
+				nil, /* tableSample */
 				noRowLocking,
 				b.allocScope(),
 				true, /* disableNotVisibleIndex */
@@ -580,6 +581,7 @@ func (b *Builder) buildDeleteCascadeMutationInput(
 			includeInverted:  false,
 		}),
 		indexFlags,
+		nil, /* tableSample */
 		noRowLocking,
 		b.allocScope(),
 		true, /* disableNotVisibleIndex */
@@ -856,6 +858,7 @@ func (b *Builder) buildUpdateCascadeMutationInput(
 			includeInverted:  false,
 		}),
 		indexFlags,
+		nil, /* tableSample */
 		noRowLocking,
 		b.allocScope(),
 		true, /* disableNotVisibleIndex */
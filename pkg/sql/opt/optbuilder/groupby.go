@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -95,6 +96,30 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets contains the grouping columns of each grouping set when the
+	// GROUP BY clause has ROLLUP, CUBE or GROUPING SETS items that expand to more
+	// than one grouping set. It is nil otherwise.
+	//
+	// Aggregations with grouping sets are built by duplicating each input row
+	// once per grouping set and projecting the grouping columns that are not
+	// part of the row's grouping set as NULL. A single GroupBy that also groups
+	// on the index of the grouping set (groupingSetCol) then computes the
+	// aggregates for all the grouping sets at once. For example:
+	//
+	//   SELECT a, b, sum(c) FROM abc GROUP BY ROLLUP (a, b)
+	//
+	//   input:           abc CROSS JOIN (VALUES (0), (1), (2)) AS v(set)
+	//   pre-projection:  CASE set WHEN 2 THEN NULL ELSE a END (as col1),
+	//                    CASE set WHEN 1 THEN NULL WHEN 2 THEN NULL ELSE b END
+	//                    (as col2), c, set
+	//   aggregation:     group by col1, col2, set, calculate sum(c)
+	groupingSets []opt.ColSet
+
+	// groupingSetCol is the column containing the index of the grouping set
+	// each row of the aggregation input is expanded for. It is only set if
+	// groupingSets is non-nil.
+	groupingSetCol opt.ColumnID
 }
 
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
@@ -203,6 +228,11 @@ type aggregateInfo struct {
 	args     memo.ScalarListExpr
 	filter   opt.ScalarExpr
 
+	// udfAgg is set if this is an invocation of a user-defined aggregate
+	// function. In that case, args contains a single tuple that packs all of
+	// the arguments of the aggregate.
+	udfAgg *memo.UserDefinedAggPrivate
+
 	// col is the output column of the aggregation.
 	col *scopeColumn
 
@@ -331,6 +361,10 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 
 	// Copy the grouping columns to the aggOutScope.
 	g.aggOutScope.appendColumns(g.groupingCols())
+
+	if g.groupingSets != nil {
+		g.groupingSetCol = b.factory.Metadata().AddColumn("grouping_set", types.Int)
+	}
 }
 
 // buildAggregation builds the aggregation operators and constructs the
@@ -349,9 +383,21 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		if g.groupingSets != nil {
+			panic(unimplemented.NewWithIssue(46280,
+				"ordered aggregates are not supported with ROLLUP, CUBE or GROUPING SETS"))
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
+	// If there are grouping sets, the input to the aggregation is expanded once
+	// per grouping set. See the comment on groupby.groupingSets.
+	var sets groupingSetsExpansion
+	if g.groupingSets != nil {
+		sets = b.expandGroupingSetsInput(fromScope)
+		groupingColSet.Add(g.groupingSetCol)
+	}
+
 	aggInfos := g.aggs
 
 	// Construct the aggregation operators.
@@ -380,7 +426,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.udfAgg != nil {
+			aggCols[i].scalar = b.factory.ConstructUserDefinedAgg(args[0], agg.udfAgg)
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -395,10 +445,17 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 		if agg.filter != nil {
 			// Column containing filter expression is always after the argument
 			// columns (which have already been processed).
-			colID := argCols[0].id
+			filterCol := argCols[0]
 			argCols = argCols[1:]
+			colID := filterCol.id
+			if sets.inputRowCol != 0 {
+				colID = sets.addInputRowFilter(b, &filterCol)
+			}
 			variable := b.factory.ConstructVariable(colID)
 			aggCols[i].scalar = b.factory.ConstructAggFilter(aggCols[i].scalar, variable)
+		} else if sets.inputRowCol != 0 {
+			variable := b.factory.ConstructVariable(sets.inputRowCol)
+			aggCols[i].scalar = b.factory.ConstructAggFilter(aggCols[i].scalar, variable)
 		}
 
 		if agg.isOrderingSensitive() {
@@ -423,7 +480,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 	// Construct the pre-projection, which renders the grouping columns and the
 	// aggregate arguments, as well as any additional order by columns.
-	b.constructProjectForScope(fromScope, g.aggInScope)
+	if g.groupingSets != nil {
+		b.constructGroupingSetsProject(fromScope, &sets)
+	} else {
+		b.constructProjectForScope(fromScope, g.aggInScope)
+	}
 
 	g.aggOutScope.expr = b.constructGroupBy(
 		g.aggInScope.expr,
@@ -505,8 +566,12 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
-	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+	if hasGroupingSets(groupBy) {
+		b.buildGroupingSets(groupBy, selects, projectionsScope, fromScope)
+	} else {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope, false /* forGroupingSets */)
+		}
 	}
 	g.buildingGroupingCols = false
 }
@@ -531,9 +596,18 @@ func (b *Builder) buildGroupingList(
 // aggInScope       The scope that will contain the grouping expressions as well
 //
 //	as the aggregate function arguments.
+//
+// forGroupingSets  True if the GROUP BY clause has grouping sets, in which case
+//
+//	the grouping columns are always synthesized (see below).
+//
+// buildGrouping returns the IDs of the grouping columns for the expression.
 func (b *Builder) buildGrouping(
-	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+	groupBy tree.Expr,
+	selects tree.SelectExprs,
+	projectionsScope, fromScope, aggInScope *scope,
+	forGroupingSets bool,
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -631,7 +705,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -640,8 +715,16 @@ func (b *Builder) buildGrouping(
 		//   SELECT x+y FROM t GROUP BY x+y
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
+		if forGroupingSets && col.scalar == nil {
+			// With grouping sets, the grouping column is NULL for the grouping sets
+			// that do not include it. It therefore cannot share its ID with an input
+			// column, which could also be used as an aggregate argument.
+			b.populateSynthesizedColumn(col, b.factory.ConstructVariable(col.id))
+		}
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -707,8 +790,31 @@ func (b *Builder) buildAggregateFunction(
 	b.subquery = nil
 	defer func() { b.subquery = subq }()
 
-	for i, pexpr := range f.Exprs {
-		info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, fromScope)
+	if f.ResolvedOverload().UDFAggregate != nil {
+		// The arguments of a user-defined aggregate are cast to the parameter
+		// types and packed into a single tuple, which is passed to the
+		// transition function along with the state value.
+		paramTypes, ok := f.ResolvedOverload().Types.(tree.ParamTypes)
+		if !ok || len(paramTypes) != len(f.Exprs) {
+			panic(errors.AssertionFailedf("unexpected parameters of user-defined aggregate %s", def.Name))
+		}
+		argTypes := make([]*types.T, len(f.Exprs))
+		argExprs := make(tree.Exprs, len(f.Exprs))
+		for i, pexpr := range f.Exprs {
+			argTypes[i] = paramTypes[i].Typ
+			texpr := pexpr.(tree.TypedExpr)
+			if !texpr.ResolvedType().Identical(argTypes[i]) {
+				texpr = tree.NewTypedCastExpr(texpr, argTypes[i])
+			}
+			argExprs[i] = texpr
+		}
+		tuple := tree.NewTypedTuple(types.MakeTuple(argTypes), argExprs)
+		info.args = memo.ScalarListExpr{b.buildAggArg(tuple, &info, tempScope, fromScope)}
+		info.udfAgg = b.buildUserDefinedAggPrivate(f, def.Name, argTypes, fromScope)
+	} else {
+		for i, pexpr := range f.Exprs {
+			info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, fromScope)
+		}
 	}
 
 	// If we have a filter, add it to tempScope after all the arguments. We'll
@@ -940,6 +1046,12 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	if colMeta.Table == 0 {
 		return false
 	}
+	if g.groupingSets != nil {
+		// With grouping sets, the PK columns can be NULL in the grouping sets that
+		// don't include them, so other columns are not functionally dependent on
+		// them. This matches Postgres.
+		return false
+	}
 	// Get all the PK columns.
 	tab := md.Table(colMeta.Table)
 	tabMeta := md.TableMeta(colMeta.Table)
@@ -0,0 +1,410 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+// This file has builder code specific to GROUP BY clauses with ROLLUP, CUBE
+// and GROUPING SETS items, as well as to GROUPING operations. See the comment
+// on groupby.groupingSets for an overview of how these aggregations are built.
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// maxGroupingSets is the maximum number of grouping sets a GROUP BY clause
+	// can expand to. This matches Postgres.
+	maxGroupingSets = 4096
+
+	// maxCubeElements is the maximum number of elements in a CUBE. This matches
+	// Postgres.
+	maxCubeElements = 12
+
+	// maxGroupingArgs is the maximum number of arguments to a GROUPING
+	// operation, which is limited by the size of its integer result. This
+	// matches Postgres.
+	maxGroupingArgs = 31
+)
+
+// hasGroupingSets returns true if the GROUP BY clause has ROLLUP, CUBE or
+// GROUPING SETS items.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupingSets builds the grouping columns of a GROUP BY clause with
+// ROLLUP, CUBE or GROUPING SETS items, and stores the grouping sets the clause
+// expands to in the groupby of fromScope. The grouping sets of the GROUP BY
+// list are the cross product of the grouping sets of its items. For example:
+//
+//	GROUP BY a, ROLLUP (b, c)
+//
+// expands to the grouping sets (a, b, c), (a, b) and (a).
+func (b *Builder) buildGroupingSets(
+	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) {
+	sets := []opt.ColSet{{}}
+	for _, e := range groupBy {
+		itemSets := b.buildGroupingSetItem(e, selects, projectionsScope, fromScope)
+		checkGroupingSetsCount(len(sets) * len(itemSets))
+		product := make([]opt.ColSet, 0, len(sets)*len(itemSets))
+		for _, set := range sets {
+			for _, itemSet := range itemSets {
+				product = append(product, set.Union(itemSet))
+			}
+		}
+		sets = product
+	}
+
+	// A single grouping set is equivalent to a simple GROUP BY.
+	if len(sets) > 1 {
+		fromScope.groupby.groupingSets = sets
+	}
+}
+
+// buildGroupingSetItem builds the grouping columns of an item of a GROUP BY
+// clause with grouping sets, and returns the grouping sets the item expands to.
+func (b *Builder) buildGroupingSetItem(
+	item tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) []opt.ColSet {
+	aggInScope := fromScope.groupby.aggInScope
+	buildGrouping := func(e tree.Expr) opt.ColSet {
+		return b.buildGrouping(
+			e, selects, projectionsScope, fromScope, aggInScope, true, /* forGroupingSets */
+		)
+	}
+
+	set, ok := item.(*tree.GroupingSet)
+	if !ok {
+		return []opt.ColSet{buildGrouping(item)}
+	}
+
+	switch set.Type {
+	case tree.RollupGroupingSet:
+		// ROLLUP (e1, ..., en) expands to the grouping sets (e1, ..., en),
+		// (e1, ..., en-1), ..., (e1) and ().
+		sets := make([]opt.ColSet, len(set.Exprs)+1)
+		var cols opt.ColSet
+		for i, e := range set.Exprs {
+			cols = cols.Union(buildGrouping(e))
+			sets[len(set.Exprs)-1-i] = cols
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		// CUBE (e1, ..., en) expands to all the subsets of its elements, from the
+		// largest to the empty set.
+		if len(set.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		elems := make([]opt.ColSet, len(set.Exprs))
+		for i, e := range set.Exprs {
+			elems[i] = buildGrouping(e)
+		}
+		n := len(elems)
+		sets := make([]opt.ColSet, 0, 1<<n)
+		for mask := 1<<n - 1; mask >= 0; mask-- {
+			var cols opt.ColSet
+			for i := range elems {
+				if mask&(1<<(n-1-i)) != 0 {
+					cols.UnionWith(elems[i])
+				}
+			}
+			sets = append(sets, cols)
+		}
+		return sets
+
+	case tree.GroupingSetsList:
+		// GROUPING SETS (...) expands to the concatenation of the grouping sets of
+		// its items.
+		var sets []opt.ColSet
+		for _, e := range set.Exprs {
+			sets = append(sets, b.buildGroupingSetItem(e, selects, projectionsScope, fromScope)...)
+			checkGroupingSetsCount(len(sets))
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %d", set.Type))
+	}
+}
+
+// checkGroupingSetsCount returns an error if a GROUP BY clause expands to more
+// than maxGroupingSets grouping sets.
+func checkGroupingSetsCount(n int) {
+	if n > maxGroupingSets {
+		panic(pgerror.Newf(pgcode.StatementTooComplex,
+			"too many grouping sets present (maximum %d)", maxGroupingSets))
+	}
+}
+
+// groupingSetsExpansion contains the state used to build the input of an
+// aggregation with grouping sets.
+type groupingSetsExpansion struct {
+	// input is the input of the aggregation with each row duplicated once per
+	// grouping set.
+	input memo.RelExpr
+
+	// inputRowCol is only set if there is an empty grouping set. An empty
+	// grouping set produces a row even if the input is empty, like an
+	// aggregation without GROUP BY. To achieve this, the expanded input has a
+	// row for each empty grouping set even if the input is empty. inputRowCol is
+	// true for the rows that originate from the input and NULL for these
+	// additional rows, and all aggregates are filtered on it.
+	inputRowCol opt.ColumnID
+
+	// filterCols contains the columns that combine inputRowCol with the FILTER
+	// expressions of aggregates.
+	filterCols []scopeColumn
+}
+
+// expandGroupingSetsInput constructs the input of an aggregation with grouping
+// sets, which duplicates each row of the FROM clause once per grouping set.
+func (b *Builder) expandGroupingSetsInput(fromScope *scope) (sets groupingSetsExpansion) {
+	g := fromScope.groupby
+	md := b.factory.Metadata()
+
+	// Construct a Values expression with the index of each grouping set.
+	rows := make(memo.ScalarListExpr, len(g.groupingSets))
+	rowTyp := types.MakeTuple([]*types.T{types.Int})
+	var emptySets memo.ScalarListExpr
+	var emptySetTypes []*types.T
+	for i := range g.groupingSets {
+		idx := b.constructGroupingSetIdx(i)
+		rows[i] = b.factory.ConstructTuple(memo.ScalarListExpr{idx}, rowTyp)
+		if g.groupingSets[i].Empty() {
+			emptySets = append(emptySets, idx)
+			emptySetTypes = append(emptySetTypes, types.Int)
+		}
+	}
+	values := b.factory.ConstructValues(rows, &memo.ValuesPrivate{
+		Cols: opt.ColList{g.groupingSetCol},
+		ID:   md.NextUniqueID(),
+	})
+
+	if len(emptySets) == 0 {
+		sets.input = b.factory.ConstructInnerJoin(
+			fromScope.expr, values, memo.TrueFilter, memo.EmptyJoinPrivate,
+		)
+		return sets
+	}
+
+	// Left join the grouping sets with the input, so that there is a
+	// NULL-extended row for each grouping set if the input is empty. These rows
+	// are only kept for the empty grouping sets.
+	sets.inputRowCol = md.AddColumn("input_row", types.Bool)
+	input := b.factory.ConstructProject(
+		fromScope.expr,
+		memo.ProjectionsExpr{
+			b.factory.ConstructProjectionsItem(memo.TrueSingleton, sets.inputRowCol),
+		},
+		fromScope.expr.Relational().OutputCols,
+	)
+	join := b.factory.ConstructLeftJoin(values, input, memo.TrueFilter, memo.EmptyJoinPrivate)
+	filter := b.factory.ConstructOr(
+		b.factory.ConstructIsNot(b.factory.ConstructVariable(sets.inputRowCol), memo.NullSingleton),
+		b.factory.ConstructIn(
+			b.factory.ConstructVariable(g.groupingSetCol),
+			b.factory.ConstructTuple(emptySets, types.MakeTuple(emptySetTypes)),
+		),
+	)
+	sets.input = b.factory.ConstructSelect(
+		join, memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	)
+	return sets
+}
+
+// addInputRowFilter synthesizes a column that is true for the rows that
+// originate from the aggregation input and pass the given FILTER column of an
+// aggregate, and returns its ID. It must only be called if inputRowCol is set.
+func (s *groupingSetsExpansion) addInputRowFilter(b *Builder, filterCol *scopeColumn) opt.ColumnID {
+	filter := filterCol.scalar
+	if filter == nil {
+		filter = b.factory.ConstructVariable(filterCol.id)
+	}
+	col := scopeColumn{name: scopeColName("")}
+	b.populateSynthesizedColumn(
+		&col, b.factory.ConstructAnd(filter, b.factory.ConstructVariable(s.inputRowCol)),
+	)
+	s.filterCols = append(s.filterCols, col)
+	return col.id
+}
+
+// constructGroupingSetsProject constructs the pre-projection of an aggregation
+// with grouping sets on top of the expanded input. It renders the same columns
+// as the pre-projection of any other aggregation, except that the grouping
+// columns are NULL for the rows of the grouping sets that don't include them.
+// The index of the grouping set and the columns needed to filter the
+// aggregates are also passed through.
+func (b *Builder) constructGroupingSetsProject(fromScope *scope, sets *groupingSetsExpansion) {
+	g := fromScope.groupby
+	setCol := b.factory.ConstructVariable(g.groupingSetCol)
+
+	cols := make([]scopeColumn, 0, len(g.aggInScope.cols)+len(g.aggInScope.extraCols)+len(sets.filterCols)+2)
+	cols = append(cols, g.aggInScope.cols...)
+	for i := len(cols) - len(g.groupStrs); i < len(cols); i++ {
+		col := &cols[i]
+		var whens memo.ScalarListExpr
+		for j := range g.groupingSets {
+			if !g.groupingSets[j].Contains(col.id) {
+				whens = append(whens, b.factory.ConstructWhen(
+					b.constructGroupingSetIdx(j), b.factory.ConstructNull(col.typ),
+				))
+			}
+		}
+		if len(whens) > 0 {
+			// The grouping columns are always synthesized when there are grouping
+			// sets, so the column has a scalar expression. See buildGrouping.
+			col.scalar = b.factory.ConstructCase(setCol, whens, col.scalar)
+		}
+	}
+	cols = append(cols, g.aggInScope.extraCols...)
+	cols = append(cols, sets.filterCols...)
+	cols = append(cols, scopeColumn{id: g.groupingSetCol, typ: types.Int})
+	if sets.inputRowCol != 0 {
+		cols = append(cols, scopeColumn{id: sets.inputRowCol, typ: types.Bool})
+	}
+	g.aggInScope.expr = b.constructProject(sets.input, cols)
+}
+
+// constructGroupingSetIdx constructs a constant with the index of a grouping
+// set.
+func (b *Builder) constructGroupingSetIdx(i int) opt.ScalarExpr {
+	return b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int)
+}
+
+// groupingInfo stores information about a GROUPING operation.
+type groupingInfo struct {
+	*tree.GroupingExpr
+
+	// args contains the resolved arguments of the operation.
+	args []tree.TypedExpr
+}
+
+// Walk is part of the tree.Expr interface.
+func (g *groupingInfo) Walk(v tree.Visitor) tree.Expr {
+	return g
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (g *groupingInfo) TypeCheck(
+	ctx context.Context, semaCtx *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return g, nil
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (g *groupingInfo) Eval(_ context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("groupingInfo must be replaced before evaluation"))
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (g *groupingInfo) ResolvedType() *types.T {
+	return types.Int
+}
+
+var _ tree.Expr = &groupingInfo{}
+var _ tree.TypedExpr = &groupingInfo{}
+
+// replaceGrouping returns a groupingInfo struct that can be used to replace a
+// GROUPING operation. The arguments are resolved here, but they can only be
+// matched with the grouping columns once those are built, which happens in
+// Builder.buildGroupingOperation.
+func (s *scope) replaceGrouping(t *tree.GroupingExpr) *groupingInfo {
+	props := &s.builder.semaCtx.Properties
+	if props.IsSet(tree.RejectAggregates) {
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", props.Context()))
+	}
+	switch s.context {
+	case exprKindWhere, exprKindOn, exprKindLateralJoin:
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", s.context))
+	}
+	if props.IsSet(tree.RejectNestedAggregates) {
+		panic(pgerror.New(pgcode.Grouping,
+			"aggregate function calls cannot contain grouping operations"))
+	}
+	if len(t.Exprs) > maxGroupingArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingArgs+1))
+	}
+
+	// We need to save and restore the previous value of the field in
+	// semaCtx in case we are recursively called within a subquery
+	// context.
+	defer s.builder.semaCtx.Properties.Restore(s.builder.semaCtx.Properties)
+	s.builder.semaCtx.Properties.Require("GROUPING", tree.RejectSpecial)
+
+	info := &groupingInfo{
+		GroupingExpr: t,
+		args:         make([]tree.TypedExpr, len(t.Exprs)),
+	}
+	for i, e := range t.Exprs {
+		info.args[i] = s.resolveType(e, types.AnyElement)
+	}
+	return info
+}
+
+// buildGroupingOperation builds a GROUPING operation. The result is a bit mask
+// in which the bit of the last argument is the least significant one, and the
+// bit of an argument is set if the argument is not part of the grouping set of
+// the current row.
+func (b *Builder) buildGroupingOperation(t *groupingInfo, inScope *scope) opt.ScalarExpr {
+	g := inScope.groupby
+	if !inScope.inGroupingContext() || inScope.inAgg || g.buildingGroupingCols {
+		panic(errGroupingArgs)
+	}
+	cols := make([]opt.ColumnID, len(t.args))
+	for i, arg := range t.args {
+		col, ok := g.groupStrs[symbolicExprStr(arg)]
+		if !ok {
+			panic(errGroupingArgs)
+		}
+		cols[i] = col.id
+	}
+
+	zero := b.factory.ConstructConstVal(tree.NewDInt(0), types.Int)
+	if g.groupingSets == nil {
+		// All the grouping columns are part of the single grouping set.
+		return zero
+	}
+	var whens memo.ScalarListExpr
+	for i, set := range g.groupingSets {
+		var mask int64
+		for j, col := range cols {
+			if !set.Contains(col) {
+				mask |= 1 << (len(cols) - 1 - j)
+			}
+		}
+		if mask != 0 {
+			whens = append(whens, b.factory.ConstructWhen(
+				b.constructGroupingSetIdx(i),
+				b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(mask)), types.Int),
+			))
+		}
+	}
+	if len(whens) == 0 {
+		return zero
+	}
+	return b.factory.ConstructCase(b.factory.ConstructVariable(g.groupingSetCol), whens, zero)
+}
+
+var errGroupingArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
@@ -0,0 +1,124 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// buildInheritedScans extends the scan of a parent table with the rows of all
+// tables which inherit from it, directly or transitively. The rows of each
+// inheriting table are projected onto the columns of the parent by name, with
+// NULL for any column which the inheriting table lacks, and the results are
+// combined with a UNION ALL. The returned scope has the same columns as
+// parentScope, with new column IDs.
+//
+// See tree.AliasedTableExpr.Only for how inheritance expansion is disabled.
+func (b *Builder) buildInheritedScans(
+	parent cat.Table,
+	parentScope *scope,
+	tableSample *tree.TableSample,
+	locking lockingSpec,
+	inScope *scope,
+) (outScope *scope) {
+	outScope = parentScope
+	for i, n := 0, parent.InheritedByCount(); i < n; i++ {
+		childID := parent.InheritedBy(i)
+		var flags cat.Flags
+		if b.insideViewDef || b.insideFuncDef || b.insideTriggerDef {
+			// Avoid taking table leases when we're creating a view or a function.
+			flags.AvoidDescriptorCaches = true
+		}
+		ds, _, err := b.catalog.ResolveDataSourceByID(b.ctx, flags, childID)
+		if err != nil {
+			panic(err)
+		}
+		child, ok := ds.(cat.Table)
+		if !ok {
+			panic(errors.AssertionFailedf("inheriting data source %d is not a table", childID))
+		}
+		// As in Postgres, the privileges of the parent table govern access to
+		// the rows of its inheriting tables, so only record the dependency.
+		b.factory.Metadata().AddDependency(opt.DepByID(childID), child, 0 /* privilege */)
+
+		childName := tree.MakeUnqualifiedTableName(child.Name())
+		childMeta := b.addTable(child, &childName)
+		policyCommandScope, childLocking := b.prepForTableScan(locking, childMeta)
+		childScope := b.buildScan(
+			childMeta,
+			tableOrdinals(child, columnKinds{
+				includeMutations: false,
+				includeSystem:    true,
+				includeInverted:  false,
+			}),
+			nil /* indexFlags */, tableSample, childLocking, inScope,
+			false, /* disableNotVisibleIndex */
+			policyCommandScope,
+		)
+		if child.InheritedByCount() > 0 {
+			childScope = b.buildInheritedScans(child, childScope, tableSample, locking, inScope)
+		}
+		childScope = b.projectInheritedColumns(parentScope, childScope)
+		outScope = b.buildInheritedUnion(outScope, childScope, inScope)
+	}
+	return outScope
+}
+
+// projectInheritedColumns projects the columns of an inheriting table's scope
+// onto the columns of its parent's scope, matching them by name.
+func (b *Builder) projectInheritedColumns(parentScope, childScope *scope) *scope {
+	projScope := childScope.push()
+	for i := range parentScope.cols {
+		parentCol := &parentScope.cols[i]
+		var childCol *scopeColumn
+		for j := range childScope.cols {
+			if childScope.cols[j].name.ReferenceName() == parentCol.name.ReferenceName() {
+				childCol = &childScope.cols[j]
+				break
+			}
+		}
+		switch {
+		case childCol == nil:
+			b.synthesizeColumn(
+				projScope, parentCol.name, parentCol.typ, nil /* expr */, b.factory.ConstructNull(parentCol.typ),
+			)
+		case !childCol.typ.Identical(parentCol.typ):
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"inherited column %q has a type conflict", parentCol.name.ReferenceName()))
+		default:
+			projScope.appendColumn(childCol)
+		}
+	}
+	b.constructProjectForScope(childScope, projScope)
+	return projScope
+}
+
+// buildInheritedUnion combines the rows of the given scopes, which must have
+// columns of identical types, with a UNION ALL. The output columns retain the
+// names, table and visibility of the left scope's columns.
+func (b *Builder) buildInheritedUnion(leftScope, rightScope, inScope *scope) (outScope *scope) {
+	outScope = inScope.push()
+	outScope.cols = make([]scopeColumn, len(leftScope.cols))
+	for i := range leftScope.cols {
+		col := leftScope.cols[i]
+		col.id = b.factory.Metadata().AddColumn(col.name.MetadataName(), col.typ)
+		col.scalar = nil
+		outScope.cols[i] = col
+	}
+	private := memo.SetPrivate{
+		LeftCols:  colsToColList(leftScope.cols),
+		RightCols: colsToColList(rightScope.cols),
+		OutCols:   colsToColList(outScope.cols),
+	}
+	outScope.expr = b.factory.ConstructUnionAll(leftScope.expr, rightScope.expr, &private)
+	return outScope
+}
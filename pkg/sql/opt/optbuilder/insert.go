@@ -177,7 +177,20 @@ func init() {
 // and thereby scrambles the input ordering.
 func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope) {
 	// Find which table we're working on, check the permissions.
-	tab, depName, alias, refColumns := b.resolveTableForMutation(ins.Table, privilege.INSERT)
+	tab, view, depName, alias, refColumns := b.resolveTableForMutation(
+		ins.Table, privilege.INSERT, tree.TriggerEventInsert,
+	)
+
+	// A view with INSTEAD OF triggers is mutated by invoking the triggers.
+	if view != nil {
+		return b.buildInsteadOfInsert(ins, inScope, view, alias)
+	}
+
+	if tab.IsForeignTable() {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot insert into foreign table \"%s\"", tab.Name(),
+		))
+	}
 
 	if tab.IsVirtualTable() {
 		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
@@ -246,7 +259,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		// Target columns are explicitly specified by name.
 		mb.addTargetNamedColsForInsert(ins.Columns)
 	} else {
-		values := mb.extractValuesInput(ins.Rows)
+		values := extractValuesInput(ins.Rows)
 		if values != nil && len(values.Rows) > 0 {
 			// Target columns are implicitly targeted by VALUES expression in the
 			// same order they appear in the target table schema.
@@ -808,15 +821,19 @@ func (mb *mutationBuilder) buildInsert(
 
 	mb.buildUniqueChecksForInsert()
 
+	mb.buildExclusionChecks(false /* onlyIfUpdated */)
+
 	mb.buildFKChecksForInsert()
 
-	mb.buildRowLevelAfterTriggers(opt.InsertOp)
+	mb.buildAfterTriggers(opt.InsertOp)
 
 	private := mb.makeMutationPrivate(returning != nil, vectorInsert)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fastPathUniqueChecks, mb.fkChecks, private,
 	)
 
+	mb.buildStatementLevelBeforeTriggers(opt.InsertOp)
+
 	mb.buildReturning(returning, returningInScope, returningOutScope)
 }
 
@@ -1029,15 +1046,19 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForUpsert()
 
+	mb.buildExclusionChecks(false /* onlyIfUpdated */)
+
 	mb.buildFKChecksForUpsert()
 
-	mb.buildRowLevelAfterTriggers(opt.InsertOp)
+	mb.buildAfterTriggers(opt.InsertOp)
 
 	private := mb.makeMutationPrivate(returning != nil, false /* vectorInsert */)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
 	)
 
+	mb.buildStatementLevelBeforeTriggers(opt.InsertOp)
+
 	returningInScope, returningOutScope := mb.buildReturningScopes(returning, nil /* colRefs */)
 	mb.buildReturning(returning, returningInScope, returningOutScope)
 }
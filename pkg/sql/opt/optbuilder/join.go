@@ -34,7 +34,7 @@ func (b *Builder) buildJoin(
 	if joinType == descpb.RightOuterJoin || joinType == descpb.FullOuterJoin {
 		leftLockCtx.isNullExtended = true
 	}
-	leftScope := b.buildDataSource(join.Left, nil /* indexFlags */, leftLockCtx, inScope)
+	leftScope := b.buildDataSource(join.Left, nil /* indexFlags */, nil /* tableSample */, leftLockCtx, inScope)
 
 	inScopeRight := inScope
 	isLateral := b.exprIsLateral(join.Right)
@@ -51,7 +51,7 @@ func (b *Builder) buildJoin(
 	if joinType == descpb.LeftOuterJoin || joinType == descpb.FullOuterJoin {
 		rightLockCtx.isNullExtended = true
 	}
-	rightScope := b.buildDataSource(join.Right, nil /* indexFlags */, rightLockCtx, inScopeRight)
+	rightScope := b.buildDataSource(join.Right, nil /* indexFlags */, nil /* tableSample */, rightLockCtx, inScopeRight)
 
 	// Check that the same table name is not used on both sides.
 	b.validateJoinTableNames(leftScope, rightScope)
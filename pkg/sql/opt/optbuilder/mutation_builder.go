@@ -213,7 +213,7 @@ type mutationBuilder struct {
 	// cascades contains foreign key check cascades; see buildFK* methods.
 	cascades memo.FKCascades
 
-	// afterTriggers contains AFTER triggers; see buildRowLevelAfterTriggers.
+	// afterTriggers contains AFTER triggers; see buildAfterTriggers.
 	afterTriggers *memo.AfterTriggers
 
 	// withID is nonzero if we need to buffer the input for FK or uniqueness
@@ -360,6 +360,7 @@ func (mb *mutationBuilder) buildInputForUpdate(
 			includeInverted:  false,
 		}),
 		indexFlags,
+		nil, /* tableSample */
 		noRowLocking,
 		inScope,
 		false, /* disableNotVisibleIndex */
@@ -479,6 +480,7 @@ func (mb *mutationBuilder) buildInputForDelete(
 			includeInverted:  false,
 		}),
 		indexFlags,
+		nil, /* tableSample */
 		noRowLocking,
 		inScope,
 		false, /* disableNotVisibleIndex */
@@ -603,7 +605,7 @@ func (mb *mutationBuilder) addTargetCol(ord int) {
 // extractValuesInput tests whether the given input is a VALUES clause with no
 // WITH, ORDER BY, or LIMIT modifier. If so, it's returned, otherwise nil is
 // returned.
-func (mb *mutationBuilder) extractValuesInput(inputRows *tree.Select) *tree.ValuesClause {
+func extractValuesInput(inputRows *tree.Select) *tree.ValuesClause {
 	if inputRows == nil {
 		return nil
 	}
@@ -615,7 +617,7 @@ func (mb *mutationBuilder) extractValuesInput(inputRows *tree.Select) *tree.Valu
 
 	// Discard parentheses.
 	if parens, ok := inputRows.Select.(*tree.ParenSelect); ok {
-		return mb.extractValuesInput(parens.Select)
+		return extractValuesInput(parens.Select)
 	}
 
 	if values, ok := inputRows.Select.(*tree.ValuesClause); ok {
@@ -638,7 +640,7 @@ func (mb *mutationBuilder) extractValuesInput(inputRows *tree.Select) *tree.Valu
 // replaceDefaultExprs returns a VALUES expression with replaced DEFAULT values,
 // or just the unchanged input expression if there are no DEFAULT values.
 func (mb *mutationBuilder) replaceDefaultExprs(inRows *tree.Select) (outRows *tree.Select) {
-	values := mb.extractValuesInput(inRows)
+	values := extractValuesInput(inRows)
 	if values == nil || len(values.Rows) == 0 {
 		return inRows
 	}
@@ -1721,6 +1723,12 @@ func (mb *mutationBuilder) buildReturningScopes(
 // checkNumCols raises an error if the expected number of columns does not match
 // the actual number of columns.
 func (mb *mutationBuilder) checkNumCols(expected, actual int) {
+	checkMutationNumCols(mb.opName, expected, actual)
+}
+
+// checkMutationNumCols raises an error if the expected number of columns for
+// the given mutation operator does not match the actual number of columns.
+func checkMutationNumCols(opName string, expected, actual int) {
 	if actual != expected {
 		more, less := "expressions", "target columns"
 		if actual < expected {
@@ -1729,7 +1737,7 @@ func (mb *mutationBuilder) checkNumCols(expected, actual int) {
 
 		panic(pgerror.Newf(pgcode.Syntax,
 			"%s has more %s than %s, %d expressions for %d targets",
-			strings.ToUpper(mb.opName), more, less, actual, expected))
+			strings.ToUpper(opName), more, less, actual, expected))
 	}
 }
 
@@ -1771,6 +1779,12 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 			}
 			return datum
 		}
+		if typ := col.DatumType(); typ.IsDomain() {
+			// A column of a domain type without a default uses the default of
+			// the domain. The value is cast to the domain so that the domain's
+			// constraints are checked.
+			return mb.parseDomainDefaultExpr(colID, typ)
+		}
 
 		return tree.DNull
 	}
@@ -1782,6 +1796,26 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	)
 }
 
+// parseDomainDefaultExpr returns the default expression of the given domain
+// type, cast to the domain, for use as the default of the given column. It is
+// cached in the same way as column default expressions.
+func (mb *mutationBuilder) parseDomainDefaultExpr(colID opt.ColumnID, typ *types.T) tree.Expr {
+	ord := mb.tabID.ColumnOrdinal(colID)
+	if mb.parsedColDefaultExprs[ord] != nil {
+		return mb.parsedColDefaultExprs[ord]
+	}
+	var expr tree.Expr = tree.DNull
+	if domain := typ.TypeMeta.DomainData; domain != nil && domain.DefaultExpr != "" {
+		var err error
+		if expr, err = parser.ParseExpr(domain.DefaultExpr); err != nil {
+			panic(err)
+		}
+	}
+	expr = &tree.CastExpr{Expr: expr, Type: typ, SyntaxMode: tree.CastShort}
+	mb.parsedColDefaultExprs[ord] = expr
+	return expr
+}
+
 // parseOnUpdateExpr parses the on update (including nullable) expression for
 // the given table column, and caches it for reuse.
 func (mb *mutationBuilder) parseOnUpdateExpr(colID opt.ColumnID) tree.Expr {
@@ -1873,6 +1907,23 @@ func (mb *mutationBuilder) parseUniqueConstraintPredicateExpr(uniq cat.UniqueOrd
 	return expr
 }
 
+// parseExclusionConstraintPredicateExpr parses the predicate of the given
+// partial exclusion constraint. The expression is not cached, since it is
+// parsed at most twice per mutation.
+func (mb *mutationBuilder) parseExclusionConstraintPredicateExpr(
+	excl cat.ExclusionOrdinal,
+) tree.Expr {
+	predStr, isPartial := mb.tab.Exclusion(excl).Predicate()
+	if !isPartial {
+		panic(errors.AssertionFailedf("exclusion constraint at ordinal %d is not partial", excl))
+	}
+	expr, err := parser.ParseExpr(predStr)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
 // getIndexLaxKeyOrdinals returns the ordinals of all lax key columns in the
 // given index. A column's ordinal is the ordered position of that column in the
 // owning table.
@@ -1972,6 +2023,7 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		// Create the cast expression.
 		variable := mb.b.factory.ConstructVariable(colID)
 		cast := mb.b.factory.ConstructAssignmentCast(variable, targetType)
+		cast = mb.b.buildDomainCheck(cast, targetType)
 
 		// Lazily create the new scope.
 		if projectionScope == nil {
@@ -332,6 +332,7 @@ func (mb *mutationBuilder) buildAntiJoinForDoNothingArbiter(
 			includeInverted:  false,
 		}),
 		indexFlags,
+		nil, /* tableSample */
 		locking,
 		inScope,
 		true, /* disableNotVisibleIndex */
@@ -472,6 +473,7 @@ func (mb *mutationBuilder) buildLeftJoinForUpsertArbiter(
 			includeInverted:  false,
 		}),
 		indexFlags,
+		nil, /* tableSample */
 		locking,
 		inScope,
 		true, /* disableNotVisibleIndex */
@@ -690,6 +692,7 @@ func (h *arbiterPredicateHelper) tableScope() *scope {
 				includeInverted:  false,
 			}),
 			nil, /* indexFlags */
+			nil, /* tableSample */
 			noRowLocking,
 			h.mb.b.allocScope(),
 			false, /* disableNotVisibleIndex */
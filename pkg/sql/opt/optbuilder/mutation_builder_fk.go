@@ -666,6 +666,7 @@ func (h *fkCheckHelper) buildOtherTableScan(parent bool) (outScope *scope, tabMe
 		otherTabMeta,
 		h.otherTabOrdinals,
 		indexFlags,
+		nil, /* tableSample */
 		locking,
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
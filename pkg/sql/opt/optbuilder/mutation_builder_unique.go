@@ -664,6 +664,7 @@ func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
 		tabMeta,
 		ordinals,
 		indexFlags,
+		nil, /* tableSample */
 		locking,
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
@@ -671,6 +672,133 @@ func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
 	), ordinals
 }
 
+// buildExclusionChecks builds check queries that enforce the table's
+// exclusion constraints. If onlyIfUpdated is true, checks are only built for
+// constraints that include updated columns.
+func (mb *mutationBuilder) buildExclusionChecks(onlyIfUpdated bool) {
+	for i, n := 0, mb.tab.ExclusionCount(); i < n; i++ {
+		if onlyIfUpdated && !mb.exclusionColsUpdated(i) {
+			continue
+		}
+		if mb.b.evalCtx.TxnIsoLevel != isolation.Serializable {
+			panic(unimplemented.New("exclusion constraint isolation",
+				"exclusion constraint under non-serializable isolation levels"))
+		}
+		mb.uniqueChecks = append(mb.uniqueChecks, mb.buildExclusionCheck(i))
+	}
+}
+
+// exclusionColsUpdated returns true if any of the columns for an exclusion
+// constraint are being updated (according to updateColIDs). When the
+// exclusion constraint has a partial predicate, it also returns true if the
+// predicate references any of the columns being updated.
+func (mb *mutationBuilder) exclusionColsUpdated(exclusionOrdinal cat.ExclusionOrdinal) bool {
+	ec := mb.tab.Exclusion(exclusionOrdinal)
+
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		if ord := ec.ColumnOrdinal(mb.tab, i); mb.updateColIDs[ord] != 0 {
+			return true
+		}
+	}
+
+	if _, isPartial := ec.Predicate(); isPartial {
+		pred := mb.parseExclusionConstraintPredicateExpr(exclusionOrdinal)
+		typedPred := mb.fetchScope.resolveAndRequireType(pred, types.Bool)
+
+		var predCols opt.ColSet
+		mb.b.buildScalar(typedPred, mb.fetchScope, nil, nil, &predCols)
+		for colID, ok := predCols.Next(0); ok; colID, ok = predCols.Next(colID + 1) {
+			ord := mb.md.ColumnMeta(colID).Table.ColumnOrdinal(colID)
+			if mb.updateColIDs[ord] != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// buildExclusionCheck creates a check for the exclusion constraint with the
+// given ordinal. Like a uniqueness check, it is a self semi-join with the new
+// values on the left and the existing values on the right, but the join
+// filters compare each column with the constraint's operator rather than with
+// equality:
+//
+//	(new_a = existing_a) AND (new_b && existing_b) AND ...
+func (mb *mutationBuilder) buildExclusionCheck(
+	exclusionOrdinal cat.ExclusionOrdinal,
+) memo.UniqueChecksItem {
+	f := mb.b.factory
+	ec := mb.tab.Exclusion(exclusionOrdinal)
+
+	// The scan of the table is built the same way as for uniqueness checks.
+	h := uniqueCheckHelper{mb: mb}
+	scanScope, scanOrdinals := h.buildTableScan()
+	checkScope, _ := mb.buildCheckInputScan(
+		checkInputScanNewVals, scanOrdinals, false, /* isFK */
+	)
+
+	_, isPartial := ec.Predicate()
+	semiJoinFilters := make(memo.FiltersExpr, 0, ec.ColumnCount()+3)
+	keyCols := make(opt.ColList, ec.ColumnCount())
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		ord := ec.ColumnOrdinal(mb.tab, i)
+		cmp := &tree.ComparisonExpr{Operator: ec.Operator(i)}
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+			mb.b.constructComparison(
+				cmp,
+				f.ConstructVariable(checkScope.cols[ord].id),
+				f.ConstructVariable(scanScope.cols[ord].id),
+			),
+		))
+		keyCols[i] = checkScope.cols[ord].id
+	}
+
+	// If the exclusion constraint is partial, only rows that satisfy the
+	// predicate on both sides of the join can conflict.
+	if isPartial {
+		pred := mb.parseExclusionConstraintPredicateExpr(exclusionOrdinal)
+
+		typedPred := checkScope.resolveAndRequireType(pred, types.Bool)
+		withScanPred := mb.b.buildScalar(typedPred, checkScope, nil, nil, nil)
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(withScanPred))
+
+		typedPred = scanScope.resolveAndRequireType(pred, types.Bool)
+		scanPred := mb.b.buildScalar(typedPred, scanScope, nil, nil, nil)
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(scanPred))
+	}
+
+	// Prevent rows from conflicting with themselves:
+	//    (new_pk1 != existing_pk1) OR (new_pk2 != existing_pk2) OR ...
+	var pkFilter opt.ScalarExpr
+	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
+	for i, ok := primaryOrds.Next(0); ok; i, ok = primaryOrds.Next(i + 1) {
+		pkFilterLocal := f.ConstructNe(
+			f.ConstructVariable(checkScope.cols[i].id),
+			f.ConstructVariable(scanScope.cols[i].id),
+		)
+		if pkFilter == nil {
+			pkFilter = pkFilterLocal
+		} else {
+			pkFilter = f.ConstructOr(pkFilter, pkFilterLocal)
+		}
+	}
+	semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(pkFilter))
+
+	semiJoin := f.ConstructSemiJoin(checkScope.expr, scanScope.expr, semiJoinFilters, memo.EmptyJoinPrivate)
+
+	// Pass through only the constraint columns, which are shown in the error
+	// message if there is a violation.
+	project := f.ConstructProject(semiJoin, nil /* projections */, keyCols.ToSet())
+
+	return f.ConstructUniqueChecksItem(project, &memo.UniqueChecksItemPrivate{
+		Table:        mb.tabID,
+		CheckOrdinal: exclusionOrdinal,
+		Exclusion:    true,
+		KeyCols:      keyCols,
+	})
+}
+
 // columnIsGenRandomUUID returns true if the expression returns the function
 // gen_random_uuid() for the given column.
 func columnIsGenRandomUUID(e memo.RelExpr, col opt.ColumnID) bool {
@@ -216,6 +216,14 @@ type plOptions struct {
 	isTriggerFn      bool
 	isDoBlock        bool
 
+	// isSecurityDefiner is true if the routine is executed with the privileges
+	// of its owner. Dynamic SQL is not allowed in this case.
+	isSecurityDefiner bool
+
+	// hasConfig is true if the routine has session variable overrides specified
+	// with SET clauses. Transaction control is not allowed in this case.
+	hasConfig bool
+
 	// skipSQL is true if SQL statements and expressions should not be built.
 	// This is used during trigger function creation.
 	skipSQL bool
@@ -275,6 +283,20 @@ func (opts plOptions) SetIsTriggerFn(isTriggerFn bool) plOptions {
 	return opts
 }
 
+// SetIsSecurityDefiner returns a new plOptions struct with the
+// isSecurityDefiner flag set to the given value.
+func (opts plOptions) SetIsSecurityDefiner(isSecurityDefiner bool) plOptions {
+	opts.isSecurityDefiner = isSecurityDefiner
+	return opts
+}
+
+// SetHasConfig returns a new plOptions struct with the hasConfig flag set to
+// the given value.
+func (opts plOptions) SetHasConfig(hasConfig bool) plOptions {
+	opts.hasConfig = hasConfig
+	return opts
+}
+
 // SetSkipSQL returns a new plOptions struct with the skipSQL flag set to the
 // given value.
 func (opts plOptions) SetSkipSQL(skipSQL bool) plOptions {
@@ -487,9 +509,7 @@ func (b *plpgsqlBuilder) addDeclarations(decls []ast.Statement, block *plBlock,
 			if err != nil {
 				panic(err)
 			}
-			if typ.Identical(types.AnyTuple) {
-				panic(recordVarErr)
-			} else if typ.IsPolymorphicType() {
+			if typ.IsPolymorphicType() {
 				// NOTE: Postgres also returns an "unsupported" error.
 				panic(pgerror.Newf(pgcode.FeatureNotSupported,
 					"variable \"%s\" has pseudo-type %s", dec.Var, typ.Name(),
@@ -691,7 +711,19 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// to the result buffer.
 			retCon := b.makeContinuation("return_next")
 			retCon.def.FirstStmtOutput.TargetBufferID = b.resultBufferID
-			retQueryScope := b.buildSQLStatement(t.SqlStmt, retCon.s)
+			query := t.SqlStmt
+			if t.DynamicQuery != nil {
+				// RETURN QUERY EXECUTE coerces the result of the dynamic query to the
+				// return type of the routine. Note that the return type of a
+				// RECORD-returning function is only known when it is invoked.
+				b.checkDynamicSQLAllowed()
+				typs := []*types.T{b.setReturnType}
+				if b.setReturnType.Family() == types.TupleFamily && !b.setReturnType.Identical(types.AnyTuple) {
+					typs = b.setReturnType.TupleContents()
+				}
+				query = b.makeDynamicExecuteQuery(t.DynamicQuery, t.Params, typs)
+			}
+			retQueryScope := b.buildSQLStatement(query, retCon.s)
 			if !b.setReturnType.Identical(types.AnyTuple) {
 				// The query must be validated against the expected return type. Do not
 				// validate during creation of a RECORD-returning function, since the
@@ -864,11 +896,24 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 				b.popContinuation()
 				return scope
 			default:
-				panic(errors.WithDetail(unsupportedPLStmtErr,
-					"query and cursor FOR loops are not yet supported",
-				))
+				// FOR target IN query LOOP ...
+				// FOR target IN cursor [ ( args ) ] LOOP ...
+				// FOR target IN EXECUTE query [ USING params ] LOOP ...
+				//
+				// The exit continuation is not pushed here, since it has to be
+				// wrapped by a continuation that closes the loop cursor.
+				return b.handleQueryForLoop(s, t, &exitCon)
 			}
 
+		case *ast.ForEachArray:
+			// Build a continuation that will resume execution after the loop.
+			exitCon := b.makeContinuationWithTyp("loop_exit", t.Label, continuationLoopExit)
+			b.appendPlpgSQLStmts(&exitCon, stmts[i+1:])
+			b.pushContinuation(exitCon)
+			scope := b.handleForEachArrayLoop(s, t)
+			b.popContinuation()
+			return scope
+
 		case *ast.Exit:
 			if t.Condition != nil {
 				// EXIT with a condition is syntactic sugar for EXIT inside an IF stmt.
@@ -1036,39 +1081,23 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// This is handled by calling the plpgsql_open_cursor internal builtin
 			// function in a separate body statement that returns no results, similar
 			// to the RAISE implementation.
-			if t.Scroll == tree.Scroll {
-				panic(scrollableCursorErr)
+			query, decl := b.resolveOpenQuery(t)
+			if decl != nil && decl.Args != nil {
+				// The arguments of a bound cursor are declared as variables in an
+				// implicit block, so that they can be referenced by the cursor query.
+				return b.handleOpenWithArgs(s, t, decl, query, stmts[i+1:])
 			}
-			openCon := b.makeContinuation("_stmt_open")
-			openCon.def.Volatility = volatility.Volatile
-			_, source, _, err := openCon.s.FindSourceProvidingColumn(b.ob.ctx, t.CurVar)
-			if err != nil {
-				if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
-					panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", t.CurVar))
-				}
-				panic(err)
-			}
-			if !source.(*scopeColumn).typ.Identical(types.RefCursor) {
-				panic(pgerror.Newf(pgcode.DatatypeMismatch,
-					"variable \"%s\" must be of type cursor or refcursor", t.CurVar,
-				))
+			scroll := t.Scroll
+			if decl != nil {
+				// The scroll option of a bound cursor is specified by its declaration.
+				scroll = decl.Scroll
 			}
-			// Initialize the routine with the information needed to pipe the first
-			// body statement into a cursor.
-			query := b.resolveOpenQuery(t)
-			fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
-			fmtCtx.FormatNode(query)
-			openCon.def.FirstStmtOutput.CursorDeclaration = &tree.RoutineOpenCursor{
-				NameArgIdx: source.(*scopeColumn).getParamOrd(),
-				Scroll:     t.Scroll,
-				CursorSQL:  fmtCtx.CloseAndGetString(),
-			}
-			openScope := b.buildSQLStatement(query, openCon.s)
-			if openScope.expr.Relational().CanMutate {
-				// Cursors with mutations are invalid.
-				panic(cursorMutationErr)
-			}
-			b.appendBodyStmtFromScope(&openCon, openScope)
+			openCon := b.makeContinuation("_stmt_open")
+			nameCol := b.resolveCursorVariable(openCon.s, t.CurVar)
+			b.buildCursorOpen(
+				&openCon, nameCol.getParamOrd(), scroll, query, t.DynamicQuery, t.Params,
+				false, /* foundColumn */
+			)
 			b.appendPlpgSQLStmts(&openCon, stmts[i+1:])
 
 			// Build a statement to generate a unique name for the cursor if one
@@ -1088,36 +1117,8 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// that calls the builtin function.
 			closeCon := b.makeContinuation("_stmt_close")
 			closeCon.def.Volatility = volatility.Volatile
-			const closeFnName = "crdb_internal.plpgsql_close"
-			props, overloads := builtinsregistry.GetBuiltinProperties(closeFnName)
-			if len(overloads) != 1 {
-				panic(errors.AssertionFailedf("expected one overload for %s", closeFnName))
-			}
-			_, source, _, err := closeCon.s.FindSourceProvidingColumn(b.ob.ctx, t.CurVar)
-			if err != nil {
-				if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
-					panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", t.CurVar))
-				}
-				panic(err)
-			}
-			if !source.(*scopeColumn).typ.Identical(types.RefCursor) {
-				panic(pgerror.Newf(pgcode.DatatypeMismatch,
-					"variable \"%s\" must be of type cursor or refcursor", t.CurVar,
-				))
-			}
-			closeCall := b.ob.factory.ConstructFunction(
-				memo.ScalarListExpr{b.ob.factory.ConstructVariable(source.(*scopeColumn).id)},
-				&memo.FunctionPrivate{
-					Name:       closeFnName,
-					Typ:        types.Int,
-					Properties: props,
-					Overload:   &overloads[0],
-				},
-			)
-			closeColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_close"))
-			closeScope := closeCon.s.push()
-			b.ob.synthesizeColumn(closeScope, closeColName, types.Int, nil /* expr */, closeCall)
-			b.ob.constructProjectForScope(closeCon.s, closeScope)
+			nameCol := b.resolveCursorVariable(closeCon.s, t.CurVar)
+			closeScope := b.buildCursorClose(closeCon.s, nameCol.id)
 			b.appendBodyStmtFromScope(&closeCon, closeScope)
 			b.appendPlpgSQLStmts(&closeCon, stmts[i+1:])
 			return b.callContinuation(&closeCon, s)
@@ -1186,9 +1187,6 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// During execution, a TxnControlExpr directs the session to commit or
 			// rollback the transaction, and supplies a plan for the continuation to
 			// run in the new transaction.
-			if t.Chain {
-				panic(txnControlWithChainErr)
-			}
 			// NOTE: postgres doesn't make the following checks until runtime (see
 			// also #119750).
 			// TODO(#88198): check the calling context, since transaction control
@@ -1199,6 +1197,9 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			if !b.options.isProcedure {
 				panic(txnInUDFErr)
 			}
+			if b.options.hasConfig {
+				panic(txnControlWithConfigErr)
+			}
 			name := "_stmt_commit"
 			txnOpType := tree.StoredProcTxnCommit
 			if t.Rollback {
@@ -1227,7 +1228,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			con := b.makeContinuation(name)
 			con.def.Volatility = volatility.Volatile
 			b.appendPlpgSQLStmts(&con, stmts)
-			return b.callContinuationWithTxnOp(&con, s, txnOpType, txnModes)
+			return b.callContinuationWithTxnOp(&con, s, txnOpType, txnModes, t.Chain)
 
 		case *ast.Call:
 			// Build a continuation that will execute the procedure, and then the
@@ -1310,6 +1311,34 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			b.appendPlpgSQLStmts(&doCon, stmts[i+1:])
 			return b.callContinuation(&doCon, s)
 
+		case *ast.Perform:
+			// PERFORM statements execute a SELECT query and discard its result. They
+			// are handled the same way as a SQL statement without an INTO target.
+			execStmt := &ast.Execute{SqlStmt: t.SqlStmt}
+			return b.buildPLpgSQLStatements(b.prependStmt(execStmt, stmts[i+1:]), s)
+
+		case *ast.DynamicExecute:
+			// EXECUTE statements run a query string that is constructed at runtime.
+			// They are handled by rewriting them as a SQL statement that calls the
+			// crdb_internal.plpgsql_execute_dynamic builtin function, which executes
+			// the query and produces its result coerced to the types of the INTO
+			// target, if any.
+			b.checkDynamicSQLAllowed()
+			b.checkDuplicateTargets(t.Target, "INTO")
+			var typs []*types.T
+			if len(t.Target) > 0 {
+				typs = b.resolveIntoTargetTypes(t.Target)
+				if b.targetIsRecordVar(t.Target) {
+					if typ, _ := b.resolveVariableForAssign(t.Target[0]); typ.Identical(types.AnyTuple) {
+						// The shape of a RECORD variable is determined by the query.
+						typs = []*types.T{types.AnyTuple}
+					}
+				}
+			}
+			query := b.makeDynamicExecuteQuery(t.Query, t.Params, typs)
+			execStmt := &ast.Execute{SqlStmt: query, Target: t.Target, Strict: t.Strict}
+			return b.buildPLpgSQLStatements(b.prependStmt(execStmt, stmts[i+1:]), s)
+
 		default:
 			panic(errors.WithDetailf(unsupportedPLStmtErr,
 				"%s is not yet supported", stmt.PlpgSQLStatementTag(),
@@ -1464,30 +1493,397 @@ func (b *plpgsqlBuilder) handleIntForLoop(
 	return b.callContinuation(&loopCon, s)
 }
 
-// resolveOpenQuery finds and validates the query that is bound to cursor for
-// the given OPEN statement.
-func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
-	// Search the blocks in reverse order to ensure that more recent declarations
-	// are encountered first.
-	var boundStmt tree.Statement
-	for i := len(b.blocks) - 1; i >= 0; i-- {
-		block := &b.blocks[i]
-		for name := range block.cursors {
-			if open.CurVar == name {
-				boundStmt = block.cursors[name].Query
-				break
+// handleQueryForLoop constructs the plan for a FOR loop that iterates over the
+// rows returned by a query, a bound cursor, or a query string that is computed
+// at execution time. The rows are read through a cursor that is opened before
+// the first iteration, and closed once the loop exits:
+//
+//	FOR target IN query LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	OPEN _loop_cursor FOR query;
+//	LOOP
+//	  FETCH _loop_cursor INTO target;
+//	  IF NOT FOUND THEN
+//	    CLOSE _loop_cursor;
+//	    EXIT;
+//	  END IF;
+//	  [body];
+//	END LOOP;
+//
+// The cursor query is extended with a leading column that is always true, which
+// allows a fetched row to be distinguished from the end of the cursor. Like in
+// Postgres, the target variables keep the values of the last row after the loop
+// exits.
+//
+// Note that the cursor is not closed when control leaves the loop through a
+// RETURN statement, an EXIT for an enclosing loop, or an error. In that case,
+// the cursor remains open until the end of the transaction.
+func (b *plpgsqlBuilder) handleQueryForLoop(
+	s *scope, forLoop *ast.ForLoop, exitCon *continuation,
+) *scope {
+	b.checkDuplicateTargets(forLoop.Target, "FOR")
+	var query tree.Statement
+	var decl *ast.CursorDeclaration
+	var cursorArgs, params []ast.Expr
+	var dynamicQuery ast.Expr
+	switch c := forLoop.Control.(type) {
+	case *ast.QueryForLoopControl:
+		query = c.Query
+		if _, ok := query.(*tree.Select); !ok {
+			panic(unimplemented.Newf("plpgsql query for loop",
+				"FOR loop over %s query is not yet supported", query.StatementTag(),
+			))
+		}
+	case *ast.CursorForLoopControl:
+		if decl = b.resolveBoundCursor(c.CurVar); decl == nil {
+			panic(cursorForLoopUnboundErr)
+		}
+		if len(forLoop.Target) != 1 {
+			panic(cursorForLoopTargetErr)
+		}
+		b.checkCursorArgs(decl, c.Args)
+		b.checkCursorQuery(decl.Query)
+		query, cursorArgs = decl.Query, c.Args
+	case *ast.DynamicForLoopControl:
+		dynamicQuery, params = c.Query, c.Params
+	default:
+		panic(errors.AssertionFailedf("unexpected FOR loop control: %T", c))
+	}
+	// Build an implicit block declaring the cursor arguments and loop target for
+	// a cursor FOR loop, as well as a hidden variable for the loop cursor.
+	b.pushNewBlock(&ast.Block{Label: forLoop.Label})
+	defer b.popBlock()
+	if decl != nil {
+		// The arguments are declared first, so that they are visible to the
+		// cursor query.
+		s = b.addCursorArgs(s, decl, cursorArgs)
+
+		// The target of a cursor FOR loop is implicitly declared as a record
+		// variable with the row type of the cursor query.
+		rowTyp := b.makeRowTypeForScope(b.buildSQLStatement(query, s))
+		b.addVariable(forLoop.Target[0], rowTyp)
+		s = b.addPLpgSQLAssign(
+			s, forLoop.Target[0], &tree.CastExpr{Expr: tree.DNull, Type: rowTyp}, noIndirection,
+		)
+	}
+	var rowTyp *types.T
+	typs := b.resolveIntoTargetTypes(forLoop.Target)
+	if b.targetIsRecordVar(forLoop.Target) {
+		rowTyp, _ = b.resolveVariableForAssign(forLoop.Target[0])
+		if rowTyp.Identical(types.AnyTuple) {
+			// The target is a RECORD variable, which takes on the row type of the
+			// query. The row type of a dynamic query is only known at execution
+			// time, so each row is produced as a single RECORD value.
+			if dynamicQuery != nil {
+				b.checkDynamicSQLAllowed()
+				query = b.makeDynamicExecuteQuery(dynamicQuery, params, []*types.T{types.AnyTuple})
+				dynamicQuery, params = nil, nil
+			}
+			rowTyp = b.makeRowTypeForScope(b.buildSQLStatement(query, s))
+			typs = rowTyp.TupleContents()
+		}
+	}
+	const cursorName = "_loop_cursor"
+	cursorOrd := b.addHiddenVariable(cursorName, types.RefCursor)
+
+	// Generate a unique name for the loop cursor. A cursor FOR loop uses the
+	// name stored in the cursor variable, if it is set.
+	cursorNameArg := b.ob.factory.ConstructNull(types.RefCursor)
+	if decl != nil {
+		cursorNameArg = b.ob.factory.ConstructVariable(b.resolveCursorVariable(s, decl.Name).id)
+	}
+	s = b.assignScalarToHiddenVariable(s, cursorOrd, b.makeCursorNameGenCall(cursorNameArg))
+
+	// The looping is implemented by three continuations: one that closes the
+	// cursor before exiting the loop, one that fetches the next row and executes
+	// the loop body, and one that opens the cursor and starts the loop.
+	closeCon := b.makeContinuationWithTyp("loop_exit", forLoop.Label, continuationLoopExit)
+	closeCon.def.Volatility = volatility.Volatile
+	b.appendBodyStmtFromScope(&closeCon,
+		b.buildCursorClose(closeCon.s, closeCon.s.findFuncArgCol(cursorOrd).id),
+	)
+	exitScope := closeCon.s.push()
+	b.ensureScopeHasExpr(exitScope)
+	b.appendBodyStmtFromScope(&closeCon, b.callContinuation(exitCon, exitScope))
+
+	loopCon := b.makeContinuationWithTyp("stmt_loop", forLoop.Label, continuationLoopContinue)
+	loopCon.def.IsRecursive = true
+
+	// Push the exit and loop continuations so that EXIT and CONTINUE statements
+	// in the loop body can call them. Reaching the end of the loop body also
+	// calls into the loop continuation.
+	b.pushContinuation(closeCon)
+	b.pushContinuation(loopCon)
+
+	// Fetch the next row and assign it to the target variables, and then
+	// execute the loop body if a row was found.
+	fetchScope := loopCon.s.push()
+	b.ensureScopeHasExpr(fetchScope)
+	fetchTyps := append([]*types.T{types.Bool}, typs...)
+	fetchScope = b.buildFetchCall(
+		fetchScope, fetchScope.findFuncArgCol(cursorOrd).id, tree.FetchNormal, 1 /* count */, fetchTyps,
+	)
+	intoScope := b.projectLoopRowAsTarget(fetchScope, forLoop.Target, rowTyp)
+	b.ob.addBarrier(intoScope)
+	found := &tree.ColumnAccessExpr{Expr: &fetchScope.cols[0], ByIndex: true, ColIndex: 0}
+	ifStmt := &ast.If{Condition: found, ThenBody: forLoop.Body, ElseBody: []ast.Statement{&ast.Exit{}}}
+	b.appendBodyStmtFromScope(&loopCon, b.buildPLpgSQLStatements([]ast.Statement{ifStmt}, intoScope))
+
+	// Now that the loop body is built, pop the loop and exit continuations.
+	b.popContinuation()
+	b.popContinuation()
+
+	// Finally, open the cursor and call the loop continuation.
+	openCon := b.makeContinuation("_stmt_open")
+	b.buildCursorOpen(
+		&openCon, cursorOrd, tree.UnspecifiedScroll, query, dynamicQuery, params, true, /* foundColumn */
+	)
+	loopScope := openCon.s.push()
+	b.ensureScopeHasExpr(loopScope)
+	b.appendBodyStmtFromScope(&openCon, b.callContinuation(&loopCon, loopScope))
+	return b.callContinuation(&openCon, s)
+}
+
+// projectLoopRowAsTarget is similar to projectTupleAsIntoTarget, but handles the
+// tuple fetched by a FOR loop over query rows. The first element of the tuple
+// indicates whether a row was found; the target variables keep their previous
+// values if not. The tuple column is passed through, so that it can be used to
+// decide whether to execute the loop body. If the target is a single
+// record-type variable, rowTyp is the type of the row that is assigned to it.
+func (b *plpgsqlBuilder) projectLoopRowAsTarget(
+	inScope *scope, target []ast.Variable, rowTyp *types.T,
+) *scope {
+	intoScope := inScope.push()
+	intoScope.appendColumnsFromScope(inScope)
+	tupleCol := b.ob.factory.ConstructVariable(inScope.cols[0].id)
+	found := b.ob.factory.ConstructColumnAccess(tupleCol, memo.TupleOrdinal(0))
+	rowElem := func(i int) opt.ScalarExpr {
+		return b.ob.factory.ConstructColumnAccess(tupleCol, memo.TupleOrdinal(i+1))
+	}
+	assign := func(name ast.Variable, val opt.ScalarExpr) {
+		typ, ord := b.resolveVariableForAssign(name)
+		_, source, _, err := inScope.FindSourceProvidingColumn(b.ob.ctx, name)
+		if err != nil {
+			panic(err)
+		}
+		prevVal := b.ob.factory.ConstructVariable(source.(*scopeColumn).id)
+		scalar := b.ob.factory.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{b.ob.factory.ConstructWhen(found, val)},
+			prevVal,
+		)
+		col := b.ob.synthesizeColumn(intoScope, scopeColName(name), typ, nil /* expr */, scalar)
+		col.setParamOrd(ord)
+	}
+	if b.targetIsRecordVar(target) {
+		typ, _ := b.resolveVariableForAssign(target[0])
+		rowTyps := rowTyp.TupleContents()
+		if len(rowTyps) == 1 && rowTyps[0].Identical(types.AnyTuple) {
+			// The row was produced as a single RECORD value.
+			assign(target[0], rowElem(0))
+		} else {
+			elems := make(memo.ScalarListExpr, len(rowTyps))
+			for i := range elems {
+				elems[i] = rowElem(i)
 			}
+			assign(target[0], b.coerceType(b.ob.factory.ConstructTuple(elems, rowTyp), typ))
+		}
+	} else {
+		for i := range target {
+			assign(target[i], rowElem(i))
+		}
+	}
+	b.ob.constructProjectForScope(inScope, intoScope)
+	return intoScope
+}
+
+// handleForEachArrayLoop constructs the plan for a FOREACH loop, which iterates
+// over the elements of an array. With SLICE 1, the loop instead executes once
+// with the whole array, since only one-dimensional arrays are supported. The
+// loop is implemented similarly to an integer FOR loop over the array indexes:
+//
+//	FOREACH target IN ARRAY arr LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	FOR _foreach_counter IN 1..cardinality(arr) LOOP
+//	  target := arr[_foreach_counter];
+//	  [body];
+//	END LOOP;
+func (b *plpgsqlBuilder) handleForEachArrayLoop(s *scope, loop *ast.ForEachArray) *scope {
+	b.checkDuplicateTargets(loop.Target, "FOREACH")
+	arrTyp := b.resolveSQLExprType(loop.Expr, s)
+	if arrTyp.Family() != types.ArrayFamily && !b.options.skipSQL {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"FOREACH expression must yield an array, not type %s", arrTyp.Name(),
+		))
+	}
+	if loop.Slice < 0 || loop.Slice > 1 {
+		panic(pgerror.Newf(pgcode.ArraySubscript,
+			"slice dimension (%d) is out of the valid range 0..1", loop.Slice,
+		))
+	}
+	// Validate the target variables.
+	elemTyp := arrTyp
+	if loop.Slice == 0 && arrTyp.Family() == types.ArrayFamily {
+		elemTyp = arrTyp.ArrayContents()
+	}
+	if len(loop.Target) == 1 {
+		typ, _ := b.resolveVariableForAssign(loop.Target[0])
+		if loop.Slice > 0 && typ.Family() != types.ArrayFamily {
+			panic(forEachNotArrayTargetErr)
 		}
+		if loop.Slice == 0 && typ.Family() == types.ArrayFamily {
+			panic(forEachArrayTargetErr)
+		}
+	} else {
+		if loop.Slice > 0 {
+			panic(forEachNotArrayTargetErr)
+		}
+		if elemTyp.Family() != types.TupleFamily && !b.options.skipSQL {
+			panic(nonCompositeRowVarErr)
+		}
+	}
+
+	// Build an implicit block declaring hidden variables for the array, the
+	// number of iterations, and an internal counter that is incremented on each
+	// iteration.
+	b.pushNewBlock(&ast.Block{Label: loop.Label})
+	defer b.popBlock()
+	const (
+		arrayName   = "_foreach_array"
+		upperName   = "_foreach_upper"
+		counterName = "_foreach_counter"
+	)
+	arrayOrd := b.addHiddenVariable(arrayName, arrTyp)
+	upperOrd := b.addHiddenVariable(upperName, types.Int)
+	counterOrd := b.addHiddenVariable(counterName, types.Int)
+	s = b.assignToHiddenVariable(s, arrayOrd, loop.Expr)
+
+	// Add a runtime check that the array is not NULL.
+	const severity, detail, hint = "ERROR", "", ""
+	b.addRuntimeCheck(s,
+		memo.ScalarListExpr{b.buildSQLExpr(
+			&tree.IsNullExpr{Expr: s.findFuncArgCol(arrayOrd)}, types.Bool, s,
+		)},
+		[]memo.ScalarListExpr{b.ob.makeConstRaiseArgs(
+			severity, "FOREACH expression must not be null", detail, hint,
+			pgcode.NullValueNotAllowed.String(),
+		)},
+	)
+
+	// Initialize the number of iterations and the loop counter.
+	var upper tree.Expr = &tree.FuncExpr{
+		Func:  tree.WrapFunction("cardinality"),
+		Exprs: tree.Exprs{s.findFuncArgCol(arrayOrd)},
+	}
+	if loop.Slice > 0 {
+		// The whole array is assigned to the target in a single iteration,
+		// unless the array is empty.
+		upper = &tree.FuncExpr{
+			Func:  tree.WrapFunction("least"),
+			Exprs: tree.Exprs{upper, tree.NewDInt(1)},
+		}
+	}
+	s = b.assignToHiddenVariable(s, upperOrd, upper)
+	s = b.assignToHiddenVariable(s, counterOrd, tree.NewDInt(1))
+
+	// The looping will be implemented by two continuations: one to execute the
+	// loop body, and one to increment the counter variable. The loop body and
+	// increment continuations will call each other recursively.
+	loopCon := b.makeContinuation("stmt_loop")
+	loopCon.def.IsRecursive = true
+	incrementCon := b.makeContinuationWithTyp("stmt_loop_inc", loop.Label, continuationLoopContinue)
+	incrementCon.def.IsRecursive = true
+
+	// Push the increment continuation so that the loop body can call into it.
+	b.pushContinuation(incrementCon)
+
+	// Now, build the loop body continuation. Assign the current element to the
+	// target variables, and then build an IF statement that checks whether the
+	// counter variable has exceeded the number of iterations, and executes the
+	// loop body if not. The target variables are only assigned if the counter is
+	// in range, so that they keep the last element after the loop exits.
+	bodyScope := loopCon.s.push()
+	b.ensureScopeHasExpr(bodyScope)
+	makeCond := func() tree.Expr {
+		return &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.LE),
+			Left:     loopCon.s.findFuncArgCol(counterOrd),
+			Right:    loopCon.s.findFuncArgCol(upperOrd),
+		}
+	}
+	makeElem := func() tree.Expr {
+		if loop.Slice > 0 {
+			return loopCon.s.findFuncArgCol(arrayOrd)
+		}
+		return &tree.IndirectionExpr{
+			Expr: loopCon.s.findFuncArgCol(arrayOrd),
+			Indirection: tree.ArraySubscripts{
+				&tree.ArraySubscript{Begin: loopCon.s.findFuncArgCol(counterOrd)},
+			},
+		}
+	}
+	assign := func(name ast.Variable, val tree.Expr) {
+		typ, _ := b.resolveVariableForAssign(name)
+		caseExpr := &tree.CaseExpr{
+			Whens: []*tree.When{{Cond: makeCond(), Val: &tree.CastExpr{Expr: val, Type: typ}}},
+			Else:  tree.NewUnresolvedName(string(name)),
+		}
+		bodyScope = b.addPLpgSQLAssign(bodyScope, name, caseExpr, noIndirection)
+	}
+	if len(loop.Target) == 1 {
+		assign(loop.Target[0], makeElem())
+	} else {
+		// Each element is a tuple, whose fields are assigned to the target
+		// variables. Missing fields are assigned NULL.
+		for i := range loop.Target {
+			var field tree.Expr = tree.DNull
+			if i < len(elemTyp.TupleContents()) {
+				field = &tree.ColumnAccessExpr{Expr: makeElem(), ByIndex: true, ColIndex: i}
+			}
+			assign(loop.Target[i], field)
+		}
+	}
+	ifStmt := &ast.If{Condition: makeCond(), ThenBody: loop.Body, ElseBody: []ast.Statement{&ast.Exit{}}}
+	b.appendBodyStmtFromScope(&loopCon, b.buildPLpgSQLStatements([]ast.Statement{ifStmt}, bodyScope))
+
+	// Now that the loop body is built, pop the increment continuation.
+	b.popContinuation()
+
+	// Finally, build the increment continuation, which increments the counter
+	// and calls recursively into the loop body continuation.
+	incScope := incrementCon.s.push()
+	b.ensureScopeHasExpr(incScope)
+	inc := &tree.BinaryExpr{
+		Operator: treebin.MakeBinaryOperator(treebin.Plus),
+		Left:     incScope.findFuncArgCol(counterOrd),
+		Right:    tree.NewDInt(1),
 	}
+	incScope = b.assignToHiddenVariable(incScope, counterOrd, inc)
+	incScope = b.callContinuation(&loopCon, incScope)
+	b.appendBodyStmtFromScope(&incrementCon, incScope)
+
+	return b.callContinuation(&loopCon, s)
+}
+
+// resolveOpenQuery finds and validates the query that is bound to cursor for
+// the given OPEN statement. It also returns the declaration of the cursor if
+// it is a bound cursor. The returned query is nil for OPEN ... FOR EXECUTE.
+func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) (tree.Statement, *ast.CursorDeclaration) {
+	decl := b.resolveBoundCursor(open.CurVar)
 	stmt := open.Query
-	if stmt != nil && boundStmt != nil {
+	hasQuery := stmt != nil || open.DynamicQuery != nil
+	if hasQuery && decl != nil {
 		// A bound cursor cannot be opened with "OPEN FOR" syntax.
 		panic(errors.WithHintf(
 			pgerror.New(pgcode.Syntax, "syntax error at or near \"FOR\""),
 			"cannot specify a query during OPEN for bound cursor \"%s\"", open.CurVar,
 		))
 	}
-	if stmt == nil && boundStmt == nil {
+	if !hasQuery && decl == nil {
 		// The query was not specified either during cursor declaration or in the
 		// open statement.
 		panic(errors.WithHintf(
@@ -1495,16 +1891,264 @@ func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 			"no query was specified for cursor \"%s\"", open.CurVar,
 		))
 	}
-	if stmt == nil {
+	if open.DynamicQuery != nil {
+		// The query will be parsed and validated at execution time.
+		return nil, nil
+	}
+	if decl != nil {
 		// This is a bound cursor.
-		stmt = boundStmt
+		b.checkCursorArgs(decl, open.Args)
+		stmt = decl.Query
+	}
+	b.checkCursorQuery(stmt)
+	return stmt, decl
+}
+
+// resolveBoundCursor returns the declaration of the bound cursor with the
+// given name, or nil if the variable is not a bound cursor.
+func (b *plpgsqlBuilder) resolveBoundCursor(name ast.Variable) *ast.CursorDeclaration {
+	// Search the blocks in reverse order to ensure that more recent declarations
+	// are encountered first.
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		if decl, ok := b.blocks[i].cursors[name]; ok {
+			return &decl
+		}
+	}
+	return nil
+}
+
+// checkCursorArgs checks that the arguments supplied when opening a bound
+// cursor match its declaration.
+func (b *plpgsqlBuilder) checkCursorArgs(decl *ast.CursorDeclaration, args []ast.Expr) {
+	switch {
+	case decl.Args == nil && args != nil:
+		panic(pgerror.Newf(pgcode.Syntax, "cursor \"%s\" has no arguments", decl.Name))
+	case decl.Args != nil && args == nil:
+		panic(pgerror.Newf(pgcode.Syntax, "cursor \"%s\" has arguments", decl.Name))
+	case len(args) < len(decl.Args):
+		panic(pgerror.Newf(pgcode.Syntax, "not enough arguments for cursor \"%s\"", decl.Name))
+	case len(args) > len(decl.Args):
+		panic(pgerror.Newf(pgcode.Syntax, "too many arguments for cursor \"%s\"", decl.Name))
 	}
+}
+
+// checkCursorQuery checks that the given statement can be used as the query
+// for a cursor.
+func (b *plpgsqlBuilder) checkCursorQuery(stmt tree.Statement) {
 	if _, ok := stmt.(*tree.Select); !ok {
 		panic(pgerror.Newf(
 			pgcode.InvalidCursorDefinition, "cannot open %s query as cursor", stmt.StatementTag(),
 		))
 	}
-	return stmt
+}
+
+// addCursorArgs declares the arguments of a bound cursor as variables in the
+// current block, and assigns them the given values.
+func (b *plpgsqlBuilder) addCursorArgs(
+	s *scope, decl *ast.CursorDeclaration, args []ast.Expr,
+) *scope {
+	for i := range decl.Args {
+		arg := &decl.Args[i]
+		typ, err := tree.ResolveType(b.ob.ctx, arg.Typ, b.ob.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
+		b.addVariable(arg.Name, typ)
+	}
+	for i := range decl.Args {
+		s = b.addPLpgSQLAssign(s, decl.Args[i].Name, args[i], noIndirection)
+	}
+	return s
+}
+
+// handleOpenWithArgs builds an OPEN statement for a bound cursor that was
+// declared with arguments. The arguments are declared as variables in an
+// implicit block that encloses the cursor query, similar to the following:
+//
+//	DECLARE
+//	  curs CURSOR (a INT) FOR SELECT * FROM xy WHERE x = a;
+//	BEGIN
+//	  OPEN curs(1);
+//	  [stmts];
+//	END
+//	=>
+//	DECLARE
+//	  curs REFCURSOR;
+//	BEGIN
+//	  DECLARE
+//	    a INT := 1;
+//	  BEGIN
+//	    OPEN curs FOR SELECT * FROM xy WHERE x = a;
+//	  END;
+//	  [stmts];
+//	END
+func (b *plpgsqlBuilder) handleOpenWithArgs(
+	s *scope, open *ast.Open, decl *ast.CursorDeclaration, query tree.Statement,
+	stmts []ast.Statement,
+) *scope {
+	// Build a continuation for the statements following the OPEN, which are
+	// outside the scope of the cursor arguments.
+	retCon := b.makeContinuation("_stmt_open_ret")
+	b.appendPlpgSQLStmts(&retCon, stmts)
+
+	// The cursor name is generated in the parent block, the same as for an OPEN
+	// statement without arguments.
+	nameCon := b.makeContinuation("_gen_cursor_name")
+	nameCon.def.Volatility = volatility.Volatile
+	nameScope := b.buildCursorNameGen(&nameCon, open.CurVar)
+
+	b.pushNewBlock(&ast.Block{})
+	defer b.popBlock()
+	nameScope = b.addCursorArgs(nameScope, decl, open.Args)
+	openCon := b.makeContinuation("_stmt_open")
+	nameCol := b.resolveCursorVariable(openCon.s, open.CurVar)
+	b.buildCursorOpen(
+		&openCon, nameCol.getParamOrd(), decl.Scroll, query, nil /* dynamicQuery */, nil, /* params */
+		false, /* foundColumn */
+	)
+	retScope := openCon.s.push()
+	b.ensureScopeHasExpr(retScope)
+	b.appendBodyStmtFromScope(&openCon, b.callContinuation(&retCon, retScope))
+
+	b.appendBodyStmtFromScope(&nameCon, b.callContinuation(&openCon, nameScope))
+	return b.callContinuation(&nameCon, s)
+}
+
+// buildCursorOpen adds a body statement to the given continuation that opens a
+// cursor with the name stored in the variable with the given ordinal. The
+// cursor reads the rows of the given query, or of the query string computed by
+// dynamicQuery when query is nil. If foundColumn is true, the cursor rows are
+// prefixed with a column that is always true, which allows a fetched row to be
+// distinguished from the NULL values returned once the cursor is exhausted.
+func (b *plpgsqlBuilder) buildCursorOpen(
+	con *continuation,
+	nameOrd int,
+	scroll tree.CursorScrollOption,
+	query tree.Statement,
+	dynamicQuery ast.Expr,
+	params []ast.Expr,
+	foundColumn bool,
+) {
+	con.def.Volatility = volatility.Volatile
+	nameCol := con.s.findFuncArgCol(nameOrd)
+	if query == nil {
+		// The query string is only known at execution time, so the cursor is
+		// opened by the crdb_internal.plpgsql_open_dynamic builtin function.
+		b.appendBodyStmtFromScope(
+			con, b.buildOpenDynamic(con.s, nameCol, scroll, dynamicQuery, params, foundColumn),
+		)
+		return
+	}
+	// Initialize the routine with the information needed to pipe the first
+	// body statement into a cursor.
+	fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
+	fmtCtx.FormatNode(query)
+	con.def.FirstStmtOutput.CursorDeclaration = &tree.RoutineOpenCursor{
+		NameArgIdx: nameOrd,
+		Scroll:     scroll,
+		CursorSQL:  fmtCtx.CloseAndGetString(),
+	}
+	openScope := b.buildSQLStatement(query, con.s)
+	if openScope.expr.Relational().CanMutate {
+		// Cursors with mutations are invalid.
+		panic(cursorMutationErr)
+	}
+	if foundColumn {
+		foundScope := openScope.push()
+		foundColName := scopeColName("").WithMetadataName(b.makeIdentifier("found"))
+		b.ob.synthesizeColumn(foundScope, foundColName, types.Bool, nil /* expr */, memo.TrueSingleton)
+		foundScope.appendColumnsFromScope(openScope)
+		foundScope.copyOrdering(openScope)
+		b.ob.constructProjectForScope(openScope, foundScope)
+		openScope = foundScope
+	}
+	b.appendBodyStmtFromScope(con, openScope)
+}
+
+// buildOpenDynamic projects a call to the crdb_internal.plpgsql_open_dynamic
+// builtin function, which opens a cursor for a query string that is computed
+// at execution time.
+func (b *plpgsqlBuilder) buildOpenDynamic(
+	s *scope,
+	nameCol *scopeColumn,
+	scroll tree.CursorScrollOption,
+	dynamicQuery ast.Expr,
+	params []ast.Expr,
+	foundColumn bool,
+) *scope {
+	b.checkDynamicSQLAllowed()
+	const openFnName = "crdb_internal.plpgsql_open_dynamic"
+	props, overloads := builtinsregistry.GetBuiltinProperties(openFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", openFnName))
+	}
+	paramTyps := make([]*types.T, len(params))
+	paramElems := make(memo.ScalarListExpr, len(params))
+	for i := range params {
+		paramTyps[i] = b.resolveSQLExprType(params[i], s)
+		paramElems[i] = b.buildSQLExpr(params[i], paramTyps[i], s)
+	}
+	paramsTyp := types.MakeTuple(paramTyps)
+	openCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			b.ob.factory.ConstructVariable(nameCol.id),
+			b.buildSQLExpr(dynamicQuery, types.String, s),
+			b.ob.factory.ConstructTuple(paramElems, paramsTyp),
+			b.ob.factory.ConstructConstVal(tree.MakeDBool(tree.DBool(foundColumn)), types.Bool),
+			b.ob.factory.ConstructConstVal(tree.MakeDBool(tree.DBool(scroll == tree.Scroll)), types.Bool),
+		},
+		&memo.FunctionPrivate{
+			Name:       openFnName,
+			Typ:        types.Int,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	b.addBarrierIfVolatile(s, openCall)
+	openColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_open"))
+	openScope := s.push()
+	b.ob.synthesizeColumn(openScope, openColName, types.Int, nil /* expr */, openCall)
+	b.ob.constructProjectForScope(s, openScope)
+	return openScope
+}
+
+// makeDynamicExecuteQuery returns a SELECT statement that executes the given
+// dynamic query with the given parameters by calling the
+// crdb_internal.plpgsql_execute_dynamic builtin function. The statement
+// produces one column for each of the given types, to which the columns of
+// the query result are coerced. If the types consist of a single RECORD type,
+// each row is instead produced as a single tuple.
+func (b *plpgsqlBuilder) makeDynamicExecuteQuery(
+	query ast.Expr, params []ast.Expr, typs []*types.T,
+) tree.Statement {
+	const executeFnName = "crdb_internal.plpgsql_execute_dynamic"
+	resultTypes := &tree.Tuple{
+		Exprs:  make(tree.Exprs, len(typs)),
+		Labels: make([]string, len(typs)),
+	}
+	for i, typ := range typs {
+		resultTypes.Exprs[i] = &tree.CastExpr{Expr: tree.DNull, Type: typ}
+		resultTypes.Labels[i] = fmt.Sprintf("column%d", i+1)
+	}
+	fn := &tree.FuncExpr{
+		Func:  tree.WrapFunction(executeFnName),
+		Exprs: tree.Exprs{query, &tree.Tuple{Exprs: params}, resultTypes},
+	}
+	return &tree.Select{Select: &tree.SelectClause{
+		Exprs: tree.SelectExprs{tree.StarSelectExpr()},
+		From: tree.From{Tables: tree.TableExprs{
+			&tree.AliasedTableExpr{Expr: &tree.RowsFromExpr{Items: tree.Exprs{fn}}},
+		}},
+	}}
+}
+
+// checkDynamicSQLAllowed panics if dynamic SQL cannot be used in the current
+// routine. Dynamic queries are executed with the privileges of the session
+// user, so they are not allowed in SECURITY DEFINER routines.
+func (b *plpgsqlBuilder) checkDynamicSQLAllowed() {
+	if b.options.isSecurityDefiner {
+		panic(dynamicSQLSecurityDefinerErr)
+	}
 }
 
 // buildCursorNameGen builds a statement that generates a unique name for the
@@ -1513,13 +2157,24 @@ func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 // builtin function.
 func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameVar ast.Variable) *scope {
 	_, source, _, _ := nameCon.s.FindSourceProvidingColumn(b.ob.ctx, nameVar)
+	nameCall := b.makeCursorNameGenCall(b.ob.factory.ConstructVariable(source.(*scopeColumn).id))
+	nameScope := nameCon.s.push()
+	b.ob.synthesizeColumn(nameScope, scopeColName(nameVar), types.RefCursor, nil /* expr */, nameCall)
+	b.ob.constructProjectForScope(nameCon.s, nameScope)
+	return nameScope
+}
+
+// makeCursorNameGenCall constructs a call to the
+// crdb_internal.plpgsql_gen_cursor_name builtin function, which returns the
+// given name if it is set, and otherwise generates a unique cursor name.
+func (b *plpgsqlBuilder) makeCursorNameGenCall(name opt.ScalarExpr) opt.ScalarExpr {
 	const nameFnName = "crdb_internal.plpgsql_gen_cursor_name"
 	props, overloads := builtinsregistry.GetBuiltinProperties(nameFnName)
 	if len(overloads) != 1 {
 		panic(errors.AssertionFailedf("expected one overload for %s", nameFnName))
 	}
-	nameCall := b.ob.factory.ConstructFunction(
-		memo.ScalarListExpr{b.ob.factory.ConstructVariable(source.(*scopeColumn).id)},
+	return b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{name},
 		&memo.FunctionPrivate{
 			Name:       nameFnName,
 			Typ:        types.RefCursor,
@@ -1527,10 +2182,6 @@ func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameVar ast.V
 			Overload:   &overloads[0],
 		},
 	)
-	nameScope := nameCon.s.push()
-	b.ob.synthesizeColumn(nameScope, scopeColName(nameVar), types.RefCursor, nil /* expr */, nameCall)
-	b.ob.constructProjectForScope(nameCon.s, nameScope)
-	return nameScope
 }
 
 // addPLpgSQLAssign adds a PL/pgSQL assignment to the current scope as a
@@ -1577,6 +2228,15 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(
 // assignToHiddenVariable is similar to addPLpgSQLAssign, but it assigns to a
 // hidden variable that is not visible to the user.
 func (b *plpgsqlBuilder) assignToHiddenVariable(inScope *scope, ord int, val ast.Expr) *scope {
+	typ, _ := b.resolveVariableForAssignByOrd(ord)
+	return b.assignScalarToHiddenVariable(inScope, ord, b.buildSQLExpr(val, typ, inScope))
+}
+
+// assignScalarToHiddenVariable is similar to assignToHiddenVariable, but
+// assigns an expression that has already been built.
+func (b *plpgsqlBuilder) assignScalarToHiddenVariable(
+	inScope *scope, ord int, scalar opt.ScalarExpr,
+) *scope {
 	typ, name := b.resolveVariableForAssignByOrd(ord)
 	assignScope := inScope.push()
 	for i := range inScope.cols {
@@ -1590,7 +2250,6 @@ func (b *plpgsqlBuilder) assignToHiddenVariable(inScope *scope, ord int, val ast
 		assignScope.appendColumn(col)
 	}
 	colName := scopeColName("").WithMetadataName(string(name))
-	scalar := b.buildSQLExpr(val, typ, inScope)
 	b.addBarrierIfVolatile(inScope, scalar)
 	col := b.ob.synthesizeColumn(assignScope, colName, typ, nil, scalar)
 	col.setParamOrd(ord)
@@ -1621,6 +2280,9 @@ func (b *plpgsqlBuilder) handleIndirectionForAssign(
 		// block.variable pair.
 		panic(pgerror.Newf(pgcode.Syntax, "\"%s.%s\" is not a known variable", ident, indirection))
 	}
+	if typ.Identical(types.AnyTuple) {
+		panic(recordFieldAssignErr)
+	}
 	var found bool
 	var elemIdx int
 	for i := range typ.TupleLabels() {
@@ -1661,6 +2323,7 @@ func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *sco
 	var targetTypes []*types.T
 	var targetNames []ast.Variable
 	var targetOrds []int
+	var recordTyp *types.T
 	targetIsRecordVar := b.targetIsRecordVar(target)
 	if targetIsRecordVar {
 		// For a single record-type variable, the SQL statement columns are assigned
@@ -1668,8 +2331,17 @@ func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *sco
 		//
 		// Note that we don't need to get the param ordinal here, since that's
 		// handled in projectRecordVar below.
-		typ, _ := b.resolveVariableForAssign(target[0])
-		targetTypes = typ.TupleContents()
+		recordTyp, _ = b.resolveVariableForAssign(target[0])
+		if recordTyp.Identical(types.AnyTuple) {
+			if len(stmtScope.cols) == 1 && stmtScope.cols[0].typ.Identical(types.AnyTuple) {
+				// The statement already produces a RECORD value, which is assigned
+				// directly to the variable.
+				return b.projectRecordVar(stmtScope, target[0], recordTyp)
+			}
+			// A RECORD variable takes on the shape of the statement's result.
+			recordTyp = b.makeRowTypeForScope(stmtScope)
+		}
+		targetTypes = recordTyp.TupleContents()
 	} else {
 		targetNames = target
 		targetTypes = make([]*types.T, len(target))
@@ -1708,7 +2380,7 @@ func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *sco
 	b.ob.constructProjectForScope(stmtScope, intoScope)
 	if targetIsRecordVar {
 		// Handle a single record-type variable (see projectRecordVar for details).
-		intoScope = b.projectRecordVar(intoScope, target[0])
+		intoScope = b.projectRecordVar(intoScope, target[0], recordTyp)
 	}
 	return intoScope
 }
@@ -1941,12 +2613,6 @@ func (b *plpgsqlBuilder) buildExceptions(block *ast.Block) *memo.ExceptionBlock
 	handlers := make([]*memo.UDFDefinition, 0, len(block.Exceptions))
 	addHandler := func(codeStr string, handler *memo.UDFDefinition) {
 		code := pgcode.MakeCode(strings.ToUpper(codeStr))
-		switch code {
-		case pgcode.TransactionRollback, pgcode.TransactionIntegrityConstraintViolation,
-			pgcode.SerializationFailure, pgcode.StatementCompletionUnknown,
-			pgcode.DeadlockDetected:
-			panic(retryableErrErr)
-		}
 		codes = append(codes, code)
 		handlers = append(handlers, handler)
 	}
@@ -2109,43 +2775,35 @@ func (b *plpgsqlBuilder) addRuntimeCheck(
 // buildFetch projects a call to the crdb_internal.plpgsql_fetch builtin
 // function, which handles cursors for the PLpgSQL FETCH and MOVE statements.
 func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
+	nameCol := b.resolveCursorVariable(s, fetch.Cursor.Name)
+	// For a FETCH statement, we have to pass the expected result types.
+	var typs []*types.T
+	if !fetch.IsMove {
+		if b.targetIsRecordVar(fetch.Target) {
+			if typ, _ := b.resolveVariableForAssign(fetch.Target[0]); typ.Identical(types.AnyTuple) {
+				panic(fetchIntoRecordErr)
+			}
+		}
+		typs = b.resolveIntoTargetTypes(fetch.Target)
+	}
+	return b.buildFetchCall(s, nameCol.id, fetch.Cursor.FetchType, fetch.Cursor.Count, typs)
+}
+
+// buildFetchCall projects a call to the crdb_internal.plpgsql_fetch builtin
+// function for the cursor with the name stored in the given column. The
+// result is a tuple with the given types, which is filled with NULL values if
+// no row was fetched.
+func (b *plpgsqlBuilder) buildFetchCall(
+	s *scope, cursorCol opt.ColumnID, fetchType tree.FetchType, count int64, typs []*types.T,
+) *scope {
 	const fetchFnName = "crdb_internal.plpgsql_fetch"
 	props, overloads := builtinsregistry.GetBuiltinProperties(fetchFnName)
 	if len(overloads) != 1 {
 		panic(errors.AssertionFailedf("expected one overload for %s", fetchFnName))
 	}
-	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, fetch.Cursor.Name)
-	if err != nil {
-		if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
-			panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", fetch.Cursor.Name))
-		}
-		panic(err)
-	}
-	if !source.(*scopeColumn).typ.Identical(types.RefCursor) {
-		panic(pgerror.Newf(pgcode.DatatypeMismatch,
-			"variable \"%s\" must be of type cursor or refcursor", fetch.Cursor.Name,
-		))
-	}
 	makeConst := func(val tree.Datum, typ *types.T) opt.ScalarExpr {
 		return b.ob.factory.ConstructConstVal(val, typ)
 	}
-	// For a FETCH statement, we have to pass the expected result types.
-	var typs []*types.T
-	if !fetch.IsMove {
-		if b.targetIsRecordVar(fetch.Target) {
-			// If the target is a single record-type variable, the columns of the
-			// FETCH are assigned as its *elements*, rather than directly to the
-			// variable.
-			typ, _ := b.resolveVariableForAssign(fetch.Target[0])
-			typs = typ.TupleContents()
-		} else {
-			typs = make([]*types.T, len(fetch.Target))
-			for i := range fetch.Target {
-				typ, _ := b.resolveVariableForAssign(fetch.Target[i])
-				typs[i] = typ
-			}
-		}
-	}
 	returnType := types.MakeTuple(typs)
 	elems := make(memo.ScalarListExpr, len(typs))
 	for i := range elems {
@@ -2160,9 +2818,9 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 	// The result of the fetch will be cast to strings and returned as an array.
 	fetchCall := b.ob.factory.ConstructFunction(
 		memo.ScalarListExpr{
-			b.ob.factory.ConstructVariable(source.(*scopeColumn).id),
-			makeConst(tree.NewDInt(tree.DInt(fetch.Cursor.FetchType)), types.Int),
-			makeConst(tree.NewDInt(tree.DInt(fetch.Cursor.Count)), types.Int),
+			b.ob.factory.ConstructVariable(cursorCol),
+			makeConst(tree.NewDInt(tree.DInt(fetchType)), types.Int),
+			makeConst(tree.NewDInt(tree.DInt(count)), types.Int),
 			b.ob.factory.ConstructTuple(elems, returnType),
 		},
 		&memo.FunctionPrivate{
@@ -2180,6 +2838,66 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 	return fetchScope
 }
 
+// buildCursorClose projects a call to the crdb_internal.plpgsql_close builtin
+// function, which closes the cursor with the name stored in the given column.
+func (b *plpgsqlBuilder) buildCursorClose(s *scope, cursorCol opt.ColumnID) *scope {
+	const closeFnName = "crdb_internal.plpgsql_close"
+	props, overloads := builtinsregistry.GetBuiltinProperties(closeFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", closeFnName))
+	}
+	closeCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{b.ob.factory.ConstructVariable(cursorCol)},
+		&memo.FunctionPrivate{
+			Name:       closeFnName,
+			Typ:        types.Int,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	closeColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_close"))
+	closeScope := s.push()
+	b.ob.synthesizeColumn(closeScope, closeColName, types.Int, nil /* expr */, closeCall)
+	b.ob.constructProjectForScope(s, closeScope)
+	return closeScope
+}
+
+// resolveCursorVariable returns the column for the variable with the given
+// name, and checks that it has the REFCURSOR type.
+func (b *plpgsqlBuilder) resolveCursorVariable(s *scope, name ast.Variable) *scopeColumn {
+	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, name)
+	if err != nil {
+		if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
+			panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", name))
+		}
+		panic(err)
+	}
+	col := source.(*scopeColumn)
+	if !col.typ.Identical(types.RefCursor) {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"variable \"%s\" must be of type cursor or refcursor", name,
+		))
+	}
+	return col
+}
+
+// resolveIntoTargetTypes returns the types of the values that are assigned to
+// the given INTO target.
+func (b *plpgsqlBuilder) resolveIntoTargetTypes(target []ast.Variable) []*types.T {
+	if b.targetIsRecordVar(target) {
+		// If the target is a single record-type variable, the columns are
+		// assigned as its *elements*, rather than directly to the variable.
+		typ, _ := b.resolveVariableForAssign(target[0])
+		return typ.TupleContents()
+	}
+	typs := make([]*types.T, len(target))
+	for i := range target {
+		typ, _ := b.resolveVariableForAssign(target[i])
+		typs[i] = typ
+	}
+	return typs
+}
+
 // targetIsSingleCompositeVar returns true if the given INTO target is a single
 // RECORD-type variable.
 func (b *plpgsqlBuilder) targetIsRecordVar(target []ast.Variable) bool {
@@ -2192,22 +2910,44 @@ func (b *plpgsqlBuilder) targetIsRecordVar(target []ast.Variable) bool {
 
 // projectRecordVar handles the special case when a single RECORD-type variable
 // is the target of an INTO clause. In this case, the columns from the SQL
-// statement should be wrapped into a tuple, which is assigned to the
-// RECORD-type variable.
-func (b *plpgsqlBuilder) projectRecordVar(s *scope, name ast.Variable) *scope {
+// statement should be wrapped into a tuple with the given type, which is
+// assigned to the RECORD-type variable. If the variable has the RECORD type and
+// the scope has a single RECORD column, the column is assigned directly.
+func (b *plpgsqlBuilder) projectRecordVar(s *scope, name ast.Variable, tupleTyp *types.T) *scope {
 	typ, ord := b.resolveVariableForAssign(name)
 	recordScope := s.push()
-	elems := make(memo.ScalarListExpr, len(s.cols))
-	for j := range elems {
-		elems[j] = b.ob.factory.ConstructVariable(s.cols[j].id)
+	var scalar opt.ScalarExpr
+	if typ.Identical(types.AnyTuple) && len(s.cols) == 1 && s.cols[0].typ.Identical(types.AnyTuple) {
+		scalar = b.ob.factory.ConstructVariable(s.cols[0].id)
+	} else {
+		elems := make(memo.ScalarListExpr, len(s.cols))
+		for j := range elems {
+			elems[j] = b.ob.factory.ConstructVariable(s.cols[j].id)
+		}
+		scalar = b.coerceType(b.ob.factory.ConstructTuple(elems, tupleTyp), typ)
 	}
-	tuple := b.ob.factory.ConstructTuple(elems, typ)
-	col := b.ob.synthesizeColumn(recordScope, scopeColName(name), typ, nil /* expr */, tuple)
+	col := b.ob.synthesizeColumn(recordScope, scopeColName(name), typ, nil /* expr */, scalar)
 	col.setParamOrd(ord)
 	recordScope.expr = b.ob.constructProject(s.expr, []scopeColumn{*col})
 	return recordScope
 }
 
+// makeRowTypeForScope returns a tuple type with the types of the visible
+// columns of the given scope, labeled with the column names. It is used to
+// determine the shape of a RECORD variable that is assigned a row.
+func (b *plpgsqlBuilder) makeRowTypeForScope(s *scope) *types.T {
+	typs := make([]*types.T, 0, len(s.cols))
+	labels := make([]string, 0, len(s.cols))
+	for i := range s.cols {
+		if s.cols[i].visibility != visible {
+			continue
+		}
+		typs = append(typs, s.cols[i].typ)
+		labels = append(labels, string(s.cols[i].name.ReferenceName()))
+	}
+	return types.MakeLabeledTuple(typs, labels)
+}
+
 // makeContinuation allocates a new continuation routine with an uninitialized
 // definition. Note that the parameters of the continuation will be determined
 // by the current block; if a child block declares new variables, its
@@ -2322,7 +3062,11 @@ func (b *plpgsqlBuilder) callContinuation(con *continuation, s *scope) *scope {
 // continuation in a TxnControlExpr that will commit or abort the current
 // transaction before resuming execution with the continuation.
 func (b *plpgsqlBuilder) callContinuationWithTxnOp(
-	con *continuation, s *scope, txnOp tree.StoredProcTxnOp, txnModes tree.TransactionModes,
+	con *continuation,
+	s *scope,
+	txnOp tree.StoredProcTxnOp,
+	txnModes tree.TransactionModes,
+	chain bool,
 ) *scope {
 	if con == nil {
 		panic(errors.AssertionFailedf("nil continuation with transaction control"))
@@ -2333,7 +3077,9 @@ func (b *plpgsqlBuilder) callContinuationWithTxnOp(
 	b.ob.addBarrier(s)
 	returnScope := s.push()
 	args := b.makeContinuationArgs(con, s)
-	txnPrivate := &memo.TxnControlPrivate{TxnOp: txnOp, TxnModes: txnModes, Def: con.def}
+	txnPrivate := &memo.TxnControlPrivate{
+		TxnOp: txnOp, TxnModes: txnModes, TxnChain: chain, Def: con.def,
+	}
 	if b.outScope != nil {
 		txnPrivate.Props = b.outScope.makePhysicalProps()
 		txnPrivate.OutCols = b.outScope.colList()
@@ -2444,6 +3190,21 @@ func (b *plpgsqlBuilder) buildSQLExpr(expr ast.Expr, typ *types.T, s *scope) opt
 	return f.ConstructSubquery(withExpr, &memo.SubqueryPrivate{})
 }
 
+// resolveSQLExprType type-checks the given SQL expression within the given
+// scope and returns its type, without building the expression.
+func (b *plpgsqlBuilder) resolveSQLExprType(expr ast.Expr, s *scope) *types.T {
+	if b.options.skipSQL {
+		// For lazy SQL evaluation, the type of the expression is not known.
+		return types.Unknown
+	}
+	expr, _ = tree.WalkExpr(s, expr)
+	typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.AnyElement)
+	if err != nil {
+		panic(err)
+	}
+	return typedExpr.ResolvedType()
+}
+
 // buildSQLStatement type-checks and builds the given SQL statement into a
 // RelExpr within the given scope.
 func (b *plpgsqlBuilder) buildSQLStatement(stmt tree.Statement, inScope *scope) (outScope *scope) {
@@ -2467,6 +3228,15 @@ func (b *plpgsqlBuilder) buildSQLStatement(stmt tree.Statement, inScope *scope)
 // coerceType implements PLpgSQL type-coercion behavior.
 func (b *plpgsqlBuilder) coerceType(scalar opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
 	resolved := scalar.DataType()
+	if typ.Identical(types.AnyTuple) {
+		// A RECORD variable takes on the shape of any composite value that is
+		// assigned to it, so the cast is a no-op.
+		if resolved.Family() != types.TupleFamily && resolved.Family() != types.UnknownFamily {
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"cannot assign non-composite value to a record variable",
+			))
+		}
+	}
 	if !resolved.Identical(typ) {
 		// Postgres will attempt to coerce the expression's type with an assignment
 		// cast. If that fails, it will convert to a string and attempt to parse the
@@ -2919,14 +3689,14 @@ var (
 	collatedVarErr = unimplemented.NewWithIssueDetail(105245, "variable collation",
 		"collation for PL/pgSQL variables is not yet supported",
 	)
-	recordVarErr = unimplemented.NewWithIssueDetail(114874, "RECORD variable",
-		"RECORD type for PL/pgSQL variables is not yet supported",
+	recordFieldAssignErr = unimplemented.New("RECORD field assignment",
+		"assigning to a field of a PL/pgSQL RECORD variable is not yet supported",
 	)
-	scrollableCursorErr = unimplemented.NewWithIssue(77102,
-		"DECLARE SCROLL CURSOR",
+	fetchIntoRecordErr = unimplemented.New("FETCH INTO RECORD",
+		"FETCH INTO a PL/pgSQL RECORD variable is not yet supported",
 	)
-	retryableErrErr = unimplemented.NewWithIssue(111446,
-		"catching a Transaction Retry error in a PLpgSQL EXCEPTION block is not yet implemented",
+	dynamicSQLSecurityDefinerErr = unimplemented.New("dynamic SQL in SECURITY DEFINER routine",
+		"dynamic SQL in a SECURITY DEFINER PL/pgSQL routine is not yet supported",
 	)
 	recordReturnErr = errors.WithHint(
 		unimplemented.NewWithIssue(115384,
@@ -2990,9 +3760,9 @@ var (
 	txnInUDFErr = errors.WithDetail(
 		pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
 		"PL/pgSQL COMMIT/ROLLBACK is not allowed inside a user-defined function")
-	txnControlWithChainErr = unimplemented.NewWithIssue(119646,
-		"COMMIT or ROLLBACK with AND CHAIN syntax is not yet implemented",
-	)
+	txnControlWithConfigErr = errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
+		"PL/pgSQL COMMIT/ROLLBACK is not allowed inside a procedure with SET options")
 	setTxnNotAfterControlStmtErr = errors.WithHint(
 		pgerror.New(pgcode.ActiveSQLTransaction, "SET TRANSACTION must be called before any query"),
 		"PL/pgSQL SET TRANSACTION statements must immediately follow COMMIT or ROLLBACK",
@@ -3000,6 +3770,21 @@ var (
 	intForLoopTargetErr = pgerror.New(pgcode.Syntax,
 		"integer FOR loop must have only one target variable",
 	)
+	cursorForLoopTargetErr = pgerror.New(pgcode.Syntax,
+		"cursor FOR loop must have only one target variable",
+	)
+	cursorForLoopUnboundErr = pgerror.New(pgcode.Syntax,
+		"cursor FOR loop must use a bound cursor variable",
+	)
+	forEachNotArrayTargetErr = pgerror.New(pgcode.DatatypeMismatch,
+		"FOREACH ... SLICE loop variable must be of an array type",
+	)
+	forEachArrayTargetErr = pgerror.New(pgcode.DatatypeMismatch,
+		"FOREACH loop variable must not be of an array type",
+	)
+	nonCompositeRowVarErr = pgerror.New(pgcode.DatatypeMismatch,
+		"cannot assign non-composite value to a row variable",
+	)
 	doBlockVersionErr = unimplemented.Newf("do blocks",
 		"DO statement usage inside a routine definition is not supported until version 25.1",
 	)
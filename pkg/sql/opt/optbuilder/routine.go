@@ -18,13 +18,16 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // buildUDF builds a set of memo groups that represents a user-defined function
@@ -272,16 +275,18 @@ func (b *Builder) buildRoutine(
 	// CTEs that mutate and are not at the top-level.
 	bodyScope := b.allocScope()
 	var params opt.ColList
-	var polyArgTyp *types.T
+	var polyArgTyp, compatArgTyp *types.T
 	if o.Types.Length() > 0 {
 		// If necessary, add DEFAULT arguments.
 		args, argTypes = b.addDefaultArgs(f, args, argTypes, bodyScope, colRefs)
 
 		// Add all input parameters to the scope.
+		// Note that the arguments for the VARIADIC parameter of a variadic
+		// routine have already been collected into an array during
+		// type-checking, so the arguments match the declared parameters.
 		paramTypes, ok := o.Types.(tree.ParamTypes)
 		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
+			panic(errors.AssertionFailedf("unexpected parameter list of type %T", o.Types))
 		}
 		if len(paramTypes) != len(args) {
 			panic(errors.AssertionFailedf(
@@ -292,21 +297,24 @@ func (b *Builder) buildRoutine(
 		// Check the parameters for polymorphic types, and resolve to a concrete
 		// type if any exist.
 		if b.evalCtx.SessionData().OptimizerUsePolymorphicParameterFix {
-			var numPolyParams int
+			var numPolyParams, numCompatParams int
 			_, numPolyParams, polyArgTyp = tree.ResolvePolymorphicArgTypes(
 				paramTypes, argTypes, nil /* anyElemTyp */, true, /* enforceConsistency */
 			)
-			if numPolyParams > 0 {
-				if polyArgTyp == nil {
-					// All supplied arguments were NULL, so a type could not be resolved
-					// for the polymorphic parameters.
-					panic(pgerror.New(pgcode.DatatypeMismatch,
-						"could not determine polymorphic type because input has type unknown",
-					))
-				}
+			_, numCompatParams, compatArgTyp = tree.ResolveAnyCompatibleArgTypes(
+				paramTypes, argTypes, true, /* enforceConsistency */
+			)
+			if (numPolyParams > 0 && polyArgTyp == nil) || (numCompatParams > 0 && compatArgTyp == nil) {
+				// All supplied arguments were NULL, so a type could not be resolved
+				// for the polymorphic parameters.
+				panic(pgerror.New(pgcode.DatatypeMismatch,
+					"could not determine polymorphic type because input has type unknown",
+				))
+			}
+			if numPolyParams > 0 || numCompatParams > 0 {
 				// If the routine returns a polymorphic type, use the resolved
-				// polymorphic argument type to determine the concrete return type.
-				b.maybeResolvePolymorphicReturnType(f, polyArgTyp)
+				// polymorphic argument types to determine the concrete return type.
+				b.maybeResolvePolymorphicReturnType(f, polyArgTyp, compatArgTyp)
 			}
 		}
 
@@ -315,7 +323,7 @@ func (b *Builder) buildRoutine(
 		params = make(opt.ColList, len(paramTypes))
 		for i := range paramTypes {
 			argTyp := argTypes[i]
-			desiredTyp := maybeReplacePolymorphicType(paramTypes[i].Typ, polyArgTyp)
+			desiredTyp := maybeReplacePolymorphicType(paramTypes[i].Typ, polyArgTyp, compatArgTyp)
 			if desiredTyp.Identical(types.AnyTuple) {
 				// This is a RECORD-typed parameter. Use the actual argument type.
 				desiredTyp = argTyp
@@ -409,6 +417,20 @@ func (b *Builder) buildRoutine(
 		b.validateGeneratorFunctionReturnType(f.ResolvedOverload(), f.ResolvedType(), inScope)
 	}
 
+	// Apply the session variable overrides of the routine while building its
+	// body, so that name resolution uses the routine's search_path.
+	if o.Type != tree.BuiltinRoutine && len(o.Config) > 0 {
+		pop, err := b.catalog.PushRoutineConfig(b.ctx, o.Config)
+		if err != nil {
+			panic(err)
+		}
+		defer func() {
+			if err := pop(); err != nil {
+				panic(err)
+			}
+		}()
+	}
+
 	// Build an expression for each statement in the function body.
 	var body []memo.RelExpr
 	var bodyProps []*physical.Required
@@ -471,14 +493,16 @@ func (b *Builder) buildRoutine(
 			}
 			routineParams = append(routineParams, routineParam{
 				name:  param.Name,
-				typ:   maybeReplacePolymorphicType(typ, polyArgTyp),
+				typ:   maybeReplacePolymorphicType(typ, polyArgTyp, compatArgTyp),
 				class: param.Class,
 			})
 		}
 		options := basePLOptions().
 			SetIsSetReturning(isSetReturning).
 			SetInsideDataSource(oldInsideDataSource).
-			SetIsProcedure(isProc)
+			SetIsProcedure(isProc).
+			SetIsSecurityDefiner(o.SecurityMode == tree.RoutineDefiner).
+			SetHasConfig(len(o.Config) > 0)
 		plBuilder := newPLpgSQLBuilder(
 			b, options, def.Name, stmt.AST.Label, colRefs,
 			routineParams, f.ResolvedType(), outScope, resultBufferID,
@@ -513,6 +537,9 @@ func (b *Builder) buildRoutine(
 				MultiColDataSource: multiColDataSource,
 				RoutineType:        o.Type,
 				RoutineLang:        o.Language,
+				Cost:               o.Cost,
+				Rows:               o.Rows,
+				Config:             o.Config,
 				Body:               body,
 				BodyProps:          bodyProps,
 				BodyStmts:          bodyStmts,
@@ -775,12 +802,14 @@ func (b *Builder) addDefaultArgs(
 }
 
 // maybeResolvePolymorphicReturnType checks whether the return type of the
-// routine is polymorphic and if so, uses the resolved polymorphic argument type
+// routine is polymorphic and if so, uses the resolved polymorphic argument types
 // to determine the concrete return type.
-func (b *Builder) maybeResolvePolymorphicReturnType(f *tree.FuncExpr, polyArgTyp *types.T) {
+func (b *Builder) maybeResolvePolymorphicReturnType(
+	f *tree.FuncExpr, polyArgTyp, compatArgTyp *types.T,
+) {
 	originalRTyp := f.ResolvedType()
 	if originalRTyp.IsPolymorphicType() {
-		f.SetTypeAnnotation(maybeReplacePolymorphicType(originalRTyp, polyArgTyp))
+		f.SetTypeAnnotation(maybeReplacePolymorphicType(originalRTyp, polyArgTyp, compatArgTyp))
 	} else if originalRTyp.Family() == types.TupleFamily && !f.ResolvedOverload().ReturnsRecordType {
 		var hasPolymorphicOutParam bool
 		for _, typ := range originalRTyp.TupleContents() {
@@ -792,7 +821,7 @@ func (b *Builder) maybeResolvePolymorphicReturnType(f *tree.FuncExpr, polyArgTyp
 		if hasPolymorphicOutParam {
 			outParamTypes := make([]*types.T, len(originalRTyp.TupleContents()))
 			for i, outParamTyp := range originalRTyp.TupleContents() {
-				outParamTypes[i] = maybeReplacePolymorphicType(outParamTyp, polyArgTyp)
+				outParamTypes[i] = maybeReplacePolymorphicType(outParamTyp, polyArgTyp, compatArgTyp)
 			}
 			f.SetTypeAnnotation(types.MakeLabeledTuple(outParamTypes, originalRTyp.TupleLabels()))
 		}
@@ -800,9 +829,13 @@ func (b *Builder) maybeResolvePolymorphicReturnType(f *tree.FuncExpr, polyArgTyp
 }
 
 // maybeReplacePolymorphicType checks whether the given type is polymorphic and
-// if so, replaces it with the given polymorphic argument type. It returns the
-// original type if it is not polymorphic.
-func maybeReplacePolymorphicType(originalTyp, polyArgTyp *types.T) *types.T {
+// if so, replaces it with the given polymorphic argument type, or with the
+// common type of the ANYCOMPATIBLE family if the type is part of that family.
+// It returns the original type if it is not polymorphic.
+func maybeReplacePolymorphicType(originalTyp, polyArgTyp, compatArgTyp *types.T) *types.T {
+	if originalTyp.IsAnyCompatibleType() {
+		polyArgTyp = compatArgTyp
+	}
 	if !originalTyp.IsPolymorphicType() || polyArgTyp == nil {
 		return originalTyp
 	}
@@ -895,3 +928,89 @@ func (b *Builder) buildPLpgSQLDoBody(do *plpgsqltree.DoBlock) *scope {
 		stmtScope, false /* isSetReturning */, false /* insideDataSource */, types.Void,
 	)
 }
+
+// buildUserDefinedAggPrivate builds the routines that implement an invocation
+// of the given user-defined aggregate function. argTypes are the types of the
+// aggregate's arguments after they have been cast to the parameter types.
+func (b *Builder) buildUserDefinedAggPrivate(
+	f *tree.FuncExpr, name string, argTypes []*types.T, inScope *scope,
+) *memo.UserDefinedAggPrivate {
+	o := f.ResolvedOverload()
+	agg := o.UDFAggregate
+	if f.OrderBy != nil {
+		panic(unimplemented.NewWithIssue(74775,
+			"ORDER BY in user-defined aggregate function calls is not supported"))
+	}
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid, b.checkPrivilegeUser); err != nil {
+		panic(err)
+	}
+
+	private := &memo.UserDefinedAggPrivate{
+		Name:      name,
+		Typ:       f.ResolvedType(),
+		InitValue: tree.DNull,
+	}
+	transitionArgTypes := make([]*types.T, 0, len(argTypes)+1)
+	transitionArgTypes = append(transitionArgTypes, agg.StateType)
+	transitionArgTypes = append(transitionArgTypes, argTypes...)
+	private.Transition = b.buildAggregateSupportRoutine(agg.TransitionFunc, transitionArgTypes, inScope)
+	if agg.FinalFunc != 0 {
+		private.Final = b.buildAggregateSupportRoutine(
+			agg.FinalFunc, []*types.T{agg.StateType}, inScope,
+		)
+	}
+	if agg.InitCond != nil {
+		d, err := eval.PerformCast(b.ctx, b.evalCtx, tree.NewDString(*agg.InitCond), agg.StateType)
+		if err != nil {
+			panic(err)
+		}
+		private.InitValue = d
+	}
+	if b.trackSchemaDeps {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
+	return private
+}
+
+// buildAggregateSupportRoutine builds the definition of a support function of a
+// user-defined aggregate, e.g. its state transition function. The routine is
+// invoked directly by the aggregator with arguments of the given types, so only
+// its definition is retained.
+func (b *Builder) buildAggregateSupportRoutine(
+	funcOID oid.Oid, argTypes []*types.T, inScope *scope,
+) *memo.UDFDefinition {
+	args := make(tree.Exprs, len(argTypes))
+	for i := range argTypes {
+		args[i] = tree.NewTypedCastExpr(tree.DNull, argTypes[i])
+	}
+	fn := &tree.FuncExpr{
+		Func:  tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: funcOID}},
+		Exprs: args,
+	}
+	typedFn, err := tree.TypeCheck(b.ctx, fn, b.semaCtx, types.AnyElement)
+	if err != nil {
+		panic(err)
+	}
+	f, ok := typedFn.(*tree.FuncExpr)
+	if !ok {
+		panic(errors.AssertionFailedf("expected aggregate support function call to remain a FuncExpr"))
+	}
+	def, err := f.Func.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+
+	// The call itself is discarded, so prevent it from being inlined into a
+	// subquery, which would lose the routine definition.
+	var routine opt.ScalarExpr
+	var disabledRules intsets.Fast
+	disabledRules.Add(int(opt.InlineUDF))
+	b.factory.DisableOptimizationRulesTemporarily(disabledRules, func() {
+		routine = b.buildRoutine(f, def, inScope, nil /* outScope */, nil /* colRefs */)
+	})
+	udf, ok := routine.(*memo.UDFCallExpr)
+	if !ok {
+		panic(errors.AssertionFailedf("expected aggregate support function to build a UDFCall"))
+	}
+	return udf.Def
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
@@ -115,6 +116,9 @@ func (b *Builder) buildScalar(
 	case *windowInfo:
 		return b.finishBuildScalarRef(t.col, inScope, outScope, outCol, colRefs)
 
+	case *groupingInfo:
+		out = b.buildGroupingOperation(t, inScope)
+
 	case *tree.AndExpr:
 		left := b.buildScalar(reType(t.TypedLeft(), types.Bool), inScope, nil, nil, colRefs)
 		right := b.buildScalar(reType(t.TypedRight(), types.Bool), inScope, nil, nil, colRefs)
@@ -278,6 +282,7 @@ func (b *Builder) buildScalar(
 		texpr := t.Expr.(tree.TypedExpr)
 		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
 		out = b.factory.ConstructCast(arg, t.ResolvedType())
+		out = b.buildDomainCheck(out, t.ResolvedType())
 
 	case *tree.CoalesceExpr:
 		args := make(memo.ScalarListExpr, len(t.Exprs))
@@ -300,6 +305,11 @@ func (b *Builder) buildScalar(
 
 	case *tree.ColumnAccessExpr:
 		input := b.buildScalar(t.Expr.(tree.TypedExpr), inScope, nil, nil, colRefs)
+		if t.ColIndex < 0 {
+			// The tuple is a RECORD whose shape is only known at execution time.
+			out = b.buildRecordFieldAccess(input, t.ColName, t.ResolvedType())
+			break
+		}
 		out = b.factory.ConstructColumnAccess(input, memo.TupleOrdinal(t.ColIndex))
 
 	case *tree.ComparisonExpr:
@@ -494,6 +504,38 @@ func (b *Builder) buildScalar(
 	return b.finishBuildScalar(scalar, out, outScope, outCol)
 }
 
+// buildRecordFieldAccess builds a call to the crdb_internal.record_field
+// builtin, which accesses the field with the given name of a RECORD-typed
+// input and coerces it to the given type. This is necessary because the
+// labels of a RECORD tuple are not known until execution time.
+func (b *Builder) buildRecordFieldAccess(
+	input opt.ScalarExpr, field tree.Name, typ *types.T,
+) opt.ScalarExpr {
+	const recordFieldFnName = "crdb_internal.record_field"
+	props, overloads := builtinsregistry.GetBuiltinProperties(recordFieldFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", recordFieldFnName))
+	}
+	// The result type is passed as a tuple containing a single typed NULL, so
+	// that it is preserved through evaluation.
+	typTuple := b.factory.ConstructTuple(
+		memo.ScalarListExpr{b.factory.ConstructConstVal(tree.DNull, typ)}, types.MakeTuple([]*types.T{typ}),
+	)
+	return b.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			input,
+			b.factory.ConstructConstVal(tree.NewDString(string(field)), types.String),
+			typTuple,
+		},
+		&memo.FunctionPrivate{
+			Name:       recordFieldFnName,
+			Typ:        typ,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+}
+
 func (b *Builder) hasSubOperator(t *tree.ComparisonExpr) bool {
 	return t.Operator.Symbol == treecmp.Any || t.Operator.Symbol == treecmp.All || t.Operator.Symbol == treecmp.Some
 }
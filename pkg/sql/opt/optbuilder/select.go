@@ -8,7 +8,9 @@ package optbuilder
 import (
 	"context"
 	"fmt"
+	"math"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -45,7 +47,11 @@ import (
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildDataSource(
-	texpr tree.TableExpr, indexFlags *tree.IndexFlags, lockCtx lockingContext, inScope *scope,
+	texpr tree.TableExpr,
+	indexFlags *tree.IndexFlags,
+	tableSample *tree.TableSample,
+	lockCtx lockingContext,
+	inScope *scope,
 ) (outScope *scope) {
 	defer func(prevAtRoot bool, prevInsideDataSource bool) {
 		inScope.atRoot = prevAtRoot
@@ -86,7 +92,8 @@ func (b *Builder) buildDataSource(
 			lockCtx.withoutTargets()
 		}
 
-		outScope = b.buildDataSource(source.Expr, indexFlags, lockCtx, inScope)
+		b.onlyDataSource = source.Only
+		outScope = b.buildDataSource(source.Expr, indexFlags, source.TableSample, lockCtx, inScope)
 
 		if source.Ordinality {
 			outScope = b.buildWithOrdinality(outScope)
@@ -102,9 +109,12 @@ func (b *Builder) buildDataSource(
 
 	case *tree.TableName:
 		tn := source
+		only := b.onlyDataSource
+		b.onlyDataSource = false
 
 		// CTEs take precedence over other data sources.
 		if cte := inScope.resolveCTE(tn); cte != nil {
+			checkNoTableSample(tableSample)
 			lockCtx.locking.ignoreLockingForCTE()
 			outScope = inScope.push()
 			inCols := make(opt.ColList, len(cte.cols), len(cte.cols)+len(inScope.ordering))
@@ -156,22 +166,28 @@ func (b *Builder) buildDataSource(
 		case cat.Table:
 			tabMeta := b.addTable(t, &resName)
 			policyCommandScope, locking := b.prepForTableScan(lockCtx.locking, tabMeta)
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
 					includeSystem:    true,
 					includeInverted:  false,
 				}),
-				indexFlags, locking, inScope,
+				indexFlags, tableSample, locking, inScope,
 				false, /* disableNotVisibleIndex */
 				policyCommandScope,
 			)
+			if !only && t.InheritedByCount() > 0 {
+				outScope = b.buildInheritedScans(t, outScope, tableSample, lockCtx.locking, inScope)
+			}
+			return outScope
 
 		case cat.Sequence:
+			checkNoTableSample(tableSample)
 			return b.buildSequenceSelect(t, &resName, inScope)
 
 		case cat.View:
+			checkNoTableSample(tableSample)
 			return b.buildView(t, &resName, lockCtx, inScope)
 
 		default:
@@ -179,7 +195,7 @@ func (b *Builder) buildDataSource(
 		}
 
 	case *tree.ParenTableExpr:
-		return b.buildDataSource(source.Expr, indexFlags, lockCtx, inScope)
+		return b.buildDataSource(source.Expr, indexFlags, tableSample, lockCtx, inScope)
 
 	case *tree.RowsFromExpr:
 		return b.buildZip(source.Items, inScope)
@@ -277,8 +293,9 @@ func (b *Builder) buildDataSource(
 
 		switch t := ds.(type) {
 		case cat.Table:
-			outScope = b.buildScanFromTableRef(t, source, indexFlags, lockCtx.locking, inScope)
+			outScope = b.buildScanFromTableRef(t, source, indexFlags, tableSample, lockCtx.locking, inScope)
 		case cat.View:
+			checkNoTableSample(tableSample)
 			if source.Columns != nil {
 				panic(pgerror.Newf(pgcode.FeatureNotSupported,
 					"cannot specify an explicit column list when accessing a view by reference"))
@@ -287,6 +304,7 @@ func (b *Builder) buildDataSource(
 
 			outScope = b.buildView(t, &tn, lockCtx, inScope)
 		case cat.Sequence:
+			checkNoTableSample(tableSample)
 			tn := tree.MakeUnqualifiedTableName(t.Name())
 			// Any explicitly listed columns are ignored.
 			outScope = b.buildSequenceSelect(t, &tn, inScope)
@@ -456,6 +474,7 @@ func (b *Builder) buildScanFromTableRef(
 	tab cat.Table,
 	ref *tree.TableRef,
 	indexFlags *tree.IndexFlags,
+	tableSample *tree.TableSample,
 	locking lockingSpec,
 	inScope *scope,
 ) (outScope *scope) {
@@ -482,11 +501,85 @@ func (b *Builder) buildScanFromTableRef(
 	var policyCommandScope cat.PolicyCommandScope
 	policyCommandScope, locking = b.prepForTableScan(locking, tabMeta)
 	return b.buildScan(
-		tabMeta, ordinals, indexFlags, locking, inScope, false, /* disableNotVisibleIndex */
+		tabMeta, ordinals, indexFlags, tableSample, locking, inScope, false, /* disableNotVisibleIndex */
 		policyCommandScope,
 	)
 }
 
+// checkNoTableSample panics if a TABLESAMPLE clause was applied to a data
+// source which is not a table.
+func checkNoTableSample(tableSample *tree.TableSample) {
+	if tableSample != nil {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"TABLESAMPLE clause can only be applied to tables and materialized views"))
+	}
+}
+
+// buildTableSample evaluates the arguments of a TABLESAMPLE clause. The
+// arguments cannot reference columns, but they can contain placeholders and
+// non-immutable functions, which are evaluated once when the query is planned.
+func (b *Builder) buildTableSample(sample *tree.TableSample) opt.TableSample {
+	// Table readers on nodes running older binaries would ignore the sample
+	// and return all rows.
+	if !b.evalCtx.Settings.Version.IsActive(b.ctx, clusterversion.V25_3_Start) {
+		panic(unimplemented.Newf("tablesample",
+			"TABLESAMPLE is only supported in v25.3 and later"))
+	}
+	res := opt.TableSample{Method: sample.Method, Set: true}
+	percent, ok := b.evalTableSampleArg(sample.Percent)
+	if ok {
+		if percent == tree.DNull {
+			panic(pgerror.New(pgcode.InvalidTablesampleArgument,
+				"TABLESAMPLE parameter cannot be null"))
+		}
+		p := float64(*percent.(*tree.DFloat))
+		if p < 0 || p > 100 || math.IsNaN(p) {
+			panic(pgerror.New(pgcode.InvalidTablesampleArgument,
+				"sample percentage must be between 0 and 100"))
+		}
+		res.Probability = p / 100
+	}
+	if sample.Repeatable != nil {
+		res.Repeatable = true
+		seed, ok := b.evalTableSampleArg(sample.Repeatable)
+		if ok {
+			if seed == tree.DNull {
+				panic(pgerror.New(pgcode.InvalidTablesampleRepeat,
+					"TABLESAMPLE REPEATABLE parameter cannot be null"))
+			}
+			res.Seed = float64(*seed.(*tree.DFloat))
+		}
+	}
+	return res
+}
+
+// evalTableSampleArg type checks and evaluates an argument of a TABLESAMPLE
+// clause. It returns ok=false if the argument cannot be evaluated yet because
+// it references placeholders whose values are not known. In that case, or if
+// the argument is not constant, the memo cannot be reused and the query is
+// planned again when it is executed.
+func (b *Builder) evalTableSampleArg(expr tree.Expr) (_ tree.Datum, ok bool) {
+	texpr, err := tree.TypeCheckAndRequire(b.ctx, expr, b.semaCtx, types.Float, "TABLESAMPLE")
+	if err != nil {
+		panic(err)
+	}
+	if tree.ContainsVars(texpr) {
+		panic(pgerror.New(pgcode.InvalidColumnReference,
+			"argument of TABLESAMPLE must not contain variables"))
+	}
+	if !eval.IsConst(b.evalCtx, texpr) {
+		b.DisableMemoReuse = true
+		if b.KeepPlaceholders {
+			return nil, false
+		}
+	}
+	d, err := eval.Expr(b.ctx, b.evalCtx, texpr)
+	if err != nil {
+		panic(err)
+	}
+	return d, true
+}
+
 // addTable adds a table to the metadata and returns the TableMeta. The table
 // name is passed separately in order to preserve knowledge of whether the
 // catalog and schema names were explicitly specified.
@@ -532,6 +625,7 @@ func (b *Builder) buildScan(
 	tabMeta *opt.TableMeta,
 	ordinals []int,
 	indexFlags *tree.IndexFlags,
+	tableSample *tree.TableSample,
 	locking lockingSpec,
 	inScope *scope,
 	disableNotVisibleIndex bool,
@@ -596,6 +690,7 @@ func (b *Builder) buildScan(
 			panic(pgerror.Newf(pgcode.Syntax,
 				"index flags not allowed with virtual tables"))
 		}
+		checkNoTableSample(tableSample)
 		if locking.isSet() {
 			panic(pgerror.Newf(pgcode.Syntax,
 				"%s not allowed with virtual tables", locking.get().Strength))
@@ -743,6 +838,13 @@ func (b *Builder) buildScan(
 		private.Flags.NoZigzagJoin = true
 	}
 	private.Flags.DisableNotVisibleIndex = disableNotVisibleIndex
+	if tableSample != nil {
+		if private.Locking.IsLocking() {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s is not supported with TABLESAMPLE", private.Locking.Strength))
+		}
+		private.TableSample = b.buildTableSample(tableSample)
+	}
 
 	b.addCheckConstraintsForTable(tabMeta)
 	b.addComputedColsForTable(tabMeta, virtualMutationColOrds)
@@ -1401,7 +1503,7 @@ func (b *Builder) buildFromTables(
 func (b *Builder) buildFromTablesRightDeep(
 	tables tree.TableExprs, lockCtx lockingContext, inScope *scope,
 ) (outScope *scope) {
-	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, lockCtx, inScope)
+	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, nil /* tableSample */, lockCtx, inScope)
 
 	// Recursively build table join.
 	tables = tables[1:]
@@ -1451,7 +1553,7 @@ func (b *Builder) exprIsLateral(t tree.TableExpr) bool {
 func (b *Builder) buildFromWithLateral(
 	tables tree.TableExprs, lockCtx lockingContext, inScope *scope,
 ) (outScope *scope) {
-	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, lockCtx, inScope)
+	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, nil /* tableSample */, lockCtx, inScope)
 	for i := 1; i < len(tables); i++ {
 		scope := inScope
 		// Lateral expressions need to be able to refer to the expressions that
@@ -1460,7 +1562,7 @@ func (b *Builder) buildFromWithLateral(
 			scope = outScope
 			scope.context = exprKindLateralJoin
 		}
-		tableScope := b.buildDataSource(tables[i], nil /* indexFlags */, lockCtx, scope)
+		tableScope := b.buildDataSource(tables[i], nil /* indexFlags */, nil /* tableSample */, lockCtx, scope)
 
 		// Check that the same table name is not used multiple times.
 		b.validateJoinTableNames(outScope, tableScope)
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -18,10 +19,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
@@ -84,7 +89,9 @@ func (mb *mutationBuilder) buildRowLevelBeforeTriggers(
 
 		// Resolve the trigger function and build the invocation.
 		args := mb.buildTriggerFunctionArgs(trigger, eventType, oldColID, newColID)
-		triggerFn, def := mb.b.buildTriggerFunction(triggers[i], mb.tab.ID(), tableTyp, args)
+		triggerFn, def := mb.b.buildTriggerFunction(
+			triggers[i], mb.tab.ID(), tableTyp, args, nil, /* ctes */
+		)
 
 		// If there is a WHEN condition, wrap the trigger function invocation in a
 		// CASE WHEN statement that checks the WHEN condition.
@@ -377,80 +384,250 @@ func (mb *mutationBuilder) recomputeComputedColsForTrigger(eventType tree.Trigge
 }
 
 // ============================================================================
-// Row-level AFTER triggers
+// Statement-level triggers
 // ============================================================================
 
-// buildRowLevelAfterTriggers builds any applicable row-level AFTER triggers
-// based on the mutation operator. Since AFTER triggers are a form of
+// statementLevelTrigger is a statement-level trigger along with the event for
+// which it fires. An UPSERT or INSERT ON CONFLICT fires both INSERT and UPDATE
+// statement-level triggers, so a trigger that matches both events fires once
+// for each of them.
+type statementLevelTrigger struct {
+	trigger   cat.Trigger
+	eventType tree.TriggerEventType
+}
+
+// getStatementLevelTriggers returns the statement-level triggers with the
+// given action time that should fire for the given mutation operator, in the
+// order in which they should be executed.
+func (mb *mutationBuilder) getStatementLevelTriggers(
+	actionTime tree.TriggerActionTime, mutation opt.Operator,
+) []statementLevelTrigger {
+	eventsToMatch := mb.getEventsToMatchForMutation(mutation)
+	// Like Postgres, the INSERT triggers of an UPSERT fire before the UPDATE
+	// triggers for BEFORE triggers, and after them for AFTER triggers.
+	eventTypes := []tree.TriggerEventType{
+		tree.TriggerEventInsert, tree.TriggerEventUpdate, tree.TriggerEventDelete,
+	}
+	if actionTime == tree.TriggerActionTimeAfter {
+		eventTypes[0], eventTypes[1] = eventTypes[1], eventTypes[0]
+	}
+	var triggers []statementLevelTrigger
+	for _, eventType := range eventTypes {
+		if !eventsToMatch.Contains(eventType) {
+			continue
+		}
+		var eventToMatch tree.TriggerEventTypeSet
+		eventToMatch.Add(eventType)
+		for _, trigger := range cat.GetStatementLevelTriggers(mb.tab, actionTime, eventToMatch) {
+			triggers = append(triggers, statementLevelTrigger{trigger: trigger, eventType: eventType})
+		}
+	}
+	return triggers
+}
+
+// buildStatementLevelBeforeTriggers builds any applicable statement-level
+// BEFORE triggers based on the mutation operator. The trigger functions are
+// invoked by a materialized With binding that wraps the mutation, so that they
+// are executed once before the mutation, even if it modifies no rows.
+//
+// NOTE: buildStatementLevelBeforeTriggers must be called after the mutation
+// operator has been constructed.
+func (mb *mutationBuilder) buildStatementLevelBeforeTriggers(mutation opt.Operator) {
+	triggers := mb.getStatementLevelTriggers(tree.TriggerActionTimeBefore, mutation)
+	if len(triggers) == 0 {
+		return
+	}
+	typeID := typedesc.TableIDToImplicitTypeOID(descpb.ID(mb.tab.ID()))
+	tableTyp, err := mb.b.semaCtx.TypeResolver.ResolveTypeByOID(mb.b.ctx, typeID)
+	if err != nil {
+		panic(err)
+	}
+	f := mb.b.factory
+	triggerExpr := mb.b.buildStatementLevelTriggers(
+		mb.tab, tableTyp, tree.TriggerActionTimeBefore, triggers, nil, /* transitions */
+	)
+	id := f.Memo().NextWithID()
+	f.Metadata().AddWithBinding(id, triggerExpr)
+	mb.outScope.expr = f.ConstructWith(triggerExpr, mb.outScope.expr, &memo.WithPrivate{
+		ID:   id,
+		Name: "before-triggers",
+		Mtr:  tree.CTEMaterializeAlways,
+	})
+}
+
+// buildStatementLevelTriggers builds an expression that invokes each of the
+// given statement-level triggers once, in order. The OLD and NEW arguments of
+// the trigger functions are always NULL. If transitions is non-nil, the
+// trigger functions can reference the transition relations that it builds.
+func (b *Builder) buildStatementLevelTriggers(
+	tab cat.Table,
+	tableTyp *types.T,
+	actionTime tree.TriggerActionTime,
+	triggers []statementLevelTrigger,
+	transitions *transitionRelationBuilder,
+) memo.RelExpr {
+	f := b.factory
+	tgWhen := tree.NewDString(tree.AsString(&actionTime))
+	tgLevel := tree.NewDString("STATEMENT")
+	tgRelID := tree.NewDOid(oid.Oid(tab.ID()))
+	tgTableName := tree.NewDString(string(tab.Name()))
+	fqName, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+	tgTableSchema := tree.NewDString(fqName.Schema())
+
+	triggerScope := b.allocScope()
+	triggerScope.expr = f.ConstructNoColsRow()
+	for i := range triggers {
+		trigger, eventType := triggers[i].trigger, triggers[i].eventType
+		if i > 0 {
+			// No need to place a barrier below the first trigger.
+			triggerScope.expr = f.ConstructBarrier(triggerScope.expr)
+		}
+
+		tgName := tree.NewDName(string(trigger.Name()))
+		tgOp := tree.NewDString(eventType.String())
+		tgNumArgs := tree.NewDInt(tree.DInt(len(trigger.FuncArgs())))
+		tgArgV := tree.NewDArray(types.String)
+		for _, arg := range trigger.FuncArgs() {
+			err = tgArgV.Append(arg)
+			if err != nil {
+				panic(err)
+			}
+		}
+		args := memo.ScalarListExpr{
+			memo.NullSingleton,                               // NEW
+			memo.NullSingleton,                               // OLD
+			f.ConstructConstVal(tgName, types.Name),          // TG_NAME
+			f.ConstructConstVal(tgWhen, types.String),        // TG_WHEN
+			f.ConstructConstVal(tgLevel, types.String),       // TG_LEVEL
+			f.ConstructConstVal(tgOp, types.String),          // TG_OP
+			f.ConstructConstVal(tgRelID, types.Oid),          // TG_RELID
+			f.ConstructConstVal(tgTableName, types.String),   // TG_RELNAME
+			f.ConstructConstVal(tgTableName, types.String),   // TG_TABLE_NAME
+			f.ConstructConstVal(tgTableSchema, types.String), // TG_TABLE_SCHEMA
+			f.ConstructConstVal(tgNumArgs, types.Int),        // TG_NARGS
+			f.ConstructConstVal(tgArgV, types.StringArray),   // TG_ARGV
+		}
+
+		// Resolve the trigger function and build the invocation.
+		triggerFn, def := b.buildTriggerFunction(
+			trigger, tab.ID(), tableTyp, args, transitions.ctesForTrigger(trigger),
+		)
+
+		// If there is a WHEN condition, wrap the trigger function invocation in a
+		// CASE WHEN statement that checks the WHEN condition. The condition of a
+		// statement-level trigger cannot reference OLD or NEW.
+		if trigger.WhenExpr() != "" {
+			triggerFn = b.buildTriggerWhen(
+				trigger, triggerScope, 0 /* oldColID */, 0 /* newColID */, triggerFn,
+				f.ConstructNull(tableTyp),
+			)
+		}
+
+		// Finally, project a column that invokes the trigger function.
+		b.projectColWithMetadataName(triggerScope, def.Name, tableTyp, triggerFn)
+	}
+	// Always wrap the expression in a barrier, or else the projections will be
+	// pruned and the triggers will not be executed.
+	return f.ConstructBarrier(triggerScope.expr)
+}
+
+// ============================================================================
+// AFTER triggers
+// ============================================================================
+
+// buildAfterTriggers builds any applicable row-level and statement-level AFTER
+// triggers based on the mutation operator. Since AFTER triggers are a form of
 // post-query, they are stored on mutationBuilder instead of being projected as
 // part of the mutation input.
 //
-// NOTE: buildRowLevelAfterTriggers doesn't actually build the expression that
-// calls the trigger functions. Instead, it stores the information needed to do
-// so after the mutation executes.
-func (mb *mutationBuilder) buildRowLevelAfterTriggers(mutation opt.Operator) {
+// NOTE: buildAfterTriggers doesn't actually build the expression that calls
+// the trigger functions. Instead, it stores the information needed to do so
+// after the mutation executes.
+func (mb *mutationBuilder) buildAfterTriggers(mutation opt.Operator) {
 	eventsToMatch := mb.getEventsToMatchForMutation(mutation)
-	triggers := cat.GetRowLevelTriggers(mb.tab, tree.TriggerActionTimeAfter, eventsToMatch)
-	if len(triggers) == 0 {
+	rowTriggers := cat.GetRowLevelTriggers(mb.tab, tree.TriggerActionTimeAfter, eventsToMatch)
+	stmtTriggers := mb.getStatementLevelTriggers(tree.TriggerActionTimeAfter, mutation)
+	if len(rowTriggers) == 0 && len(stmtTriggers) == 0 {
 		return
 	}
-	mb.ensureWithID()
 
-	var visibleColOrds intsets.Fast
-	for i := 0; i < mb.tab.ColumnCount(); i++ {
-		if mb.tab.Column(i).Visibility() == cat.Visible {
-			visibleColOrds.Add(i)
+	// The modified rows are only needed by row-level triggers, and by triggers
+	// that reference transition relations. Statement-level triggers without
+	// transition relations don't need the mutation input to be buffered.
+	needRows := len(rowTriggers) > 0
+	for i := range stmtTriggers {
+		if hasTransitionRelations(stmtTriggers[i].trigger) {
+			needRows = true
+			break
 		}
 	}
 
-	var fetchCols opt.ColList
-	if mutation == opt.DeleteOp || mutation == opt.UpdateOp || mb.canaryColID != 0 {
-		// For DELETE, UPDATE, and UPSERT/ON CONFLICT, we need to provide the old
-		// values for each row.
-		fetchCols = make(opt.ColList, 0, visibleColOrds.Len())
-		for i, ok := visibleColOrds.Next(0); ok; i, ok = visibleColOrds.Next(i + 1) {
-			if mb.fetchColIDs[i] == 0 {
-				panic(errors.AssertionFailedf("fetchColID is 0"))
+	var fetchCols, updateCols, insertCols opt.ColList
+	if needRows {
+		mb.ensureWithID()
+
+		var visibleColOrds intsets.Fast
+		for i := 0; i < mb.tab.ColumnCount(); i++ {
+			if mb.tab.Column(i).Visibility() == cat.Visible {
+				visibleColOrds.Add(i)
 			}
-			mb.triggerColIDs.Add(mb.fetchColIDs[i])
-			fetchCols = append(fetchCols, mb.fetchColIDs[i])
 		}
-	}
-	// makeNewCols builds a new ColList from the given ColList with only the
-	// visible columns. If there are zero values, fetchColIDs will be used to
-	// substitute.
-	makeNewCols := func(cols opt.OptionalColList) opt.ColList {
-		newCols := make(opt.ColList, 0, visibleColOrds.Len())
-		for i, ok := visibleColOrds.Next(0); ok; i, ok = visibleColOrds.Next(i + 1) {
-			col := cols[i]
-			if col == 0 {
-				col = mb.fetchColIDs[i]
+
+		if mutation == opt.DeleteOp || mutation == opt.UpdateOp || mb.canaryColID != 0 {
+			// For DELETE, UPDATE, and UPSERT/ON CONFLICT, we need to provide the old
+			// values for each row.
+			fetchCols = make(opt.ColList, 0, visibleColOrds.Len())
+			for i, ok := visibleColOrds.Next(0); ok; i, ok = visibleColOrds.Next(i + 1) {
+				if mb.fetchColIDs[i] == 0 {
+					panic(errors.AssertionFailedf("fetchColID is 0"))
+				}
+				mb.triggerColIDs.Add(mb.fetchColIDs[i])
+				fetchCols = append(fetchCols, mb.fetchColIDs[i])
 			}
-			if col == 0 {
-				panic(errors.AssertionFailedf("col is 0"))
+		}
+		// makeNewCols builds a new ColList from the given ColList with only the
+		// visible columns. If there are zero values, fetchColIDs will be used to
+		// substitute.
+		makeNewCols := func(cols opt.OptionalColList) opt.ColList {
+			newCols := make(opt.ColList, 0, visibleColOrds.Len())
+			for i, ok := visibleColOrds.Next(0); ok; i, ok = visibleColOrds.Next(i + 1) {
+				col := cols[i]
+				if col == 0 {
+					col = mb.fetchColIDs[i]
+				}
+				if col == 0 {
+					panic(errors.AssertionFailedf("col is 0"))
+				}
+				mb.triggerColIDs.Add(col)
+				newCols = append(newCols, col)
 			}
-			mb.triggerColIDs.Add(col)
-			newCols = append(newCols, col)
+			return newCols
+		}
+		if mb.canaryColID != 0 || mutation == opt.UpdateOp {
+			updateCols = makeNewCols(mb.updateColIDs)
+		}
+		if mb.canaryColID != 0 || mutation == opt.InsertOp {
+			insertCols = makeNewCols(mb.insertColIDs)
+		}
+		if mb.canaryColID != 0 {
+			mb.triggerColIDs.Add(mb.canaryColID)
 		}
-		return newCols
-	}
-	var updateCols, insertCols opt.ColList
-	if mb.canaryColID != 0 || mutation == opt.UpdateOp {
-		updateCols = makeNewCols(mb.updateColIDs)
-	}
-	if mb.canaryColID != 0 || mutation == opt.InsertOp {
-		insertCols = makeNewCols(mb.insertColIDs)
-	}
-	if mb.canaryColID != 0 {
-		mb.triggerColIDs.Add(mb.canaryColID)
 	}
 	if mb.afterTriggers != nil {
 		panic(errors.AssertionFailedf("afterTriggers already set"))
 	}
+	triggers := make([]cat.Trigger, 0, len(rowTriggers)+len(stmtTriggers))
+	triggers = append(triggers, rowTriggers...)
+	for i := range stmtTriggers {
+		triggers = append(triggers, stmtTriggers[i].trigger)
+	}
 	mb.afterTriggers = &memo.AfterTriggers{
 		Triggers: triggers,
-		Builder: mb.newRowLevelAfterTriggerBuilder(
-			mutation, triggers, fetchCols, updateCols, insertCols,
+		Builder: mb.newAfterTriggerBuilder(
+			mutation, rowTriggers, stmtTriggers, fetchCols, updateCols, insertCols,
 		),
 		WithID: mb.withID,
 	}
@@ -480,17 +657,20 @@ func (mb *mutationBuilder) getEventsToMatchForMutation(
 	return eventsToMatch
 }
 
-// rowLevelAfterTriggerBuilder is a memo.PostQueryBuilder implementation for
-// row-level AFTER triggers.
+// afterTriggerBuilder is a memo.PostQueryBuilder implementation for AFTER
+// triggers.
 //
 // It provides a method to build the trigger-function invocations over the set
-// of rows that were modified by the mutation.
+// of rows that were modified by the mutation. Row-level triggers are invoked
+// once for each row, followed by statement-level triggers, which are invoked
+// once regardless of the number of modified rows.
 //
 // See testdata/trigger for some examples.
-type rowLevelAfterTriggerBuilder struct {
+type afterTriggerBuilder struct {
 	mutation     opt.Operator
 	mutatedTable cat.Table
-	triggers     []cat.Trigger
+	rowTriggers  []cat.Trigger
+	stmtTriggers []statementLevelTrigger
 
 	// stmtTreeInitFn returns a statementTree that tracks the mutations in
 	// ancestor statements. It may be unset if there are no ancestor statements.
@@ -515,25 +695,32 @@ type rowLevelAfterTriggerBuilder struct {
 	canaryCol opt.ColumnID
 }
 
-var _ memo.PostQueryBuilder = &rowLevelAfterTriggerBuilder{}
+var _ memo.PostQueryBuilder = &afterTriggerBuilder{}
 
-func (mb *mutationBuilder) newRowLevelAfterTriggerBuilder(
-	mutation opt.Operator, triggers []cat.Trigger, fetchCols, updateCols, insertCols opt.ColList,
-) *rowLevelAfterTriggerBuilder {
-	return &rowLevelAfterTriggerBuilder{
+func (mb *mutationBuilder) newAfterTriggerBuilder(
+	mutation opt.Operator,
+	rowTriggers []cat.Trigger,
+	stmtTriggers []statementLevelTrigger,
+	fetchCols, updateCols, insertCols opt.ColList,
+) *afterTriggerBuilder {
+	tb := &afterTriggerBuilder{
 		mutation:       mutation,
 		mutatedTable:   mb.tab,
-		triggers:       triggers,
+		rowTriggers:    rowTriggers,
+		stmtTriggers:   stmtTriggers,
 		stmtTreeInitFn: mb.b.stmtTree.GetInitFnForPostQuery(),
 		fetchCols:      fetchCols,
 		updateCols:     updateCols,
 		insertCols:     insertCols,
-		canaryCol:      mb.canaryColID,
 	}
+	if len(fetchCols) > 0 || len(updateCols) > 0 || len(insertCols) > 0 {
+		tb.canaryCol = mb.canaryColID
+	}
+	return tb
 }
 
 // Build is part of the memo.PostQueryBuilder interface.
-func (tb *rowLevelAfterTriggerBuilder) Build(
+func (tb *afterTriggerBuilder) Build(
 	ctx context.Context,
 	semaCtx *tree.SemaContext,
 	evalCtx *eval.Context,
@@ -558,18 +745,7 @@ func (tb *rowLevelAfterTriggerBuilder) Build(
 			inFetchCols := tb.fetchCols.RemapColumns(colMap)
 			inUpdateCols := tb.updateCols.RemapColumns(colMap)
 			inInsertCols := tb.insertCols.RemapColumns(colMap)
-			colCount := len(inFetchCols) + len(inUpdateCols) + len(inInsertCols)
-			if tb.canaryCol != 0 {
-				// Make space for the canary column.
-				colCount++
-			}
-			inCols := make(opt.ColList, 0, colCount)
-			outCols := make(opt.ColList, 0, colCount)
-
-			// Allocate a new scope to build the expression that will call the trigger
-			// functions for each row scanned from the buffer.
-			triggerScope := b.allocScope()
-			var inCanaryCol, outCanaryCol opt.ColumnID
+			var inCanaryCol opt.ColumnID
 			if tb.canaryCol != 0 {
 				inCanaryColID, ok := colMap.Get(int(tb.canaryCol))
 				if !ok {
@@ -577,188 +753,838 @@ func (tb *rowLevelAfterTriggerBuilder) Build(
 						tb.canaryCol, colMap.String()))
 				}
 				inCanaryCol = opt.ColumnID(inCanaryColID)
-				colType := md.ColumnMeta(inCanaryCol).Type
-				colName := scopeColName("").WithMetadataName("canary")
-				col := b.synthesizeColumn(triggerScope, colName, colType, nil /* expr */, nil /* scalar */)
-				outCanaryCol = col.id
-				inCols = append(inCols, inCanaryCol)
-				outCols = append(outCols, outCanaryCol)
 			}
-			addCols := func(cols opt.ColList, suffix string) opt.ColList {
-				startIdx := len(outCols)
-				for _, col := range cols {
-					colMeta := md.ColumnMeta(col)
-					name := scopeColName("").WithMetadataName(fmt.Sprintf("%s_%s", colMeta.Alias, suffix))
-					outCol := b.synthesizeColumn(
-						triggerScope, name, colMeta.Type, nil /* expr */, nil, /* scalar */
-					)
-					inCols = append(inCols, col)
-					outCols = append(outCols, outCol.id)
-				}
-				return outCols[startIdx:len(outCols):len(outCols)]
+			if binding != 0 {
+				md.AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+					Props: bindingProps,
+				}))
 			}
-			outFetchCols := addCols(inFetchCols, "old")
-			outUpdateCols := addCols(inUpdateCols, "new")
-			outInsertCols := addCols(inInsertCols, "new")
-			md.AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
-				Props: bindingProps,
-			}))
-			triggerScope.expr = f.ConstructWithScan(&memo.WithScanPrivate{
-				With:    binding,
-				InCols:  inCols,
-				OutCols: outCols,
-				ID:      md.NextUniqueID(),
-			})
-
-			// Project the old and new values into tuples. These will become the OLD and
-			// NEW arguments to the trigger functions.
-			makeTuple := func(cols opt.ColList) opt.ScalarExpr {
-				elems := make([]opt.ScalarExpr, len(cols))
-				for i, col := range cols {
-					elems[i] = f.ConstructVariable(col)
+			transitions := &transitionRelationBuilder{
+				b:            b,
+				tableTyp:     tableTyp,
+				binding:      binding,
+				inFetchCols:  inFetchCols,
+				inUpdateCols: inUpdateCols,
+				inInsertCols: inInsertCols,
+				inCanaryCol:  inCanaryCol,
+			}
+
+			var triggerExpr memo.RelExpr
+			if len(tb.rowTriggers) > 0 {
+				triggerExpr = tb.buildRowLevelTriggers(
+					ctx, b, tableTyp, binding, inFetchCols, inUpdateCols, inInsertCols, inCanaryCol,
+					transitions,
+				)
+			}
+			if len(tb.stmtTriggers) > 0 {
+				stmtExpr := b.buildStatementLevelTriggers(
+					tb.mutatedTable, tableTyp, tree.TriggerActionTimeAfter, tb.stmtTriggers, transitions,
+				)
+				if triggerExpr != nil {
+					// Statement-level triggers fire after all of the row-level triggers
+					// have fired. Bind the row-level triggers to a materialized With, which
+					// is executed before the statement-level triggers.
+					id := f.Memo().NextWithID()
+					md.AddWithBinding(id, triggerExpr)
+					stmtExpr = f.ConstructWith(triggerExpr, stmtExpr, &memo.WithPrivate{
+						ID:   id,
+						Name: "row-level-triggers",
+						Mtr:  tree.CTEMaterializeAlways,
+					})
 				}
-				return f.ConstructTuple(elems, tableTyp)
+				triggerExpr = stmtExpr
 			}
-			var canaryCheck opt.ScalarExpr
-			if tb.canaryCol != 0 {
-				canaryCheck = f.ConstructIs(f.ConstructVariable(outCanaryCol), memo.NullSingleton)
+			return transitions.wrap(triggerExpr)
+		})
+}
+
+// buildRowLevelTriggers builds an expression that invokes the row-level AFTER
+// triggers for each row scanned from the buffered mutation input.
+func (tb *afterTriggerBuilder) buildRowLevelTriggers(
+	ctx context.Context,
+	b *Builder,
+	tableTyp *types.T,
+	binding opt.WithID,
+	inFetchCols, inUpdateCols, inInsertCols opt.ColList,
+	inCanaryCol opt.ColumnID,
+	transitions *transitionRelationBuilder,
+) memo.RelExpr {
+	f := b.factory
+	md := f.Metadata()
+
+	colCount := len(inFetchCols) + len(inUpdateCols) + len(inInsertCols)
+	if inCanaryCol != 0 {
+		// Make space for the canary column.
+		colCount++
+	}
+	inCols := make(opt.ColList, 0, colCount)
+	outCols := make(opt.ColList, 0, colCount)
+
+	// Allocate a new scope to build the expression that will call the trigger
+	// functions for each row scanned from the buffer.
+	triggerScope := b.allocScope()
+	var outCanaryCol opt.ColumnID
+	if inCanaryCol != 0 {
+		colType := md.ColumnMeta(inCanaryCol).Type
+		colName := scopeColName("").WithMetadataName("canary")
+		col := b.synthesizeColumn(triggerScope, colName, colType, nil /* expr */, nil /* scalar */)
+		outCanaryCol = col.id
+		inCols = append(inCols, inCanaryCol)
+		outCols = append(outCols, outCanaryCol)
+	}
+	addCols := func(cols opt.ColList, suffix string) opt.ColList {
+		startIdx := len(outCols)
+		for _, col := range cols {
+			colMeta := md.ColumnMeta(col)
+			name := scopeColName("").WithMetadataName(fmt.Sprintf("%s_%s", colMeta.Alias, suffix))
+			outCol := b.synthesizeColumn(
+				triggerScope, name, colMeta.Type, nil /* expr */, nil, /* scalar */
+			)
+			inCols = append(inCols, col)
+			outCols = append(outCols, outCol.id)
+		}
+		return outCols[startIdx:len(outCols):len(outCols)]
+	}
+	outFetchCols := addCols(inFetchCols, "old")
+	outUpdateCols := addCols(inUpdateCols, "new")
+	outInsertCols := addCols(inInsertCols, "new")
+	triggerScope.expr = f.ConstructWithScan(&memo.WithScanPrivate{
+		With:    binding,
+		InCols:  inCols,
+		OutCols: outCols,
+		ID:      md.NextUniqueID(),
+	})
+
+	// Project the old and new values into tuples. These will become the OLD and
+	// NEW arguments to the trigger functions.
+	makeTuple := func(cols opt.ColList) opt.ScalarExpr {
+		elems := make([]opt.ScalarExpr, len(cols))
+		for i, col := range cols {
+			elems[i] = f.ConstructVariable(col)
+		}
+		return f.ConstructTuple(elems, tableTyp)
+	}
+	var canaryCheck opt.ScalarExpr
+	if outCanaryCol != 0 {
+		canaryCheck = f.ConstructIs(f.ConstructVariable(outCanaryCol), memo.NullSingleton)
+	}
+
+	// Build an expression for the old values of each row.
+	oldScalar := opt.ScalarExpr(memo.NullSingleton)
+	if len(outFetchCols) > 0 {
+		oldScalar = makeTuple(outFetchCols)
+		if outCanaryCol != 0 {
+			// For an UPSERT/ON CONFLICT, the OLD column is non-null only for the
+			// conflicting rows, which are identified by the canary column.
+			oldScalar = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(canaryCheck, f.ConstructNull(tableTyp))},
+				oldScalar,
+			)
+		}
+	}
+	// Build an expression for the new values of each row.
+	newScalar := opt.ScalarExpr(memo.NullSingleton)
+	if outCanaryCol != 0 {
+		// For an UPSERT/ON CONFLICT, the NEW column contains either inserted or
+		// updated values, depending on the canary column.
+		newScalar = f.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{f.ConstructWhen(canaryCheck, makeTuple(outInsertCols))},
+			makeTuple(outUpdateCols),
+		)
+	} else if len(outUpdateCols) > 0 {
+		newScalar = makeTuple(outUpdateCols)
+	} else if len(outInsertCols) > 0 {
+		newScalar = makeTuple(outInsertCols)
+	}
+	oldColID := b.projectColWithMetadataName(triggerScope, triggerColOld, tableTyp, oldScalar)
+	newColID := b.projectColWithMetadataName(triggerScope, triggerColNew, tableTyp, newScalar)
+	tgWhen := tree.NewDString("AFTER")
+	tgLevel := tree.NewDString("ROW")
+	tgRelID := tree.NewDOid(oid.Oid(tb.mutatedTable.ID()))
+	tgTableName := tree.NewDString(string(tb.mutatedTable.Name()))
+	fqName, err := b.catalog.FullyQualifiedName(ctx, tb.mutatedTable)
+	if err != nil {
+		panic(err)
+	}
+	tgTableSchema := tree.NewDString(fqName.Schema())
+	var tgOp opt.ScalarExpr
+	switch tb.mutation {
+	case opt.InsertOp:
+		tgOp = f.ConstructConstVal(tree.NewDString("INSERT"), types.String)
+		if outCanaryCol != 0 {
+			tgOp = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(canaryCheck, tgOp)},
+				f.ConstructConstVal(tree.NewDString("UPDATE"), types.String),
+			)
+		}
+	case opt.UpdateOp:
+		tgOp = f.ConstructConstVal(tree.NewDString("UPDATE"), types.String)
+	case opt.DeleteOp:
+		tgOp = f.ConstructConstVal(tree.NewDString("DELETE"), types.String)
+	default:
+		panic(errors.AssertionFailedf("unexpected mutation type: %v", tb.mutation))
+	}
+
+	for i, trigger := range tb.rowTriggers {
+		if i > 0 {
+			// No need to place a barrier below the first trigger.
+			triggerScope.expr = f.ConstructBarrier(triggerScope.expr)
+		}
+
+		tgName := tree.NewDName(string(trigger.Name()))
+		tgNumArgs := tree.NewDInt(tree.DInt(len(trigger.FuncArgs())))
+		tgArgV := tree.NewDArray(types.String)
+		for _, arg := range trigger.FuncArgs() {
+			err = tgArgV.Append(arg)
+			if err != nil {
+				panic(err)
 			}
+		}
+		args := memo.ScalarListExpr{
+			f.ConstructVariable(newColID),              // NEW
+			f.ConstructVariable(oldColID),              // OLD
+			f.ConstructConstVal(tgName, types.Name),    // TG_NAME
+			f.ConstructConstVal(tgWhen, types.String),  // TG_WHEN
+			f.ConstructConstVal(tgLevel, types.String), // TG_LEVEL
+			tgOp,                                    // TG_OP
+			f.ConstructConstVal(tgRelID, types.Oid), // TG_RELID
+			f.ConstructConstVal(tgTableName, types.String),   // TG_RELNAME
+			f.ConstructConstVal(tgTableName, types.String),   // TG_TABLE_NAME
+			f.ConstructConstVal(tgTableSchema, types.String), // TG_TABLE_SCHEMA
+			f.ConstructConstVal(tgNumArgs, types.Int),        // TG_NARGS
+			f.ConstructConstVal(tgArgV, types.StringArray),   // TG_ARGV
+		}
+
+		// Resolve the trigger function and build the invocation.
+		triggerFn, def := b.buildTriggerFunction(
+			trigger, tb.mutatedTable.ID(), tableTyp, args, transitions.ctesForTrigger(trigger),
+		)
 
-			// Build an expression for the old values of each row.
-			oldScalar := opt.ScalarExpr(memo.NullSingleton)
-			if len(outFetchCols) > 0 {
-				oldScalar = makeTuple(outFetchCols)
-				if outCanaryCol != 0 {
-					// For an UPSERT/ON CONFLICT, the OLD column is non-null only for the
-					// conflicting rows, which are identified by the canary column.
-					oldScalar = f.ConstructCase(
-						memo.TrueSingleton,
-						memo.ScalarListExpr{f.ConstructWhen(canaryCheck, f.ConstructNull(tableTyp))},
-						oldScalar,
-					)
+		// If there is a WHEN condition, wrap the trigger function invocation in a
+		// CASE WHEN statement that checks the WHEN condition.
+		if trigger.WhenExpr() != "" {
+			triggerFn = b.buildTriggerWhen(
+				trigger, triggerScope, oldColID, newColID, triggerFn, f.ConstructNull(tableTyp),
+			)
+		}
+
+		// For UPSERT and INSERT ON CONFLICT, UPDATE triggers should only fire for
+		// the conflicting rows, which are identified by the canary column. INSERT
+		// triggers should only fire for non-conflicting rows. A trigger that
+		// matches both operations can fire unconditionally.
+		if outCanaryCol != 0 {
+			var hasInsert, hasUpdate bool
+			for j := 0; j < trigger.EventCount(); j++ {
+				if trigger.Event(j).EventType == tree.TriggerEventInsert {
+					hasInsert = true
+				} else if trigger.Event(j).EventType == tree.TriggerEventUpdate {
+					hasUpdate = true
 				}
 			}
-			// Build an expression for the new values of each row.
-			newScalar := opt.ScalarExpr(memo.NullSingleton)
-			if outCanaryCol != 0 {
-				// For an UPSERT/ON CONFLICT, the NEW column contains either inserted or
-				// updated values, depending on the canary column.
-				newScalar = f.ConstructCase(
+			if hasInsert && !hasUpdate {
+				triggerFn = f.ConstructCase(
+					memo.TrueSingleton,
+					memo.ScalarListExpr{f.ConstructWhen(canaryCheck, triggerFn)},
+					f.ConstructNull(tableTyp),
+				)
+			} else if hasUpdate && !hasInsert {
+				triggerFn = f.ConstructCase(
 					memo.TrueSingleton,
-					memo.ScalarListExpr{f.ConstructWhen(canaryCheck, makeTuple(outInsertCols))},
-					makeTuple(outUpdateCols),
+					memo.ScalarListExpr{f.ConstructWhen(canaryCheck, f.ConstructNull(tableTyp))},
+					triggerFn,
 				)
-			} else if len(outUpdateCols) > 0 {
-				newScalar = makeTuple(outUpdateCols)
-			} else if len(outInsertCols) > 0 {
-				newScalar = makeTuple(outInsertCols)
 			}
-			oldColID := b.projectColWithMetadataName(triggerScope, triggerColOld, tableTyp, oldScalar)
-			newColID := b.projectColWithMetadataName(triggerScope, triggerColNew, tableTyp, newScalar)
-			tgWhen := tree.NewDString("AFTER")
-			tgLevel := tree.NewDString("ROW")
-			tgRelID := tree.NewDOid(oid.Oid(tb.mutatedTable.ID()))
-			tgTableName := tree.NewDString(string(tb.mutatedTable.Name()))
-			fqName, err := b.catalog.FullyQualifiedName(ctx, tb.mutatedTable)
-			if err != nil {
-				panic(err)
+		}
+
+		// Finally, project a column that invokes the trigger function.
+		b.projectColWithMetadataName(triggerScope, def.Name, tableTyp, triggerFn)
+	}
+	// Always wrap the expression in a barrier, or else the projections will be
+	// pruned and the triggers will not be executed.
+	return f.ConstructBarrier(triggerScope.expr)
+}
+
+// ============================================================================
+// Transition relations
+// ============================================================================
+
+// hasTransitionRelations returns true if the given trigger references the OLD
+// TABLE or NEW TABLE transition relation.
+func hasTransitionRelations(trigger cat.Trigger) bool {
+	return trigger.OldTransitionAlias() != "" || trigger.NewTransitionAlias() != ""
+}
+
+// transitionRelationBuilder builds the transition relations of AFTER triggers.
+// The OLD TABLE and NEW TABLE relations contain the old and new values of the
+// rows modified by the mutation, respectively. Each relation is built as a
+// materialized With binding over the buffered mutation input, which is made
+// available to the trigger function as a CTE named by the alias from the
+// trigger's REFERENCING clause.
+type transitionRelationBuilder struct {
+	b        *Builder
+	tableTyp *types.T
+
+	// binding identifies the buffered mutation input. The following columns
+	// are the columns of the buffered input, with one entry per visible column
+	// in the table. See afterTriggerBuilder.
+	binding      opt.WithID
+	inFetchCols  opt.ColList
+	inUpdateCols opt.ColList
+	inInsertCols opt.ColList
+	inCanaryCol  opt.ColumnID
+
+	// ctes contains the transition relations that have been built so far, in
+	// the order in which they were built.
+	ctes []transitionRelation
+}
+
+// transitionRelation is a transition relation for a given trigger event.
+type transitionRelation struct {
+	eventType tree.TriggerEventType
+	isNew     bool
+	cte       *cteSource
+}
+
+// ctesForTrigger returns the transition relations referenced by the given
+// trigger, keyed by their aliases. It returns nil if the trigger does not
+// reference any transition relations.
+func (tr *transitionRelationBuilder) ctesForTrigger(trigger cat.Trigger) map[string]*cteSource {
+	if tr == nil || !hasTransitionRelations(trigger) {
+		return nil
+	}
+	// Transition relations can only be specified for triggers with a single
+	// event.
+	if trigger.EventCount() != 1 {
+		panic(errors.AssertionFailedf(
+			"expected a single event for trigger %s with transition relations", trigger.Name()))
+	}
+	eventType := trigger.Event(0).EventType
+	ctes := make(map[string]*cteSource, 2)
+	if alias := trigger.OldTransitionAlias(); alias != "" {
+		ctes[string(alias)] = tr.getOrBuild(eventType, false /* isNew */)
+	}
+	if alias := trigger.NewTransitionAlias(); alias != "" {
+		ctes[string(alias)] = tr.getOrBuild(eventType, true /* isNew */)
+	}
+	return ctes
+}
+
+// getOrBuild returns the OLD or NEW transition relation for the given event,
+// building it if necessary.
+func (tr *transitionRelationBuilder) getOrBuild(
+	eventType tree.TriggerEventType, isNew bool,
+) *cteSource {
+	for i := range tr.ctes {
+		if tr.ctes[i].eventType == eventType && tr.ctes[i].isNew == isNew {
+			return tr.ctes[i].cte
+		}
+	}
+	var cols opt.ColList
+	switch {
+	case !isNew:
+		cols = tr.inFetchCols
+	case eventType == tree.TriggerEventInsert:
+		cols = tr.inInsertCols
+	default:
+		cols = tr.inUpdateCols
+	}
+	if tr.binding == 0 || len(cols) != len(tr.tableTyp.TupleContents()) {
+		panic(errors.AssertionFailedf("missing columns for transition relation"))
+	}
+	f := tr.b.factory
+	md := f.Metadata()
+	inCols := make(opt.ColList, 0, len(cols)+1)
+	outCols := make(opt.ColList, 0, len(cols)+1)
+	var outColSet opt.ColSet
+	presentation := make(physical.Presentation, len(cols))
+	for i, col := range cols {
+		name := tr.tableTyp.TupleLabels()[i]
+		outCol := md.AddColumn(name, md.ColumnMeta(col).Type)
+		inCols = append(inCols, col)
+		outCols = append(outCols, outCol)
+		outColSet.Add(outCol)
+		presentation[i] = opt.AliasedColumn{Alias: name, ID: outCol}
+	}
+	var outCanaryCol opt.ColumnID
+	if tr.inCanaryCol != 0 {
+		outCanaryCol = md.AddColumn("canary", md.ColumnMeta(tr.inCanaryCol).Type)
+		inCols = append(inCols, tr.inCanaryCol)
+		outCols = append(outCols, outCanaryCol)
+	}
+	expr := f.ConstructWithScan(&memo.WithScanPrivate{
+		With:    tr.binding,
+		InCols:  inCols,
+		OutCols: outCols,
+		ID:      md.NextUniqueID(),
+	})
+	if outCanaryCol != 0 {
+		// For an UPSERT/ON CONFLICT, the inserted rows are identified by a NULL
+		// canary column, and the updated rows by a non-NULL canary column.
+		var filter opt.ScalarExpr
+		if eventType == tree.TriggerEventInsert {
+			filter = f.ConstructIs(f.ConstructVariable(outCanaryCol), memo.NullSingleton)
+		} else {
+			filter = f.ConstructIsNot(f.ConstructVariable(outCanaryCol), memo.NullSingleton)
+		}
+		expr = f.ConstructSelect(expr, memo.FiltersExpr{f.ConstructFiltersItem(filter)})
+		expr = f.ConstructProject(expr, nil /* projections */, outColSet)
+	}
+
+	name := "old-table"
+	if isNew {
+		name = "new-table"
+	}
+	id := f.Memo().NextWithID()
+	md.AddWithBinding(id, expr)
+	cte := &cteSource{
+		id:   id,
+		name: tree.AliasClause{Alias: tree.Name(name)},
+		cols: presentation,
+		expr: expr,
+		mtr:  tree.CTEMaterializeAlways,
+	}
+	tr.ctes = append(tr.ctes, transitionRelation{eventType: eventType, isNew: isNew, cte: cte})
+	return cte
+}
+
+// wrap wraps the given expression in a With for each transition relation that
+// was built, so that the transition relations are materialized before the
+// trigger functions are invoked.
+func (tr *transitionRelationBuilder) wrap(expr memo.RelExpr) memo.RelExpr {
+	f := tr.b.factory
+	for i := len(tr.ctes) - 1; i >= 0; i-- {
+		cte := tr.ctes[i].cte
+		expr = f.ConstructWith(cte.expr, expr, &memo.WithPrivate{
+			ID:   cte.id,
+			Name: string(cte.name.Alias),
+			Mtr:  cte.mtr,
+		})
+	}
+	return expr
+}
+
+// ============================================================================
+// INSTEAD OF triggers
+// ============================================================================
+
+// insteadOfTriggerBuilder builds a mutation of a view that has INSTEAD OF
+// triggers for the mutation event. Views are not updatable, so rather than
+// modifying the view, the mutation invokes the triggers once for each affected
+// row. The trigger functions are responsible for modifying the underlying
+// tables.
+type insteadOfTriggerBuilder struct {
+	b         *Builder
+	view      cat.View
+	viewTyp   *types.T
+	alias     tree.TableName
+	eventType tree.TriggerEventType
+	triggers  []cat.Trigger
+}
+
+func newInsteadOfTriggerBuilder(
+	b *Builder, view cat.View, alias tree.TableName, eventType tree.TriggerEventType,
+) *insteadOfTriggerBuilder {
+	typeID := typedesc.TableIDToImplicitTypeOID(descpb.ID(view.ID()))
+	viewTyp, err := b.semaCtx.TypeResolver.ResolveTypeByOID(b.ctx, typeID)
+	if err != nil {
+		panic(err)
+	}
+	return &insteadOfTriggerBuilder{
+		b:         b,
+		view:      view,
+		viewTyp:   viewTyp,
+		alias:     alias,
+		eventType: eventType,
+		triggers:  cat.GetInsteadOfTriggers(view, eventType),
+	}
+}
+
+// buildInsteadOfInsert builds an INSERT into a view, which is performed by the
+// view's INSTEAD OF INSERT triggers. Each input row is passed to the triggers
+// as the NEW row. Views do not have default values, so columns that are not
+// targeted by the input are NULL.
+func (b *Builder) buildInsteadOfInsert(
+	ins *tree.Insert, inScope *scope, view cat.View, alias tree.TableName,
+) (outScope *scope) {
+	if ins.OnConflict != nil {
+		op := "ON CONFLICT"
+		if ins.OnConflict.IsUpsertAlias() {
+			op = "UPSERT"
+		}
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported on view \"%s\"", op, view.Name()))
+	}
+	tb := newInsteadOfTriggerBuilder(b, view, alias, tree.TriggerEventInsert)
+	colTypes := tb.viewTyp.TupleContents()
+
+	// If the target columns are not explicitly specified by name, the input
+	// columns are mapped to the view columns by ordinal position.
+	var targetOrds []int
+	if len(ins.Columns) != 0 {
+		var seen intsets.Fast
+		targetOrds = tb.targetOrdinals(ins.Columns, &seen)
+	}
+
+	// Build the input rows, or a single empty row for DEFAULT VALUES.
+	var inputScope *scope
+	if ins.DefaultValues() {
+		inputScope = inScope.push()
+		inputScope.expr = b.factory.ConstructNoColsRow()
+	} else {
+		desiredTypes := colTypes
+		if targetOrds != nil {
+			desiredTypes = make([]*types.T, len(targetOrds))
+			for i, ord := range targetOrds {
+				desiredTypes[i] = colTypes[ord]
 			}
-			tgTableSchema := tree.NewDString(fqName.Schema())
-			var tgOp opt.ScalarExpr
-			switch tb.mutation {
-			case opt.InsertOp:
-				tgOp = f.ConstructConstVal(tree.NewDString("INSERT"), types.String)
-				if outCanaryCol != 0 {
-					tgOp = f.ConstructCase(
-						memo.TrueSingleton,
-						memo.ScalarListExpr{f.ConstructWhen(canaryCheck, tgOp)},
-						f.ConstructConstVal(tree.NewDString("UPDATE"), types.String),
-					)
-				}
-			case opt.UpdateOp:
-				tgOp = f.ConstructConstVal(tree.NewDString("UPDATE"), types.String)
-			case opt.DeleteOp:
-				tgOp = f.ConstructConstVal(tree.NewDString("DELETE"), types.String)
-			default:
-				panic(errors.AssertionFailedf("unexpected mutation type: %v", tb.mutation))
+		}
+		inputScope = b.buildStmt(replaceDefaultValsWithNull(ins.Rows), desiredTypes, inScope)
+		if targetOrds == nil {
+			targetOrds = make([]int, min(len(inputScope.cols), len(colTypes)))
+			for i := range targetOrds {
+				targetOrds[i] = i
 			}
+		}
+		checkMutationNumCols("insert", len(targetOrds), len(inputScope.cols))
+	}
 
-			for i, trigger := range tb.triggers {
-				if i > 0 {
-					// No need to place a barrier below the first trigger.
-					triggerScope.expr = f.ConstructBarrier(triggerScope.expr)
-				}
+	// Project the NEW row from the input columns.
+	elems := make(memo.ScalarListExpr, len(colTypes))
+	for i := range elems {
+		elems[i] = b.factory.ConstructNull(colTypes[i])
+	}
+	for i, ord := range targetOrds {
+		col := &inputScope.cols[i]
+		elems[ord] = tb.assignmentCast(b.factory.ConstructVariable(col.id), col.typ, ord)
+	}
+	newColID := tb.projectRow(inputScope, triggerColNew, elems)
 
-				tgName := tree.NewDName(string(trigger.Name()))
-				tgNumArgs := tree.NewDInt(tree.DInt(len(trigger.FuncArgs())))
-				tgArgV := tree.NewDArray(types.String)
-				for _, arg := range trigger.FuncArgs() {
-					err = tgArgV.Append(arg)
-					if err != nil {
-						panic(err)
-					}
-				}
-				args := memo.ScalarListExpr{
-					f.ConstructVariable(newColID),              // NEW
-					f.ConstructVariable(oldColID),              // OLD
-					f.ConstructConstVal(tgName, types.Name),    // TG_NAME
-					f.ConstructConstVal(tgWhen, types.String),  // TG_WHEN
-					f.ConstructConstVal(tgLevel, types.String), // TG_LEVEL
-					tgOp,                                    // TG_OP
-					f.ConstructConstVal(tgRelID, types.Oid), // TG_RELID
-					f.ConstructConstVal(tgTableName, types.String),   // TG_RELNAME
-					f.ConstructConstVal(tgTableName, types.String),   // TG_TABLE_NAME
-					f.ConstructConstVal(tgTableSchema, types.String), // TG_TABLE_SCHEMA
-					f.ConstructConstVal(tgNumArgs, types.Int),        // TG_NARGS
-					f.ConstructConstVal(tgArgV, types.StringArray),   // TG_ARGV
-				}
+	rowColID := tb.buildTriggers(inputScope, 0 /* oldColID */, newColID)
+	return tb.buildOutput(inputScope, rowColID, ins.Returning)
+}
 
-				// Resolve the trigger function and build the invocation.
-				triggerFn, def := b.buildTriggerFunction(trigger, tb.mutatedTable.ID(), tableTyp, args)
+// buildInsteadOfUpdate builds an UPDATE of a view, which is performed by the
+// view's INSTEAD OF UPDATE triggers. Each row of the view that satisfies the
+// WHERE clause is passed to the triggers as the OLD row, along with the NEW
+// row computed from the SET expressions.
+func (b *Builder) buildInsteadOfUpdate(
+	upd *tree.Update, inScope *scope, view cat.View, depName opt.MDDepName, alias tree.TableName,
+) (outScope *scope) {
+	if len(upd.From) > 0 {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"UPDATE ... FROM is not supported on view \"%s\"", view.Name()))
+	}
+	if upd.Limit != nil {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"UPDATE ... LIMIT is not supported on view \"%s\"", view.Name()))
+	}
 
-				// If there is a WHEN condition, wrap the trigger function invocation in a
-				// CASE WHEN statement that checks the WHEN condition.
-				if trigger.WhenExpr() != "" {
-					triggerFn = b.buildTriggerWhen(
-						trigger, triggerScope, oldColID, newColID, triggerFn, f.ConstructNull(tableTyp),
-					)
-				}
+	// Check Select permission as well, since the rows of the view must be read.
+	b.checkPrivilege(depName, view, privilege.SELECT)
 
-				// For UPSERT and INSERT ON CONFLICT, UPDATE triggers should only fire for
-				// the conflicting rows, which are identified by the canary column. INSERT
-				// triggers should only fire for non-conflicting rows. A trigger that
-				// matches both operations can fire unconditionally.
-				if outCanaryCol != 0 {
-					var hasInsert, hasUpdate bool
-					for j := 0; j < trigger.EventCount(); j++ {
-						if trigger.Event(j).EventType == tree.TriggerEventInsert {
-							hasInsert = true
-						} else if trigger.Event(j).EventType == tree.TriggerEventUpdate {
-							hasUpdate = true
-						}
-					}
-					if hasInsert && !hasUpdate {
-						triggerFn = f.ConstructCase(
-							memo.TrueSingleton,
-							memo.ScalarListExpr{f.ConstructWhen(canaryCheck, triggerFn)},
-							f.ConstructNull(tableTyp),
-						)
-					} else if hasUpdate && !hasInsert {
-						triggerFn = f.ConstructCase(
-							memo.TrueSingleton,
-							memo.ScalarListExpr{f.ConstructWhen(canaryCheck, f.ConstructNull(tableTyp))},
-							triggerFn,
-						)
-					}
-				}
+	tb := newInsteadOfTriggerBuilder(b, view, alias, tree.TriggerEventUpdate)
+	colTypes := tb.viewTyp.TupleContents()
+
+	scanScope := tb.buildScan(inScope)
+	b.buildWhere(upd.Where, scanScope, nil /* colRefs */)
+	oldElems := tb.makeRowElems(scanScope)
+
+	// SET expressions should reject aggregates, generators, etc.
+	scalarProps := &b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	b.semaCtx.Properties.Require("UPDATE SET", tree.RejectSpecial)
 
-				// Finally, project a column that invokes the trigger function.
-				b.projectColWithMetadataName(triggerScope, def.Name, tableTyp, triggerFn)
+	// Columns that are not targeted by the SET expressions keep their old
+	// values in the NEW row.
+	newElems := make(memo.ScalarListExpr, len(oldElems))
+	copy(newElems, oldElems)
+	addCol := func(ord int, expr tree.Expr) {
+		if _, ok := expr.(tree.DefaultVal); ok {
+			expr = tree.DNull
+		}
+		texpr := scanScope.resolveType(expr, colTypes[ord])
+		scalar := b.buildScalar(texpr, scanScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		newElems[ord] = tb.assignmentCast(scalar, texpr.ResolvedType(), ord)
+	}
+	var seen intsets.Fast
+	for _, set := range upd.Exprs {
+		ords := tb.targetOrdinals(set.Names, &seen)
+		if !set.Tuple {
+			addCol(ords[0], set.Expr)
+			continue
+		}
+		t, ok := set.Expr.(*tree.Tuple)
+		if !ok {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"source for a multiple-column UPDATE item on a view must be a ROW() expression"))
+		}
+		if len(t.Exprs) != len(ords) {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"number of columns (%d) does not match number of values (%d)",
+				len(ords), len(t.Exprs)))
+		}
+		for i, ord := range ords {
+			addCol(ord, t.Exprs[i])
+		}
+	}
+	oldColID := tb.projectRow(scanScope, triggerColOld, oldElems)
+	newColID := tb.projectRow(scanScope, triggerColNew, newElems)
+
+	rowColID := tb.buildTriggers(scanScope, oldColID, newColID)
+	return tb.buildOutput(scanScope, rowColID, upd.Returning)
+}
+
+// buildInsteadOfDelete builds a DELETE from a view, which is performed by the
+// view's INSTEAD OF DELETE triggers. Each row of the view that satisfies the
+// WHERE clause is passed to the triggers as the OLD row.
+func (b *Builder) buildInsteadOfDelete(
+	del *tree.Delete, inScope *scope, view cat.View, depName opt.MDDepName, alias tree.TableName,
+) (outScope *scope) {
+	if len(del.Using) > 0 {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"DELETE ... USING is not supported on view \"%s\"", view.Name()))
+	}
+	if del.Limit != nil {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"DELETE ... LIMIT is not supported on view \"%s\"", view.Name()))
+	}
+
+	// Check Select permission as well, since the rows of the view must be read.
+	b.checkPrivilege(depName, view, privilege.SELECT)
+
+	tb := newInsteadOfTriggerBuilder(b, view, alias, tree.TriggerEventDelete)
+	scanScope := tb.buildScan(inScope)
+	b.buildWhere(del.Where, scanScope, nil /* colRefs */)
+	oldColID := tb.projectRow(scanScope, triggerColOld, tb.makeRowElems(scanScope))
+
+	rowColID := tb.buildTriggers(scanScope, oldColID, 0 /* newColID */)
+	return tb.buildOutput(scanScope, rowColID, del.Returning)
+}
+
+// targetOrdinals returns the ordinals of the view columns with the given
+// names. seen tracks the columns that have already been targeted by the
+// statement, and an error is raised if a column is targeted more than once.
+func (tb *insteadOfTriggerBuilder) targetOrdinals(names tree.NameList, seen *intsets.Fast) []int {
+	ords := make([]int, len(names))
+	for i, name := range names {
+		ord := -1
+		for j, label := range tb.viewTyp.TupleLabels() {
+			if label == string(name) {
+				ord = j
+				break
 			}
-			// Always wrap the expression in a barrier, or else the projections will be
-			// pruned and the triggers will not be executed.
-			return f.ConstructBarrier(triggerScope.expr)
-		})
+		}
+		if ord == -1 {
+			panic(colinfo.NewUndefinedColumnError(string(name)))
+		}
+		if seen.Contains(ord) {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"multiple assignments to the same column %q", name))
+		}
+		seen.Add(ord)
+		ords[i] = ord
+	}
+	return ords
+}
+
+// assignmentCast returns the given expression with an assignment cast to the
+// type of the view column with the given ordinal, if necessary.
+func (tb *insteadOfTriggerBuilder) assignmentCast(
+	expr opt.ScalarExpr, srcType *types.T, ord int,
+) opt.ScalarExpr {
+	targetType := tb.viewTyp.TupleContents()[ord]
+	if srcType.Identical(targetType) {
+		return expr
+	}
+	if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+		panic(sqlerrors.NewInvalidAssignmentCastError(
+			srcType, targetType, tb.viewTyp.TupleLabels()[ord],
+		))
+	}
+	return tb.b.buildDomainCheck(tb.b.factory.ConstructAssignmentCast(expr, targetType), targetType)
+}
+
+// buildScan builds the rows of the view, which are the candidates for an
+// UPDATE or DELETE. The columns of the view are qualified by the alias of the
+// mutation target.
+func (tb *insteadOfTriggerBuilder) buildScan(inScope *scope) *scope {
+	viewName, err := tb.b.catalog.FullyQualifiedName(tb.b.ctx, tb.view)
+	if err != nil {
+		panic(err)
+	}
+	outScope := tb.b.buildView(tb.view, &viewName, noLocking, inScope)
+	if len(outScope.cols) != len(tb.viewTyp.TupleContents()) {
+		panic(errors.AssertionFailedf("unexpected number of columns for view %s", tb.view.Name()))
+	}
+	for i := range outScope.cols {
+		outScope.cols[i].table = tb.alias
+	}
+	return outScope
+}
+
+// makeRowElems returns the elements of a row of the view built by buildScan.
+func (tb *insteadOfTriggerBuilder) makeRowElems(scanScope *scope) memo.ScalarListExpr {
+	elems := make(memo.ScalarListExpr, len(scanScope.cols))
+	for i := range scanScope.cols {
+		elems[i] = tb.b.factory.ConstructVariable(scanScope.cols[i].id)
+	}
+	return elems
+}
+
+// projectRow projects a column with the given name which contains a tuple of
+// the view's record type with the given elements.
+func (tb *insteadOfTriggerBuilder) projectRow(
+	s *scope, name string, elems memo.ScalarListExpr,
+) opt.ColumnID {
+	tup := tb.b.factory.ConstructTuple(elems, tb.viewTyp)
+	return tb.b.projectColWithMetadataName(s, name, tb.viewTyp, tup)
+}
+
+// buildTriggers projects an invocation of each INSTEAD OF trigger for the rows
+// in the given scope, in order. Like Postgres, a row for which a trigger
+// returns NULL is skipped: it is not passed to the remaining triggers, and it
+// is not counted as modified. For INSERT and UPDATE, each trigger is passed the
+// row returned by the previous one as the NEW row.
+//
+// buildTriggers returns the column containing the row that is used by the
+// RETURNING clause: the row returned by the last trigger for INSERT and
+// UPDATE, and the OLD row for DELETE.
+func (tb *insteadOfTriggerBuilder) buildTriggers(
+	s *scope, oldColID, newColID opt.ColumnID,
+) opt.ColumnID {
+	b, f := tb.b, tb.b.factory
+	tgWhen := tree.NewDString("INSTEAD OF")
+	tgLevel := tree.NewDString("ROW")
+	tgOp := tree.NewDString(tb.eventType.String())
+	tgRelID := tree.NewDOid(oid.Oid(tb.view.ID()))
+	tgTableName := tree.NewDString(string(tb.view.Name()))
+	fqName, err := b.catalog.FullyQualifiedName(b.ctx, tb.view)
+	if err != nil {
+		panic(err)
+	}
+	tgTableSchema := tree.NewDString(fqName.Schema())
+
+	for _, trigger := range tb.triggers {
+		s.expr = f.ConstructBarrier(s.expr)
+
+		tgNew := opt.ScalarExpr(memo.NullSingleton)
+		if newColID != 0 {
+			tgNew = f.ConstructVariable(newColID)
+		}
+		tgOld := opt.ScalarExpr(memo.NullSingleton)
+		if oldColID != 0 {
+			tgOld = f.ConstructVariable(oldColID)
+		}
+		tgName := tree.NewDName(string(trigger.Name()))
+		tgNumArgs := tree.NewDInt(tree.DInt(len(trigger.FuncArgs())))
+		tgArgV := tree.NewDArray(types.String)
+		for _, arg := range trigger.FuncArgs() {
+			if err = tgArgV.Append(arg); err != nil {
+				panic(err)
+			}
+		}
+		args := memo.ScalarListExpr{
+			tgNew,                                   // NEW
+			tgOld,                                   // OLD
+			f.ConstructConstVal(tgName, types.Name), // TG_NAME
+			f.ConstructConstVal(tgWhen, types.String),        // TG_WHEN
+			f.ConstructConstVal(tgLevel, types.String),       // TG_LEVEL
+			f.ConstructConstVal(tgOp, types.String),          // TG_OP
+			f.ConstructConstVal(tgRelID, types.Oid),          // TG_RELID
+			f.ConstructConstVal(tgTableName, types.String),   // TG_RELNAME
+			f.ConstructConstVal(tgTableName, types.String),   // TG_TABLE_NAME
+			f.ConstructConstVal(tgTableSchema, types.String), // TG_TABLE_SCHEMA
+			f.ConstructConstVal(tgNumArgs, types.Int),        // TG_NARGS
+			f.ConstructConstVal(tgArgV, types.StringArray),   // TG_ARGV
+		}
+
+		// Resolve the trigger function and build the invocation. INSTEAD OF
+		// triggers cannot have a WHEN condition.
+		triggerFn, def := b.buildTriggerFunction(
+			trigger, tb.view.ID(), tb.viewTyp, args, nil, /* ctes */
+		)
+		triggerFnColID := b.projectColWithMetadataName(s, def.Name, tb.viewTyp, triggerFn)
+
+		// Skip the row if the trigger returned NULL.
+		filter := f.ConstructIsNot(f.ConstructVariable(triggerFnColID), memo.NullSingleton)
+		s.expr = f.ConstructSelect(s.expr, memo.FiltersExpr{f.ConstructFiltersItem(filter)})
+
+		if tb.eventType != tree.TriggerEventDelete {
+			newColID = triggerFnColID
+		}
+	}
+	// Always wrap the expression in a barrier, or else the projections could be
+	// pruned and the triggers would not be executed.
+	s.expr = f.ConstructBarrier(s.expr)
+
+	if tb.eventType == tree.TriggerEventDelete {
+		return oldColID
+	}
+	return newColID
+}
+
+// buildOutput builds the result of the mutation from the rows in the given
+// scope, which were handled by the triggers. If there is a RETURNING clause,
+// its expressions can reference the fields of the given row column as columns
+// of the view. Otherwise, the result is the number of rows handled by the
+// triggers, which is reported as the number of rows affected.
+func (tb *insteadOfTriggerBuilder) buildOutput(
+	s *scope, rowColID opt.ColumnID, returning tree.ReturningClause,
+) (outScope *scope) {
+	b, f := tb.b, tb.b.factory
+	if !resultsNeeded(returning) {
+		outScope = s.replace()
+		countCol := b.synthesizeColumn(
+			outScope, scopeColName("count"), types.Int, nil /* expr */, nil, /* scalar */
+		)
+		aggs := memo.AggregationsExpr{f.ConstructAggregationsItem(f.ConstructCountRows(), countCol.id)}
+		outScope.expr = f.ConstructScalarGroupBy(s.expr, aggs, &memo.GroupingPrivate{})
+		return outScope
+	}
+
+	colTypes, labels := tb.viewTyp.TupleContents(), tb.viewTyp.TupleLabels()
+	returningScope := s.replace()
+	projections := make(memo.ProjectionsExpr, len(colTypes))
+	for i, typ := range colTypes {
+		elem := f.ConstructColumnAccess(f.ConstructVariable(rowColID), memo.TupleOrdinal(i))
+		col := b.synthesizeColumn(
+			returningScope, scopeColName(tree.Name(labels[i])), typ, nil /* expr */, elem,
+		)
+		col.table = tb.alias
+		projections[i] = f.ConstructProjectionsItem(elem, col.id)
+	}
+	returningScope.expr = f.ConstructProject(s.expr, projections, opt.ColSet{})
+
+	outScope = returningScope.replace()
+	b.analyzeReturningList(
+		returning.(*tree.ReturningExprs), nil /* desiredTypes */, returningScope, outScope,
+	)
+	b.buildProjectionList(returningScope, outScope, nil /* colRefs */)
+	b.constructProjectForScope(returningScope, outScope)
+	return outScope
+}
+
+// replaceDefaultValsWithNull replaces DEFAULT expressions in a VALUES input
+// with NULL, which is the default value of every column of a view.
+func replaceDefaultValsWithNull(rows *tree.Select) *tree.Select {
+	values := extractValuesInput(rows)
+	if values == nil {
+		return rows
+	}
+	newRows := make([]tree.Exprs, len(values.Rows))
+	for i, tuple := range values.Rows {
+		newRows[i] = make(tree.Exprs, len(tuple))
+		for j, val := range tuple {
+			if _, ok := val.(tree.DefaultVal); ok {
+				val = tree.DNull
+			}
+			newRows[i][j] = val
+		}
+	}
+	return &tree.Select{Select: &tree.ValuesClause{Rows: newRows}}
 }
 
 // ============================================================================
@@ -773,8 +1599,16 @@ type cachedTriggerFunc struct {
 
 // buildTriggerFunction resolves and builds a trigger function invocation for
 // the given trigger, using the given arguments.
+//
+// ctes contains the transition relations that the trigger function can
+// reference, keyed by their aliases. It is nil if the trigger does not
+// reference any transition relations.
 func (b *Builder) buildTriggerFunction(
-	trigger cat.Trigger, tableID cat.StableID, tableTyp *types.T, args memo.ScalarListExpr,
+	trigger cat.Trigger,
+	tableID cat.StableID,
+	tableTyp *types.T,
+	args memo.ScalarListExpr,
+	ctes map[string]*cteSource,
 ) (opt.ScalarExpr, *tree.ResolvedFunctionDefinition) {
 	cached := b.builtTriggerFuncs[tableID]
 	for _, cachedFunc := range cached {
@@ -786,6 +1620,7 @@ func (b *Builder) buildTriggerFunction(
 
 	f := b.factory
 	triggerFuncScope := b.allocScope()
+	triggerFuncScope.ctes = ctes
 	funcRef := &tree.FunctionOID{OID: catid.FuncIDToOID(catid.DescID(trigger.FuncID()))}
 	funcExpr := tree.FuncExpr{Func: tree.ResolvableFunctionReference{FunctionReference: funcRef}}
 	triggerFuncScope.resolveType(&funcExpr, types.AnyElement)
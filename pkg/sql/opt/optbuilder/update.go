@@ -71,7 +71,20 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	}
 
 	// Find which table we're working on, check the permissions.
-	tab, depName, alias, refColumns := b.resolveTableForMutation(upd.Table, privilege.UPDATE)
+	tab, view, depName, alias, refColumns := b.resolveTableForMutation(
+		upd.Table, privilege.UPDATE, tree.TriggerEventUpdate,
+	)
+
+	// A view with INSTEAD OF triggers is mutated by invoking the triggers.
+	if view != nil {
+		return b.buildInsteadOfUpdate(upd, inScope, view, depName, alias)
+	}
+
+	if tab.IsForeignTable() {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot update foreign table \"%s\"", tab.Name(),
+		))
+	}
 
 	if tab.IsVirtualTable() {
 		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
@@ -402,9 +415,11 @@ func (mb *mutationBuilder) buildUpdate(
 
 	mb.buildUniqueChecksForUpdate()
 
+	mb.buildExclusionChecks(true /* onlyIfUpdated */)
+
 	mb.buildFKChecksForUpdate()
 
-	mb.buildRowLevelAfterTriggers(opt.UpdateOp)
+	mb.buildAfterTriggers(opt.UpdateOp)
 
 	private := mb.makeMutationPrivate(returning != nil, false /* vectorInsert */)
 	for _, col := range mb.extraAccessibleCols {
@@ -415,5 +430,7 @@ func (mb *mutationBuilder) buildUpdate(
 	mb.outScope.expr = mb.b.factory.ConstructUpdate(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
 	)
+
+	mb.buildStatementLevelBeforeTriggers(opt.UpdateOp)
 	mb.buildReturning(returning, returningInScope, returningOutScope)
 }
@@ -259,6 +259,11 @@ func (b *Builder) shouldCreateDefaultColumn(texpr tree.TypedExpr) bool {
 			// Special case for functions that have a dynamic type which will be
 			// resolved later.
 			return false
+		case "crdb_internal.plpgsql_execute_dynamic":
+			// Special case for the builtin used to implement PLpgSQL EXECUTE, which
+			// produces one column for each element of its result type, which may
+			// be empty.
+			return false
 		}
 	}
 
@@ -570,12 +575,21 @@ func (b *Builder) checkMultipleMutationsCascade(
 // table's MDDepName and alias, and the IDs of any columns explicitly specified
 // by the TableExpr (see tree.TableRef).
 //
-// If the name does not resolve to a table, then resolveTableForMutation raises
-// an error. Privileges are checked when resolving the table, and an error is
-// raised if the current user does not have the given privilege.
+// If the name resolves to a view with INSTEAD OF triggers for the given event,
+// the view is returned instead of a table, and the mutation must be performed
+// by the triggers. Otherwise, if the name does not resolve to a table, then
+// resolveTableForMutation raises an error. Privileges are checked when
+// resolving the table, and an error is raised if the current user does not
+// have the given privilege.
 func (b *Builder) resolveTableForMutation(
-	n tree.TableExpr, priv privilege.Kind,
-) (tab cat.Table, depName opt.MDDepName, alias tree.TableName, columns []tree.ColumnID) {
+	n tree.TableExpr, priv privilege.Kind, eventType tree.TriggerEventType,
+) (
+	tab cat.Table,
+	view cat.View,
+	depName opt.MDDepName,
+	alias tree.TableName,
+	columns []tree.ColumnID,
+) {
 	// Strip off an outer AliasedTableExpr if there is one.
 	var outerAlias *tree.TableName
 	if ate, ok := n.(*tree.AliasedTableExpr); ok {
@@ -590,8 +604,19 @@ func (b *Builder) resolveTableForMutation(
 
 	switch t := n.(type) {
 	case *tree.TableName:
-		tab, alias = b.resolveTable(t, priv)
-		depName = opt.DepByName(t)
+		var ds cat.DataSource
+		ds, depName, alias = b.resolveDataSource(t, priv)
+		switch ds := ds.(type) {
+		case cat.Table:
+			tab = ds
+		case cat.View:
+			if len(cat.GetInsteadOfTriggers(ds, eventType)) == 0 {
+				panic(sqlerrors.NewWrongObjectTypeError(t, "table"))
+			}
+			view = ds
+		default:
+			panic(sqlerrors.NewWrongObjectTypeError(t, "table"))
+		}
 
 	case *tree.TableRef:
 		tab = b.resolveTableRef(t, priv)
@@ -617,11 +642,11 @@ func (b *Builder) resolveTableForMutation(
 	}
 
 	// We can't mutate materialized views.
-	if tab.IsMaterializedView() {
+	if tab != nil && tab.IsMaterializedView() {
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
-	return tab, depName, alias, columns
+	return tab, view, depName, alias, columns
 }
 
 // resolveTable returns the table in the catalog with the given name. If the
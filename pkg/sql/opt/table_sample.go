@@ -0,0 +1,43 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opt
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// TableSample represents the TABLESAMPLE clause of a scan (see
+// tree.TableSample). The zero value indicates that the scan is not sampled.
+type TableSample struct {
+	// Method is the sampling method.
+	Method tree.TableSampleMethod
+
+	// Probability is the probability with which each row (BERNOULLI) or each
+	// range (SYSTEM) of the table is returned.
+	Probability float64
+
+	// Repeatable is true if a seed was specified with REPEATABLE, in which case
+	// the same sample is returned every time the scan is executed, as long as
+	// the table does not change. Otherwise, a random seed is chosen each time
+	// the scan is executed.
+	Repeatable bool
+	Seed       float64
+
+	// Set is true if the scan is sampled.
+	Set bool
+}
+
+func (ts TableSample) String() string {
+	if !ts.Set {
+		return ""
+	}
+	if ts.Repeatable {
+		return fmt.Sprintf("%s (%g) REPEATABLE (%g)", ts.Method, ts.Probability*100, ts.Seed)
+	}
+	return fmt.Sprintf("%s (%g)", ts.Method, ts.Probability*100)
+}
@@ -356,11 +356,16 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(
+					def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrability,
+				)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
@@ -375,6 +380,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						def.Unique.Deferrability,
 					)
 				} else {
 					tab.addIndex(
@@ -683,6 +689,7 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrability:            d.Deferrability,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
@@ -718,7 +725,11 @@ func (tt *Table) addCheckConstraint(check *tree.CheckConstraintTableDef) {
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrability tree.ConstraintDeferrability,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -739,6 +750,7 @@ func (tt *Table) addUniqueConstraint(
 		columnOrdinals: cols,
 		withoutIndex:   withoutIndex,
 		validated:      true,
+		deferrability:  deferrability,
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -757,6 +769,31 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+func (tt *Table) addExclusionConstraint(def *tree.ExclusionConstraintTableDef) {
+	cols := make([]int, len(def.Elems))
+	operators := make([]treecmp.ComparisonOperator, len(def.Elems))
+	for i, elem := range def.Elems {
+		cols[i] = tt.FindOrdinal(string(elem.Column))
+		operators[i] = elem.Operator
+	}
+	name := string(def.Name)
+	if name == "" {
+		name = fmt.Sprintf("%s_excl_%d", tt.TabName.Table(), len(tt.exclusionConstraints)+1)
+	}
+	e := ExclusionConstraint{
+		name:           name,
+		tabID:          tt.TabID,
+		columnOrdinals: cols,
+		operators:      operators,
+		validated:      true,
+		deferrability:  def.Deferrability,
+	}
+	if def.Predicate != nil {
+		e.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.exclusionConstraints = append(tt.exclusionConstraints, e)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
@@ -882,7 +919,9 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.ConstraintNotDeferrable,
+		)
 	}
 
 	// The test catalog does not support the hash-sharded index syntactic sugar.
@@ -96,6 +96,7 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 	var outParamTypes []*types.T
 	var outParamNames []string
 	var defaultExprs []tree.Expr
+	var variadic bool
 	for i := range c.Params {
 		param := &c.Params[i]
 		typ, err := tree.ResolveType(context.Background(), param.Type, tc)
@@ -123,6 +124,9 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 		if param.DefaultVal != nil {
 			defaultExprs = append(defaultExprs, param.DefaultVal)
 		}
+		if param.Class == tree.RoutineParamVariadic {
+			variadic = true
+		}
 	}
 
 	// Determine OUT parameter based return type.
@@ -188,6 +192,7 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 		OutParamOrdinals:  outParamOrdinals,
 		OutParamTypes:     outParams,
 		DefaultExprs:      defaultExprs,
+		Variadic:          variadic,
 	}
 	overload.ReturnsRecordType = !c.IsProcedure && retType.Identical(types.AnyTuple)
 	if c.ReturnType != nil && c.ReturnType.SetOf {
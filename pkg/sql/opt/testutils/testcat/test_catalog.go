@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
@@ -394,6 +395,13 @@ func (tc *Catalog) GetRoutineOwner(
 	return tc.GetCurrentUser(), nil
 }
 
+// PushRoutineConfig is part of the cat.Catalog interface.
+func (tc *Catalog) PushRoutineConfig(
+	ctx context.Context, config tree.RoutineConfig,
+) (pop func() error, err error) {
+	return func() error { return nil }, nil
+}
+
 // IsOwner is part of the cat.Catalog interface.
 func (tc *Catalog) IsOwner(
 	ctx context.Context, o cat.Object, user username.SQLUsername,
@@ -891,6 +899,8 @@ type Table struct {
 
 	uniqueConstraints []UniqueConstraint
 
+	exclusionConstraints []ExclusionConstraint
+
 	// partitionBy is the partitioning clause that corresponds to the primary
 	// index. Used to initialize the partitioning for the primary index.
 	partitionBy *tree.PartitionBy
@@ -971,6 +981,11 @@ func (tt *Table) IsMaterializedView() bool {
 	return false
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (tt *Table) IsForeignTable() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (tt *Table) ColumnCount() int {
 	return len(tt.Columns)
@@ -1061,6 +1076,16 @@ func (tt *Table) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	return &tt.uniqueConstraints[i]
 }
 
+// ExclusionCount is part of the cat.Table interface.
+func (tt *Table) ExclusionCount() int {
+	return len(tt.exclusionConstraints)
+}
+
+// Exclusion is part of the cat.Table interface.
+func (tt *Table) Exclusion(i cat.ExclusionOrdinal) cat.ExclusionConstraint {
+	return &tt.exclusionConstraints[i]
+}
+
 // Zone is part of the cat.Table interface.
 func (tt *Table) Zone() cat.Zone {
 	zone := zonepb.DefaultZoneConfig()
@@ -1201,6 +1226,16 @@ func (tt *Table) Trigger(i int) cat.Trigger {
 	return &tt.Triggers[i]
 }
 
+// InheritedByCount is a part of the cat.Table interface.
+func (tt *Table) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is a part of the cat.Table interface.
+func (tt *Table) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 // IsRowLevelSecurityEnabled is part of the cat.Table interface.
 func (tt *Table) IsRowLevelSecurityEnabled() bool { return tt.rlsEnabled }
 
@@ -1702,10 +1737,11 @@ type ForeignKeyConstraint struct {
 	originColumnOrdinals     []int
 	referencedColumnOrdinals []int
 
-	validated    bool
-	matchMethod  tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validated     bool
+	matchMethod   tree.CompositeKeyMatchMethod
+	deleteAction  tree.ReferenceAction
+	updateAction  tree.ReferenceAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1773,6 +1809,11 @@ func (fk *ForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction {
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // UniqueConstraint implements cat.UniqueConstraint. See that interface
 // for more information on the fields.
 type UniqueConstraint struct {
@@ -1784,6 +1825,7 @@ type UniqueConstraint struct {
 	canUseTombstones      bool
 	tombstoneIndexOrdinal cat.IndexOrdinal
 	validated             bool
+	deferrability         tree.ConstraintDeferrability
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1846,6 +1888,71 @@ func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
+// ExclusionConstraint implements cat.ExclusionConstraint. See that interface
+// for more information on the fields.
+type ExclusionConstraint struct {
+	name           string
+	tabID          cat.StableID
+	columnOrdinals []int
+	operators      []treecmp.ComparisonOperator
+	predicate      string
+	validated      bool
+	deferrability  tree.ConstraintDeferrability
+}
+
+var _ cat.ExclusionConstraint = &ExclusionConstraint{}
+
+// Name is part of the cat.ExclusionConstraint interface.
+func (e *ExclusionConstraint) Name() string {
+	return e.name
+}
+
+// TableID is part of the cat.ExclusionConstraint interface.
+func (e *ExclusionConstraint) TableID() cat.StableID {
+	return e.tabID
+}
+
+// ColumnCount is part of the cat.ExclusionConstraint interface.
+func (e *ExclusionConstraint) ColumnCount() int {
+	return len(e.columnOrdinals)
+}
+
+// ColumnOrdinal is part of the cat.ExclusionConstraint interface.
+func (e *ExclusionConstraint) ColumnOrdinal(tab cat.Table, i int) int {
+	if tab.ID() != e.tabID {
+		panic(errors.AssertionFailedf(
+			"invalid table %d passed to ColumnOrdinal (expected %d)",
+			tab.ID(), e.tabID,
+		))
+	}
+	return e.columnOrdinals[i]
+}
+
+// Operator is part of the cat.ExclusionConstraint interface.
+func (e *ExclusionConstraint) Operator(i int) treecmp.ComparisonOperator {
+	return e.operators[i]
+}
+
+// Predicate is part of the cat.ExclusionConstraint interface.
+func (e *ExclusionConstraint) Predicate() (string, bool) {
+	return e.predicate, e.predicate != ""
+}
+
+// Validated is part of the cat.ExclusionConstraint interface.
+func (e *ExclusionConstraint) Validated() bool {
+	return e.validated
+}
+
+// Deferrability is part of the cat.ExclusionConstraint interface.
+func (e *ExclusionConstraint) Deferrability() tree.ConstraintDeferrability {
+	return e.deferrability
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
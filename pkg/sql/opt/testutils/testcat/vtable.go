@@ -135,6 +135,8 @@ var systemTables = []string{
 	systemschema.StatementExecutionStatsTableSchema,
 	systemschema.TableMetadataTableSchema,
 	systemschema.PreparedTransactionsTableSchema,
+	systemschema.NotificationsTableSchema,
+	systemschema.ReplicationSlotsTableSchema,
 }
 
 func init() {
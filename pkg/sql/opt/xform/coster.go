@@ -969,6 +969,14 @@ func (c *coster) computeProjectCost(prj *memo.ProjectExpr) memo.Cost {
 	synthesizedColCount := len(prj.Projections)
 	cost := memo.Cost{C: rowCount * float64(synthesizedColCount) * cpuCostFactor}
 
+	// Add the execution cost specified with the COST option of any
+	// user-defined functions that are evaluated on each row.
+	for i := range prj.Projections {
+		if udf, ok := prj.Projections[i].Element.(*memo.UDFCallExpr); ok && udf.Def.Cost > 0 {
+			cost.C += rowCount * udf.Def.Cost * cpuCostFactor
+		}
+	}
+
 	// Add the CPU cost of emitting the rows.
 	cost.C += rowCount * cpuCostFactor
 	return cost
@@ -629,6 +629,13 @@ func (oc *optCatalog) GetRoutineOwner(
 	return fnDesc.FuncDesc().Privileges.Owner(), nil
 }
 
+// PushRoutineConfig is part of the cat.Catalog interface.
+func (oc *optCatalog) PushRoutineConfig(
+	ctx context.Context, config tree.RoutineConfig,
+) (pop func() error, err error) {
+	return oc.planner.pushRoutineConfig(ctx, config)
+}
+
 // dataSourceForDesc returns a data source wrapper for the given descriptor.
 // The wrapper might come from the cache, or it may be created now.
 func (oc *optCatalog) dataSourceForDesc(
@@ -666,10 +673,11 @@ func (oc *optCatalog) dataSourceForDesc(
 func (oc *optCatalog) dataSourceForTable(
 	ctx context.Context, flags cat.Flags, desc catalog.TableDescriptor, name *cat.DataSourceName,
 ) (cat.DataSource, error) {
-	if desc.IsVirtualTable() {
+	if desc.IsVirtualTable() || desc.IsForeignTable() {
 		// Virtual tables can have multiple effective instances that utilize the
 		// same descriptor, so we can't cache them (see the comment for
-		// optVirtualTable.id for more information).
+		// optVirtualTable.id for more information). Foreign tables store no data
+		// and are scanned like virtual tables.
 		return newOptVirtualTable(ctx, oc, desc, name)
 	}
 
@@ -914,6 +922,8 @@ type optTable struct {
 
 	uniqueConstraints []optUniqueConstraint
 
+	exclusionConstraints []optExclusionConstraint
+
 	outboundFKs []optForeignKeyConstraint
 	inboundFKs  []optForeignKeyConstraint
 
@@ -1058,18 +1068,43 @@ func newOptTable(
 		ot.colMap.Set(descpb.ColumnID(ot.columns[i].ColID()), i)
 	}
 
-	// Add unique without index constraints. Constraints for implicitly
-	// partitioned unique indexes will be added below.
-	ot.uniqueConstraints = make([]optUniqueConstraint, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
-	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
-		ot.uniqueConstraints[i] = optUniqueConstraint{
+	// Add unique without index constraints and exclusion constraints, which
+	// are stored as unique without index constraints. Constraints for
+	// implicitly partitioned unique indexes will be added below.
+	ot.uniqueConstraints = make([]optUniqueConstraint, 0, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
+	for _, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
+		if uc := u.UniqueWithoutIndexDesc(); uc.IsExclusion() {
+			operators := make([]treecmp.ComparisonOperator, len(uc.ExclusionOperators))
+			for i, op := range uc.ExclusionOperators {
+				var err error
+				if operators[i], err = tree.ExclusionOperator(op); err != nil {
+					return nil, err
+				}
+			}
+			ot.exclusionConstraints = append(ot.exclusionConstraints, optExclusionConstraint{
+				name:      u.GetName(),
+				table:     ot.ID(),
+				columns:   uc.ColumnIDs,
+				operators: operators,
+				predicate: u.GetPredicate(),
+				validity:  u.GetConstraintValidity(),
+				deferrability: tree.MakeConstraintDeferrability(
+					uc.Deferrable, uc.InitiallyDeferred,
+				),
+			})
+			continue
+		}
+		ot.uniqueConstraints = append(ot.uniqueConstraints, optUniqueConstraint{
 			name:         u.GetName(),
 			table:        ot.ID(),
 			columns:      u.CollectKeyColumnIDs().Ordered(),
 			predicate:    u.GetPredicate(),
 			withoutIndex: true,
 			validity:     u.GetConstraintValidity(),
-		}
+			deferrability: tree.MakeConstraintDeferrability(
+				u.UniqueWithoutIndexDesc().Deferrable, u.UniqueWithoutIndexDesc().InitiallyDeferred,
+			),
+		})
 	}
 
 	// Build the indexes.
@@ -1182,6 +1217,9 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability: tree.MakeConstraintDeferrability(
+				fk.ForeignKeyDesc().Deferrable, fk.ForeignKeyDesc().InitiallyDeferred,
+			),
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1195,6 +1233,9 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability: tree.MakeConstraintDeferrability(
+				fk.ForeignKeyDesc().Deferrable, fk.ForeignKeyDesc().InitiallyDeferred,
+			),
 		})
 	}
 
@@ -1390,6 +1431,11 @@ func (ot *optTable) IsMaterializedView() bool {
 	return ot.desc.MaterializedView()
 }
 
+// IsForeignTable implements the cat.Table interface.
+func (ot *optTable) IsForeignTable() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optTable) ColumnCount() int {
 	return len(ot.columns)
@@ -1494,6 +1540,16 @@ func (ot *optTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	return &ot.uniqueConstraints[i]
 }
 
+// ExclusionCount is part of the cat.Table interface.
+func (ot *optTable) ExclusionCount() int {
+	return len(ot.exclusionConstraints)
+}
+
+// Exclusion is part of the cat.Table interface.
+func (ot *optTable) Exclusion(i cat.ExclusionOrdinal) cat.ExclusionConstraint {
+	return &ot.exclusionConstraints[i]
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optTable) Zone() cat.Zone {
 	return ot.zone
@@ -1594,6 +1650,16 @@ func (ot *optTable) Trigger(i int) cat.Trigger {
 	return &ot.triggers[i]
 }
 
+// InheritedByCount is part of the cat.Table interface.
+func (ot *optTable) InheritedByCount() int {
+	return len(ot.desc.GetInheritedBy())
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (ot *optTable) InheritedBy(i int) cat.StableID {
+	return cat.StableID(ot.desc.GetInheritedBy()[i])
+}
+
 // IsRowLevelSecurityEnabled is part of the cat.Table interface.
 func (ot *optTable) IsRowLevelSecurityEnabled() bool { return ot.rlsEnabled }
 
@@ -2197,6 +2263,7 @@ type optUniqueConstraint struct {
 	canUseTombstones      bool
 	tombstoneIndexOrdinal cat.IndexOrdinal
 	validity              descpb.ConstraintValidity
+	deferrability         tree.ConstraintDeferrability
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -2256,6 +2323,11 @@ func (u *optUniqueConstraint) Validated() bool {
 	return u.validity == descpb.ConstraintValidity_Validated
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
 // interface. It is a hack to make unique hash sharded index work before issue
 // #75070 is resolved. Be sure to remove `ignoreUniquenessCheck` field from
@@ -2264,6 +2336,70 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// optExclusionConstraint implements cat.ExclusionConstraint and represents an
+// exclusion constraint on a table.
+type optExclusionConstraint struct {
+	name string
+
+	table     cat.StableID
+	columns   []descpb.ColumnID
+	operators []treecmp.ComparisonOperator
+	predicate string
+
+	validity      descpb.ConstraintValidity
+	deferrability tree.ConstraintDeferrability
+}
+
+var _ cat.ExclusionConstraint = &optExclusionConstraint{}
+
+// Name is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) Name() string {
+	return e.name
+}
+
+// TableID is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) TableID() cat.StableID {
+	return e.table
+}
+
+// ColumnCount is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) ColumnCount() int {
+	return len(e.columns)
+}
+
+// ColumnOrdinal is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) ColumnOrdinal(tab cat.Table, i int) int {
+	if tab.ID() != e.table {
+		panic(errors.AssertionFailedf(
+			"invalid table %d passed to ColumnOrdinal (expected %d)",
+			tab.ID(), e.table,
+		))
+	}
+	optTab := convertTableToOptTable(tab)
+	ord, _ := optTab.LookupColumnOrdinal(e.columns[i])
+	return ord
+}
+
+// Operator is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) Operator(i int) treecmp.ComparisonOperator {
+	return e.operators[i]
+}
+
+// Predicate is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) Predicate() (string, bool) {
+	return e.predicate, e.predicate != ""
+}
+
+// Validated is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) Validated() bool {
+	return e.validity == descpb.ConstraintValidity_Validated
+}
+
+// Deferrability is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) Deferrability() tree.ConstraintDeferrability {
+	return e.deferrability
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -2277,10 +2413,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validity      descpb.ConstraintValidity
+	match         tree.CompositeKeyMatchMethod
+	deleteAction  tree.ReferenceAction
+	updateAction  tree.ReferenceAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2352,6 +2489,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2426,7 +2568,7 @@ func newOptVirtualTable(
 		name: *name,
 	}
 
-	ot.columns = make([]cat.Column, len(desc.PublicColumns())+1)
+	ot.columns = make([]cat.Column, len(ot.publicColumns())+1)
 	// Init dummy PK column.
 	ot.columns[0].Init(
 		0,
@@ -2442,7 +2584,7 @@ func newOptVirtualTable(
 		cat.NotGeneratedAsIdentity,
 		nil, /* generatedAsIdentitySequenceOption */
 	)
-	for i, d := range desc.PublicColumns() {
+	for i, d := range ot.publicColumns() {
 		cd := d.ColumnDesc()
 		ot.columns[i+1].Init(
 			i+1,
@@ -2550,6 +2692,11 @@ func (ot *optVirtualTable) IsMaterializedView() bool {
 	return false
 }
 
+// IsForeignTable implements the cat.Table interface.
+func (ot *optVirtualTable) IsForeignTable() bool {
+	return ot.desc.IsForeignTable()
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optVirtualTable) ColumnCount() int {
 	return len(ot.columns)
@@ -2562,12 +2709,22 @@ func (ot *optVirtualTable) Column(i int) *cat.Column {
 
 // getCol is part of optCatalogTableInterface.
 func (ot *optVirtualTable) getCol(i int) catalog.Column {
-	if i > 0 && i <= len(ot.desc.PublicColumns()) {
-		return ot.desc.PublicColumns()[i-1]
+	if cols := ot.publicColumns(); i > 0 && i <= len(cols) {
+		return cols[i-1]
 	}
 	return nil
 }
 
+// publicColumns returns the columns of the table exposed to queries. Foreign
+// table descriptors also contain the hidden rowid column of their unused
+// primary index, which is omitted.
+func (ot *optVirtualTable) publicColumns() []catalog.Column {
+	if ot.desc.IsForeignTable() {
+		return ot.desc.VisibleColumns()
+	}
+	return ot.desc.PublicColumns()
+}
+
 // IndexCount is part of the cat.Table interface.
 func (ot *optVirtualTable) IndexCount() int {
 	// Primary index is always present, so count is always >= 1.
@@ -2659,6 +2816,16 @@ func (ot *optVirtualTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("no unique constraints"))
 }
 
+// ExclusionCount is part of the cat.Table interface.
+func (ot *optVirtualTable) ExclusionCount() int {
+	return 0
+}
+
+// Exclusion is part of the cat.Table interface.
+func (ot *optVirtualTable) Exclusion(i cat.ExclusionOrdinal) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("no exclusion constraints"))
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optVirtualTable) Zone() cat.Zone {
 	panic(errors.AssertionFailedf("no zone"))
@@ -2730,6 +2897,16 @@ func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
 	panic(errors.AssertionFailedf("no triggers"))
 }
 
+// InheritedByCount is part of the cat.Table interface.
+func (ot *optVirtualTable) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (ot *optVirtualTable) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("no inheriting tables"))
+}
+
 // IsRowLevelSecurityEnabled is part of the cat.Table interface.
 func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool { return false }
 
@@ -159,6 +159,7 @@ func (ef *execFactory) ConstructScan(
 	scan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
 	scan.lockingDurability = descpb.ToScanLockingDurability(params.Locking.Durability)
 	scan.localityOptimized = params.LocalityOptimized
+	scan.tableSample = params.TableSample
 	if !ef.isExplain && !ef.planner.SessionData().Internal {
 		idxUsageKey := roachpb.IndexUsageKey{
 			TableID: roachpb.TableID(tabDesc.GetID()),
@@ -213,6 +214,12 @@ func (ef *execFactory) ConstructFilter(
 	f.filter = filter
 	f.reqOrdering = ReqOrdering(reqOrdering)
 
+	// Let the foreign-data wrapper of a foreign table scan evaluate the filter
+	// when reading the rows. The filter is still applied by the filterNode.
+	if d, ok := p.(*delayedNode); ok && d.foreignScan != nil {
+		d.foreignScan.pushFilter(filter)
+	}
+
 	// If there's a spool, pull it up.
 	if spool, ok := f.input.(*spoolNode); ok {
 		f.input = spool.input
@@ -562,6 +569,10 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.DistsqlBlocklist,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		if agg.UserDefined != nil {
+			f.userDefined = agg.UserDefined
+			f.resultType = agg.ResultType
+		}
 
 		n.funcs = append(n.funcs, f)
 	}
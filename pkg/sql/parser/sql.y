@@ -331,6 +331,9 @@ func (u *sqlSymUnion) tableNames() tree.TableNames {
 func (u *sqlSymUnion) indexFlags() *tree.IndexFlags {
     return u.val.(*tree.IndexFlags)
 }
+func (u *sqlSymUnion) tableSample() *tree.TableSample {
+    return u.val.(*tree.TableSample)
+}
 func (u *sqlSymUnion) arraySubscript() *tree.ArraySubscript {
     return u.val.(*tree.ArraySubscript)
 }
@@ -640,12 +643,21 @@ func (u *sqlSymUnion) readWriteMode() tree.ReadWriteMode {
 func (u *sqlSymUnion) deferrableMode() tree.DeferrableMode {
     return u.val.(tree.DeferrableMode)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) idxElem() tree.IndexElem {
     return u.val.(tree.IndexElem)
 }
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) exclusionElem() tree.ExclusionElem {
+    return u.val.(tree.ExclusionElem)
+}
+func (u *sqlSymUnion) exclusionElems() tree.ExclusionElemList {
+    return u.val.(tree.ExclusionElemList)
+}
 func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
     return u.val.(tree.IndexInvisibility)
 }
@@ -812,6 +824,18 @@ func (u *sqlSymUnion) typeReference() tree.ResolvableTypeReference {
 func (u *sqlSymUnion) typeReferences() []tree.ResolvableTypeReference {
     return u.val.([]tree.ResolvableTypeReference)
 }
+func (u *sqlSymUnion) domainTypeDef() *tree.DomainTypeDef {
+    return u.val.(*tree.DomainTypeDef)
+}
+func (u *sqlSymUnion) domainCheckConstraint() tree.DomainCheckConstraint {
+    return u.val.(tree.DomainCheckConstraint)
+}
+func (u *sqlSymUnion) foreignOption() tree.ForeignOption {
+    return u.val.(tree.ForeignOption)
+}
+func (u *sqlSymUnion) foreignOptions() tree.ForeignOptions {
+    return u.val.(tree.ForeignOptions)
+}
 func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacement {
     return u.val.(*tree.AlterTypeAddValuePlacement)
 }
@@ -875,6 +899,12 @@ func (u *sqlSymUnion) routineParams() tree.RoutineParams {
 func (u *sqlSymUnion) routineParam() tree.RoutineParam {
     return u.val.(tree.RoutineParam)
 }
+func (u *sqlSymUnion) aggregateOptions() []tree.AggregateOption {
+    return u.val.([]tree.AggregateOption)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
 func (u *sqlSymUnion) routineParamClass() tree.RoutineParamClass {
     return u.val.(tree.RoutineParamClass)
 }
@@ -973,13 +1003,13 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
 %token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY AVOID_FULL_SCAN
 
-%token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIDIRECTIONAL BIGINT BIGSERIAL BINARY BIT
+%token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BERNOULLI BETWEEN BIDIRECTIONAL BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY BYPASSRLS
 
-%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHAIN CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
-%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
+%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMBINEFUNC COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
 %token <str> CONVERSION CONVERT COPY COS_DISTANCE COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
@@ -998,7 +1028,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> EXPIRATION EXPLAIN EXPORT EXTENSION EXTERNAL EXTRACT EXTRACT_DURATION EXTREMES
 
 %token <str> FAILURE FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
-%token <str> FILES FILTER
+%token <str> FILES FILTER FINALFUNC
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX FORCE_INVERTED_INDEX
 %token <str> FORCE_NOT_NULL FORCE_NULL FORCE_QUOTE FORCE_ZIGZAG
 %token <str> FOREIGN FORMAT FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
@@ -1013,7 +1043,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
-%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
+%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERIT INHERITS INITCOND INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
 %token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
@@ -1025,7 +1055,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGGED LOGICAL LOGICALLY LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGGED LOGICAL LOGICALLY LOGIN LOOKUP LOW LSHIFT
 
 %token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
@@ -1036,7 +1066,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NODE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
 %token <str> NOTHING NOTHING_AFTER_RETURNING
-%token <str> NOTNULL
+%token <str> NOTIFY NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY OPT OPTION OPTIONS OR
@@ -1057,14 +1087,14 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SELECT SEQUENCE SEQUENCES
-%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
+%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS SFUNC
 %token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
 %token <str> SNAPSHOT SOME SOURCE SPLIT SQL SQLLOGIN
-%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING STYPE SUBJECT SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
-%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
+%token <str> TABLE TABLES TABLESAMPLE TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
 %token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
 %token <str> TRANSACTION TRANSACTIONS TRANSFER TRANSFORM TREAT TRIGGER TRIGGERS TRIM TRUE
 %token <str> TRUNCATE TRUSTED TYPE TYPES
@@ -1077,7 +1107,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRAPPER WRITE
 
 %token <str> YEAR
 
@@ -1131,7 +1161,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
 %type <tree.Statement> alter_schema_stmt
-%type <tree.Statement> alter_unsupported_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_func_stmt
 %type <tree.Statement> alter_proc_stmt
 %type <tree.Statement> alter_policy_stmt
@@ -1228,6 +1258,9 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> alter_proc_set_schema_stmt
 %type <tree.Statement> alter_proc_owner_stmt
 
+// ALTER AGGREGATE
+%type <tree.Statement> alter_aggregate_stmt
+
 %type <tree.Statement> backup_stmt
 %type <tree.Statement> begin_stmt
 
@@ -1270,6 +1303,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
+%type <tree.Statement> create_aggregate_stmt
 %type <tree.Statement> create_proc_stmt
 %type <tree.Statement> create_trigger_stmt
 %type <tree.Statement> create_policy_stmt
@@ -1287,6 +1321,9 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <*tree.CheckExternalConnectionOptions> opt_with_check_external_connection_options_list check_external_connection_options_list check_external_connection_options
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
+%type <tree.Statement> create_server_stmt
+%type <tree.Statement> create_foreign_table_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -1299,9 +1336,13 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
+%type <tree.Statement> drop_server_stmt
+%type <tree.Statement> drop_foreign_table_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
+%type <tree.Statement> drop_aggregate_stmt
 %type <tree.Statement> drop_policy_stmt
 %type <tree.Statement> drop_proc_stmt
 %type <tree.Statement> drop_trigger_stmt
@@ -1347,6 +1388,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1415,6 +1457,8 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
 %type <tree.Statement> unlisten_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
 %type <tree.Statement> use_stmt
@@ -1493,6 +1537,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.UserPriority> transaction_user_priority
 %type <tree.ReadWriteMode> transaction_read_mode
 %type <tree.DeferrableMode> transaction_deferrable_mode
+%type <bool> opt_transaction_chain
 
 %type <str> name opt_name opt_name_parens
 %type <str> privilege savepoint_name
@@ -1532,7 +1577,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <*tree.PartitionByTable> opt_partition_by_table partition_by_table
 %type <*tree.PartitionByIndex> opt_partition_by_index partition_by_index
 %type <str> partition opt_partition
-%type <str> opt_create_table_inherits
+%type <tree.TableNames> opt_create_table_inherits
 %type <tree.ListPartition> list_partition
 %type <[]tree.ListPartition> list_partitions
 %type <tree.RangePartition> range_partition
@@ -1600,6 +1645,8 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <*tree.TenantSpec> virtual_cluster_spec virtual_cluster_spec_opt_all
 
 %type <bool> opt_unique opt_concurrently opt_cluster opt_without_index
+%type <bool> constraints_set_mode
+%type <tree.ConstraintDeferrability> opt_deferrable
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
 %type <tree.Expr> select_fetch_first_value
@@ -1623,6 +1670,8 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <*tree.ArraySubscript> array_subscript
 %type <tree.Expr> opt_slice_bound
 %type <*tree.IndexFlags> opt_index_flags
+%type <*tree.TableSample> opt_tablesample_clause
+%type <tree.Expr> opt_repeatable_clause
 %type <*tree.IndexFlags> index_flags_param
 %type <*tree.IndexFlags> index_flags_param_list
 %type <tree.Expr> a_expr b_expr c_expr d_expr typed_literal
@@ -1643,7 +1692,10 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby sortby_index
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
-%type <tree.TableExpr> table_ref numeric_table_ref func_table
+%type <tree.ExclusionElemList> exclusion_elem_list
+%type <tree.ExclusionElem> exclusion_elem
+%type <str> opt_exclusion_using
+%type <tree.TableExpr> table_ref numeric_table_ref func_table table_ref_relation_expr
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
 %type <tree.TableExpr> joined_table
@@ -1663,6 +1715,10 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.ResolvableTypeReference> typename simple_typename cast_target
 %type <*types.T> const_typename
 %type <*tree.AlterTypeAddValuePlacement> opt_add_val_placement
+%type <*tree.DomainTypeDef> opt_domain_elem_list
+%type <tree.DomainCheckConstraint> domain_check_constraint
+%type <tree.ForeignOption> foreign_option
+%type <tree.ForeignOptions> foreign_option_list opt_foreign_options
 %type <bool> opt_timezone
 %type <*types.T> numeric opt_numeric_modifiers
 %type <*types.T> opt_float
@@ -1782,7 +1838,9 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.RoutineParam> routine_param_with_default routine_param table_func_column
 %type <tree.ResolvableTypeReference> routine_return_type routine_param_type
 %type <tree.RoutineOptions> opt_create_routine_opt_list create_routine_opt_list alter_func_opt_list
-%type <tree.RoutineOption> create_routine_opt_item common_routine_opt_item
+%type <[]tree.AggregateOption> aggregate_opt_list
+%type <tree.AggregateOption> aggregate_opt
+%type <tree.RoutineOption> create_routine_opt_item common_routine_opt_item routine_set_rest
 %type <tree.RoutineParamClass> routine_param_class
 %type <*tree.UnresolvedObjectName> routine_create_name
 %type <tree.DoBlockOptions> do_stmt_opt_list
@@ -1920,6 +1978,8 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
 | unlisten_stmt
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
@@ -1930,7 +1990,6 @@ alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_virtual_cluster_stmt   /* SKIP DOC */
-| alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1943,11 +2002,13 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
 | alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 | alter_policy_stmt             // EXTEND WITH HELP: ALTER POLICY
 | alter_job_stmt                // EXTEND WITH HELP: ALTER JOB
@@ -2983,17 +3044,25 @@ alter_table_cmd:
   }
   // ALTER TABLE <name> ALTER CONSTRAINT ...
 | ALTER CONSTRAINT constraint_name error { return unimplementedWithIssueDetail(sqllex, 31632, "alter constraint") }
-  // ALTER TABLE <name> INHERITS ....
-| INHERITS error
+  // ALTER TABLE <name> INHERIT <parent>
+| INHERIT table_name
+  {
+    $$.val = &tree.AlterTableInherit{Parent: $2.unresolvedObjectName().ToTableName()}
+  }
+| INHERITS table_name
   {
     /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "alter table inherits")
+    $$.val = &tree.AlterTableInherit{Parent: $2.unresolvedObjectName().ToTableName()}
   }
-  // ALTER TABLE <name> NO INHERITS ....
-| NO INHERITS error
+  // ALTER TABLE <name> NO INHERIT <parent>
+| NO INHERIT table_name
+  {
+    $$.val = &tree.AlterTableInherit{Parent: $3.unresolvedObjectName().ToTableName(), NoInherit: true}
+  }
+| NO INHERITS table_name
   {
     /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "alter table no inherits")
+    $$.val = &tree.AlterTableInherit{Parent: $3.unresolvedObjectName().ToTableName(), NoInherit: true}
   }
   // ALTER TABLE <name> ALTER PRIMARY KEY USING COLUMNS ( <colnames...> )
 | ALTER PRIMARY KEY USING COLUMNS '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -4123,15 +4192,120 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
-alter_unsupported_stmt:
-  ALTER DOMAIN error
+// %Help: ALTER DOMAIN - change the definition of a domain
+// %Category: DDL
+// %Text:
+//   ALTER DOMAIN <name> { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN <name> { SET | DROP } NOT NULL
+//   ALTER DOMAIN <name> ADD [CONSTRAINT <constraint_name>] CHECK (<expr>)
+//   ALTER DOMAIN <name> DROP CONSTRAINT [IF EXISTS] <constraint_name> [CASCADE | RESTRICT]
+//   ALTER DOMAIN <name> RENAME TO <newname>
+//   ALTER DOMAIN <name> OWNER TO {<newowner> | CURRENT_USER | SESSION_USER }
+//   ALTER DOMAIN <name> SET SCHEMA <newschemaname>
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{Default: $6.expr()},
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name DROP DEFAULT
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{},
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: true},
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: false},
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name ADD domain_check_constraint
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{Constraint: $5.domainCheckConstraint()},
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        DropBehavior: $7.dropBehavior(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($8),
+        IfExists: true,
+        DropBehavior: $9.dropBehavior(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name RENAME TO name
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeRename{
+        NewName: tree.Name($6),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeSetSchema{
+        Schema: tree.Name($6),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name OWNER TO role_spec
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeOwner{
+        Owner: $6.roleSpec(),
+      },
+      Domain: true,
+    }
+  }
+| ALTER DOMAIN type_name RENAME CONSTRAINT error
   {
-    return unimplemented(sqllex, "alter domain")
+    return unimplementedWithIssueDetail(sqllex, 27796, "alter domain rename constraint")
   }
-| ALTER AGGREGATE error
+| ALTER DOMAIN type_name VALIDATE CONSTRAINT error
   {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
+    return unimplementedWithIssueDetail(sqllex, 27796, "alter domain validate constraint")
   }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
 
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
@@ -5243,6 +5417,72 @@ table_rls_mode:
     $$.val = tree.TableRLSNoForce
   }
 
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , COMBINEFUNC = combinefunc ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: CREATE FUNCTION, DROP AGGREGATE
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE routine_create_name func_params '(' aggregate_opt_list ')'
+  {
+    n, err := tree.MakeCreateAggregate(
+      $2.bool(), $4.unresolvedObjectName().ToRoutineName(), $5.routineParams(), $7.aggregateOptions(),
+    )
+    if err != nil {
+      return setErrNoDetails(sqllex, err)
+    }
+    $$.val = n
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_opt_list:
+  aggregate_opt
+  {
+    $$.val = []tree.AggregateOption{$1.aggregateOption()}
+  }
+| aggregate_opt_list ',' aggregate_opt
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_opt:
+  SFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateOptionStateFunc,
+      Func: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| STYPE '=' typename
+  {
+    $$.val = tree.AggregateOption{Kind: tree.AggregateOptionStateType, Type: $3.typeReference()}
+  }
+| FINALFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateOptionFinalFunc,
+      Func: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| COMBINEFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{
+      Kind: tree.AggregateOptionCombineFunc,
+      Func: $3.unresolvedObjectName().ToRoutineName(),
+    }
+  }
+| INITCOND '=' SCONST
+  {
+    $$.val = tree.AggregateOption{Kind: tree.AggregateOptionInitCond, InitCond: $3}
+  }
+
 // %Help: CREATE FUNCTION - define a new function
 // %Category: DDL
 // %Text:
@@ -5431,7 +5671,7 @@ routine_param_class:
 | OUT { $$.val = tree.RoutineParamOut }
 | INOUT { $$.val = tree.RoutineParamInOut }
 | IN OUT { $$.val = tree.RoutineParamInOut }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| VARIADIC { $$.val = tree.RoutineParamVariadic }
 
 routine_param_type:
   typename
@@ -5540,22 +5780,53 @@ common_routine_opt_item:
   }
 | COST numeric_only
   {
-    return unimplemented(sqllex, "create function/procedure ... cost")
+    cost, _ := constant.Float64Val(constant.ToFloat($2.numVal().AsConstantValue()))
+    $$.val = tree.RoutineCost(cost)
   }
 | ROWS numeric_only
   {
-    return unimplemented(sqllex, "create function/procedure ... rows")
+    rows, _ := constant.Float64Val(constant.ToFloat($2.numVal().AsConstantValue()))
+    $$.val = tree.RoutineRows(rows)
   }
 | SUPPORT name
   {
-    return unimplemented(sqllex, "create function/procedure ... support")
+    $$.val = tree.RoutineSupport($2)
+  }
+| PARALLEL name
+  {
+    parallel, err := tree.AsRoutineParallel($2)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = parallel
+  }
+| SET routine_set_rest
+  {
+    $$.val = $2.functionOption()
+  }
+| RESET session_var
+  {
+    $$.val = &tree.RoutineSet{Name: $2, Reset: true}
+  }
+| RESET_ALL ALL
+  {
+    $$.val = &tree.RoutineSet{Reset: true, ResetAll: true}
   }
 
-// In theory we should parse the a whole set/reset statement here. But it's fine
-// to just return fast on SET/RESET keyword for now since it's not supported
-// yet.
-| SET { return unimplemented(sqllex, "create function/procedure ... set") }
-| PARALLEL { return unimplemented(sqllex, "create function/procedure ... parallel") }
+routine_set_rest:
+  var_name to_or_eq var_list
+  {
+    $$.val = &tree.RoutineSet{Name: strings.Join($1.strs(), "."), Values: $3.exprs()}
+  }
+| var_name FROM CURRENT
+  {
+    $$.val = &tree.RoutineSet{Name: strings.Join($1.strs(), "."), FromCurrent: true}
+  }
+| TIME ZONE zone_value
+  {
+    /* SKIP DOC */
+    $$.val = &tree.RoutineSet{Name: "timezone", Values: tree.Exprs{$3.expr()}}
+  }
 
 routine_as:
   SCONST
@@ -5671,6 +5942,32 @@ drop_func_stmt:
   }
 | DROP FUNCTION error // SHOW HELP: DROP FUNCTION
 
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( [ [ argmode ] [ argname ] argtype [, ...] ] ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Aggregate: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Aggregate: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
 // %Help: DROP PROCEDURE - remove a procedure
 // %Category: DDL
 // %Text:
@@ -5832,6 +6129,44 @@ alter_proc_owner_stmt:
     }
   }
 
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    RENAME TO new_name
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    SET SCHEMA new_schema
+//
+// %SeeAlso: CREATE AGGREGATE
+alter_aggregate_stmt:
+  ALTER AGGREGATE function_with_paramtypes RENAME TO name
+  {
+    $$.val = &tree.AlterRoutineRename{
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterRoutineSetOwner{
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterRoutineSetSchema{
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
 opt_no:
   NO
   {
@@ -6055,18 +6390,15 @@ drop_trigger_stmt:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
 | CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
-| CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
@@ -6081,20 +6413,16 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
 | DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
-| DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
 
@@ -6107,10 +6435,14 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
+| create_server_stmt   // EXTEND WITH HELP: CREATE SERVER
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
 | create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
 | create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
 
@@ -6497,8 +6829,12 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
+| drop_server_stmt   // EXTEND WITH HELP: DROP SERVER
+| drop_foreign_table_stmt // EXTEND WITH HELP: DROP FOREIGN TABLE
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
 | drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
 | drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
 
@@ -6565,6 +6901,36 @@ drop_table_stmt:
   }
 | DROP TABLE error // SHOW HELP: DROP TABLE
 
+// %Help: DROP FOREIGN TABLE - remove a foreign table
+// %Category: DDL
+// %Text: DROP FOREIGN TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FOREIGN TABLE, DROP SERVER
+drop_foreign_table_stmt:
+  DROP FOREIGN TABLE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{Names: $4.tableNames(), IfExists: false, DropBehavior: $5.dropBehavior(), Foreign: true}
+  }
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{Names: $6.tableNames(), IfExists: true, DropBehavior: $7.dropBehavior(), Foreign: true}
+  }
+| DROP FOREIGN TABLE error // SHOW HELP: DROP FOREIGN TABLE
+
+// %Help: DROP SERVER - remove a foreign server
+// %Category: DDL
+// %Text: DROP SERVER [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE SERVER, DROP FOREIGN TABLE
+drop_server_stmt:
+  DROP SERVER name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropServer{Names: $3.nameList(), IfExists: false, DropBehavior: $4.dropBehavior()}
+  }
+| DROP SERVER IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropServer{Names: $5.nameList(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP SERVER error // SHOW HELP: DROP SERVER
+
 // %Help: DROP INDEX - remove an index
 // %Category: DDL
 // %Text: DROP INDEX [CONCURRENTLY] [IF EXISTS] <idxname> [, ...] [CASCADE | RESTRICT]
@@ -6635,6 +7001,31 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+      Domain: true,
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+      Domain: true,
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
 // %Text: DROP VIRTUAL CLUSTER [IF EXISTS] <virtual_cluster_spec> [IMMEDIATE]
@@ -7674,7 +8065,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -8164,6 +8555,35 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set the checking mode of deferrable constraints
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// Deferred constraints are checked when the transaction commits, instead of
+// at the end of each statement.
+// %SeeAlso: SET TRANSACTION, CREATE TABLE
+set_constraints_stmt:
+  SET CONSTRAINTS ALL constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS name_list constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+constraints_set_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -10714,6 +11134,7 @@ create_table_stmt:
       StorageParams: $10.storageParams(),
       OnCommit: $11.createTableOnCommitSetting(),
       Locality: $12.locality(),
+      Inherits: $8.tableNames(),
     }
   }
 | CREATE opt_persistence_temp_table TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_create_table_on_commit opt_locality
@@ -10729,8 +11150,98 @@ create_table_stmt:
       StorageParams: $13.storageParams(),
       OnCommit: $14.createTableOnCommitSetting(),
       Locality: $15.locality(),
+      Inherits: $11.tableNames(),
+    }
+  }
+
+// %Help: CREATE FOREIGN TABLE - create a new foreign table
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [IF NOT EXISTS] <tablename> ( <colname> <type> [NOT NULL] [, ...] )
+//   SERVER <server_name> [OPTIONS (<option> '<value>' [, ...])]
+// %SeeAlso: CREATE SERVER, DROP FOREIGN TABLE
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateTable{
+      Table: name,
+      IfNotExists: false,
+      Defs: $6.tblDefs(),
+      Foreign: &tree.ForeignTableDef{
+        Server: tree.Name($9),
+        Options: $10.foreignOptions(),
+      },
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    name := $7.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateTable{
+      Table: name,
+      IfNotExists: true,
+      Defs: $9.tblDefs(),
+      Foreign: &tree.ForeignTableDef{
+        Server: tree.Name($12),
+        Options: $13.foreignOptions(),
+      },
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
+// %Help: CREATE SERVER - define a new foreign server
+// %Category: DDL
+// %Text:
+// CREATE SERVER [IF NOT EXISTS] <name> FOREIGN DATA WRAPPER <wrapper_name>
+//   [OPTIONS (<option> '<value>' [, ...])]
+//
+// The available foreign-data wrappers are file_fdw and postgres_fdw.
+// %SeeAlso: CREATE FOREIGN TABLE, DROP SERVER
+create_server_stmt:
+  CREATE SERVER name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateServer{
+      Name: tree.Name($3),
+      Wrapper: tree.Name($7),
+      Options: $8.foreignOptions(),
+    }
+  }
+| CREATE SERVER IF NOT EXISTS name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateServer{
+      IfNotExists: true,
+      Name: tree.Name($6),
+      Wrapper: tree.Name($10),
+      Options: $11.foreignOptions(),
     }
   }
+| CREATE SERVER error // SHOW HELP: CREATE SERVER
+
+opt_foreign_options:
+  OPTIONS '(' foreign_option_list ')'
+  {
+    $$.val = $3.foreignOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ForeignOptions(nil)
+  }
+
+foreign_option_list:
+  foreign_option
+  {
+    $$.val = tree.ForeignOptions{$1.foreignOption()}
+  }
+| foreign_option_list ',' foreign_option
+  {
+    $$.val = append($1.foreignOptions(), $3.foreignOption())
+  }
+
+foreign_option:
+  unrestricted_name SCONST
+  {
+    $$.val = tree.ForeignOption{Name: tree.Name($1), Value: $2}
+  }
 
 opt_locality:
   locality
@@ -10758,12 +11269,11 @@ opt_table_with:
 opt_create_table_inherits:
   /* EMPTY */
   {
-    $$ = ""
+    $$.val = tree.TableNames(nil)
   }
-| INHERITS error
+| INHERITS '(' table_name_list ')'
   {
-    /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "create table inherits")
+    $$.val = $3.tableNames()
   }
 
 opt_with_storage_parameter_list:
@@ -11206,6 +11716,22 @@ col_qualification_elem:
       Match: $4.compositeKeyMatchMethod(),
     }
   }
+| DEFERRABLE
+  {
+    $$.val = tree.ConstraintAttrDeferrable
+  }
+| NOT DEFERRABLE
+  {
+    $$.val = tree.ConstraintAttrNotDeferrable
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintAttrInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintAttrInitiallyImmediate
+  }
 | generated_as '(' a_expr ')' STORED
   {
     $$.val = &tree.ColumnComputedDef{Expr: $3.expr(), Virtual: false}
@@ -11385,6 +11911,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability().IsDeferrable() {
+      return setErr(sqllex, pgerror.New(pgcode.FeatureNotSupported, "CHECK constraints cannot be marked DEFERRABLE"))
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -11392,6 +11921,9 @@ constraint_elem:
 | UNIQUE opt_without_index '(' index_params ')'
     opt_storing opt_partition_by_index opt_deferrable opt_where_clause
   {
+    if err := tree.CheckUniqueConstraintDeferrability(false /* primaryKey */, $2.bool(), $8.constraintDeferrability()); err != nil {
+      return setErr(sqllex, err)
+    }
     $$.val = &tree.UniqueConstraintTableDef{
       WithoutIndex: $2.bool(),
       IndexTableDef: tree.IndexTableDef{
@@ -11400,6 +11932,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -11423,11 +11956,57 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
+    }
+  }
+| EXCLUDE opt_exclusion_using '(' exclusion_elem_list ')' opt_where_clause opt_deferrable
+  {
+    $$.val = &tree.ExclusionConstraintTableDef{
+      Using: $2,
+      Elems: $4.exclusionElems(),
+      Predicate: $6.expr(),
+      Deferrability: $7.constraintDeferrability(),
+    }
+  }
+
+opt_exclusion_using:
+  USING name
+  {
+    switch $2 {
+      case "gist", "btree":
+      case "gin", "hash", "spgist", "brin", "cspann", "hnsw":
+        return setErr(sqllex, pgerror.Newf(pgcode.FeatureNotSupported,
+          "access method %q does not support exclusion constraints", $2))
+      default:
+        sqllex.Error("unrecognized access method: " + $2)
+        return 1
     }
+    $$ = $2
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclusion_elem_list:
+  exclusion_elem
+  {
+    $$.val = tree.ExclusionElemList{$1.exclusionElem()}
+  }
+| exclusion_elem_list ',' exclusion_elem
+  {
+    $$.val = append($1.exclusionElems(), $3.exclusionElem())
   }
-| EXCLUDE USING error
+
+exclusion_elem:
+  name WITH all_op
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      return setErr(sqllex, pgerror.Newf(pgcode.WrongObjectType,
+        "operator %s is not supported in exclusion constraints", $3.op()))
+    }
+    $$.val = tree.ExclusionElem{Column: tree.Name($1), Operator: op}
   }
 
 
@@ -11535,13 +12114,33 @@ create_as_col_qualification_elem:
     }
   }
 
+// NOT DEFERRABLE is only supported in column constraints, since it would be
+// ambiguous with NOT VALID in ALTER TABLE ... ADD CONSTRAINT.
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
 
 storing:
   COVERING
@@ -12209,8 +12808,67 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <name> [AS] <type>
+//   [ DEFAULT <expr> ]
+//   [ NOT NULL | NULL ]
+//   [ [CONSTRAINT <constraint_name>] CHECK (<expr>) ] [...]
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name opt_as typename opt_domain_elem_list
+  {
+    def := $6.domainTypeDef()
+    def.BaseType = $5.typeReference()
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName(),
+      Variety: tree.Domain,
+      Domain: def,
+    }
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
+
+opt_domain_elem_list:
+  opt_domain_elem_list DEFAULT b_expr
+  {
+    def := $1.domainTypeDef()
+    def.DefaultExpr = $3.expr()
+    $$.val = def
+  }
+| opt_domain_elem_list NOT NULL
+  {
+    def := $1.domainTypeDef()
+    def.NotNull = true
+    $$.val = def
+  }
+| opt_domain_elem_list NULL
+  {
+    def := $1.domainTypeDef()
+    def.NotNull = false
+    $$.val = def
+  }
+| opt_domain_elem_list domain_check_constraint
+  {
+    def := $1.domainTypeDef()
+    def.Checks = append(def.Checks, $2.domainCheckConstraint())
+    $$.val = def
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.DomainTypeDef{}
+  }
+
+domain_check_constraint:
+  CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainCheckConstraint{Expr: $3.expr()}
+  }
+| CONSTRAINT constraint_name CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainCheckConstraint{Name: tree.Name($2), Expr: $5.expr()}
+  }
 
 opt_enum_val_list:
   enum_val_list
@@ -13160,20 +13818,20 @@ begin_stmt:
 // %Help: COMMIT - commit the current transaction
 // %Category: Txn
 // %Text:
-// COMMIT [TRANSACTION]
-// END [TRANSACTION]
+// COMMIT [TRANSACTION] [AND [NO] CHAIN]
+// END [TRANSACTION] [AND [NO] CHAIN]
 // %SeeAlso: BEGIN, ROLLBACK, WEBDOCS/commit-transaction.html
 commit_stmt:
-  COMMIT opt_transaction
+  COMMIT opt_transaction opt_transaction_chain
   {
-    $$.val = &tree.CommitTransaction{}
+    $$.val = &tree.CommitTransaction{Chain: $3.bool()}
   }
 | COMMIT error // SHOW HELP: COMMIT
 
 abort_stmt:
-  ABORT opt_abort_mod
+  ABORT opt_abort_mod opt_transaction_chain
   {
-    $$.val = &tree.RollbackTransaction{}
+    $$.val = &tree.RollbackTransaction{Chain: $3.bool()}
   }
 
 opt_abort_mod:
@@ -13184,13 +13842,13 @@ opt_abort_mod:
 // %Help: ROLLBACK - abort the current (sub-)transaction
 // %Category: Txn
 // %Text:
-// ROLLBACK [TRANSACTION]
+// ROLLBACK [TRANSACTION] [AND [NO] CHAIN]
 // ROLLBACK [TRANSACTION] TO [SAVEPOINT] <savepoint name>
 // %SeeAlso: BEGIN, COMMIT, SAVEPOINT, WEBDOCS/rollback-transaction.html
 rollback_stmt:
-  ROLLBACK opt_transaction
+  ROLLBACK opt_transaction opt_transaction_chain
   {
-     $$.val = &tree.RollbackTransaction{}
+     $$.val = &tree.RollbackTransaction{Chain: $3.bool()}
   }
 | ROLLBACK opt_transaction TO savepoint_name
   {
@@ -13213,9 +13871,9 @@ legacy_begin_stmt:
 | BEGIN error // SHOW HELP: BEGIN
 
 legacy_end_stmt:
-  END opt_transaction
+  END opt_transaction opt_transaction_chain
   {
-    $$.val = &tree.CommitTransaction{}
+    $$.val = &tree.CommitTransaction{Chain: $3.bool()}
   }
 | END error // SHOW HELP: COMMIT
 
@@ -13224,6 +13882,20 @@ opt_transaction:
   TRANSACTION {}
 | /* EMPTY */ {}
 
+opt_transaction_chain:
+  AND CHAIN
+  {
+    $$.val = true
+  }
+| AND NO CHAIN
+  {
+    $$.val = false
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 savepoint_name:
   SAVEPOINT name
   {
@@ -14475,9 +15147,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.GroupingSetsList, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -14658,6 +15339,7 @@ opt_index_flags:
 //   <source> NATURAL [ <jointype> ] JOIN <source>
 //   <source> CROSS JOIN <source>
 //   <source> WITH ORDINALITY
+//   <source> TABLESAMPLE { BERNOULLI | SYSTEM } ( <percent> ) [ REPEATABLE ( <seed> ) ]
 //   '[' EXPLAIN ... ']'
 //   '[' SHOW ... ']'
 //
@@ -14675,25 +15357,25 @@ opt_index_flags:
 //
 // %SeeAlso: WEBDOCS/table-expressions.html
 table_ref:
-  numeric_table_ref opt_index_flags opt_ordinality opt_alias_clause
+  numeric_table_ref opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
   {
     /* SKIP DOC */
     $$.val = &tree.AliasedTableExpr{
-        Expr:       $1.tblExpr(),
-        IndexFlags: $2.indexFlags(),
-        Ordinality: $3.bool(),
-        As:         $4.aliasClause(),
+        Expr:        $1.tblExpr(),
+        IndexFlags:  $2.indexFlags(),
+        Ordinality:  $3.bool(),
+        As:          $4.aliasClause(),
+        TableSample: $5.tableSample(),
     }
   }
-| relation_expr opt_index_flags opt_ordinality opt_alias_clause
+| table_ref_relation_expr opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
   {
-    name := $1.unresolvedObjectName().ToTableName()
-    $$.val = &tree.AliasedTableExpr{
-      Expr:       &name,
-      IndexFlags: $2.indexFlags(),
-      Ordinality: $3.bool(),
-      As:         $4.aliasClause(),
-    }
+    ate := $1.tblExpr().(*tree.AliasedTableExpr)
+    ate.IndexFlags = $2.indexFlags()
+    ate.Ordinality = $3.bool()
+    ate.As = $4.aliasClause()
+    ate.TableSample = $5.tableSample()
+    $$.val = ate
   }
 | select_with_parens opt_ordinality opt_alias_clause
   {
@@ -14859,6 +15541,30 @@ tableref_col_list:
     $$.val = append($1.tableRefCols(), tree.ColumnID($3.int64()))
   }
 
+opt_tablesample_clause:
+  TABLESAMPLE BERNOULLI '(' a_expr ')' opt_repeatable_clause
+  {
+    $$.val = &tree.TableSample{Method: tree.TableSampleBernoulli, Percent: $4.expr(), Repeatable: $6.expr()}
+  }
+| TABLESAMPLE SYSTEM '(' a_expr ')' opt_repeatable_clause
+  {
+    $$.val = &tree.TableSample{Method: tree.TableSampleSystem, Percent: $4.expr(), Repeatable: $6.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.TableSample)(nil)
+  }
+
+opt_repeatable_clause:
+  REPEATABLE '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
 opt_ordinality:
   WITH_LA ORDINALITY
   {
@@ -15049,6 +15755,30 @@ relation_expr:
 | ONLY table_name         { $$.val = $2.unresolvedObjectName() }
 | ONLY '(' table_name ')' { $$.val = $3.unresolvedObjectName() }
 
+// table_ref_relation_expr is the same as relation_expr, but records whether
+// ONLY was specified so that scans of the table exclude inheriting tables.
+table_ref_relation_expr:
+  table_name
+  {
+    name := $1.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name}
+  }
+| table_name '*'
+  {
+    name := $1.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name}
+  }
+| ONLY table_name
+  {
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name, Only: true}
+  }
+| ONLY '(' table_name ')'
+  {
+    name := $3.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name, Only: true}
+  }
+
 relation_expr_list:
   relation_expr
   {
@@ -15061,6 +15791,32 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
+// %Help: LISTEN - listen for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - generate a notification on a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: $4}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
 // UNLISTEN
 unlisten_stmt:
    UNLISTEN type_name
@@ -16536,7 +17292,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingExpr{Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -16547,8 +17306,14 @@ func_application:
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: $3.exprs(), OrderBy: $4.orderBy(), AggType: tree.GeneralAgg}
   }
-| func_application_name '(' VARIADIC a_expr opt_sort_clause_no_index ')' { return unimplemented(sqllex, "variadic") }
-| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause_no_index ')' { return unimplemented(sqllex, "variadic") }
+| func_application_name '(' VARIADIC a_expr opt_sort_clause_no_index ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: tree.Exprs{$4.expr()}, OrderBy: $5.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
+| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause_no_index ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: append($3.exprs(), $6.expr()), OrderBy: $7.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
 | func_application_name '(' ALL expr_list opt_sort_clause_no_index ')'
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Type: tree.AllFuncType, Exprs: $4.exprs(), OrderBy: $5.orderBy(), AggType: tree.GeneralAgg}
@@ -18156,6 +18921,7 @@ unreserved_keyword:
 | BATCH
 | BEFORE
 | BEGIN
+| BERNOULLI
 | BIDIRECTIONAL
 | BINARY
 | BUCKET_COUNT
@@ -18170,12 +18936,14 @@ unreserved_keyword:
 | CAPABILITIES
 | CAPABILITY
 | CASCADE
+| CHAIN
 | CHANGEFEED
 | CHECK_FILES
 | CLOSE
 | CLUSTER
 | CLUSTERS
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -18252,6 +19020,7 @@ unreserved_keyword:
 | EXTREMES
 | FAILURE
 | FILES
+| FINALFUNC
 | FILTER
 | FIRST
 | FOLLOWING
@@ -18300,7 +19069,9 @@ unreserved_keyword:
 | INCREMENTAL_LOCATION
 | INDEX
 | INDEXES
+| INHERIT
 | INHERITS
+| INITCOND
 | INJECT
 | INPUT
 | INSERT
@@ -18332,6 +19103,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGICAL
@@ -18375,6 +19147,7 @@ unreserved_keyword:
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -18499,6 +19272,7 @@ unreserved_keyword:
 | SCROLL
 | SETTING
 | SETTINGS
+| SFUNC
 | STATUS
 | SAVEPOINT
 | SCANS
@@ -18552,6 +19326,7 @@ unreserved_keyword:
 | STRAIGHT
 | STREAM
 | STRICT
+| STYPE
 | SUBSCRIPTION
 | SUBJECT
 | SUPER
@@ -18619,6 +19394,7 @@ unreserved_keyword:
 | VOTERS
 | WITHIN
 | WITHOUT
+| WRAPPER
 | WRITE
 | YEAR
 | ZONE
@@ -18661,6 +19437,7 @@ bare_label_keywords:
 | BATCH
 | BEFORE
 | BEGIN
+| BERNOULLI
 | BETWEEN
 | BIDIRECTIONAL
 | BIGINT
@@ -18683,6 +19460,7 @@ bare_label_keywords:
 | CASCADE
 | CASE
 | CAST
+| CHAIN
 | CHANGEFEED
 | CHARACTERISTICS
 | CHECK
@@ -18694,6 +19472,7 @@ bare_label_keywords:
 | COLLATION
 | COLUMN
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -18793,6 +19572,7 @@ bare_label_keywords:
 | FALSE
 | FAMILY
 | FILES
+| FINALFUNC
 | FIRST
 | FLOAT
 | FOLLOWING
@@ -18855,7 +19635,9 @@ bare_label_keywords:
 | INDEX_AFTER_ORDER_BY_BEFORE_AT
 | INDEX_BEFORE_NAME_THEN_PAREN
 | INDEX_BEFORE_PAREN
+| INHERIT
 | INHERITS
+| INITCOND
 | INITIALLY
 | INJECT
 | INNER
@@ -18902,6 +19684,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -18962,6 +19745,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -19108,6 +19892,7 @@ bare_label_keywords:
 | SETS
 | SETTING
 | SETTINGS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -19146,6 +19931,7 @@ bare_label_keywords:
 | STREAM
 | STRICT
 | STRING
+| STYPE
 | SUBSCRIPTION
 | SUBSTRING
 | SUBJECT
@@ -19158,6 +19944,7 @@ bare_label_keywords:
 | SYSTEM
 | TABLE
 | TABLES
+| TABLESAMPLE
 | TABLESPACE
 | TEMP
 | TEMPLATE
@@ -19233,6 +20020,7 @@ bare_label_keywords:
 | VOTERS
 | WHEN
 | WORK
+| WRAPPER
 | WRITE
 | ZONE
 
@@ -19344,6 +20132,7 @@ type_func_name_no_crdb_extra_keyword:
 | OVERLAPS
 | RIGHT
 | SIMILAR
+| TABLESAMPLE
 
 // CockroachDB-specific keywords that can be used in type/function
 // identifiers.
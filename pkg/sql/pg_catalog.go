@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/replslot"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/prep"
@@ -714,6 +715,7 @@ var (
 	relKindView             = tree.NewDString("v")
 	relKindMaterializedView = tree.NewDString("m")
 	relKindSequence         = tree.NewDString("S")
+	relKindForeignTable     = tree.NewDString("f")
 
 	relPersistencePermanent = tree.NewDString("p")
 	relPersistenceTemporary = tree.NewDString("t")
@@ -743,6 +745,9 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			relKind = relKindSequence
 			relAm = oidZero
 			replIdent = "n"
+		} else if table.IsForeignTable() {
+			relKind = relKindForeignTable
+			relAm = oidZero
 		}
 		relPersistence := relPersistencePermanent
 		if table.IsTemporary() {
@@ -972,6 +977,9 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		deferrability := constraintDeferrability(c)
+		condeferrable := tree.MakeDBool(tree.DBool(deferrability.IsDeferrable()))
+		condeferred := tree.MakeDBool(tree.DBool(deferrability.IsInitiallyDeferred()))
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -1074,6 +1082,50 @@ func populateTableConstraints(
 				return err
 			}
 			condef = tree.NewDString(buf.String())
+		} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && uwoi.UniqueWithoutIndexDesc().IsExclusion() {
+			contype = conTypeExclusion
+			uc := uwoi.UniqueWithoutIndexDesc()
+			conoid = h.UniqueWithoutIndexConstraintOid(
+				db.GetID(), sc.GetID(), table.GetID(), uwoi,
+			)
+			if conkey, err = colIDArrayToDatum(uc.ColumnIDs); err != nil {
+				return err
+			}
+			colNames, err := catalog.ColumnNamesForIDs(table, uc.ColumnIDs)
+			if err != nil {
+				return err
+			}
+			f := tree.NewFmtCtx(tree.FmtSimple)
+			f.WriteString("EXCLUDE ")
+			if uc.ExclusionMethod != "" {
+				f.WriteString("USING ")
+				f.WriteString(uc.ExclusionMethod)
+				f.WriteByte(' ')
+			}
+			f.WriteByte('(')
+			for i := range colNames {
+				if i > 0 {
+					f.WriteString(", ")
+				}
+				f.FormatNameP(&colNames[i])
+				f.WriteString(" WITH ")
+				f.WriteString(uc.ExclusionOperators[i])
+			}
+			f.WriteByte(')')
+			if uwoi.GetPredicate() != "" {
+				pred, err := schemaexpr.FormatExprForDisplay(ctx, table, uwoi.GetPredicate(), p.EvalContext(), p.SemaCtx(), p.SessionData(), tree.FmtPGCatalog)
+				if err != nil {
+					return err
+				}
+				f.WriteString(fmt.Sprintf(" WHERE (%s)", pred))
+			}
+			if d := constraintDeferrability(c); d.IsDeferrable() {
+				f.FormatNode(&d)
+			}
+			if !uwoi.IsConstraintValidated() {
+				f.WriteString(" NOT VALID")
+			}
+			condef = tree.NewDString(f.CloseAndGetString())
 		} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
 			contype = conTypeUnique
 			f := tree.NewFmtCtx(tree.FmtSimple)
@@ -1087,6 +1139,9 @@ func populateTableConstraints(
 			}
 			f.WriteString(strings.Join(colNames, ", "))
 			f.WriteByte(')')
+			if d := constraintDeferrability(c); d.IsDeferrable() {
+				f.FormatNode(&d)
+			}
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1122,8 +1177,8 @@ func populateTableConstraints(
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1912,36 +1967,104 @@ https://www.postgresql.org/docs/9.5/catalog-pg-extension.html`,
 }
 
 var pgCatalogForeignDataWrapperTable = virtualSchemaTable{
-	comment: `foreign data wrappers (empty - feature does not exist)
+	comment: `foreign data wrappers
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-data-wrapper.html`,
 	schema: vtable.PGCatalogForeignDataWrapper,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign data wrappers are not supported.
+		// Foreign-data wrappers are built in, so they are owned by root and
+		// have no handler or validator functions.
+		h := makeOidHasher()
+		ownerOid := h.UserOid(username.RootUserName())
+		for _, name := range foreignDataWrapperNames() {
+			if err := addRow(
+				h.ForeignDataWrapperOid(name), // oid
+				tree.NewDName(name),           // fdwname
+				ownerOid,                      // fdwowner
+				oidZero,                       // fdwhandler
+				oidZero,                       // fdwvalidator
+				tree.DNull,                    // fdwacl
+				tree.DNull,                    // fdwoptions
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogForeignServerTable = virtualSchemaTable{
-	comment: `foreign servers (empty - feature does not exist)
+	comment: `foreign servers
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-server.html`,
 	schema: vtable.PGCatalogForeignServer,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign servers are not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(ctx context.Context, db catalog.DatabaseDescriptor) error {
+				// Foreign servers belong to their database, so they share its owner.
+				ownerOid, err := getOwnerOID(ctx, p, db)
+				if err != nil {
+					return err
+				}
+				return db.ForEachForeignServer(func(name string, server *descpb.DatabaseDescriptor_ForeignServer) error {
+					options, err := makeForeignOptionsArray(server.Options)
+					if err != nil {
+						return err
+					}
+					return addRow(
+						h.ForeignServerOid(db.GetID(), name),    // oid
+						tree.NewDName(name),                     // srvname
+						ownerOid,                                // srvowner
+						h.ForeignDataWrapperOid(server.Wrapper), // srvfdw
+						tree.DNull,                              // srvtype
+						tree.DNull,                              // srvversion
+						tree.DNull,                              // srvacl
+						options,                                 // srvoptions
+					)
+				})
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogForeignTableTable = virtualSchemaTable{
-	comment: `foreign tables (empty  - feature does not exist)
+	comment: `foreign tables
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-table.html`,
 	schema: vtable.PGCatalogForeignTable,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign tables are not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		opts := forEachTableDescOptions{virtualOpts: hideVirtual}
+		return forEachTableDesc(ctx, p, dbContext, opts, func(ctx context.Context, descCtx tableDescContext) error {
+			db, table := descCtx.database, descCtx.table
+			if !table.IsForeignTable() {
+				return nil
+			}
+			ft := table.GetForeignTable()
+			options, err := makeForeignOptionsArray(ft.Options)
+			if err != nil {
+				return err
+			}
+			serverOid := h.ForeignServerOid(db.GetID(), ft.Server)
+			return addRow(
+				tableOid(table.GetID()), // ftrelid
+				serverOid,               // ftserver
+				options,                 // ftoptions
+			)
+		})
 	},
-	unimplemented: true,
+}
+
+// makeForeignOptionsArray returns the options of a foreign server or foreign
+// table as an array of name=value strings, or NULL if there are none.
+func makeForeignOptionsArray(options []descpb.ForeignOption) (tree.Datum, error) {
+	if len(options) == 0 {
+		return tree.DNull, nil
+	}
+	arr := tree.NewDArray(types.String)
+	for _, opt := range options {
+		if err := arr.Append(tree.NewDString(opt.Name + "=" + opt.Value)); err != nil {
+			return nil, err
+		}
+	}
+	return arr, nil
 }
 
 func makeZeroedOidVector(size int) (tree.Datum, error) {
@@ -2146,14 +2269,26 @@ func indexDefFromDescriptor(
 }
 
 var pgCatalogInheritsTable = virtualSchemaTable{
-	comment: `table inheritance hierarchy (empty - feature does not exist)
+	comment: `table inheritance hierarchy
 https://www.postgresql.org/docs/9.5/catalog-pg-inherits.html`,
 	schema: vtable.PGCatalogInherits,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Table inheritance is not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		opts := forEachTableDescOptions{virtualOpts: hideVirtual} /* virtual tables have no parents */
+		return forEachTableDesc(ctx, p, dbContext, opts, func(
+			ctx context.Context, descCtx tableDescContext) error {
+			table := descCtx.table
+			for i, parentID := range table.GetInherits() {
+				if err := addRow(
+					tableOid(table.GetID()),      // inhrelid
+					tableOid(parentID),           // inhparent
+					tree.NewDInt(tree.DInt(i+1)), // inhseqno
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
 }
 
 // Match the OIDs that Postgres uses for languages.
@@ -2691,6 +2826,8 @@ func addPgProcUDFRow(
 	kind := proKindFunction
 	if fnDesc.IsProcedure() {
 		kind = proKindProcedure
+	} else if fnDesc.IsAggregate() {
+		kind = proKindAggregate
 	}
 
 	lang := languageInternalOid
@@ -2706,6 +2843,7 @@ func addPgProcUDFRow(
 	var foundAnyArgNames bool
 	var nArgs, nArgDefaults int
 	var argDefaultsBuilder strings.Builder
+	variadicType := oidZero
 	for _, param := range fnDesc.GetParams() {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
 		if tree.IsInParamClass(class) {
@@ -2744,6 +2882,8 @@ func addPgProcUDFRow(
 			argMode = proArgModeInOut
 		case tree.RoutineParamVariadic:
 			argMode = proArgModeVariadic
+			// provariadic is the element type of the variadic array parameter.
+			variadicType = tree.NewDOid(param.Type.ArrayContents().Oid())
 		default:
 			return errors.AssertionFailedf("unknown parameter class %d", class)
 		}
@@ -2771,15 +2911,32 @@ func addPgProcUDFRow(
 	if nArgDefaults > 0 {
 		argDefaults = tree.NewDString("(" + argDefaultsBuilder.String() + ")")
 	}
+	cost, rows := tree.DNull, tree.DNull
+	if c := fnDesc.GetCost(); c != 0 {
+		cost = tree.NewDFloat(tree.DFloat(c))
+	}
+	if r := fnDesc.GetRows(); r != 0 {
+		rows = tree.NewDFloat(tree.DFloat(r))
+	}
+	config := tree.DNull
+	if settings := fnDesc.GetConfig(); len(settings) > 0 {
+		configArray := tree.NewDArray(types.String)
+		for _, c := range settings {
+			if err := configArray.Append(tree.NewDString(c.Name + "=" + c.Value)); err != nil {
+				return err
+			}
+		}
+		config = configArray
+	}
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
 		tree.NewDName(fnDesc.GetName()),                 // proname
 		schemaOid(scDesc.GetID()),                       // pronamespace
 		h.UserOid(fnDesc.GetPrivileges().Owner()),       // proowner
 		lang,            // prolang
-		tree.DNull,      // procost
-		tree.DNull,      // prorows
-		oidZero,         // provariadic // TODO(88947): this might need an adjustment.
+		cost,            // procost
+		rows,            // prorows
+		variadicType,    // provariadic
 		tree.DNull,      // prosupport
 		kind,            // prokind
 		tree.DBoolFalse, // prosecdef
@@ -2787,7 +2944,7 @@ func addPgProcUDFRow(
 		tree.MakeDBool(fnDesc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT), // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)),                         // proretset
 		tree.NewDString(funcVolatility(fnDesc.GetVolatility())),                              // provolatile
-		tree.DNull,                                      // proparallel
+		tree.NewDString(funcParallel(fnDesc.GetParallel())),                                  // proparallel
 		tree.NewDInt(tree.DInt(nArgs)),                  // pronargs
 		tree.NewDInt(tree.DInt(nArgDefaults)),           // pronargdefaults
 		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()), // prorettype
@@ -2800,7 +2957,7 @@ func addPgProcUDFRow(
 		tree.NewDString(fnDesc.GetFunctionBody()),       // prosrc
 		tree.DNull,                                      // probin
 		tree.DNull,                                      // prosqlbody
-		tree.DNull,                                      // proconfig
+		config,                                          // proconfig
 		tree.DNull,                                      // proacl
 	)
 }
@@ -3321,7 +3478,6 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
 	_ = typTypeRange
 
@@ -3420,6 +3576,19 @@ func addPGTypeRow(
 	typArray := oidZero
 	builtinPrefix := builtins.PGIOBuiltinPrefix(typ)
 	typrelid := oidZero
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		if d := typ.TypeMeta.DomainData; d != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(d.NotNull))
+			typBaseType = tree.NewDOid(d.BaseType.Oid())
+			if d.DefaultExpr != "" {
+				typDefault = tree.NewDString(d.DefaultExpr)
+			}
+		}
+	}
 	switch typ.Family() {
 	case types.ArrayFamily:
 		switch typ.Oid() {
@@ -3496,13 +3665,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -4505,12 +4674,12 @@ https://www.postgresql.org/docs/14/view-pg-cursors.html`,
 				return err
 			}
 			if err := addRow(
-				tree.NewDString(string(name)),          /* name */
-				tree.NewDString(c.statement),           /* statement */
-				tree.MakeDBool(tree.DBool(c.withHold)), /* is_holdable */
-				tree.DBoolFalse,                        /* is_binary */
-				tree.DBoolFalse,                        /* is_scrollable */
-				tz,                                     /* creation_date */
+				tree.NewDString(string(name)),                /* name */
+				tree.NewDString(c.statement),                 /* statement */
+				tree.MakeDBool(tree.DBool(c.withHold)),       /* is_holdable */
+				tree.DBoolFalse,                              /* is_binary */
+				tree.MakeDBool(tree.DBool(c.isScrollable())), /* is_scrollable */
+				tz, /* creation_date */
 			); err != nil {
 				return err
 			}
@@ -4646,12 +4815,53 @@ var pgCatalogLargeobjectMetadataTable = virtualSchemaTable{
 }
 
 var pgCatalogReplicationSlotsTable = virtualSchemaTable{
-	comment: "pg_replication_slots was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogReplicationSlots,
+	comment: `replication slots
+https://www.postgresql.org/docs/16/view-pg-replication-slots.html`,
+	schema: vtable.PgCatalogReplicationSlots,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if !p.IsActive(ctx, clusterversion.V25_3_ReplicationSlotsTable) {
+			return nil
+		}
+		slots, err := replslot.List(ctx, p.InternalSQLTxn())
+		if err != nil {
+			return err
+		}
+		if len(slots) == 0 {
+			return nil
+		}
+		dbs, err := p.Descriptors().GetAllDatabaseDescriptorsMap(ctx, p.txn)
+		if err != nil {
+			return err
+		}
+		for _, slot := range slots {
+			database := tree.DNull
+			if db, ok := dbs[slot.DatabaseID]; ok {
+				database = tree.NewDName(db.GetName())
+			}
+			confirmedFlushLSN := tree.NewDString(slot.ConfirmedFlushLSN.String())
+			// Whether a stream is currently running from the slot is not tracked
+			// across the cluster, so slots are always reported as inactive.
+			if err := addRow(
+				tree.NewDName(slot.Name),    // slot_name
+				tree.NewDName(slot.Plugin),  // plugin
+				tree.NewDString("logical"),  // slot_type
+				dbOid(slot.DatabaseID),      // datoid
+				database,                    // database
+				tree.DBoolFalse,             // temporary
+				tree.DBoolFalse,             // active
+				tree.DNull,                  // active_pid
+				tree.DNull,                  // xmin
+				tree.DNull,                  // catalog_xmin
+				confirmedFlushLSN,           // restart_lsn
+				confirmedFlushLSN,           // confirmed_flush_lsn
+				tree.NewDString("reserved"), // wal_status
+				tree.DNull,                  // safe_wal_size
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogSubscriptionRelTable = virtualSchemaTable{
@@ -5227,6 +5437,8 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	foreignDataWrapperTypeTag
+	foreignServerTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5389,6 +5601,19 @@ func (h oidHasher) rewriteOid(source descpb.ID, depended descpb.ID) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) ForeignDataWrapperOid(name string) *tree.DOid {
+	h.writeTypeTag(foreignDataWrapperTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) ForeignServerOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(foreignServerTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 // DBSchemaRoleOid creates an OID based on the combination of a db/schema/role.
 // This is used to generate a unique row identifier for pg_default_acl.
 func (h oidHasher) DBSchemaRoleOid(
@@ -5439,6 +5664,19 @@ func funcVolatility(v catpb.Function_Volatility) string {
 	}
 }
 
+func funcParallel(p catpb.Function_Parallel) string {
+	switch p {
+	case catpb.Function_PARALLEL_UNSAFE:
+		return "u"
+	case catpb.Function_PARALLEL_RESTRICTED:
+		return "r"
+	case catpb.Function_PARALLEL_SAFE:
+		return "s"
+	default:
+		return ""
+	}
+}
+
 // populateVirtualIndexForTable is used to populate the virtual index with context of the given table descriptor.
 func populateVirtualIndexForTable(
 	ctx context.Context,
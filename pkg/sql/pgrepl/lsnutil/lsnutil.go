@@ -6,7 +6,7 @@
 package lsnutil
 
 import (
-	"time"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -14,7 +14,17 @@ import (
 
 // HLCToLSN converts a HLC to a LSN.
 // It is in a separate package to prevent the `lsn` package importing `log`.
+//
+// The LSN is the wall time of the timestamp in nanoseconds. The logical
+// component is dropped, so all timestamps sharing a wall time map to the same
+// LSN. Logical replication streams account for this by emitting all changes
+// which share a wall time as a single transaction.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
-	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
+	return lsn.LSN(h.WallTime)
+}
+
+// LSNToHLC converts a LSN to the largest HLC which maps to it, such that every
+// timestamp greater than the returned timestamp maps to a greater LSN.
+func LSNToHLC(l lsn.LSN) hlc.Timestamp {
+	return hlc.Timestamp{WallTime: int64(l), Logical: math.MaxInt32}
 }
@@ -84,28 +84,17 @@ func TestDataDriven(t *testing.T) {
 				// IDENTIFY_SYSTEM needs some redaction to be deterministic.
 				rows, err := conn.Query(ctx, "IDENTIFY_SYSTEM", pgx.QueryExecModeSimpleProtocol)
 				require.NoError(t, err)
-				var sb strings.Builder
-				for rows.Next() {
-					vals, err := rows.Values()
-					require.NoError(t, err)
-					for i, val := range vals {
-						if i > 0 {
-							sb.WriteRune('\n')
-						}
-						switch rows.FieldDescriptions()[i].Name {
-						case "systemid":
-							val = "some_cluster_id"
-						case "xlogpos":
-							val = "some_lsn"
-						}
-						sb.WriteString(rows.FieldDescriptions()[i].Name)
-						sb.WriteString(": ")
-						sb.WriteString(fmt.Sprintf("%v", val))
-					}
-				}
-				require.NoError(t, rows.Err())
-				rows.Close()
-				return sb.String()
+				return redactedRowsOutput(t, rows, map[string]string{
+					"systemid": "some_cluster_id",
+					"xlogpos":  "some_lsn",
+				})
+			case "create_replication_slot":
+				// The consistent point of a slot depends on the time it was created.
+				rows, err := conn.Query(ctx, d.Input, pgx.QueryExecModeSimpleProtocol)
+				require.NoError(t, err)
+				return redactedRowsOutput(t, rows, map[string]string{
+					"consistent_point": "some_lsn",
+				})
 			default:
 				t.Errorf("unhandled command %s", d.Cmd)
 			}
@@ -113,3 +102,30 @@ func TestDataDriven(t *testing.T) {
 		})
 	})
 }
+
+// redactedRowsOutput formats rows as one "column: value" line per column,
+// replacing the values of the columns in redact.
+func redactedRowsOutput(t *testing.T, rows pgx.Rows, redact map[string]string) string {
+	defer rows.Close()
+	var sb strings.Builder
+	for rows.Next() {
+		vals, err := rows.Values()
+		require.NoError(t, err)
+		for i, val := range vals {
+			if sb.Len() > 0 {
+				sb.WriteRune('\n')
+			}
+			name := rows.FieldDescriptions()[i].Name
+			if r, ok := redact[name]; ok {
+				val = r
+			}
+			sb.WriteString(name)
+			sb.WriteString(": ")
+			sb.WriteString(fmt.Sprintf("%v", val))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err.Error()
+	}
+	return sb.String()
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package pgoutput encodes the messages of the Postgres logical streaming
+// replication protocol, as produced by the pgoutput output plugin, and the
+// messages of the streaming replication protocol which carry them.
+//
+// See https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// and https://www.postgresql.org/docs/current/protocol-replication.html.
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// ProtoVersion is the version of the logical replication protocol produced by
+// the Encoder.
+const ProtoVersion = 1
+
+// Logical replication message types.
+const (
+	msgBegin    = 'B'
+	msgCommit   = 'C'
+	msgRelation = 'R'
+	msgInsert   = 'I'
+	msgUpdate   = 'U'
+	msgDelete   = 'D'
+)
+
+// Streaming replication message types.
+const (
+	msgXLogData              = 'w'
+	msgPrimaryKeepalive      = 'k'
+	msgStandbyStatusUpdate   = 'r'
+	msgHotStandbyFeedback    = 'h'
+	tupleNew                 = 'N'
+	tupleOld                 = 'O'
+	tupleKey                 = 'K'
+	tupleDataNull            = 'n'
+	tupleDataText            = 't'
+	replicaIdentityFull      = 'f'
+	relationColumnFlagKey    = 1
+	standbyStatusUpdateBytes = 1 + 8*4 + 1
+)
+
+// pgEpoch is the epoch of the timestamps in the replication protocol.
+var pgEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Column is a column of a Relation.
+type Column struct {
+	Name string
+	Type *types.T
+	// Key is set if the column is part of the replica identity of the
+	// relation, i.e. its primary key.
+	Key bool
+}
+
+// Relation describes a table whose changes are streamed. A Relation message
+// must be sent before the first change to the table and whenever its schema
+// changes.
+type Relation struct {
+	OID       oid.Oid
+	Namespace string
+	Name      string
+	Columns   []Column
+}
+
+// Encoder encodes logical replication messages. The returned byte slices are
+// only valid until the next call to the Encoder.
+type Encoder struct {
+	buf    []byte
+	fmtCtx *tree.FmtCtx
+}
+
+// NewEncoder creates an Encoder.
+func NewEncoder() *Encoder {
+	return &Encoder{fmtCtx: tree.NewFmtCtx(tree.FmtPgwireText)}
+}
+
+// Begin encodes a Begin message for a transaction which ends at finalLSN and
+// was committed at commitTime.
+func (e *Encoder) Begin(finalLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	e.buf = append(e.buf[:0], msgBegin)
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(finalLSN))
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(pgTime(commitTime)))
+	e.buf = binary.BigEndian.AppendUint32(e.buf, xid)
+	return e.buf
+}
+
+// Commit encodes a Commit message.
+func (e *Encoder) Commit(commitLSN, endLSN lsn.LSN, commitTime time.Time) []byte {
+	e.buf = append(e.buf[:0], msgCommit)
+	e.buf = append(e.buf, 0 /* flags */)
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(commitLSN))
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(endLSN))
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(pgTime(commitTime)))
+	return e.buf
+}
+
+// Relation encodes a Relation message. The replica identity of the relation
+// is reported as FULL, since the old values of all columns are sent with
+// updates and deletes.
+func (e *Encoder) Relation(rel Relation) []byte {
+	e.buf = append(e.buf[:0], msgRelation)
+	e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(rel.OID))
+	e.buf = appendString(e.buf, rel.Namespace)
+	e.buf = appendString(e.buf, rel.Name)
+	e.buf = append(e.buf, replicaIdentityFull)
+	e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(len(rel.Columns)))
+	for _, col := range rel.Columns {
+		var flags byte
+		if col.Key {
+			flags |= relationColumnFlagKey
+		}
+		e.buf = append(e.buf, flags)
+		e.buf = appendString(e.buf, col.Name)
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(col.Type.Oid()))
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(col.Type.TypeModifier()))
+	}
+	return e.buf
+}
+
+// Insert encodes an Insert message for a new row.
+func (e *Encoder) Insert(relOID oid.Oid, row tree.Datums) []byte {
+	e.buf = append(e.buf[:0], msgInsert)
+	e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(relOID))
+	e.buf = append(e.buf, tupleNew)
+	e.appendTuple(row)
+	return e.buf
+}
+
+// Update encodes an Update message. The old row is omitted if it is nil.
+func (e *Encoder) Update(relOID oid.Oid, oldRow, newRow tree.Datums) []byte {
+	e.buf = append(e.buf[:0], msgUpdate)
+	e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(relOID))
+	if oldRow != nil {
+		e.buf = append(e.buf, tupleOld)
+		e.appendTuple(oldRow)
+	}
+	e.buf = append(e.buf, tupleNew)
+	e.appendTuple(newRow)
+	return e.buf
+}
+
+// Delete encodes a Delete message. If keyOnly is set, only the key columns of
+// the old row are set and the others are NULL.
+func (e *Encoder) Delete(relOID oid.Oid, oldRow tree.Datums, keyOnly bool) []byte {
+	e.buf = append(e.buf[:0], msgDelete)
+	e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(relOID))
+	if keyOnly {
+		e.buf = append(e.buf, tupleKey)
+	} else {
+		e.buf = append(e.buf, tupleOld)
+	}
+	e.appendTuple(oldRow)
+	return e.buf
+}
+
+func (e *Encoder) appendTuple(row tree.Datums) {
+	e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(len(row)))
+	for _, d := range row {
+		if d == tree.DNull {
+			e.buf = append(e.buf, tupleDataNull)
+			continue
+		}
+		e.fmtCtx.Reset()
+		e.fmtCtx.FormatNode(d)
+		e.buf = append(e.buf, tupleDataText)
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(e.fmtCtx.Buffer.Len()))
+		e.buf = append(e.buf, e.fmtCtx.Buffer.Bytes()...)
+	}
+}
+
+// XLogData wraps a logical replication message into a XLogData message, which
+// is sent to the client as a CopyData message. start is the position of the
+// message, and end is the current end of the stream.
+func XLogData(buf []byte, start, end lsn.LSN, now time.Time, data []byte) []byte {
+	buf = append(buf[:0], msgXLogData)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(start))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(end))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(pgTime(now)))
+	return append(buf, data...)
+}
+
+// PrimaryKeepalive encodes a primary keepalive message, which is sent to the
+// client as a CopyData message. If replyRequested is set, the client should
+// reply with a standby status update immediately.
+func PrimaryKeepalive(buf []byte, end lsn.LSN, now time.Time, replyRequested bool) []byte {
+	buf = append(buf[:0], msgPrimaryKeepalive)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(end))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(pgTime(now)))
+	if replyRequested {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// StandbyStatusUpdate is sent by the client to report its progress.
+type StandbyStatusUpdate struct {
+	// Written, Flushed and Applied are the positions up to which the client
+	// has received, durably stored and applied the changes.
+	Written, Flushed, Applied lsn.LSN
+	ClientTime                time.Time
+	// ReplyRequested is set if the client wants a keepalive immediately.
+	ReplyRequested bool
+}
+
+// ParseStandbyStatusUpdate parses a CopyData message sent by the client. ok is
+// false if the message is a hot standby feedback message, which does not apply
+// to logical replication and should be ignored.
+func ParseStandbyStatusUpdate(data []byte) (_ StandbyStatusUpdate, ok bool, _ error) {
+	if len(data) == 0 {
+		return StandbyStatusUpdate{}, false, errors.New("empty replication message")
+	}
+	switch data[0] {
+	case msgStandbyStatusUpdate:
+	case msgHotStandbyFeedback:
+		return StandbyStatusUpdate{}, false, nil
+	default:
+		return StandbyStatusUpdate{}, false, errors.Newf("unexpected replication message type %q", data[0])
+	}
+	if len(data) < standbyStatusUpdateBytes {
+		return StandbyStatusUpdate{}, false, errors.Newf(
+			"standby status update too short: %d bytes", len(data))
+	}
+	data = data[1:]
+	readLSN := func() lsn.LSN {
+		l := lsn.LSN(binary.BigEndian.Uint64(data))
+		data = data[8:]
+		return l
+	}
+	var u StandbyStatusUpdate
+	u.Written = readLSN()
+	u.Flushed = readLSN()
+	u.Applied = readLSN()
+	u.ClientTime = pgEpoch.Add(time.Duration(int64(readLSN())) * time.Microsecond)
+	u.ReplyRequested = data[0] != 0
+	return u, true, nil
+}
+
+// pgTime returns the number of microseconds since the Postgres epoch.
+func pgTime(t time.Time) int64 {
+	return t.Sub(pgEpoch).Microseconds()
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, s...)
+	return append(buf, 0)
+}
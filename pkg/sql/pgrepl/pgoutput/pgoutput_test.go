@@ -0,0 +1,137 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgoutput
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	e := NewEncoder()
+	ts := pgEpoch.Add(1500 * time.Microsecond)
+	u64 := func(v uint64) []byte { return binary.BigEndian.AppendUint64(nil, v) }
+	u32 := func(v uint32) []byte { return binary.BigEndian.AppendUint32(nil, v) }
+	u16 := func(v uint16) []byte { return binary.BigEndian.AppendUint16(nil, v) }
+	cat := func(parts ...[]byte) []byte {
+		var out []byte
+		for _, p := range parts {
+			out = append(out, p...)
+		}
+		return out
+	}
+
+	t.Run("begin", func(t *testing.T) {
+		require.Equal(t,
+			cat([]byte{'B'}, u64(100), u64(1500), u32(7)),
+			e.Begin(lsn.LSN(100), ts, 7),
+		)
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		require.Equal(t,
+			cat([]byte{'C', 0}, u64(100), u64(101), u64(1500)),
+			e.Commit(lsn.LSN(100), lsn.LSN(101), ts),
+		)
+	})
+
+	t.Run("relation", func(t *testing.T) {
+		rel := Relation{
+			OID:       104,
+			Namespace: "public",
+			Name:      "t",
+			Columns: []Column{
+				{Name: "k", Type: types.Int, Key: true},
+				{Name: "v", Type: types.String},
+			},
+		}
+		require.Equal(t,
+			cat(
+				u32(104), []byte("public\x00t\x00f"), u16(2),
+				[]byte{1}, []byte("k\x00"), u32(20), u32(0xffffffff),
+				[]byte{0}, []byte("v\x00"), u32(25), u32(0xffffffff),
+			),
+			e.Relation(rel)[1:],
+		)
+	})
+
+	row := tree.Datums{tree.NewDInt(1), tree.DNull, tree.NewDString("a'b")}
+	tuple := cat(u16(3), []byte{'t'}, u32(1), []byte("1"), []byte{'n'}, []byte{'t'}, u32(3), []byte("a'b"))
+
+	t.Run("insert", func(t *testing.T) {
+		require.Equal(t, cat([]byte{'I'}, u32(104), []byte{'N'}, tuple), e.Insert(104, row))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		require.Equal(t,
+			cat([]byte{'U'}, u32(104), []byte{'O'}, tuple, []byte{'N'}, tuple),
+			e.Update(104, row, row),
+		)
+		require.Equal(t,
+			cat([]byte{'U'}, u32(104), []byte{'N'}, tuple),
+			e.Update(104, nil, row),
+		)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.Equal(t, cat([]byte{'D'}, u32(104), []byte{'O'}, tuple), e.Delete(104, row, false))
+		require.Equal(t, cat([]byte{'D'}, u32(104), []byte{'K'}, tuple), e.Delete(104, row, true))
+	})
+
+	t.Run("xlogdata", func(t *testing.T) {
+		require.Equal(t,
+			cat([]byte{'w'}, u64(10), u64(20), u64(1500), []byte("data")),
+			XLogData(nil, lsn.LSN(10), lsn.LSN(20), ts, []byte("data")),
+		)
+	})
+
+	t.Run("keepalive", func(t *testing.T) {
+		require.Equal(t,
+			cat([]byte{'k'}, u64(20), u64(1500), []byte{1}),
+			PrimaryKeepalive(nil, lsn.LSN(20), ts, true),
+		)
+	})
+}
+
+func TestParseStandbyStatusUpdate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	msg := []byte{'r'}
+	for _, v := range []uint64{30, 20, 10, 1500} {
+		msg = binary.BigEndian.AppendUint64(msg, v)
+	}
+	msg = append(msg, 1)
+
+	u, ok, err := ParseStandbyStatusUpdate(msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, StandbyStatusUpdate{
+		Written:        lsn.LSN(30),
+		Flushed:        lsn.LSN(20),
+		Applied:        lsn.LSN(10),
+		ClientTime:     pgEpoch.Add(1500 * time.Microsecond),
+		ReplyRequested: true,
+	}, u)
+
+	_, ok, err = ParseStandbyStatusUpdate([]byte{'h', 0, 0})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = ParseStandbyStatusUpdate(msg[:10])
+	require.ErrorContains(t, err, "too short")
+
+	_, _, err = ParseStandbyStatusUpdate([]byte{'x'})
+	require.ErrorContains(t, err, "unexpected replication message type")
+}
@@ -30,3 +30,18 @@ func (o Option) Format(ctx *tree.FmtCtx) {
 		ctx.FormatNode(o.Value)
 	}
 }
+
+// StringValue returns the value of the option as a string, or the empty string
+// if the option has no value.
+func (o Option) StringValue() string {
+	switch v := o.Value.(type) {
+	case nil:
+		return ""
+	case *tree.StrVal:
+		return v.RawString()
+	case *tree.NumVal:
+		return v.String()
+	default:
+		return tree.AsStringWithFlags(v, tree.FmtBareStrings)
+	}
+}
@@ -23,7 +23,7 @@ func (rrs *ReadReplicationSlot) Format(ctx *tree.FmtCtx) {
 }
 
 func (rrs *ReadReplicationSlot) StatementReturnType() tree.StatementReturnType {
-	return tree.Replication
+	return tree.Rows
 }
 
 func (rrs *ReadReplicationSlot) StatementType() tree.StatementType {
@@ -0,0 +1,285 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package replslot manages the logical replication slots stored in
+// system.replication_slots.
+//
+// A slot records the position up to which a replication client has confirmed
+// receipt of changes, in the form of a LSN. Each slot owns a protected
+// timestamp record on its database which is kept at the confirmed position so
+// that the changes the client has yet to consume are not garbage collected.
+package replslot
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptreconcile"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// MetaType is the meta type for protected timestamp records associated with
+// replication slots. The meta of such a record is the name of the slot.
+const MetaType = "replication_slots"
+
+// PgOutputPlugin is the name of the only output plugin supported for logical
+// replication slots.
+const PgOutputPlugin = "pgoutput"
+
+// MaxNameLength is the maximum length of a replication slot name, matching
+// NAMEDATALEN in Postgres.
+const MaxNameLength = 63
+
+// Slot is a logical replication slot.
+type Slot struct {
+	Name       string
+	Plugin     string
+	DatabaseID descpb.ID
+	Created    time.Time
+	// ConfirmedFlushLSN is the position up to which the client has confirmed
+	// receipt of changes. Streaming from the slot resumes after this position.
+	ConfirmedFlushLSN lsn.LSN
+	// ProtectedTimestampRecord is the ID of the protected timestamp record which
+	// prevents the changes after ConfirmedFlushLSN from being garbage collected.
+	ProtectedTimestampRecord uuid.UUID
+}
+
+// ValidateName returns an error if name is not a valid replication slot name.
+// As in Postgres, names may only contain lower case letters, numbers and
+// underscores.
+func ValidateName(name string) error {
+	if name == "" {
+		return pgerror.New(pgcode.InvalidName, "replication slot name must not be empty")
+	}
+	if len(name) > MaxNameLength {
+		return pgerror.Newf(pgcode.NameTooLong, "replication slot name %q is too long", name)
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidName, "replication slot name %q contains invalid character", name),
+				"Replication slot names may only contain lower case letters, numbers, and the underscore character.",
+			)
+		}
+	}
+	return nil
+}
+
+// Create creates a replication slot on the given database whose consistent
+// point is the read timestamp of txn. The changes committed after the
+// consistent point are protected from garbage collection until the slot is
+// advanced or dropped.
+func Create(
+	ctx context.Context,
+	txn isql.Txn,
+	pts protectedts.Manager,
+	name string,
+	plugin string,
+	databaseID descpb.ID,
+) (Slot, error) {
+	if err := ValidateName(name); err != nil {
+		return Slot{}, err
+	}
+	ts := txn.KV().ReadTimestamp()
+	slot := Slot{
+		Name:                     name,
+		Plugin:                   plugin,
+		DatabaseID:               databaseID,
+		ConfirmedFlushLSN:        lsnutil.HLCToLSN(ts),
+		ProtectedTimestampRecord: uuid.MakeV4(),
+	}
+	row, err := txn.QueryRowEx(
+		ctx,
+		"insert-replication-slot",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.replication_slots
+       (slot_name, plugin, database_id, confirmed_flush_lsn, protected_timestamp_record)
+     VALUES ($1, $2, $3, $4, $5)
+     ON CONFLICT (slot_name) DO NOTHING
+     RETURNING created`,
+		slot.Name,
+		slot.Plugin,
+		int64(slot.DatabaseID),
+		tree.NewDPGLSN(slot.ConfirmedFlushLSN),
+		slot.ProtectedTimestampRecord,
+	)
+	if err != nil {
+		return Slot{}, err
+	}
+	if row == nil {
+		return Slot{}, pgerror.Newf(pgcode.DuplicateObject, "replication slot %q already exists", name)
+	}
+	slot.Created = tree.MustBeDTimestampTZ(row[0]).Time
+	if err := pts.WithTxn(txn).Protect(ctx, makeRecord(slot)); err != nil {
+		return Slot{}, err
+	}
+	return slot, nil
+}
+
+// Get returns the replication slot with the given name. An UndefinedObject
+// error is returned if the slot does not exist.
+func Get(ctx context.Context, txn isql.Txn, name string) (Slot, error) {
+	row, err := txn.QueryRowEx(
+		ctx,
+		"select-replication-slot",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT slot_name, plugin, database_id, created, confirmed_flush_lsn, protected_timestamp_record
+       FROM system.replication_slots WHERE slot_name = $1`,
+		name,
+	)
+	if err != nil {
+		return Slot{}, err
+	}
+	if row == nil {
+		return Slot{}, notFoundError(name)
+	}
+	return slotFromRow(row)
+}
+
+// List returns all replication slots, ordered by name.
+func List(ctx context.Context, txn isql.Txn) ([]Slot, error) {
+	rows, err := txn.QueryBufferedEx(
+		ctx,
+		"list-replication-slots",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT slot_name, plugin, database_id, created, confirmed_flush_lsn, protected_timestamp_record
+       FROM system.replication_slots ORDER BY slot_name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]Slot, 0, len(rows))
+	for _, row := range rows {
+		slot, err := slotFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// Drop removes the replication slot with the given name and releases its
+// protected timestamp record. An UndefinedObject error is returned if the slot
+// does not exist.
+func Drop(ctx context.Context, txn isql.Txn, pts protectedts.Manager, name string) error {
+	row, err := txn.QueryRowEx(
+		ctx,
+		"delete-replication-slot",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE slot_name = $1 RETURNING protected_timestamp_record`,
+		name,
+	)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return notFoundError(name)
+	}
+	if row[0] == tree.DNull {
+		return nil
+	}
+	recordID := tree.MustBeDUuid(row[0]).UUID
+	if err := pts.WithTxn(txn).Release(ctx, recordID); err != nil &&
+		!errors.Is(err, protectedts.ErrNotExists) {
+		return err
+	}
+	return nil
+}
+
+// Advance moves the confirmed position of the replication slot forward to the
+// given LSN, and moves its protected timestamp along with it. Positions at or
+// before the current confirmed position are ignored.
+func Advance(
+	ctx context.Context, txn isql.Txn, pts protectedts.Manager, name string, to lsn.LSN,
+) error {
+	slot, err := Get(ctx, txn, name)
+	if err != nil {
+		return err
+	}
+	if to <= slot.ConfirmedFlushLSN {
+		return nil
+	}
+	if _, err := txn.ExecEx(
+		ctx,
+		"advance-replication-slot",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`UPDATE system.replication_slots SET confirmed_flush_lsn = $2 WHERE slot_name = $1`,
+		name,
+		tree.NewDPGLSN(to),
+	); err != nil {
+		return err
+	}
+	if slot.ProtectedTimestampRecord == uuid.Nil {
+		return nil
+	}
+	return pts.WithTxn(txn).UpdateTimestamp(ctx, slot.ProtectedTimestampRecord, lsnutil.LSNToHLC(to))
+}
+
+// MakeStatusFunc returns a function which determines whether the replication
+// slot implied with this value of meta has been dropped, in which case its
+// protected timestamp record should be removed by the reconciler.
+func MakeStatusFunc() ptreconcile.StatusFunc {
+	return func(ctx context.Context, txn isql.Txn, meta []byte) (shouldRemove bool, _ error) {
+		row, err := txn.QueryRowEx(ctx, "check-for-dropped-replication-slot", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT EXISTS (SELECT 1 FROM system.replication_slots WHERE slot_name = $1)`, string(meta))
+		if err != nil {
+			return false, err
+		}
+		if row == nil {
+			return false, errors.AssertionFailedf("no row returned when checking for a dropped replication slot")
+		}
+		return !bool(tree.MustBeDBool(row[0])), nil
+	}
+}
+
+func makeRecord(slot Slot) *ptpb.Record {
+	return &ptpb.Record{
+		ID:        slot.ProtectedTimestampRecord.GetBytesMut(),
+		Timestamp: lsnutil.LSNToHLC(slot.ConfirmedFlushLSN),
+		Mode:      ptpb.PROTECT_AFTER,
+		MetaType:  MetaType,
+		Meta:      []byte(slot.Name),
+		Target:    ptpb.MakeSchemaObjectsTarget(descpb.IDs{slot.DatabaseID}),
+	}
+}
+
+func slotFromRow(row tree.Datums) (Slot, error) {
+	if len(row) != 6 {
+		return Slot{}, errors.AssertionFailedf("unexpected number of columns in replication slot row: %d", len(row))
+	}
+	slot := Slot{
+		Name:              string(tree.MustBeDString(row[0])),
+		Plugin:            string(tree.MustBeDString(row[1])),
+		DatabaseID:        descpb.ID(tree.MustBeDInt(row[2])),
+		Created:           tree.MustBeDTimestampTZ(row[3]).Time,
+		ConfirmedFlushLSN: tree.MustBeDPGLSN(row[4]).LSN,
+	}
+	if row[5] != tree.DNull {
+		slot.ProtectedTimestampRecord = tree.MustBeDUuid(row[5]).UUID
+	}
+	return slot, nil
+}
+
+func notFoundError(name string) error {
+	return pgerror.Newf(pgcode.UndefinedObject, "replication slot %q does not exist", name)
+}
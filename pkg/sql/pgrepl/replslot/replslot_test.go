@@ -0,0 +1,41 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package replslot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateName(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		name string
+		code pgcode.Code
+	}{
+		{name: "slot_1"},
+		{name: strings.Repeat("a", MaxNameLength)},
+		{name: "", code: pgcode.InvalidName},
+		{name: strings.Repeat("a", MaxNameLength+1), code: pgcode.NameTooLong},
+		{name: "Slot", code: pgcode.InvalidName},
+		{name: "my-slot", code: pgcode.InvalidName},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateName(tc.name)
+			if tc.code == (pgcode.Code{}) {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, tc.code, pgerror.GetPGCode(err))
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/listennotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -54,6 +55,7 @@ type commandResult struct {
 	// buffer contains items that are sent before the connection is closed.
 	buffer struct {
 		notices            []pgnotice.Notice
+		notifications      []listennotify.Notification
 		paramStatusUpdates []paramStatusUpdate
 	}
 
@@ -143,6 +145,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, n := range r.buffer.notifications {
+		if err := r.conn.bufferNotification(n); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err when sending notification"))
+		}
+	}
+
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
@@ -327,6 +335,11 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.NotificationResult interface.
+func (r *commandResult) BufferNotification(n listennotify.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, n)
+}
+
 // SendNotice is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SendNotice(
 	ctx context.Context, notice pgnotice.Notice, immediateFlush bool,
@@ -394,6 +407,16 @@ func (r *commandResult) SendCopyOut(
 	return r.conn.bufferCopyOut(cols, format)
 }
 
+// SendCopyBoth is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendCopyBoth(ctx context.Context) error {
+	r.assertNotReleased()
+	r.conn.writerState.fi.registerCmd(r.pos)
+	if err := r.conn.bufferCopyBoth(); err != nil {
+		return err
+	}
+	return r.conn.Flush(r.pos)
+}
+
 // SendCopyData is part of the sql.CopyOutResult interface.
 func (r *commandResult) SendCopyData(ctx context.Context, copyData []byte, isHeader bool) error {
 	if err := r.beforeAdd(); err != nil {
@@ -644,6 +667,11 @@ func (r *limitedCommandResult) moreResultsNeeded(ctx context.Context) error {
 			if err := r.conn.Flush(r.pos); err != nil {
 				return err
 			}
+		case sql.DeliverNotifications:
+			// Notifications are only delivered between transactions, and a
+			// transaction is open while the portal is suspended. The notifications
+			// stay queued until the next Sync outside of a transaction.
+			r.conn.stmtBuf.AdvanceOne()
 		default:
 			// If the portal is immediately followed by a COMMIT, we can proceed and
 			// let the portal be destroyed at the end of the transaction.
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/listennotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgreplparser"
@@ -41,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/ring"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/crlib/crtime"
 	"github.com/cockroachdb/errors"
@@ -105,6 +107,11 @@ type conn struct {
 	readBuf    pgwirebase.ReadBuffer
 	msgBuilder writeBuffer
 
+	// replicationInput receives the Copy-both messages sent by the client
+	// while a START_REPLICATION stream is running. It is only accessed by the
+	// network routine.
+	replicationInput *sql.ReplicationInput
+
 	// vecsScratch is a scratch space used by bufferBatch.
 	vecsScratch coldata.TypedVecs
 
@@ -248,6 +255,18 @@ func (c *conn) processCommands(
 	// using the defer above.
 	authOK = true
 
+	// Notifications for the channels the session is listening on can arrive
+	// at any time. Let the command processor know about them, so that they
+	// are sent to the client even if it is idle.
+	if notificationsReady := connHandler.NotificationsReady(); notificationsReady != nil {
+		stopper := sqlServer.GetExecutorConfig().Stopper
+		if retErr = stopper.RunAsyncTask(ctx, "pgwire-notifications", func(ctx context.Context) {
+			c.pushNotificationDeliveries(ctx, stopper, notificationsReady)
+		}); retErr != nil {
+			return
+		}
+	}
+
 	// Now actually process commands.
 	reservedOwned = false // We're about to pass ownership away.
 	retErr = sqlServer.ServeConn(
@@ -270,6 +289,35 @@ func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) erro
 	return c.writeErrFields(ctx, noticeErr, &c.writerState.buf)
 }
 
+// pushNotificationDeliveries pushes a DeliverNotifications command into the
+// statement buffer whenever notificationsReady is signaled, until the
+// connection is closed.
+func (c *conn) pushNotificationDeliveries(
+	ctx context.Context, stopper *stop.Stopper, notificationsReady <-chan struct{},
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stopper.ShouldQuiesce():
+			return
+		case <-notificationsReady:
+			if err := c.stmtBuf.Push(ctx, sql.DeliverNotifications{}); err != nil {
+				// The statement buffer has been closed.
+				return
+			}
+		}
+	}
+}
+
+func (c *conn) bufferNotification(n listennotify.Notification) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(n.SenderPID)
+	c.msgBuilder.writeTerminatedString(n.Channel)
+	c.msgBuilder.writeTerminatedString(n.Payload)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) sendInitialConnData(
 	ctx context.Context,
 	sqlServer *sql.Server,
@@ -360,8 +408,24 @@ func (c *conn) handleSimpleQuery(
 			log.SqlExec.Infof(ctx, "could not parse simple query in replication protocol: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 		}
-		switch stmt.AST.(type) {
-		case *pgrepltree.IdentifySystem:
+		switch ast := stmt.AST.(type) {
+		case *pgrepltree.IdentifySystem,
+			*pgrepltree.CreateReplicationSlot,
+			*pgrepltree.DropReplicationSlot,
+			*pgrepltree.ReadReplicationSlot:
+		case *pgrepltree.StartReplication:
+			// The stream runs in the connExecutor, while this network routine
+			// keeps reading from the connection and routes the Copy-both messages
+			// sent by the client to the stream through replicationInput.
+			c.replicationInput = sql.NewReplicationInput()
+			return c.stmtBuf.Push(ctx, sql.StartReplication{
+				ParsedStmt:   stmt,
+				Stmt:         ast,
+				Input:        c.replicationInput,
+				TimeReceived: timeReceived,
+				ParseStart:   startParse,
+				ParseEnd:     crtime.NowMono(),
+			})
 		default:
 			log.SqlExec.Infof(ctx, "unhandled replication protocol query: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{
@@ -798,6 +862,23 @@ func (c *conn) handleFlush(ctx context.Context) error {
 	return c.stmtBuf.Push(ctx, sql.Flush{})
 }
 
+// handleReplicationInput routes a Copy-both message sent by the client during a
+// START_REPLICATION stream to the stream.
+func (c *conn) handleReplicationInput(ctx context.Context, typ pgwirebase.ClientMessageType) error {
+	switch typ {
+	case pgwirebase.ClientMsgCopyData:
+		return c.replicationInput.Push(ctx, c.readBuf.Msg)
+	case pgwirebase.ClientMsgCopyDone:
+		c.replicationInput.Close(nil /* err */)
+	case pgwirebase.ClientMsgCopyFail:
+		c.replicationInput.Close(pgerror.Newf(
+			pgcode.QueryCanceled, "START_REPLICATION failed: %s", string(c.readBuf.Msg),
+		))
+	}
+	c.replicationInput = nil
+	return nil
+}
+
 // BeginCopyIn is part of the pgwirebase.Conn interface.
 func (c *conn) BeginCopyIn(
 	ctx context.Context, columns []colinfo.ResultColumn, format pgwirebase.FormatCode,
@@ -874,6 +955,9 @@ func cookTag(
 			tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
 		}
 
+	case tree.Replication:
+		// Replication commands are completed with their bare tag.
+
 	default:
 		panic(errors.AssertionFailedf("unexpected result type %v", stmtType))
 	}
@@ -1175,6 +1259,14 @@ func (c *conn) bufferCopyOut(columns []colinfo.ResultColumn, format pgwirebase.F
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
 }
 
+func (c *conn) bufferCopyBoth() error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	// Replication streams use the textual format and have no columns.
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(0)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) bufferCopyData(copyData []byte, res *commandResult) error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDataCommand)
 	if _, err := c.msgBuilder.Write(copyData); err != nil {
@@ -1365,6 +1457,11 @@ func (c *conn) CreateDrainResult(pos sql.CmdPos) sql.DrainResult {
 	return c.newMiscResult(pos, noCompletionMsg)
 }
 
+// CreateDeliverNotificationsResult is part of the sql.ClientComm interface.
+func (c *conn) CreateDeliverNotificationsResult(pos sql.CmdPos) sql.DeliverNotificationsResult {
+	return c.newMiscResult(pos, flush)
+}
+
 // CreateBindResult is part of the sql.ClientComm interface.
 func (c *conn) CreateBindResult(pos sql.CmdPos) sql.BindResult {
 	return c.newMiscResult(pos, bindComplete)
@@ -1413,6 +1510,16 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
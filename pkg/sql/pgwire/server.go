@@ -1405,6 +1405,9 @@ func (s *Server) serveImpl(
 				return false, isSimpleQuery, c.handleFlush(ctx)
 
 			case pgwirebase.ClientMsgCopyData, pgwirebase.ClientMsgCopyDone, pgwirebase.ClientMsgCopyFail:
+				if c.replicationInput != nil {
+					return false, isSimpleQuery, c.handleReplicationInput(ctx, typ)
+				}
 				// We're supposed to ignore these messages, per the protocol spec. This
 				// state will happen when an error occurs on the server-side during a copy
 				// operation: the server will send an error and a ready message back to
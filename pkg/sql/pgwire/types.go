@@ -55,6 +55,11 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// Like Postgres, report values of a domain type with the base type of the
+	// domain.
+	if base := t.DomainBaseType(); base != nil {
+		t = base
+	}
 	size := tree.PGWireTypeSize(t)
 	tOid := t.Oid()
 	if tOid == oid.T_text && t.Width() > 0 {
@@ -195,10 +195,12 @@ var _ planNode = &cancelQueriesNode{}
 var _ planNode = &cancelSessionsNode{}
 var _ planNode = &changeDescriptorBackedPrivilegesNode{}
 var _ planNode = &completionsNode{}
+var _ planNode = &createAggregateNode{}
 var _ planNode = &createDatabaseNode{}
 var _ planNode = &createFunctionNode{}
 var _ planNode = &createIndexNode{}
 var _ planNode = &createSequenceNode{}
+var _ planNode = &createServerNode{}
 var _ planNode = &createStatsNode{}
 var _ planNode = &createTableNode{}
 var _ planNode = &createTypeNode{}
@@ -213,6 +215,7 @@ var _ planNode = &dropDatabaseNode{}
 var _ planNode = &dropIndexNode{}
 var _ planNode = &dropSchemaNode{}
 var _ planNode = &dropSequenceNode{}
+var _ planNode = &dropServerNode{}
 var _ planNode = &dropTableNode{}
 var _ planNode = &dropTypeNode{}
 var _ planNode = &DropRoleNode{}
@@ -281,6 +284,7 @@ var _ planNodeReadingOwnWrites = &alterSchemaNode{}
 var _ planNodeReadingOwnWrites = &alterSequenceNode{}
 var _ planNodeReadingOwnWrites = &alterTableNode{}
 var _ planNodeReadingOwnWrites = &alterTypeNode{}
+var _ planNodeReadingOwnWrites = &createAggregateNode{}
 var _ planNodeReadingOwnWrites = &createFunctionNode{}
 var _ planNodeReadingOwnWrites = &createIndexNode{}
 var _ planNodeReadingOwnWrites = &createSequenceNode{}
@@ -486,6 +490,9 @@ type postQueryMetadata struct {
 	// plan for the cascade/triggers. This plan is not populated upfront; it is
 	// created only when it needs to run, after the main query.
 	plan planMaybePhysical
+	// subqueryPlans contains the subqueries of the plan, which are run before
+	// it. They are only used to materialize With bindings built for triggers.
+	subqueryPlans []subquery
 }
 
 // checkPlan is a query tree that is executed after the main one. It can only
@@ -502,12 +509,18 @@ func (p *planComponents) close(ctx context.Context) {
 	}
 	for i := range p.cascades {
 		p.cascades[i].plan.Close(ctx)
+		for j := range p.cascades[i].subqueryPlans {
+			p.cascades[i].subqueryPlans[j].plan.Close(ctx)
+		}
 	}
 	for i := range p.checkPlans {
 		p.checkPlans[i].plan.Close(ctx)
 	}
 	for i := range p.triggers {
 		p.triggers[i].plan.Close(ctx)
+		for j := range p.triggers[i].subqueryPlans {
+			p.triggers[i].subqueryPlans[j].plan.Close(ctx)
+		}
 	}
 }
 
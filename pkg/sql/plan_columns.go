@@ -183,6 +183,10 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 
 	case *identifySystemNode:
 		return n.getColumns(mut, colinfo.IdentifySystemColumns)
+	case *createReplicationSlotNode:
+		return n.getColumns(mut, colinfo.CreateReplicationSlotColumns)
+	case *readReplicationSlotNode:
+		return n.getColumns(mut, colinfo.ReadReplicationSlotColumns)
 	}
 
 	// Every other node has no columns in their results.
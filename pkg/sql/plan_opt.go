@@ -80,7 +80,7 @@ func (p *planner) prepareUsingOptimizer(
 		*tree.RenameIndex, *tree.RenameTable, *tree.Revoke, *tree.RevokeRole,
 		*tree.RollbackPrepared, *tree.RollbackToSavepoint, *tree.RollbackTransaction,
 		*tree.Savepoint, *tree.SetTransaction, *tree.SetTracing, *tree.SetSessionAuthorizationDefault,
-		*tree.SetSessionCharacteristics:
+		*tree.SetSessionCharacteristics, *tree.SetConstraints:
 		// These statements do not have result columns and do not support placeholders
 		// so there is no need to do anything during prepare.
 		//
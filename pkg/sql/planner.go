@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/evalcatalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
+	"github.com/cockroachdb/cockroach/pkg/sql/listennotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/prep"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -100,6 +101,11 @@ type extendedEvalContext struct {
 	// jobs refers to jobs in extraTxnState.
 	jobs *txnJobsCollection
 
+	// deferredConstraints refers to deferredConstraints in extraTxnState. It
+	// is nil if the planner does not own the transaction, in which case all
+	// constraints are checked immediately.
+	deferredConstraints *txnDeferredConstraints
+
 	statsProvider *persistedsqlstats.PersistedSQLStats
 
 	localStatsProvider *sslocal.SQLStats
@@ -240,6 +246,10 @@ type planner struct {
 
 	createdSequences createdSequences
 
+	// notificationListener tracks the channels the session is listening on. It
+	// is nil for internal planners.
+	notificationListener *listennotify.Listener
+
 	// autoCommit indicates whether the plan is allowed (but not required) to
 	// commit the transaction along with other KV operations. Committing the txn
 	// might be beneficial because it may enable the 1PC optimization. Note that
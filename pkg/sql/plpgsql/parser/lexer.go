@@ -8,6 +8,7 @@ package parser
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -190,61 +191,89 @@ func (l *lexer) MakeExecSqlStmt() (*plpgsqltree.Execute, error) {
 	}, nil
 }
 
+// MakeDynamicExecuteStmt reads a dynamic EXECUTE statement, starting after the
+// EXECUTE keyword. The INTO and USING clauses can be specified in either order.
+// Syntax:
+//
+//	EXECUTE command_string [ INTO [ STRICT ] target ] [ USING expression [, ...] ];
 func (l *lexer) MakeDynamicExecuteStmt() (*plpgsqltree.DynamicExecute, error) {
-	cmdStr, _, err := l.ReadSqlStatement(INTO, USING, ';')
+	queryStr, terminator, err := l.ReadSqlExpr(INTO, USING, ';')
 	if err != nil {
 		return nil, err
 	}
-	ret := &plpgsqltree.DynamicExecute{
-		Query: cmdStr,
+	query, err := l.ParseExpr(queryStr)
+	if err != nil {
+		return nil, err
 	}
-
-	var lval plpgsqlSymType
-	l.Lex(&lval)
-	for {
-		if lval.id == INTO {
-			if ret.Into {
+	ret := &plpgsqltree.DynamicExecute{Query: query}
+	var sawInto, sawUsing bool
+	for terminator != ';' {
+		switch terminator {
+		case INTO:
+			if sawInto {
 				return nil, errors.New("multiple INTO keywords")
 			}
-			ret.Into = true
-			nextTok := l.Peek()
-			if nextTok.id == int32(STRICT) {
-				l.Lex(&lval)
+			sawInto = true
+			// Move past the INTO keyword.
+			l.lastPos++
+			if l.Peek().id == STRICT {
+				l.lastPos++
 				ret.Strict = true
 			}
-			// TODO we need to read each "INTO" variable name instead of just a
-			// string.
-			_, _, err = l.ReadSqlExpr(USING, ';')
+			var startPos, endPos, targetEnd int
+			startPos, endPos, terminator, err = l.readSQLConstruct(
+				true /* isExpr */, false /* allowEmpty */, USING, ';',
+			)
+			if err != nil {
+				return nil, err
+			}
+			ret.Target, targetEnd, err = l.readTarget(startPos, endPos)
 			if err != nil {
 				return nil, err
 			}
-			l.Lex(&lval)
-		} else if lval.id == USING {
-			if ret.Params != nil {
+			if targetEnd != endPos {
+				return nil, errors.Newf("expected INTO target to be a comma-separated list")
+			}
+		case USING:
+			if sawUsing {
 				return nil, errors.New("multiple USING keywords")
 			}
-			ret.Params = make([]plpgsqltree.Expr, 0)
-			for {
-				_, _, err = l.ReadSqlExpr(',', ';', INTO)
+			sawUsing = true
+			for terminator == USING || terminator == ',' {
+				// Move past the USING keyword or comma.
+				l.lastPos++
+				var paramStr string
+				paramStr, terminator, err = l.ReadSqlExpr(',', INTO, ';')
 				if err != nil {
 					return nil, err
 				}
-				ret.Params = append(ret.Params, nil)
-				l.Lex(&lval)
-				if lval.id == ';' {
-					break
+				param, err := l.ParseExpr(paramStr)
+				if err != nil {
+					return nil, err
 				}
+				ret.Params = append(ret.Params, param)
 			}
-		} else if lval.id == ';' {
-			break
-		} else {
-			return nil, errors.Newf("unexpected token: %s", lval.id)
+		default:
+			return nil, errors.New("unexpected end of EXECUTE statement")
 		}
 	}
-
+	// Move past the semicolon.
+	l.lastPos++
 	return ret, nil
 }
 
+// ParseReturnQueryExecute reads the RETURN QUERY EXECUTE statement, starting
+// at the EXECUTE keyword. The terminating semicolon is not consumed.
+func (l *lexer) ParseReturnQueryExecute() (plpgsqltree.Statement, error) {
+	// Move past the EXECUTE keyword.
+	l.Advance(1)
+	query, params, err := l.readDynamicQuery(';')
+	if err != nil {
+		return nil, err
+	}
+	return &plpgsqltree.ReturnQuery{DynamicQuery: query, Params: params}, nil
+}
+
 func (l *lexer) MakeFetchOrMoveStmt(isMove bool) (plpgsqltree.Statement, error) {
 	if l.parser.Lookahead() != -1 {
 		// Push back the lookahead token so that it can be included.
@@ -364,6 +393,178 @@ func (l *lexer) ReadIntegerForLoopControl() (plpgsqltree.ForLoopControl, error)
 	}, err
 }
 
+// ReadQueryOrCursorForLoopControl reads the loop control statement for a loop
+// over the rows of a query, a bound cursor, or a dynamic query. Syntax:
+//
+//	query LOOP
+//	cursor_var [ ( arg_expression [, ...] ) ] LOOP
+//	EXECUTE query_expression [ USING expression [, ...] ] LOOP
+func (l *lexer) ReadQueryOrCursorForLoopControl() (plpgsqltree.ForLoopControl, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	tok := l.Peek()
+	if tok.id == EXECUTE {
+		l.lastPos++
+		query, params, err := l.ReadDynamicQuery(LOOP)
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.DynamicForLoopControl{Query: query, Params: params}, nil
+	}
+	if tok.id == IDENT && l.lastPos+2 < len(l.tokens) {
+		// A single identifier, optionally followed by a list of arguments, is a
+		// loop over a bound cursor. Make sure not to mistake a VALUES or TABLE
+		// query for a cursor.
+		nextTok := l.tokens[l.lastPos+2]
+		switch strings.ToLower(tok.str) {
+		case "select", "values", "table", "with":
+		default:
+			if nextTok.id == LOOP || nextTok.id == '(' {
+				curVar := plpgsqltree.Variable(strings.TrimSpace(l.getStr(l.lastPos+1, l.lastPos+2)))
+				l.lastPos++
+				var args []plpgsqltree.Expr
+				if nextTok.id == '(' {
+					// Move past the opening parenthesis.
+					l.lastPos++
+					var err error
+					if args, err = l.ReadCursorArgs(); err != nil {
+						return nil, err
+					}
+					// Move past the closing parenthesis.
+					l.lastPos++
+				}
+				if l.Peek().id != LOOP {
+					return nil, errors.New("missing LOOP keyword")
+				}
+				l.lastPos++
+				return &plpgsqltree.CursorForLoopControl{CurVar: curVar, Args: args}, nil
+			}
+		}
+	}
+	sqlStr, terminator, err := l.ReadSqlStatement(LOOP)
+	if err != nil {
+		return nil, err
+	}
+	if terminator == 0 {
+		return nil, errors.New("missing LOOP keyword")
+	}
+	l.lastPos++
+	stmt, err := parser.ParseOne(sqlStr)
+	if err != nil {
+		return nil, err
+	}
+	ann := tree.MakeAnnotations(stmt.NumAnnotations)
+	return &plpgsqltree.QueryForLoopControl{
+		Query:       stmt.AST,
+		Annotations: &ann,
+	}, nil
+}
+
+// ReadDynamicQuery reads the query string expression and parameters of a
+// dynamic query, and moves past the given terminator. Syntax:
+//
+//	query_expression [ USING expression [, ...] ] terminator
+func (l *lexer) ReadDynamicQuery(
+	terminator int,
+) (query plpgsqltree.Expr, params []plpgsqltree.Expr, err error) {
+	if query, params, err = l.readDynamicQuery(terminator); err != nil {
+		return nil, nil, err
+	}
+	// Move past the terminator.
+	l.lastPos++
+	return query, params, nil
+}
+
+// readDynamicQuery is similar to ReadDynamicQuery, but does not move past the
+// terminator.
+func (l *lexer) readDynamicQuery(
+	terminator int,
+) (query plpgsqltree.Expr, params []plpgsqltree.Expr, err error) {
+	queryStr, terminatorMet, err := l.ReadSqlExpr(terminator, USING)
+	if err != nil {
+		return nil, nil, err
+	}
+	if query, err = l.ParseExpr(queryStr); err != nil {
+		return nil, nil, err
+	}
+	for terminatorMet == USING || terminatorMet == ',' {
+		// Move past the USING keyword or comma.
+		l.lastPos++
+		var paramStr string
+		paramStr, terminatorMet, err = l.ReadSqlExpr(terminator, ',')
+		if err != nil {
+			return nil, nil, err
+		}
+		param, err := l.ParseExpr(paramStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		params = append(params, param)
+	}
+	if terminatorMet != terminator {
+		return nil, nil, errors.New("unexpected end of dynamic query")
+	}
+	return query, params, nil
+}
+
+// ReadCursorArgs reads a comma-separated list of cursor argument expressions,
+// stopping before the closing parenthesis.
+func (l *lexer) ReadCursorArgs() ([]plpgsqltree.Expr, error) {
+	var args []plpgsqltree.Expr
+	for {
+		argStr, terminator, err := l.ReadSqlExpr(',', ')')
+		if err != nil {
+			return nil, err
+		}
+		arg, err := l.ParseExpr(argStr)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if terminator != ',' {
+			if terminator == 0 {
+				return nil, errors.New("missing \")\" after cursor arguments")
+			}
+			return args, nil
+		}
+		// Move past the comma.
+		l.lastPos++
+	}
+}
+
+// ReadDeclDataType reads and parses the data type of a variable or cursor
+// argument declaration, stopping before one of the given terminators.
+func (l *lexer) ReadDeclDataType(
+	terminator1 int, terminators ...int,
+) (tree.ResolvableTypeReference, error) {
+	sqlStr, _, err := l.ReadSqlExpr(terminator1, terminators...)
+	if err != nil {
+		return nil, err
+	}
+	// This is an inlined version of GetTypeFromValidSQLSyntax which doesn't
+	// return an assertion failure.
+	castExpr, err := l.ParseExpr("1::" + sqlStr)
+	if err != nil {
+		return nil, errors.New("unable to parse type of variable declaration")
+	}
+	switch t := castExpr.(type) {
+	case *tree.CollateExpr:
+		return types.MakeCollatedString(types.String, t.Locale), nil
+	case *tree.CastExpr:
+		return t.Type, nil
+	default:
+		err := errors.New("unable to parse type of variable declaration")
+		if strings.Contains(sqlStr, "%") {
+			err = errors.WithIssueLink(errors.WithHint(err,
+				"you may have attempted to use %TYPE or %ROWTYPE syntax, which is unsupported.",
+			), errors.IssueLink{IssueURL: build.MakeIssueURL(114676)})
+		}
+		return nil, err
+	}
+}
+
 // makeDoStmt analyzes and parses the options supplied to a DO statement.
 func makeDoStmt(options tree.DoBlockOptions) (*plpgsqltree.DoBlock, error) {
 	doBlockBodyStr, err := tree.AnalyzeDoBlockOptions(options)
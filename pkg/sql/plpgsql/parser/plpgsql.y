@@ -2,15 +2,11 @@
 package parser
 
 import (
-  "strings"
-
-  "github.com/cockroachdb/cockroach/pkg/build"
   "github.com/cockroachdb/cockroach/pkg/sql/parser"
   "github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
   "github.com/cockroachdb/cockroach/pkg/sql/scanner"
   "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
   "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
-  "github.com/cockroachdb/cockroach/pkg/sql/types"
   "github.com/cockroachdb/errors"
   "github.com/cockroachdb/redact"
 )
@@ -189,6 +185,14 @@ func (u *plpgsqlSymUnion) forLoopControl() plpgsqltree.ForLoopControl {
 		return u.val.(plpgsqltree.ForLoopControl)
 }
 
+func (u *plpgsqlSymUnion) cursorArg() plpgsqltree.CursorArg {
+    return u.val.(plpgsqltree.CursorArg)
+}
+
+func (u *plpgsqlSymUnion) cursorArgs() []plpgsqltree.CursorArg {
+    return u.val.([]plpgsqltree.CursorArg)
+}
+
 func (u *plpgsqlSymUnion) doBlockOptions() tree.DoBlockOptions {
     return u.val.(tree.DoBlockOptions)
 }
@@ -345,7 +349,9 @@ func (u *plpgsqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <str> decl_varname decl_defkey
 %type <bool> decl_const decl_notnull
 %type <plpgsqltree.Expr>	decl_defval decl_cursor_query
-%type <tree.ResolvableTypeReference>	decl_datatype
+%type <tree.ResolvableTypeReference>	decl_datatype decl_cursor_argtype
+%type <plpgsqltree.CursorArg>	decl_cursor_arg
+%type <[]plpgsqltree.CursorArg>	decl_cursor_args decl_cursor_arglist
 %type <str>	decl_collate
 
 %type <str>	expr_until_semi expr_until_paren stmt_until_semi
@@ -395,7 +401,7 @@ func (u *plpgsqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <*plpgsqltree.RaiseOption> option_expr
 %type <[]plpgsqltree.RaiseOption> option_exprs opt_option_exprs
 %type <plpgsqltree.Expr> format_expr
-%type <[]plpgsqltree.Expr> opt_format_exprs format_exprs
+%type <[]plpgsqltree.Expr> opt_format_exprs format_exprs opt_cursor_args cursor_args
 
 %type <tree.CursorScrollOption>	opt_scrollable
 
@@ -488,6 +494,7 @@ decl_statement: decl_varname decl_const decl_datatype decl_collate decl_notnull
     $$.val = &plpgsqltree.CursorDeclaration{
       Name: plpgsqltree.Variable($1),
       Scroll: $2.cursorScrollOption(),
+      Args: $4.cursorArgs(),
       Query: $6.sqlStatement(),
       Annotations: &ann,
     }
@@ -521,25 +528,43 @@ decl_cursor_query: stmt_until_semi ';'
   }
 ;
 
-decl_cursor_args: '('
+decl_cursor_args: '(' decl_cursor_arglist ')'
   {
-    return unimplemented(plpgsqllex, "cursor arguments")
+    $$.val = $2.cursorArgs()
   }
 | /* EMPTY */
   {
+    $$.val = []plpgsqltree.CursorArg(nil)
   }
 ;
 
 decl_cursor_arglist: decl_cursor_arg
   {
+    $$.val = []plpgsqltree.CursorArg{$1.cursorArg()}
   }
 | decl_cursor_arglist ',' decl_cursor_arg
   {
+    $$.val = append($1.cursorArgs(), $3.cursorArg())
   }
 ;
 
-decl_cursor_arg: decl_varname decl_datatype
+decl_cursor_arg: decl_varname decl_cursor_argtype
   {
+    $$.val = plpgsqltree.CursorArg{
+      Name: plpgsqltree.Variable($1),
+      Typ: $2.typ(),
+    }
+  }
+;
+
+decl_cursor_argtype:
+  {
+    // Read until reaching the end of the cursor argument.
+    typ, err := plpgsqllex.(*lexer).ReadDeclDataType(',', ')')
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = typ
   }
 ;
 
@@ -573,32 +598,13 @@ decl_datatype:
   {
     // Read until reaching one of the tokens that can follow a declaration
     // data type.
-    sqlStr, _, err := plpgsqllex.(*lexer).ReadSqlExpr(
+    typ, err := plpgsqllex.(*lexer).ReadDeclDataType(
       ';', COLLATE, NOT, '=', COLON_EQUALS, DECLARE,
     )
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    // This is an inlined version of GetTypeFromValidSQLSyntax which doesn't
-    // return an assertion failure.
-    castExpr, err := plpgsqllex.(*lexer).ParseExpr("1::" + sqlStr)
-    if err != nil {
-      return setErr(plpgsqllex, errors.New("unable to parse type of variable declaration"))
-    }
-    switch t := castExpr.(type) {
-    case *tree.CollateExpr:
-      $$.val = types.MakeCollatedString(types.String, t.Locale)
-    case *tree.CastExpr:
-      $$.val = t.Type
-    default:
-      err := errors.New("unable to parse type of variable declaration")
-      if strings.Contains(sqlStr, "%") {
-        err = errors.WithIssueLink(errors.WithHint(err,
-          "you may have attempted to use %TYPE or %ROWTYPE syntax, which is unsupported.",
-        ), errors.IssueLink{IssueURL: build.MakeIssueURL(114676)})
-      }
-      return setErr(plpgsqllex, err)
-    }
+    $$.val = typ
   }
 ;
 
@@ -779,7 +785,17 @@ proc_stmt:pl_block ';'
 
 stmt_perform: PERFORM stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "perform")
+    // PERFORM executes a query and discards the result. The query is written
+    // as a SELECT statement with the SELECT keyword replaced by PERFORM.
+    stmt, err := parser.ParseOne("SELECT " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    ann := tree.MakeAnnotations(stmt.NumAnnotations)
+    $$.val = &plpgsqltree.Perform{
+      SqlStmt: stmt.AST,
+      Annotations: &ann,
+    }
   }
 ;
 
@@ -1114,7 +1130,12 @@ for_control:
 	    }
 	    $$.val = forLoopControl
 	  case LOOP:
-	    return unimplemented(plpgsqllex, "for loop over query or cursor")
+	    // This is an iteration over the rows of a query or cursor.
+	    forLoopControl, err := plpgsqllex.(*lexer).ReadQueryOrCursorForLoopControl()
+	    if err != nil {
+	      return setErr(plpgsqllex, err)
+	    }
+	    $$.val = forLoopControl
 	  default:
 	    return setErr(plpgsqllex, errors.New("unterminated FOR loop definition"))
 	  }
@@ -1131,17 +1152,41 @@ for_target:
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH
+stmt_foreach_a: opt_loop_label FOREACH for_target foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    loopLabel, loopEndLabel := $1, $10
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    var slice int64
+    if sliceVal := $4.numVal(); sliceVal != nil {
+      var err error
+      slice, err = sliceVal.AsInt64()
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+    }
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForEachArray{
+      Label: loopLabel,
+      Target: $3.variables(),
+      Slice: int(slice),
+      Expr: expr,
+      Body: $9.statements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = (*tree.NumVal)(nil)
   }
 | SLICE ICONST
   {
+    $$.val = $2
   }
 ;
 
@@ -1189,13 +1234,13 @@ return_expr:
 
 return_query:
   {
+    var retQuery plpgsqltree.Statement
+    var err error
     if plpgsqllex.(*lexer).peekForExecute() {
-      // Advance the lexer by one token so that the error correctly points to
-      // the EXECUTE keyword.
-      plpgsqllex.(*lexer).Advance(1)
-      return unimplemented (plpgsqllex, "return dynamic sql query")
+      retQuery, err = plpgsqllex.(*lexer).ParseReturnQueryExecute()
+    } else {
+      retQuery, err = plpgsqllex.(*lexer).ParseReturnQuery()
     }
-    retQuery, err := plpgsqllex.(*lexer).ParseReturnQuery()
     if err != nil {
       return setErr(plpgsqllex, err)
     }
@@ -1402,13 +1447,25 @@ stmt_dynexecute: EXECUTE
   }
 ;
 
-stmt_open: OPEN IDENT ';'
+stmt_open: OPEN IDENT opt_cursor_args ';'
   {
-    $$.val = &plpgsqltree.Open{CurVar: plpgsqltree.Variable($2)}
+    $$.val = &plpgsqltree.Open{
+      CurVar: plpgsqltree.Variable($2),
+      Args: $3.exprs(),
+    }
   }
-| OPEN IDENT opt_scrollable FOR EXECUTE 
+| OPEN IDENT opt_scrollable FOR EXECUTE
   {
-    return unimplemented(plpgsqllex, "cursor for execute")
+    query, params, err := plpgsqllex.(*lexer).ReadDynamicQuery(';')
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Open{
+      CurVar: plpgsqltree.Variable($2),
+      Scroll: $3.cursorScrollOption(),
+      DynamicQuery: query,
+      Params: params,
+    }
   }
 | OPEN IDENT opt_scrollable FOR stmt_until_semi ';'
   {
@@ -1429,6 +1486,26 @@ stmt_open: OPEN IDENT ';'
   }
 ;
 
+opt_cursor_args: '(' cursor_args ')'
+  {
+    $$.val = $2.exprs()
+  }
+| /* EMPTY */
+  {
+    $$.val = []plpgsqltree.Expr(nil)
+  }
+;
+
+cursor_args:
+  {
+    args, err := plpgsqllex.(*lexer).ReadCursorArgs()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = args
+  }
+;
+
 stmt_fetch: FETCH
   {
     fetch, err := plpgsqllex.(*lexer).MakeFetchOrMoveStmt(false)
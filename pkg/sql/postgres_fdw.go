@@ -0,0 +1,217 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgx/v5"
+)
+
+// postgresFDW is a foreign-data wrapper which reads the rows of foreign tables
+// from a table in a PostgreSQL-compatible server. Each scan opens a new
+// connection and runs a single query, which evaluates the filters that can be
+// pushed down.
+type postgresFDW struct{}
+
+var _ ForeignDataWrapper = postgresFDW{}
+
+var postgresFDWServerOptions = []string{"dbname", "host", "password", "port", "sslmode", "user"}
+var postgresFDWTableOptions = []string{"schema_name", "table_name"}
+
+func init() {
+	RegisterForeignDataWrapper("postgres_fdw", postgresFDW{})
+}
+
+// ValidateServerOptions is part of the ForeignDataWrapper interface.
+func (postgresFDW) ValidateServerOptions(options map[string]string) error {
+	if err := CheckForeignOptionNames(options, postgresFDWServerOptions...); err != nil {
+		return err
+	}
+	if port, ok := options["port"]; ok {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return pgerror.Newf(pgcode.FdwInvalidAttributeValue, "invalid port %q", port)
+		}
+	}
+	return nil
+}
+
+// ValidateTableOptions is part of the ForeignDataWrapper interface.
+func (postgresFDW) ValidateTableOptions(options map[string]string) error {
+	return CheckForeignOptionNames(options, postgresFDWTableOptions...)
+}
+
+// Scan is part of the ForeignDataWrapper interface.
+func (postgresFDW) Scan(
+	ctx context.Context, params ForeignScanParams, push func(tree.Datums) error,
+) error {
+	query, args := makePostgresFDWQuery(params)
+	conn, err := pgx.Connect(ctx, makePostgresFDWConnString(params.ServerOptions))
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.FdwUnableToEstablishConnection,
+			"could not connect to server %q", params.Table.GetForeignTable().Server)
+	}
+	defer func() { _ = conn.Close(ctx) }()
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.FdwError,
+			"error reading foreign table %q", params.Table.GetName())
+	}
+	defer rows.Close()
+	for rows.Next() {
+		row := make(tree.Datums, len(params.Columns))
+		for i, val := range rows.RawValues() {
+			if i >= len(row) {
+				break
+			}
+			if val == nil {
+				row[i] = tree.DNull
+				continue
+			}
+			row[i], err = rowenc.ParseDatumStringAs(
+				ctx, params.Columns[i].GetType(), string(val), params.EvalCtx, nil, /* semaCtx */
+			)
+			if err != nil {
+				return errors.Wrapf(err, "parsing value of column %q", params.Columns[i].GetName())
+			}
+		}
+		if err := push(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return pgerror.Wrapf(err, pgcode.FdwError,
+			"error reading foreign table %q", params.Table.GetName())
+	}
+	return nil
+}
+
+// makePostgresFDWConnString returns a keyword/value connection string for the
+// options of a postgres_fdw server.
+func makePostgresFDWConnString(options map[string]string) string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		v := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(options[name])
+		fmt.Fprintf(&buf, "%s='%s'", name, v)
+	}
+	return buf.String()
+}
+
+// makePostgresFDWQuery returns the query which reads the needed columns of a
+// foreign table from the remote server, along with its arguments. Column
+// values are read in their text representation.
+func makePostgresFDWQuery(params ForeignScanParams) (string, []any) {
+	schema, ok := params.TableOptions["schema_name"]
+	if !ok {
+		schema = "public"
+	}
+	table, ok := params.TableOptions["table_name"]
+	if !ok {
+		table = params.Table.GetName()
+	}
+	var buf strings.Builder
+	buf.WriteString("SELECT ")
+	if len(params.Columns) == 0 {
+		buf.WriteString("NULL")
+	}
+	for i, col := range params.Columns {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		writePostgresFDWIdent(&buf, col.GetName())
+		buf.WriteString("::text")
+	}
+	buf.WriteString(" FROM ")
+	writePostgresFDWIdent(&buf, schema)
+	buf.WriteByte('.')
+	writePostgresFDWIdent(&buf, table)
+
+	var args []any
+	where := " WHERE "
+	for _, f := range params.Filters {
+		cond, arg, ok := postgresFDWCondition(f, len(args)+1)
+		if !ok {
+			continue
+		}
+		buf.WriteString(where)
+		buf.WriteString(cond)
+		where = " AND "
+		if arg != nil {
+			args = append(args, arg)
+		}
+	}
+	return buf.String(), args
+}
+
+// postgresFDWCondition returns the remote condition for a pushed-down filter,
+// using $argIdx to refer to the returned argument, if any. Only filters which
+// the remote server evaluates the same way are pushed down: strings are
+// compared bytewise as they are locally, and ordering comparisons of floats
+// and decimals are evaluated locally since NaN values are ordered
+// differently.
+func postgresFDWCondition(f ForeignFilter, argIdx int) (cond string, arg any, ok bool) {
+	var buf strings.Builder
+	writePostgresFDWIdent(&buf, f.Column.GetName())
+	switch f.Op {
+	case treecmp.IsNotDistinctFrom:
+		buf.WriteString(" IS NULL")
+		return buf.String(), nil, true
+	case treecmp.IsDistinctFrom:
+		buf.WriteString(" IS NOT NULL")
+		return buf.String(), nil, true
+	}
+	ordering := f.Op != treecmp.EQ && f.Op != treecmp.NE
+	var castTo string
+	switch t := f.Value.(type) {
+	case *tree.DInt:
+		arg, castTo = int64(*t), "int8"
+	case *tree.DBool:
+		arg, castTo = bool(*t), "bool"
+	case *tree.DString:
+		buf.WriteString(` COLLATE "C"`)
+		arg, castTo = string(*t), "text"
+	case *tree.DFloat:
+		if ordering {
+			return "", nil, false
+		}
+		arg, castTo = float64(*t), "float8"
+	case *tree.DDecimal:
+		if ordering {
+			return "", nil, false
+		}
+		arg, castTo = t.Decimal.String(), "text::numeric"
+	default:
+		return "", nil, false
+	}
+	fmt.Fprintf(&buf, " %s $%d::%s", f.Op, argIdx, castTo)
+	return buf.String(), arg, true
+}
+
+// writePostgresFDWIdent writes the given identifier as a quoted identifier.
+func writePostgresFDWIdent(buf *strings.Builder, name string) {
+	buf.WriteByte('"')
+	buf.WriteString(strings.ReplaceAll(name, `"`, `""`))
+	buf.WriteByte('"')
+}
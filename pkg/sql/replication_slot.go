@@ -0,0 +1,207 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/replslot"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createReplicationSlotNode struct {
+	zeroInputPlanNode
+	optColumnsSlot
+	n     *pgrepltree.CreateReplicationSlot
+	slot  replslot.Slot
+	shown bool
+}
+
+// CreateReplicationSlot creates a logical replication slot using the pgoutput
+// plugin on the current database.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := p.checkReplicationSlotsSupported(ctx, "CREATE_REPLICATION_SLOT"); err != nil {
+		return nil, err
+	}
+	if n.Kind == pgrepltree.PhysicalReplication {
+		return nil, unimplemented.New("physical_replication_slot",
+			"physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, unimplemented.New("temporary_replication_slot",
+			"temporary replication slots are not supported")
+	}
+	if string(n.Plugin) != replslot.PgOutputPlugin {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"logical decoding output plugin %q is not supported", n.Plugin)
+	}
+	if p.SessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return nil, pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication slots require a database connection")
+	}
+	for _, o := range n.Options {
+		val := o.StringValue()
+		switch strings.ToLower(string(o.Key)) {
+		case "snapshot":
+			// No snapshot is exported, so "export" behaves the same as "nothing".
+			switch strings.ToLower(val) {
+			case "nothing", "export":
+			case "use":
+				return nil, unimplemented.New("replication_slot_use_snapshot",
+					"USE_SNAPSHOT is not supported")
+			default:
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"unrecognized value for CREATE_REPLICATION_SLOT option \"snapshot\": %q", val)
+			}
+		case "two_phase":
+			if val == "" || strings.EqualFold(val, "true") || strings.EqualFold(val, "on") || val == "1" {
+				return nil, unimplemented.New("replication_slot_two_phase",
+					"two-phase decoding is not supported")
+			}
+		case "reserve_wal", "failover":
+			// There is no WAL to reserve, and slots are stored in a system table
+			// and so are always available after a failover.
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"unrecognized CREATE_REPLICATION_SLOT option %q", o.Key)
+		}
+	}
+	return &createReplicationSlotNode{n: n}, nil
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	p := params.p
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(params.ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	n.slot, err = replslot.Create(
+		params.ctx,
+		p.InternalSQLTxn(),
+		p.ExecCfg().ProtectedTimestampProvider,
+		string(n.n.Slot),
+		string(n.n.Plugin),
+		db.GetID(),
+	)
+	return err
+}
+
+func (n *createReplicationSlotNode) Next(params runParams) (bool, error) {
+	if n.shown {
+		return false, nil
+	}
+	n.shown = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums {
+	return tree.Datums{
+		tree.NewDString(n.slot.Name),
+		tree.NewDString(n.slot.ConfirmedFlushLSN.String()),
+		tree.DNull, // snapshot_name
+		tree.NewDString(n.slot.Plugin),
+	}
+}
+
+func (n *createReplicationSlotNode) Close(ctx context.Context) {}
+
+type dropReplicationSlotNode struct {
+	zeroInputPlanNode
+	n *pgrepltree.DropReplicationSlot
+}
+
+// DropReplicationSlot drops a replication slot, releasing the changes it was
+// retaining.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	if err := p.checkReplicationSlotsSupported(ctx, "DROP_REPLICATION_SLOT"); err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{n: n}, nil
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	return replslot.Drop(
+		params.ctx,
+		params.p.InternalSQLTxn(),
+		params.p.ExecCfg().ProtectedTimestampProvider,
+		string(n.n.Slot),
+	)
+}
+
+func (n *dropReplicationSlotNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropReplicationSlotNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropReplicationSlotNode) Close(ctx context.Context)           {}
+
+type readReplicationSlotNode struct {
+	zeroInputPlanNode
+	optColumnsSlot
+	n     *pgrepltree.ReadReplicationSlot
+	shown bool
+}
+
+// ReadReplicationSlot reads the position of a physical replication slot. As in
+// Postgres, it returns NULLs if the slot does not exist and an error if the
+// slot is a logical replication slot. Since only logical replication slots are
+// supported, it never returns a position.
+func (p *planner) ReadReplicationSlot(
+	ctx context.Context, n *pgrepltree.ReadReplicationSlot,
+) (planNode, error) {
+	if err := p.checkReplicationSlotsSupported(ctx, "READ_REPLICATION_SLOT"); err != nil {
+		return nil, err
+	}
+	return &readReplicationSlotNode{n: n}, nil
+}
+
+func (n *readReplicationSlotNode) startExec(params runParams) error {
+	_, err := replslot.Get(params.ctx, params.p.InternalSQLTxn(), string(n.n.Slot))
+	if err == nil {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"cannot use READ_REPLICATION_SLOT with a logical replication slot")
+	}
+	if pgerror.GetPGCode(err) == pgcode.UndefinedObject {
+		return nil
+	}
+	return err
+}
+
+func (n *readReplicationSlotNode) Next(params runParams) (bool, error) {
+	if n.shown {
+		return false, nil
+	}
+	n.shown = true
+	return true, nil
+}
+
+func (n *readReplicationSlotNode) Values() tree.Datums {
+	return tree.Datums{tree.DNull, tree.DNull, tree.DNull}
+}
+
+func (n *readReplicationSlotNode) Close(ctx context.Context) {}
+
+// checkReplicationSlotsSupported returns an error if replication slots cannot
+// be used by the given statement.
+func (p *planner) checkReplicationSlotsSupported(ctx context.Context, stmtName string) error {
+	if !p.IsActive(ctx, clusterversion.V25_3_ReplicationSlotsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s unsupported in mixed-version cluster", stmtName)
+	}
+	if !p.EvalContext().TxnImplicit {
+		return pgerror.Newf(pgcode.ActiveSQLTransaction,
+			"%s cannot run inside a transaction block", stmtName)
+	}
+	return nil
+}
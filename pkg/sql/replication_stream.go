@@ -0,0 +1,255 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/replslot"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessionphase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxlog"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// ReplicationConn is used by a logical replication stream to exchange Copy-both
+// messages with the client.
+type ReplicationConn interface {
+	// Start switches the connection to Copy-both mode. It must be called
+	// before Send and Recv, once the stream has validated its options.
+	Start(ctx context.Context) error
+	// Send sends a CopyData message to the client and flushes it.
+	Send(ctx context.Context, data []byte) error
+	// Recv returns the payload of the next CopyData message sent by the client.
+	// io.EOF is returned once the client has ended the stream with CopyDone.
+	Recv(ctx context.Context) ([]byte, error)
+}
+
+// StartReplicationHook streams the changes retained by the logical replication
+// slot to the client using the pgoutput protocol. It returns when the client
+// ends the stream, or with an error. It is set by the CCL changefeed package.
+var StartReplicationHook func(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	slot replslot.Slot,
+	stmt *pgrepltree.StartReplication,
+	conn ReplicationConn,
+) error
+
+// ReplicationInput carries the CopyData messages sent by the client during a
+// replication stream from the network routine, which keeps reading from the
+// connection, to the connExecutor running the stream.
+type ReplicationInput struct {
+	msgs    chan []byte
+	stopped chan struct{}
+
+	mu struct {
+		syncutil.Mutex
+		closed  bool
+		stopped bool
+		// err is set if the client ended the stream with CopyFail.
+		err error
+	}
+}
+
+// NewReplicationInput creates a ReplicationInput.
+func NewReplicationInput() *ReplicationInput {
+	return &ReplicationInput{
+		msgs:    make(chan []byte, 16),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Push delivers the payload of a CopyData message sent by the client. The
+// payload is copied. Push blocks while the stream is not keeping up, and
+// drops the message if the stream has already stopped.
+func (ri *ReplicationInput) Push(ctx context.Context, data []byte) error {
+	ri.mu.Lock()
+	closed := ri.mu.closed
+	ri.mu.Unlock()
+	if closed {
+		return nil
+	}
+	msg := make([]byte, len(data))
+	copy(msg, data)
+	select {
+	case ri.msgs <- msg:
+		return nil
+	case <-ri.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close is called when the client ends the stream, either with CopyDone, in
+// which case err is nil, or with CopyFail. It must be called from the same
+// goroutine as Push.
+func (ri *ReplicationInput) Close(err error) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	if ri.mu.closed {
+		return
+	}
+	ri.mu.closed = true
+	ri.mu.err = err
+	close(ri.msgs)
+}
+
+// Recv returns the next message sent by the client. io.EOF is returned after
+// the client ended the stream with CopyDone.
+func (ri *ReplicationInput) Recv(ctx context.Context) ([]byte, error) {
+	select {
+	case msg, ok := <-ri.msgs:
+		if ok {
+			return msg, nil
+		}
+		ri.mu.Lock()
+		defer ri.mu.Unlock()
+		if ri.mu.err != nil {
+			return nil, ri.mu.err
+		}
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Stop is called once the stream has finished, unblocking any pending Push.
+func (ri *ReplicationInput) Stop() {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	if !ri.mu.stopped {
+		ri.mu.stopped = true
+		close(ri.stopped)
+	}
+}
+
+// replicationConn implements ReplicationConn over a StartReplicationResult.
+type replicationConn struct {
+	res     StartReplicationResult
+	input   *ReplicationInput
+	started bool
+}
+
+var _ ReplicationConn = (*replicationConn)(nil)
+
+// Start is part of the ReplicationConn interface.
+func (c *replicationConn) Start(ctx context.Context) error {
+	if c.started {
+		return errors.AssertionFailedf("replication stream already started")
+	}
+	c.started = true
+	return c.res.SendCopyBoth(ctx)
+}
+
+// Send is part of the ReplicationConn interface.
+func (c *replicationConn) Send(ctx context.Context, data []byte) error {
+	return c.res.SendCopyData(ctx, data, false /* isHeader */)
+}
+
+// Recv is part of the ReplicationConn interface.
+func (c *replicationConn) Recv(ctx context.Context) ([]byte, error) {
+	return c.input.Recv(ctx)
+}
+
+// execStartReplication runs a logical replication stream from a replication
+// slot. The stream is not part of a transaction, so any error is reported on
+// the result and the state of the connExecutor is unchanged.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) {
+	defer cmd.Input.Stop()
+	if err := ex.execStartReplicationInternal(ctx, cmd, res); err != nil {
+		log.SqlExec.Infof(ctx, "error executing %s: %v", cmd, err)
+		res.SetError(err)
+	}
+}
+
+func (ex *connExecutor) execStartReplicationInternal(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) error {
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return pgerror.New(pgcode.ActiveSQLTransaction,
+			"START_REPLICATION cannot run inside a transaction block")
+	}
+	if cmd.Stmt.Kind != pgrepltree.LogicalReplication {
+		return unimplemented.New("physical_replication",
+			"physical replication is not supported")
+	}
+	if ex.sessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires a database connection")
+	}
+	if !ex.server.cfg.Settings.Version.IsActive(ctx, clusterversion.V25_3_ReplicationSlotsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"START_REPLICATION unsupported in mixed-version cluster")
+	}
+	if StartReplicationHook == nil {
+		return sqlerrors.NewCCLRequiredError(errors.New("logical replication requires a CCL binary"))
+	}
+
+	var slot replslot.Slot
+	if err := ex.server.cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		var err error
+		slot, err = replslot.Get(ctx, txn, string(cmd.Stmt.Slot))
+		if err != nil {
+			return err
+		}
+		db, err := txn.Descriptors().ByNameWithLeased(txn.KV()).Get().Database(ctx, ex.sessionData().Database)
+		if err != nil {
+			return err
+		}
+		if db.GetID() != slot.DatabaseID {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", slot.Name)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Register the stream as an active query, so that it shows up in SHOW
+	// QUERIES and can be canceled.
+	ctx, cancelQuery := ctxlog.WithCancel(ctx)
+	defer cancelQuery()
+	queryID := ex.server.cfg.GenerateID()
+	func() {
+		ex.mu.Lock()
+		defer ex.mu.Unlock()
+		ex.mu.ActiveQueries[queryID] = &queryMeta{
+			start:       ex.phaseTimes.GetSessionPhaseTime(sessionphase.SessionQueryReceived),
+			stmt:        cmd.ParsedStmt,
+			phase:       executing,
+			cancelQuery: cancelQuery,
+		}
+	}()
+	defer ex.removeActiveQuery(queryID, cmd.Stmt)
+
+	res.DisableBuffering()
+	conn := &replicationConn{res: res, input: cmd.Input}
+	if err := StartReplicationHook(ctx, ex.server.cfg, slot, cmd.Stmt, conn); err != nil {
+		if ctx.Err() != nil {
+			return cancelchecker.QueryCanceledError
+		}
+		return err
+	}
+	if !conn.started {
+		return nil
+	}
+	return res.SendCopyDone(ctx)
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
@@ -247,6 +248,20 @@ func (g *routineGenerator) ResolvedType() *types.T {
 
 // Start is part of the eval.ValueGenerator interface.
 func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
+	if len(g.expr.Config) > 0 {
+		// Apply the session variable overrides of the routine for the duration
+		// of its execution, including any nested routines that defer their
+		// execution to this one.
+		pop, pushErr := g.p.pushRoutineConfig(ctx, g.expr.Config)
+		if pushErr != nil {
+			return pushErr
+		}
+		defer func() {
+			if popErr := pop(); popErr != nil && err == nil {
+				err = popErr
+			}
+		}()
+	}
 	enabledStepping := false
 	var prevSteppingMode kv.SteppingMode
 	var prevSeqNum enginepb.TxnSeq
@@ -383,6 +398,10 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 // using the current PLpgSQL block's savepoint. Otherwise, it will do nothing,
 // in which case the savepoint will be rolled back either by a parent PLpgSQL
 // block (if the error is eventually caught), or when the transaction aborts.
+//
+// Transaction retry errors can only be caught when the transaction is able to
+// retry partially from the block's savepoint (e.g. under read committed).
+// Otherwise, the error is propagated so that the whole transaction is retried.
 func (g *routineGenerator) handleException(ctx context.Context, err error) error {
 	caughtCode := pgerror.GetPGCode(err)
 	if caughtCode == pgcode.Uncategorized {
@@ -397,12 +416,16 @@ func (g *routineGenerator) handleException(ctx context.Context, err error) error
 			// This block has no exception handler.
 			continue
 		}
+		var partialRetry bool
 		if !g.p.Txn().CanUseSavepoint(ctx, blockState.SavepointTok.(kv.SavepointToken)) {
-			// The current transaction state does not allow roll-back.
-			// TODO(111446): some retryable errors allow the transaction to be rolled
-			// back partially (e.g. for read committed). We should be able to take
-			// advantage of that mechanism here as well.
-			return err
+			// The current transaction state does not allow roll-back, unless the
+			// error is a retryable error that only requires a partial retry (e.g.
+			// for read committed). In that case, the transaction can be rolled back
+			// to the block's savepoint and continue from there.
+			if !canPartiallyRetry(g.p.Txn(), err) {
+				return err
+			}
+			partialRetry = true
 		}
 		// Unset the exception handler to indicate that it has already encountered an
 		// error.
@@ -433,6 +456,17 @@ func (g *routineGenerator) handleException(ctx context.Context, err error) error
 				// This error is unexpected, so return immediately.
 				return errors.CombineErrors(err, errors.WithAssertionFailure(spErr))
 			}
+			if partialRetry {
+				// Clear the retryable error from the transaction and establish a new
+				// read snapshot, as is done for per-statement retries under read
+				// committed.
+				if retryErr := g.p.Txn().PrepareForPartialRetry(ctx); retryErr != nil {
+					return errors.CombineErrors(err, retryErr)
+				}
+				if stepErr := g.p.Txn().Step(ctx, false /* allowReadTimestampStep */); stepErr != nil {
+					return errors.CombineErrors(err, stepErr)
+				}
+			}
 			// Truncate the arguments using the number of variables in scope for the
 			// current block. This is necessary because the error may originate from
 			// a child block, but propagate up to a parent block. See the BlockState
@@ -467,6 +501,18 @@ func (g *routineGenerator) handleException(ctx context.Context, err error) error
 	return err
 }
 
+// canPartiallyRetry returns true if the given error is a retryable error that
+// allows the transaction to be rolled back to a savepoint and continue, rather
+// than restarting from the beginning. This is only possible for isolation
+// levels that establish a new read snapshot for each statement.
+func canPartiallyRetry(txn *kv.Txn, err error) bool {
+	if !txn.IsoLevel().PerStatementReadSnapshot() {
+		return false
+	}
+	var retryErr *kvpb.TransactionRetryWithProtoRefreshError
+	return errors.As(err, &retryErr) && !retryErr.TxnMustRestartFromBeginning()
+}
+
 // closeCursors closes any cursors that were opened within the scope of the
 // current block. It is used for PLpgSQL exception handling.
 func (g *routineGenerator) closeCursors(blockState *tree.BlockState) error {
@@ -549,6 +595,11 @@ func (g *routineGenerator) CanOptimizeTailCall(nestedRoutine *tree.RoutineExpr)
 	// always more than one body statement if a cursor is opened. This is enforced
 	// during exec-building. For this reason, we only have to check for an
 	// exception handler.
+	if len(nestedRoutine.Config) > 0 {
+		// The nested routine must apply its session variable overrides when it
+		// starts and restore them when it finishes, so it cannot be deferred.
+		return false
+	}
 	if g.expr.BlockState != nil {
 		// If the current routine has an exception handler (which is the case when
 		// BlockState is non-nil), the nested routine must either be part of the
@@ -563,6 +614,37 @@ func (g *routineGenerator) CanOptimizeTailCall(nestedRoutine *tree.RoutineExpr)
 	return true
 }
 
+// pushRoutineConfig pushes a copy of the current session data onto the session
+// data stack and applies the given session variable overrides of a routine to
+// it. The returned function pops the session data, which restores the previous
+// values of the overridden session variables.
+func (p *planner) pushRoutineConfig(
+	ctx context.Context, config tree.RoutineConfig,
+) (pop func() error, err error) {
+	sds := p.EvalContext().SessionDataStack
+	prevSearchPath := p.semaCtx.SearchPath
+	sds.PushTopClone()
+	pop = func() error {
+		p.semaCtx.SearchPath = prevSearchPath
+		return sds.Pop()
+	}
+	m := p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, sds.Top())
+	for _, c := range config {
+		_, v, err := getSessionVar(c.Name, false /* missingOk */)
+		if err == nil && v.Set == nil {
+			err = newCannotChangeParameterError(c.Name)
+		}
+		if err == nil {
+			err = v.Set(ctx, m, c.Value)
+		}
+		if err != nil {
+			return nil, errors.CombineErrors(err, pop())
+		}
+	}
+	p.semaCtx.SearchPath = &sds.Top().SearchPath
+	return pop, nil
+}
+
 func (g *routineGenerator) SendDeferredRoutine(nestedRoutine *tree.RoutineExpr, args tree.Datums) {
 	g.deferredRoutine.expr = nestedRoutine
 	g.deferredRoutine.args = args
@@ -577,38 +659,66 @@ func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursor
 		return nil, errors.AssertionFailedf("expected non-null cursor name")
 	}
 	cursorName := tree.Name(tree.MustBeDString(g.args[open.NameArgIdx]))
+	return newPLpgSQLCursorHelper(
+		g.p,
+		cursorName,
+		open.CursorSQL,
+		plan.main.planColumns(),
+		plan.flags.IsSet(planFlagContainsLocking),
+		open.Scroll == tree.Scroll,
+	)
+}
+
+// newPLpgSQLCursorHelper returns a helper that collects rows with the given
+// columns, which are used to open a PL/pgSQL cursor with the given name. If
+// scroll is true, the cursor is able to move backward.
+func newPLpgSQLCursorHelper(
+	p *planner,
+	cursorName tree.Name,
+	cursorSQL string,
+	resultCols colinfo.ResultColumns,
+	containsLocking bool,
+	scroll bool,
+) (*plpgsqlCursorHelper, error) {
 	if cursorName == "" {
 		// Specifying the empty string as a cursor name conflicts with the
 		// "unnamed" portal, which always exists.
 		return nil, pgerror.Newf(pgcode.DuplicateCursor, "cursor \"\" already in use")
 	}
+	if scroll && containsLocking {
+		return nil, errors.WithDetail(
+			pgerror.Newf(pgcode.FeatureNotSupported,
+				"DECLARE SCROLL CURSOR must not contain locking"),
+			"Scrollable cursors must be READ ONLY.",
+		)
+	}
 	// Disabling the CloseCursorsAtCommit setting provides oracle-compatible
 	// behavior, where cursors are holdable by default unless they contain
 	// locking.
-	withHold := !g.p.SessionData().CloseCursorsAtCommit && !plan.flags.IsSet(planFlagContainsLocking)
-	planCols := plan.main.planColumns()
+	withHold := !p.SessionData().CloseCursorsAtCommit && !containsLocking
 	cursorHelper := &plpgsqlCursorHelper{
 		cursorName: cursorName,
-		cursorSql:  open.CursorSQL,
+		cursorSql:  cursorSQL,
 		withHold:   withHold,
+		scroll:     scroll,
 	}
 	// Use context.Background(), since the cursor can outlive the context in which
 	// it was created.
 	cursorHelper.ctx = context.Background()
-	cursorHelper.resultCols = make(colinfo.ResultColumns, len(planCols))
-	copy(cursorHelper.resultCols, planCols)
-	mon := g.p.Mon()
+	cursorHelper.resultCols = make(colinfo.ResultColumns, len(resultCols))
+	copy(cursorHelper.resultCols, resultCols)
+	mon := p.Mon()
 	if withHold {
-		mon = g.p.sessionMonitor
+		mon = p.sessionMonitor
 		if mon == nil {
 			return nil, errors.AssertionFailedf("cannot open cursor WITH HOLD without an active session")
 		}
 	}
 	cursorHelper.container.InitWithParentMon(
 		cursorHelper.ctx,
-		getTypesFromResultColumns(planCols),
+		getTypesFromResultColumns(resultCols),
 		mon,
-		g.p.ExtendedEvalContextCopy(),
+		p.ExtendedEvalContextCopy(),
 		"routine_open_cursor", /* opName */
 	)
 	return cursorHelper, nil
@@ -627,6 +737,7 @@ type plpgsqlCursorHelper struct {
 	cursorSql   string
 	addedCursor bool
 	withHold    bool
+	scroll      bool
 }
 
 var _ isql.Rows = &plpgsqlCursorHelper{}
@@ -645,8 +756,17 @@ func (h *plpgsqlCursorHelper) createCursor(p *planner) error {
 	if err := p.checkIfCursorExists(h.cursorName); err != nil {
 		return err
 	}
+	if h.scroll {
+		// The rows of a scrollable cursor are buffered again as they are read,
+		// so that the cursor can move backward.
+		rows, err := newScrollableRows(p, h, h.withHold)
+		if err != nil {
+			return err
+		}
+		cursor.Rows = rows
+	}
 	if err := p.sqlCursors.addCursor(h.cursorName, cursor); err != nil {
-		return err
+		return errors.CombineErrors(err, cursor.Rows.Close())
 	}
 	h.addedCursor = true
 	return nil
@@ -670,6 +790,30 @@ func (a *storedProcTxnStateAccessor) setStoredProcTxnState(
 	a.ex.extraTxnState.storedProcTxnState.resumeProc = resumeProc
 }
 
+// getChainedTxnModes returns the characteristics of the current transaction
+// as transaction modes, overridden by any of the given modes that are set. It
+// is used for PL/pgSQL COMMIT AND CHAIN and ROLLBACK AND CHAIN.
+func (a *storedProcTxnStateAccessor) getChainedTxnModes(
+	txnModes tree.TransactionModes,
+) *tree.TransactionModes {
+	if a.ex == nil {
+		panic(errors.AssertionFailedf("getChainedTxnModes is not supported without connExecutor"))
+	}
+	chained := a.ex.state.chainedTxnModes()
+	if txnModes.Isolation != tree.UnspecifiedIsolation {
+		chained.Isolation = txnModes.Isolation
+	}
+	if txnModes.UserPriority != tree.UnspecifiedUserPriority {
+		chained.UserPriority = txnModes.UserPriority
+	}
+	if txnModes.ReadWriteMode != tree.UnspecifiedReadWriteMode {
+		chained.ReadWriteMode = txnModes.ReadWriteMode
+	}
+	chained.AsOf = txnModes.AsOf
+	chained.Deferrable = txnModes.Deferrable
+	return &chained
+}
+
 func (a *storedProcTxnStateAccessor) getTxnOp() tree.StoredProcTxnOp {
 	if a.ex == nil {
 		return tree.StoredProcTxnNoOp
@@ -713,6 +857,12 @@ func (p *planner) EvalTxnControlExpr(
 	if err != nil {
 		return nil, err
 	}
-	p.storedProcTxnState.setStoredProcTxnState(expr.Op, &expr.Modes, resumeProc.(*memo.Memo))
+	txnModes := &expr.Modes
+	if expr.Chain {
+		// The new transaction inherits the characteristics of the current one,
+		// unless they are overridden by SET TRANSACTION statements.
+		txnModes = p.storedProcTxnState.getChainedTxnModes(expr.Modes)
+	}
+	p.storedProcTxnState.setStoredProcTxnState(expr.Op, txnModes, resumeProc.(*memo.Memo))
 	return tree.DNull, nil
 }
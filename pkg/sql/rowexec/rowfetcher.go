@@ -41,6 +41,10 @@ type rowFetcher interface {
 		ctx context.Context, destination rowenc.EncDatumRow, colIdxMap catalog.TableColMap,
 	) (ok bool, err error)
 
+	// Key returns the key of the first KV of the next row, or nil if there are
+	// no more rows.
+	Key() roachpb.Key
+
 	Reset()
 	GetBytesRead() int64
 	GetKVPairsRead() int64
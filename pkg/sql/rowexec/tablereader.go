@@ -10,6 +10,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
@@ -45,6 +47,12 @@ type tableReader struct {
 
 	ignoreMisplannedRanges bool
 
+	// See TableReaderSpec.Sample.
+	sample *execinfrapb.TableSampleSpec
+	// keyPrefixLength is the length of the table/index prefix of the keys,
+	// which is excluded when deciding whether a row is part of the sample.
+	keyPrefixLength int
+
 	// fetcher wraps a row.Fetcher, allowing the tableReader to add a stat
 	// collection layer.
 	fetcher rowFetcher
@@ -102,6 +110,8 @@ func newTableReader(
 	tr.parallelize = spec.Parallelize
 	tr.batchBytesLimit = batchBytesLimit
 	tr.maxTimestampAge = time.Duration(spec.MaxTimestampAgeNanos)
+	tr.sample = spec.Sample
+	tr.keyPrefixLength = int(spec.FetchSpec.KeyPrefixLength)
 
 	// Make sure the key column types are hydrated. The fetched column types
 	// will be hydrated in ProcessorBase.Init below.
@@ -276,6 +286,17 @@ func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata
 			return nil, meta
 		}
 
+		// The key of the first KV of the next row has to be examined before
+		// the row is fetched.
+		keep := true
+		if tr.sample != nil {
+			var err error
+			if keep, err = tr.keepSampledRow(tr.fetcher.Key()); err != nil {
+				tr.MoveToDraining(err)
+				break
+			}
+		}
+
 		row, _, err := tr.fetcher.NextRow(tr.Ctx())
 		if row == nil || err != nil {
 			tr.MoveToDraining(err)
@@ -287,6 +308,9 @@ func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata
 		// case can avoid tracking of the stall time which gives a noticeable
 		// performance hit.
 		tr.rowsRead++
+		if !keep {
+			continue
+		}
 		if outRow := tr.ProcessRowHelper(row); outRow != nil {
 			return outRow, nil
 		}
@@ -294,6 +318,20 @@ func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata
 	return nil, tr.DrainHelper()
 }
 
+// keepSampledRow returns whether the row starting with the given key is part
+// of the sample. It must agree with the decision made by the cFetcher.
+func (tr *tableReader) keepSampledRow(key roachpb.Key) (bool, error) {
+	if key == nil {
+		// There are no more rows.
+		return true, nil
+	}
+	prefixLen, err := keys.GetRowPrefixLength(key)
+	if err != nil {
+		return false, err
+	}
+	return tr.sample.KeepRow(key[tr.keyPrefixLength:prefixLen]), nil
+}
+
 func (tr *tableReader) close() {
 	if tr.InternalClose() {
 		if tr.fetcher != nil {
@@ -1096,6 +1096,10 @@ func (b *builderState) ResolveUserDefinedTypeType(
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
 			"cannot modify table record type %q", typ.GetName()))
+	case descpb.TypeDescriptor_DOMAIN:
+		// Domain types are only supported by the legacy schema changer.
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			redact.Sprintf("domain type %q", typ.GetName())))
 	default:
 		panic(errors.AssertionFailedf("unknown type kind %s", typ.GetKind()))
 	}
@@ -1148,6 +1152,10 @@ func (b *builderState) resolveRelation(
 		if t.IsTemporary() {
 			panic(scerrors.NotImplementedErrorf(nil /* n */, "dropping a temporary table"))
 		}
+		if t.IsForeignTable() {
+			// Foreign tables are only supported by the legacy schema changer.
+			panic(scerrors.NotImplementedErrorf(nil /* n */, "foreign table"))
+		}
 	} else if typ, isType := rel.(catalog.TypeDescriptor); isType {
 		if typ.GetKind() == descpb.TypeDescriptor_ALIAS && typ.GetID() == descpb.InvalidID {
 			// This case handles the types in types.PublicSchemaAliases -- BOX2D,
@@ -1460,6 +1468,12 @@ func (b *builderState) ResolveRoutine(
 		)
 	}
 
+	if ol.Class == tree.AggregateClass {
+		// User-defined aggregate functions are only handled by the legacy
+		// schema changer.
+		panic(scerrors.NotImplementedErrorf(routineObj, "user-defined aggregate functions"))
+	}
+
 	fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 	if p.RequireOwnership {
 		b.mustOwn(fnID)
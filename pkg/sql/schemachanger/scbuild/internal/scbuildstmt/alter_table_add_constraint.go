@@ -39,6 +39,21 @@ func alterTableAddConstraint(
 	stmt tree.Statement,
 	t *tree.AlterTableAddConstraint,
 ) {
+	// The declarative schema changer elements do not record whether a
+	// constraint is deferrable, nor the operators of an exclusion constraint,
+	// so fall back to the legacy schema changer.
+	switch d := t.ConstraintDef.(type) {
+	case *tree.ExclusionConstraintTableDef:
+		panic(scerrors.NotImplementedErrorf(t, "exclusion constraint"))
+	case *tree.UniqueConstraintTableDef:
+		if d.Deferrability.IsDeferrable() {
+			panic(scerrors.NotImplementedErrorf(t, "deferrable unique constraint"))
+		}
+	case *tree.ForeignKeyConstraintTableDef:
+		if d.Deferrability.IsDeferrable() {
+			panic(scerrors.NotImplementedErrorf(t, "deferrable foreign key constraint"))
+		}
+	}
 	switch d := t.ConstraintDef.(type) {
 	case *tree.UniqueConstraintTableDef:
 		if d.PrimaryKey {
@@ -0,0 +1,115 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+func alterTableInherit(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, stmt tree.Statement, n *tree.AlterTableInherit,
+) {
+	parentElts := b.ResolveTable(n.Parent.ToUnresolvedObjectName(), ResolveParams{
+		RequiredPrivilege: privilege.CREATE,
+	})
+	_, _, parent := scpb.FindTable(parentElts)
+	n.Parent.ObjectNamePrefix = b.NamePrefix(parent)
+	// The parent's descriptor is modified to record the inherited-by back
+	// reference, so it must be allowed to undergo a schema change as well.
+	checkTableSchemaChangePrerequisites(b, parentElts, stmt)
+
+	existing := b.QueryByID(tbl.TableID).FilterTableInheritance().Filter(
+		func(_ scpb.Status, target scpb.TargetStatus, e *scpb.TableInheritance) bool {
+			return target == scpb.ToPublic && e.ParentTableID == parent.TableID
+		}).MustGetZeroOrOneElement()
+
+	if n.NoInherit {
+		if existing == nil {
+			panic(pgerror.Newf(pgcode.UndefinedTable,
+				"relation %q is not a parent of relation %q", n.Parent.Object(), tn.Object()))
+		}
+		b.Drop(existing)
+		return
+	}
+
+	if existing != nil {
+		panic(pgerror.Newf(pgcode.DuplicateRelation,
+			"relation %q would be inherited from more than once", n.Parent.Object()))
+	}
+	if parent.TableID == tbl.TableID || isDescendantTable(b, tbl.TableID, parent.TableID) {
+		panic(pgerror.Newf(pgcode.DuplicateRelation,
+			"circular inheritance not allowed"))
+	}
+	if parent.IsTemporary && !tbl.IsTemporary {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"cannot inherit from temporary relation %q", n.Parent.Object()))
+	}
+	checkInheritedColumnsMatch(b, tbl.TableID, parentElts)
+	b.Add(&scpb.TableInheritance{
+		TableID:       tbl.TableID,
+		ParentTableID: parent.TableID,
+	})
+}
+
+// isDescendantTable returns true if candidateID inherits, directly or
+// transitively, from tableID.
+func isDescendantTable(b BuildCtx, tableID, candidateID catid.DescID) bool {
+	found := false
+	scpb.ForEachTableInheritance(b.BackReferences(tableID), func(
+		_ scpb.Status, target scpb.TargetStatus, e *scpb.TableInheritance,
+	) {
+		if found || target != scpb.ToPublic || e.ParentTableID != tableID {
+			return
+		}
+		found = e.TableID == candidateID || isDescendantTable(b, e.TableID, candidateID)
+	})
+	return found
+}
+
+// checkInheritedColumnsMatch verifies that the child table has a column of
+// the same name and type for every visible column of the parent, and that
+// columns which are NOT NULL in the parent are also NOT NULL in the child.
+func checkInheritedColumnsMatch(
+	b BuildCtx, childID catid.DescID, parentElts ElementResultSet,
+) {
+	childElts := b.QueryByID(childID).Filter(publicTargetFilter)
+	scpb.ForEachColumn(parentElts.Filter(publicTargetFilter), func(
+		_ scpb.Status, _ scpb.TargetStatus, col *scpb.Column,
+	) {
+		if col.IsHidden || col.IsInaccessible || col.IsSystemColumn {
+			return
+		}
+		parentName := mustRetrieveColumnNameElem(b, col.TableID, col.ColumnID)
+		var childName *scpb.ColumnName
+		scpb.ForEachColumnName(childElts, func(
+			_ scpb.Status, _ scpb.TargetStatus, e *scpb.ColumnName,
+		) {
+			if e.Name == parentName.Name {
+				childName = e
+			}
+		})
+		if childName == nil {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"child table is missing column %q", parentName.Name))
+		}
+		parentType := retrieveColumnTypeElem(b, col.TableID, col.ColumnID)
+		childType := retrieveColumnTypeElem(b, childID, childName.ColumnID)
+		if !parentType.Type.Identical(childType.Type) {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"child table has different type for column %q", parentName.Name))
+		}
+		if retrieveColumnNotNull(b, col.TableID, col.ColumnID) != nil &&
+			retrieveColumnNotNull(b, childID, childName.ColumnID) == nil {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"column %q in child table must be marked NOT NULL", parentName.Name))
+		}
+	})
+}
@@ -28,6 +28,14 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	if n.Replace {
 		panic(scerrors.NotImplementedError(n))
 	}
+	for _, option := range n.Options {
+		switch option.(type) {
+		case tree.RoutineCost, tree.RoutineRows, tree.RoutineParallel, tree.RoutineSupport, *tree.RoutineSet:
+			// These options do not have corresponding elements yet, so fall back
+			// to the legacy schema changer.
+			panic(scerrors.NotImplementedErrorf(n, "COST, ROWS, PARALLEL, SUPPORT and SET routine options"))
+		}
+	}
 	b.IncrementSchemaChangeCreateCounter("function")
 
 	var dbElts, scElts ElementResultSet
@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
@@ -35,8 +36,16 @@ func CreateTrigger(b BuildCtx, n *tree.CreateTrigger) {
 	validateFunctionRelationReferences(b, refProvider, namespace.DatabaseID)
 	validateFunctionToFunctionReferences(b, refProvider, namespace.DatabaseID)
 
-	_, _, tbl := scpb.FindTable(relationElements)
-	tableID, triggerID := tbl.TableID, b.NextTableTriggerID(tbl.TableID)
+	// INSTEAD OF triggers are created on views rather than tables.
+	var tableID catid.DescID
+	if _, _, tbl := scpb.FindTable(relationElements); tbl != nil {
+		tableID = tbl.TableID
+	} else if _, _, view := scpb.FindView(relationElements); view != nil {
+		tableID = view.ViewID
+	} else {
+		panic(errors.AssertionFailedf("expected relation %v to be resolved", n.TableName))
+	}
+	triggerID := b.NextTableTriggerID(tableID)
 
 	trigger := &scpb.Trigger{
 		TableID:   tableID,
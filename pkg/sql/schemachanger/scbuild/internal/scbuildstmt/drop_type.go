@@ -22,6 +22,9 @@ func DropType(b BuildCtx, n *tree.DropType) {
 	if n.DropBehavior == tree.DropCascade {
 		panic(scerrors.NotImplementedErrorf(n, "DROP TYPE CASCADE is not yet supported"))
 	}
+	if n.Domain {
+		panic(scerrors.NotImplementedErrorf(n, "DROP DOMAIN"))
+	}
 	var toCheckBackrefs []catid.DescID
 	arrayTypesToAlsoCheck := make(map[catid.DescID]catid.DescID)
 	for _, name := range n.Names {
@@ -238,6 +238,8 @@ func dropCascadeDescriptor(b BuildCtx, id catid.DescID) {
 			dropCascadeDescriptor(next, t.TableID)
 		case *scpb.PolicyDeps:
 			dropCascadeDescriptor(next, t.TableID)
+		case *scpb.TableInheritance:
+			dropCascadeDescriptor(next, t.TableID)
 		case *scpb.Column, *scpb.ColumnType, *scpb.SecondaryIndexPartial:
 			// These only have type references.
 			break
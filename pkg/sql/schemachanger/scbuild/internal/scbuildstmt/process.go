@@ -217,3 +217,7 @@ var isV251Active = func(_ tree.NodeFormatter, _ sessiondatapb.NewSchemaChangerMo
 var isV252Active = func(_ tree.NodeFormatter, _ sessiondatapb.NewSchemaChangerMode, activeVersion clusterversion.ClusterVersion) bool {
 	return activeVersion.IsActive(clusterversion.V25_2)
 }
+
+var isV253Active = func(_ tree.NodeFormatter, _ sessiondatapb.NewSchemaChangerMode, activeVersion clusterversion.ClusterVersion) bool {
+	return activeVersion.IsActive(clusterversion.V25_3_Start)
+}
@@ -737,6 +737,8 @@ func (i *immediateVisitor) SetObjectParentID(ctx context.Context, op scop.SetObj
 			ReturnType:  t.GetReturnType().Type,
 			ReturnSet:   t.GetReturnType().ReturnSet,
 			IsProcedure: t.IsProcedure(),
+			IsAggregate: t.IsAggregate(),
+			IsVariadic:  t.IsVariadic(),
 		}
 		for pIdx, p := range t.Params {
 			class := funcdesc.ToTreeRoutineParamClass(p.Class)
@@ -7,8 +7,10 @@ package scmutationexec
 
 import (
 	"context"
+	"slices"
 
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 )
@@ -30,3 +32,43 @@ func (i *immediateVisitor) SetTableSchemaLocked(
 	tbl.SchemaLocked = op.Locked
 	return nil
 }
+
+func (i *immediateVisitor) AddTableInheritance(
+	ctx context.Context, op scop.AddTableInheritance,
+) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil {
+		return err
+	}
+	parent, err := i.checkOutTable(ctx, op.ParentTableID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(tbl.Inherits, op.ParentTableID) {
+		tbl.Inherits = append(tbl.Inherits, op.ParentTableID)
+	}
+	if !slices.Contains(parent.InheritedBy, op.TableID) {
+		parent.InheritedBy = append(parent.InheritedBy, op.TableID)
+	}
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTableInheritance(
+	ctx context.Context, op scop.RemoveTableInheritance,
+) error {
+	tbl, err := i.checkOutTable(ctx, op.TableID)
+	if err != nil {
+		return err
+	}
+	tbl.Inherits = slices.DeleteFunc(tbl.Inherits, func(id descpb.ID) bool {
+		return id == op.ParentTableID
+	})
+	parent, err := i.checkOutTable(ctx, op.ParentTableID)
+	if err != nil {
+		return err
+	}
+	parent.InheritedBy = slices.DeleteFunc(parent.InheritedBy, func(id descpb.ID) bool {
+		return id == op.TableID
+	})
+	return nil
+}
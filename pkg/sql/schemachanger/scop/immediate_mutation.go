@@ -1175,3 +1175,19 @@ type SetTableSchemaLocked struct {
 	TableID descpb.ID
 	Locked  bool
 }
+
+// AddTableInheritance links a table to one of the tables it inherits from,
+// updating the back-reference in the parent table.
+type AddTableInheritance struct {
+	immediateMutationOp
+	TableID       descpb.ID
+	ParentTableID descpb.ID
+}
+
+// RemoveTableInheritance unlinks a table from one of the tables it inherits
+// from, removing the back-reference in the parent table.
+type RemoveTableInheritance struct {
+	immediateMutationOp
+	TableID       descpb.ID
+	ParentTableID descpb.ID
+}
@@ -169,6 +169,8 @@ type ImmediateMutationVisitor interface {
 	MarkRecreatedIndexAsInvisible(context.Context, MarkRecreatedIndexAsInvisible) error
 	MarkRecreatedIndexesAsVisible(context.Context, MarkRecreatedIndexesAsVisible) error
 	SetTableSchemaLocked(context.Context, SetTableSchemaLocked) error
+	AddTableInheritance(context.Context, AddTableInheritance) error
+	RemoveTableInheritance(context.Context, RemoveTableInheritance) error
 }
 
 // Visit is part of the ImmediateMutationOp interface.
@@ -930,3 +932,13 @@ func (op MarkRecreatedIndexesAsVisible) Visit(ctx context.Context, v ImmediateMu
 func (op SetTableSchemaLocked) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.SetTableSchemaLocked(ctx, op)
 }
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTableInheritance) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTableInheritance(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTableInheritance) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTableInheritance(ctx, op)
+}
@@ -2489,6 +2489,43 @@ func (c *ElementCollection[E]) FilterTableData() *ElementCollection[*TableData]
 	return (*ElementCollection[*TableData])(ret)
 }
 
+func (e TableInheritance) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TableInheritance) Element() Element {
+	return e.TableInheritance
+}
+
+// ForEachTableInheritance iterates over elements of type TableInheritance.
+// Deprecated
+func ForEachTableInheritance(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TableInheritance),
+) {
+  c.FilterTableInheritance().ForEach(fn)
+}
+
+// FindTableInheritance finds the first element of type TableInheritance.
+// Deprecated
+func FindTableInheritance(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TableInheritance) {
+	if tc := c.FilterTableInheritance(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TableInheritance)
+	}
+	return current, target, element
+}
+
+// TableInheritanceElements filters elements of type TableInheritance.
+func (c *ElementCollection[E]) FilterTableInheritance() *ElementCollection[*TableInheritance] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TableInheritance)
+		return ok
+	})
+	return (*ElementCollection[*TableInheritance])(ret)
+}
+
 func (e TableLocalityGlobal) element() {}
 
 // Element implements ElementGetter.
@@ -3442,6 +3479,8 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_TableComment{ TableComment: t}
 		case *TableData:
 			e.ElementOneOf = &ElementProto_TableData{ TableData: t}
+		case *TableInheritance:
+			e.ElementOneOf = &ElementProto_TableInheritance{ TableInheritance: t}
 		case *TableLocalityGlobal:
 			e.ElementOneOf = &ElementProto_TableLocalityGlobal{ TableLocalityGlobal: t}
 		case *TableLocalityPrimaryRegion:
@@ -3559,6 +3598,7 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_Table)(nil)),
 	((*ElementProto_TableComment)(nil)),
 	((*ElementProto_TableData)(nil)),
+	((*ElementProto_TableInheritance)(nil)),
 	((*ElementProto_TableLocalityGlobal)(nil)),
 	((*ElementProto_TableLocalityPrimaryRegion)(nil)),
 	((*ElementProto_TableLocalityRegionalByRow)(nil)),
@@ -3655,6 +3695,7 @@ func GetElementTypes() []interface{} {
 	((*Table)(nil)),
 	((*TableComment)(nil)),
 	((*TableData)(nil)),
+	((*TableInheritance)(nil)),
 	((*TableLocalityGlobal)(nil)),
 	((*TableLocalityPrimaryRegion)(nil)),
 	((*TableLocalityRegionalByRow)(nil)),
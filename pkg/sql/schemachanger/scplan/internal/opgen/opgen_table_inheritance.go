@@ -0,0 +1,38 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.TableInheritance)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.TableInheritance) *scop.AddTableInheritance {
+					return &scop.AddTableInheritance{
+						TableID:       this.TableID,
+						ParentTableID: this.ParentTableID,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.TableInheritance) *scop.RemoveTableInheritance {
+					return &scop.RemoveTableInheritance{
+						TableID:       this.TableID,
+						ParentTableID: this.ParentTableID,
+					}
+				}),
+			),
+		),
+	)
+}
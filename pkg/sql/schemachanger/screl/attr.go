@@ -509,6 +509,10 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.RowLevelSecurityForced)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
 	),
+	rel.EntityMapping(t((*scpb.TableInheritance)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(ReferencedDescID, "ParentTableID"),
+	),
 	rel.EntityMapping(t((*scpb.LDRJobIDs)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
 	),
@@ -9063,6 +9063,78 @@ specified store on the node it's run from. One of 'mvccGC', 'merge', 'split',
 			CalledOnNullInput: true,
 		},
 	),
+	"crdb_internal.plpgsql_open_dynamic": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "name", Typ: types.RefCursor},
+				{Name: "query", Typ: types.String},
+				{Name: "params", Typ: types.AnyTuple},
+				{Name: "foundColumn", Typ: types.Bool},
+				{Name: "scroll", Typ: types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, errors.AssertionFailedf("expected non-null cursor name")
+				}
+				if args[1] == tree.DNull {
+					return nil, pgerror.New(
+						pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null",
+					)
+				}
+				var params tree.Datums
+				if args[2] != tree.DNull {
+					params = tree.MustBeDTuple(args[2]).D
+				}
+				return tree.DNull, evalCtx.Planner.PLpgSQLOpenDynamicCursor(
+					ctx,
+					tree.Name(tree.MustBeDString(args[0])),
+					string(tree.MustBeDString(args[1])),
+					params,
+					args[3] == tree.DBoolTrue,
+					args[4] == tree.DBoolTrue,
+				)
+			},
+			Info:              "This function is used internally to implement the PLpgSQL OPEN ... FOR EXECUTE statement and FOR loops over dynamic queries.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+	"crdb_internal.record_field": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "record", Typ: types.AnyTuple},
+				{Name: "field", Typ: types.String},
+				{Name: "type", Typ: types.AnyElement},
+			},
+			// The result type is passed as a tuple containing a single typed NULL
+			// value, so that the type is preserved through evaluation.
+			ReturnType: func(args []tree.TypedExpr) *types.T {
+				if len(args) < 3 {
+					return tree.UnknownReturnType
+				}
+				typ := args[2].ResolvedType()
+				if typ.Family() != types.TupleFamily || len(typ.TupleContents()) != 1 {
+					return tree.UnknownReturnType
+				}
+				return typ.TupleContents()[0]
+			},
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				typ := args[2].(tree.TypedExpr).ResolvedType().TupleContents()[0]
+				return eval.ResolveRecordField(
+					ctx, evalCtx, tree.MustBeDTuple(args[0]), string(tree.MustBeDString(args[1])), typ,
+				)
+			},
+			Info:       "This function is used internally to access a field of a PLpgSQL RECORD variable by name.",
+			Volatility: volatility.Stable,
+		},
+	),
 	"crdb_internal.protect_mvcc_history": makeBuiltin(
 		tree.FunctionProperties{
 			Category:     builtinconstants.CategoryClusterReplication,
@@ -2662,6 +2662,10 @@ var builtinOidsArray = []string{
 	2699: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
 	2700: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
 	2701: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2702: `pg_notify(channel: string, payload: string) -> void`,
+	2703: `crdb_internal.plpgsql_open_dynamic(name: refcursor, query: string, params: tuple, foundColumn: bool, scroll: bool) -> int`,
+	2704: `crdb_internal.plpgsql_execute_dynamic(query: string, params: tuple, resultTypes: anyelement) -> anyelement`,
+	2705: `crdb_internal.record_field(record: tuple, field: string, type: anyelement) -> anyelement`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -768,6 +768,26 @@ The last argument is a JSONB object containing the following optional fields:
 		makeInternallyExecutedQueryGeneratorOverload(false /* withSessionBound */, true /* withOverrides */, true /* withTxn */),
 		makeInternallyExecutedQueryGeneratorOverload(true /* withSessionBound */, true /* withOverrides */, true /* withTxn */),
 	),
+	"crdb_internal.plpgsql_execute_dynamic": makeBuiltin(
+		tree.FunctionProperties{
+			Undocumented:     true,
+			Category:         builtinconstants.CategoryGenerator,
+			DistsqlBlocklist: true, // the query is executed through the planner
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "query", Typ: types.String},
+				{Name: "params", Typ: types.AnyTuple},
+				{Name: "resultTypes", Typ: types.AnyElement},
+			},
+			ReturnType:        tree.IdentityReturnType(2),
+			Generator:         eval.GeneratorOverload(makePLpgSQLExecuteDynamicGenerator),
+			Class:             tree.GeneratorClass,
+			Info:              "This function is used internally to implement the PLpgSQL EXECUTE and RETURN QUERY EXECUTE statements.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
 }
 
 var decodePlanGistGeneratorType = types.String
@@ -3913,3 +3933,95 @@ func (qi *internallyExecutedQueryIterator) Close(context.Context) {
 func (qi *internallyExecutedQueryIterator) ResolvedType() *types.T {
 	return internallyExecutedQueryGeneratorType
 }
+
+// plpgsqlExecuteDynamicGenerator executes a dynamic SQL query for a PLpgSQL
+// EXECUTE statement, and produces its result rows coerced to the types of the
+// resultTypes tuple. If the resultTypes tuple consists of a single RECORD
+// column, each row is instead produced as a single tuple that is labeled with
+// the names of the result columns.
+type plpgsqlExecuteDynamicGenerator struct {
+	evalCtx *eval.Context
+	query   string
+	params  tree.Datums
+	typ     *types.T
+
+	rows   []tree.Datums
+	rowTyp *types.T
+	idx    int
+	buf    tree.Datums
+}
+
+var _ eval.ValueGenerator = &plpgsqlExecuteDynamicGenerator{}
+
+func makePLpgSQLExecuteDynamicGenerator(
+	_ context.Context, evalCtx *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	if args[0] == tree.DNull {
+		return nil, pgerror.New(
+			pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null",
+		)
+	}
+	var params tree.Datums
+	if args[1] != tree.DNull {
+		params = tree.MustBeDTuple(args[1]).D
+	}
+	typ := args[2].ResolvedType()
+	if typ.Family() != types.TupleFamily {
+		return nil, errors.AssertionFailedf("expected tuple of result types, got %s", typ.SQLStringForError())
+	}
+	return &plpgsqlExecuteDynamicGenerator{
+		evalCtx: evalCtx,
+		query:   string(tree.MustBeDString(args[0])),
+		params:  params,
+		typ:     typ,
+		buf:     make(tree.Datums, len(typ.TupleContents())),
+	}, nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *plpgsqlExecuteDynamicGenerator) ResolvedType() *types.T {
+	return g.typ
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *plpgsqlExecuteDynamicGenerator) Start(ctx context.Context, _ *kv.Txn) (err error) {
+	g.rows, g.rowTyp, err = g.evalCtx.Planner.PLpgSQLExecuteDynamic(ctx, g.query, g.params)
+	g.idx = -1
+	return err
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *plpgsqlExecuteDynamicGenerator) Next(ctx context.Context) (bool, error) {
+	g.idx++
+	if g.idx >= len(g.rows) {
+		return false, nil
+	}
+	row := g.rows[g.idx]
+	resultTypes := g.typ.TupleContents()
+	if len(resultTypes) == 1 && resultTypes[0].Identical(types.AnyTuple) {
+		// The result is assigned to a RECORD variable, so it takes on the shape
+		// of the query's result columns.
+		g.buf[0] = tree.NewDTuple(g.rowTyp, row...)
+		return true, nil
+	}
+	for i := range g.buf {
+		if i < len(row) {
+			var err error
+			g.buf[i], err = eval.PerformCastNoTruncate(ctx, g.evalCtx, row[i], resultTypes[i])
+			if err != nil {
+				return false, err
+			}
+		} else {
+			g.buf[i] = tree.DNull
+		}
+	}
+	return true, nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *plpgsqlExecuteDynamicGenerator) Values() (tree.Datums, error) {
+	return g.buf, nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *plpgsqlExecuteDynamicGenerator) Close(context.Context) {}
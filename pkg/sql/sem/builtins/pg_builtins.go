@@ -1369,6 +1369,34 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// See https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-INFO-SESSION.
+	"pg_notify": makeBuiltin(tree.FunctionProperties{DistsqlBlocklist: true},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "channel", Typ: types.String},
+				{Name: "payload", Typ: types.String},
+			},
+			ReturnType:        tree.FixedReturnType(types.Void),
+			CalledOnNullInput: true,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				var channel, payload string
+				if args[0] != tree.DNull {
+					channel = string(tree.MustBeDString(args[0]))
+				}
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := evalCtx.Planner.SendNotification(ctx, channel, payload); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification event containing the payload to the sessions " +
+				"listening on the channel once the current transaction commits.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	"pg_sleep": makeBuiltin(
 		tree.FunctionProperties{},
 		tree.Overload{
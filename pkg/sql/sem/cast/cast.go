@@ -227,6 +227,29 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Domains have dynamic OIDs, so they can't be populated in castMap. A cast
+	// to or from a domain is valid if the corresponding cast of its base type
+	// is valid. The base type of a domain must be hydrated.
+	if src.IsDomain() || tgt.IsDomain() {
+		if src.Oid() == tgt.Oid() {
+			return Cast{
+				MaxContext: ContextImplicit,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+		if src.IsDomain() {
+			if src = src.DomainBaseType(); src == nil {
+				return Cast{}, false
+			}
+		}
+		if tgt.IsDomain() {
+			if tgt = tgt.DomainBaseType(); tgt == nil {
+				return Cast{}, false
+			}
+		}
+		return LookupCast(src, tgt)
+	}
+
 	// Enums have dynamic OIDs, so they can't be populated in castMap. Instead,
 	// we dynamically create cast structs for valid enum casts.
 	if srcFamily == types.EnumFamily && tgtFamily == types.StringFamily {
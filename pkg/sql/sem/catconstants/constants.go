@@ -107,6 +107,8 @@ const (
 	TxnExecInsightsTableName               SystemTableName = "transaction_execution_insights"
 	TableMetadata                          SystemTableName = "table_metadata"
 	PreparedTransactionsTableName          SystemTableName = "prepared_transactions"
+	NotificationsTableName                 SystemTableName = "notifications"
+	ReplicationSlotsTableName              SystemTableName = "replication_slots"
 )
 
 // Oid for virtual database and table.
@@ -96,6 +96,12 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	// Values of a domain have the representation of its base type. Note that
+	// the constraints of the domain are not checked here; the optimizer adds
+	// the checks when it builds casts to domains.
+	if base := t.DomainBaseType(); base != nil {
+		t = base
+	}
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -425,6 +425,30 @@ type Planner interface {
 	// PLpgSQL FETCH statement.
 	PLpgSQLFetchCursor(ctx context.Context, cursor *tree.CursorStmt) (res tree.Datums, err error)
 
+	// PLpgSQLOpenDynamicCursor executes the given query with the given
+	// placeholder values and opens a cursor with the given name over its result.
+	// If foundColumn is true, each row of the cursor is prefixed with a true
+	// value, so that a fetched row can be distinguished from the end of the
+	// cursor. If scroll is true, the cursor is able to move backward. Used to
+	// implement the PLpgSQL OPEN ... FOR EXECUTE statement and FOR loops over
+	// dynamic queries.
+	PLpgSQLOpenDynamicCursor(
+		ctx context.Context,
+		cursorName tree.Name,
+		query string,
+		params tree.Datums,
+		foundColumn bool,
+		scroll bool,
+	) error
+
+	// PLpgSQLExecuteDynamic executes the given query with the given placeholder
+	// values and returns the buffered result rows, along with a labeled tuple
+	// type describing the result columns. Used to implement the PLpgSQL
+	// EXECUTE and RETURN QUERY EXECUTE statements.
+	PLpgSQLExecuteDynamic(
+		ctx context.Context, query string, params tree.Datums,
+	) (rows []tree.Datums, rowTyp *types.T, err error)
+
 	// AutoCommit indicates whether the Planner has flagged the current statement
 	// as eligible for transaction auto-commit.
 	AutoCommit() bool
@@ -448,6 +472,11 @@ type Planner interface {
 
 	// ClearTableStatsCache removes all entries from the node's table stats cache.
 	ClearTableStatsCache()
+
+	// SendNotification sends a notification with the given payload on the
+	// given channel once the current transaction commits. It is used by
+	// pg_notify.
+	SendNotification(ctx context.Context, channel, payload string) error
 }
 
 // InternalRows is an iterator interface that's exposed by the internal
@@ -10,6 +10,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -250,9 +251,46 @@ func (e *evaluator) EvalColumnAccessExpr(
 	if d == tree.DNull {
 		return d, nil
 	}
+	if expr.ColIndex < 0 {
+		return ResolveRecordField(ctx, e.ctx(), d.(*tree.DTuple), string(expr.ColName), expr.ResolvedType())
+	}
 	return d.(*tree.DTuple).D[expr.ColIndex], nil
 }
 
+// ResolveRecordField returns the field with the given name from a tuple whose
+// shape was not known during type-checking, such as the value of a PL/pgSQL
+// RECORD variable. The field is cast to the given type, converting through a
+// string if there is no direct cast.
+func ResolveRecordField(
+	ctx context.Context, evalCtx *Context, tup *tree.DTuple, field string, typ *types.T,
+) (tree.Datum, error) {
+	idx := -1
+	for i, label := range tup.ResolvedType().TupleLabels() {
+		if label == field {
+			if idx != -1 {
+				return nil, pgerror.Newf(pgcode.AmbiguousColumn, "column reference %q is ambiguous", label)
+			}
+			idx = i
+		}
+	}
+	if idx < 0 || idx >= len(tup.D) {
+		return nil, pgerror.Newf(pgcode.UndefinedColumn,
+			"could not identify column %q in record data type", field,
+		)
+	}
+	d := tup.D[idx]
+	if d == tree.DNull || d.ResolvedType().Identical(typ) {
+		return d, nil
+	}
+	if !cast.ValidCast(d.ResolvedType(), typ, cast.ContextExplicit) {
+		var err error
+		if d, err = PerformCast(ctx, evalCtx, d, types.String); err != nil {
+			return nil, err
+		}
+	}
+	return PerformCast(ctx, evalCtx, d, typ)
+}
+
 func (e *evaluator) EvalColumnItem(ctx context.Context, expr *tree.ColumnItem) (tree.Datum, error) {
 	return nil, errors.AssertionFailedf("unhandled type %T", expr)
 }
@@ -11,7 +11,6 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
 type Expr = tree.Expr
@@ -183,14 +182,25 @@ func (s *Declaration) WalkStmt(visitor StatementVisitor) Statement {
 
 type CursorDeclaration struct {
 	StatementImpl
-	Name        Variable
-	Scroll      tree.CursorScrollOption
+	Name   Variable
+	Scroll tree.CursorScrollOption
+	// Args are the arguments of the cursor, which can be referenced by the
+	// query and are supplied when the cursor is opened. Args is nil if the
+	// cursor was declared without an argument list.
+	Args        []CursorArg
 	Query       tree.Statement
 	Annotations *tree.Annotations
 }
 
+// CursorArg is an argument in the declaration of a bound cursor.
+type CursorArg struct {
+	Name Variable
+	Typ  tree.ResolvableTypeReference
+}
+
 func (s *CursorDeclaration) CopyNode() *CursorDeclaration {
 	copyNode := *s
+	copyNode.Args = append([]CursorArg(nil), copyNode.Args...)
 	return &copyNode
 }
 
@@ -203,7 +213,20 @@ func (s *CursorDeclaration) Format(ctx *tree.FmtCtx) {
 		case tree.NoScroll:
 			ctx.WriteString(" NO SCROLL")
 		}
-		ctx.WriteString(" CURSOR FOR ")
+		ctx.WriteString(" CURSOR ")
+		if s.Args != nil {
+			ctx.WriteString("(")
+			for i := range s.Args {
+				if i > 0 {
+					ctx.WriteString(", ")
+				}
+				ctx.FormatNode(&s.Args[i].Name)
+				ctx.WriteString(" ")
+				ctx.FormatTypeReference(s.Args[i].Typ)
+			}
+			ctx.WriteString(") ")
+		}
+		ctx.WriteString("FOR ")
 		ctx.FormatNode(s.Query)
 		ctx.WriteString(";\n")
 	})
@@ -639,6 +662,85 @@ func (c *IntForLoopControl) Format(ctx *tree.FmtCtx) {
 	}
 }
 
+// QueryForLoopControl is used for a FOR loop that iterates over the rows
+// returned by a query.
+type QueryForLoopControl struct {
+	Query       tree.Statement
+	Annotations *tree.Annotations
+}
+
+var _ ForLoopControl = &QueryForLoopControl{}
+
+func (c *QueryForLoopControl) isForLoopControl() {}
+
+func (c *QueryForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.WithAnnotations(c.Annotations, func() {
+		ctx.FormatNode(c.Query)
+	})
+}
+
+// CursorForLoopControl is used for a FOR loop that iterates over the rows
+// returned by a bound cursor, which is opened with the given arguments.
+type CursorForLoopControl struct {
+	CurVar Variable
+	// Args is nil if the cursor was not given an argument list.
+	Args []Expr
+}
+
+var _ ForLoopControl = &CursorForLoopControl{}
+
+func (c *CursorForLoopControl) isForLoopControl() {}
+
+func (c *CursorForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&c.CurVar)
+	formatCursorArgs(ctx, c.Args)
+}
+
+// DynamicForLoopControl is used for a FOR loop that iterates over the rows
+// returned by a query string that is computed at execution time.
+type DynamicForLoopControl struct {
+	Query  Expr
+	Params []Expr
+}
+
+var _ ForLoopControl = &DynamicForLoopControl{}
+
+func (c *DynamicForLoopControl) isForLoopControl() {}
+
+func (c *DynamicForLoopControl) Format(ctx *tree.FmtCtx) {
+	formatDynamicQuery(ctx, c.Query, c.Params)
+}
+
+// formatCursorArgs formats the arguments supplied when opening a bound cursor.
+func formatCursorArgs(ctx *tree.FmtCtx, args []Expr) {
+	if args == nil {
+		return
+	}
+	ctx.WriteString("(")
+	for i := range args {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(args[i])
+	}
+	ctx.WriteString(")")
+}
+
+// formatDynamicQuery formats an EXECUTE clause with a query string and
+// optional USING parameters.
+func formatDynamicQuery(ctx *tree.FmtCtx, query Expr, params []Expr) {
+	ctx.WriteString("EXECUTE ")
+	ctx.FormatNode(query)
+	for i := range params {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(params[i])
+	}
+}
+
 // stmt_for
 type ForLoop struct {
 	StatementImpl
@@ -685,6 +787,12 @@ func (s *ForLoop) PlpgSQLStatementTag() string {
 	switch s.Control.(type) {
 	case *IntForLoopControl:
 		return "stmt_for_int_loop"
+	case *QueryForLoopControl:
+		return "stmt_for_query_loop"
+	case *CursorForLoopControl:
+		return "stmt_for_cursor_loop"
+	case *DynamicForLoopControl:
+		return "stmt_for_dynamic_loop"
 	}
 	return "stmt_for_unknown"
 }
@@ -709,14 +817,51 @@ func (s *ForLoop) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_foreach_a
 type ForEachArray struct {
 	StatementImpl
-	Label string
-	Var   *Variable
-	Slice int // TODO(drewk): not sure what this is
+	Label  string
+	Target []Variable
+	// Slice is the number of array dimensions assigned to the target on each
+	// iteration. It is zero if the loop iterates over individual elements.
+	Slice int
 	Expr  Expr
 	Body  []Statement
 }
 
+func (s *ForEachArray) CopyNode() *ForEachArray {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), copyNode.Target...)
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
+}
+
 func (s *ForEachArray) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOREACH ")
+	for i, target := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatName(string(target))
+	}
+	if s.Slice != 0 {
+		ctx.WriteString(" SLICE ")
+		ctx.WriteString(strconv.Itoa(s.Slice))
+	}
+	ctx.WriteString(" IN ARRAY ")
+	ctx.FormatNode(s.Expr)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForEachArray) PlpgSQLStatementTag() string {
@@ -724,7 +869,20 @@ func (s *ForEachArray) PlpgSQLStatementTag() string {
 }
 
 func (s *ForEachArray) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForEachArray).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 // stmt_exit
@@ -857,10 +1015,16 @@ type ReturnQuery struct {
 	StatementImpl
 	SqlStmt     tree.Statement
 	Annotations *tree.Annotations
+
+	// DynamicQuery and Params are set instead of SqlStmt for the RETURN QUERY
+	// EXECUTE form, which executes a query string built at runtime.
+	DynamicQuery Expr
+	Params       []Expr
 }
 
 func (s *ReturnQuery) CopyNode() *ReturnQuery {
 	copyNode := *s
+	copyNode.Params = append([]Expr(nil), s.Params...)
 	return &copyNode
 }
 
@@ -870,6 +1034,9 @@ func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
 		if s.SqlStmt != nil {
 			ctx.WriteByte(' ')
 			ctx.FormatNode(s.SqlStmt)
+		} else if s.DynamicQuery != nil {
+			ctx.WriteByte(' ')
+			formatDynamicQuery(ctx, s.DynamicQuery, s.Params)
 		}
 		ctx.WriteString(";\n")
 	})
@@ -1024,35 +1191,47 @@ func (s *Execute) WalkStmt(visitor StatementVisitor) Statement {
 }
 
 // stmt_dynexecute
-// TODO(chengxiong): query should be a better expression type.
 type DynamicExecute struct {
 	StatementImpl
-	Query  string
-	Into   bool
+	// Query is an expression that evaluates to the string of the command to
+	// execute.
+	Query  Expr
+	Target []Variable
 	Strict bool
-	Target Variable
 	Params []Expr
 }
 
 func (s *DynamicExecute) CopyNode() *DynamicExecute {
 	copyNode := *s
+	copyNode.Target = append([]Variable(nil), s.Target...)
 	copyNode.Params = append([]Expr(nil), s.Params...)
 	return &copyNode
 }
 
 func (s *DynamicExecute) Format(ctx *tree.FmtCtx) {
-	// TODO(drewk): Pretty print the original command
-	ctx.WriteString("EXECUTE a dynamic command")
-	if s.Into {
-		ctx.WriteString(" WITH INTO")
+	ctx.WriteString("EXECUTE ")
+	ctx.FormatNode(s.Query)
+	if s.Target != nil {
+		ctx.WriteString(" INTO ")
 		if s.Strict {
-			ctx.WriteString(" STRICT")
+			ctx.WriteString("STRICT ")
+		}
+		for i := range s.Target {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(&s.Target[i])
 		}
 	}
-	if s.Params != nil {
-		ctx.WriteString(" WITH USING")
+	for i := range s.Params {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(s.Params[i])
 	}
-	ctx.WriteString("\n")
+	ctx.WriteString(";\n")
 }
 
 func (s *DynamicExecute) PlpgSQLStatementTag() string {
@@ -1067,10 +1246,27 @@ func (s *DynamicExecute) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_perform
 type Perform struct {
 	StatementImpl
-	Expr Expr
+	// SqlStmt is the SELECT statement obtained by replacing the PERFORM keyword
+	// with SELECT.
+	SqlStmt     tree.Statement
+	Annotations *tree.Annotations
+}
+
+func (s *Perform) CopyNode() *Perform {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *Perform) Format(ctx *tree.FmtCtx) {
+	ctx.WithAnnotations(s.Annotations, func() {
+		// The query is formatted without its leading SELECT keyword, which is
+		// replaced by PERFORM.
+		stmtCtx := ctx.Clone()
+		stmtCtx.FormatNode(s.SqlStmt)
+		ctx.WriteString("PERFORM ")
+		ctx.WriteString(strings.TrimPrefix(stmtCtx.CloseAndGetString(), "SELECT "))
+		ctx.WriteString(";\n")
+	})
 }
 
 func (s *Perform) PlpgSQLStatementTag() string {
@@ -1078,7 +1274,8 @@ func (s *Perform) PlpgSQLStatementTag() string {
 }
 
 func (s *Perform) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_call
@@ -1215,14 +1412,23 @@ func (s *GetDiagnostics) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_open
 type Open struct {
 	StatementImpl
-	CurVar      Variable
-	Scroll      tree.CursorScrollOption
+	CurVar Variable
+	Scroll tree.CursorScrollOption
+	// Args are the arguments supplied to a bound cursor. Args is nil if no
+	// argument list was given.
+	Args        []Expr
 	Query       tree.Statement
 	Annotations *tree.Annotations
+	// DynamicQuery is set for OPEN ... FOR EXECUTE, in which case the query
+	// string is computed at execution time with the given parameters.
+	DynamicQuery Expr
+	Params       []Expr
 }
 
 func (s *Open) CopyNode() *Open {
 	copyNode := *s
+	copyNode.Args = append([]Expr(nil), copyNode.Args...)
+	copyNode.Params = append([]Expr(nil), copyNode.Params...)
 	return &copyNode
 }
 
@@ -1230,6 +1436,7 @@ func (s *Open) Format(ctx *tree.FmtCtx) {
 	ctx.WithAnnotations(s.Annotations, func() {
 		ctx.WriteString("OPEN ")
 		ctx.FormatNode(&s.CurVar)
+		formatCursorArgs(ctx, s.Args)
 		switch s.Scroll {
 		case tree.Scroll:
 			ctx.WriteString(" SCROLL")
@@ -1239,6 +1446,9 @@ func (s *Open) Format(ctx *tree.FmtCtx) {
 		if s.Query != nil {
 			ctx.WriteString(" FOR ")
 			ctx.FormatNode(s.Query)
+		} else if s.DynamicQuery != nil {
+			ctx.WriteString(" FOR ")
+			formatDynamicQuery(ctx, s.DynamicQuery, s.Params)
 		}
 		ctx.WriteString(";\n")
 	})
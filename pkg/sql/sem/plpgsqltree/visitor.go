@@ -7,7 +7,6 @@ package plpgsqltree
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	unimp "github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
 // StatementVisitor defines methods that are called plpgsql statements during
@@ -71,6 +70,30 @@ func (v *SQLStmtVisitor) visitStmt(stmt tree.Statement) (newStmt tree.Statement,
 	return newStmt, v.Err
 }
 
+// visitExprs calls the visitor function on each of the given expressions. If
+// any of the expressions are changed, a new slice is returned and changed is
+// true.
+func (v *SQLStmtVisitor) visitExprs(
+	exprs []tree.Expr,
+) (newExprs []tree.Expr, changed bool, err error) {
+	newExprs = exprs
+	for i := range exprs {
+		var e tree.Expr
+		e, err = v.visitExpr(exprs[i])
+		if err != nil {
+			return exprs, false, err
+		}
+		if e != exprs[i] {
+			if !changed {
+				newExprs = append([]tree.Expr(nil), exprs...)
+				changed = true
+			}
+			newExprs[i] = e
+		}
+	}
+	return newExprs, changed, nil
+}
+
 func (v *SQLStmtVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool) {
 	if v.Err != nil {
 		return stmt, false
@@ -104,9 +127,36 @@ func (v *SQLStmtVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool)
 		if v.Err != nil {
 			return stmt, false
 		}
-		if t.Query != s {
+		e, v.Err = v.visitExpr(t.DynamicQuery)
+		if v.Err != nil {
+			return stmt, false
+		}
+		var newArgs, newParams []tree.Expr
+		var argsChanged, paramsChanged bool
+		newArgs, argsChanged, v.Err = v.visitExprs(t.Args)
+		if v.Err != nil {
+			return stmt, false
+		}
+		newParams, paramsChanged, v.Err = v.visitExprs(t.Params)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s || t.DynamicQuery != e || argsChanged || paramsChanged {
 			cpy := t.CopyNode()
 			cpy.Query = s
+			cpy.DynamicQuery = e
+			cpy.Args = newArgs
+			cpy.Params = newParams
+			newStmt = cpy
+		}
+	case *Perform:
+		s, v.Err = v.visitStmt(t.SqlStmt)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.SqlStmt != s {
+			cpy := t.CopyNode()
+			cpy.SqlStmt = s
 			newStmt = cpy
 		}
 	case *Declaration:
@@ -205,9 +255,21 @@ func (v *SQLStmtVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool)
 		if v.Err != nil {
 			return stmt, false
 		}
-		if t.SqlStmt != s {
+		e, v.Err = v.visitExpr(t.DynamicQuery)
+		if v.Err != nil {
+			return stmt, false
+		}
+		var newParams []tree.Expr
+		var changed bool
+		newParams, changed, v.Err = v.visitExprs(t.Params)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.SqlStmt != s || t.DynamicQuery != e || changed {
 			cpy := t.CopyNode()
 			cpy.SqlStmt = s
+			cpy.DynamicQuery = e
+			cpy.Params = newParams
 			newStmt = cpy
 		}
 	case *Raise:
@@ -249,18 +311,21 @@ func (v *SQLStmtVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool)
 		}
 
 	case *DynamicExecute:
-		for i, p := range t.Params {
-			e, v.Err = v.visitExpr(p)
-			if v.Err != nil {
-				return stmt, false
-			}
-			if t.Params[i] != e {
-				if newStmt != stmt {
-					cpy := t.CopyNode()
-					newStmt = cpy
-				}
-				newStmt.(*DynamicExecute).Params[i] = e
-			}
+		e, v.Err = v.visitExpr(t.Query)
+		if v.Err != nil {
+			return stmt, false
+		}
+		var newParams []tree.Expr
+		var changed bool
+		newParams, changed, v.Err = v.visitExprs(t.Params)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != e || changed {
+			cpy := t.CopyNode()
+			cpy.Query = e
+			cpy.Params = newParams
+			newStmt = cpy
 		}
 	case *Call:
 		e, v.Err = v.visitExpr(t.Proc)
@@ -305,10 +370,56 @@ func (v *SQLStmtVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool)
 				}
 				newStmt = cpy
 			}
+		case *QueryForLoopControl:
+			s, v.Err = v.visitStmt(c.Query)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if c.Query != s {
+				cpy := t.CopyNode()
+				cpy.Control = &QueryForLoopControl{Query: s, Annotations: c.Annotations}
+				newStmt = cpy
+			}
+		case *CursorForLoopControl:
+			var newArgs []tree.Expr
+			var changed bool
+			newArgs, changed, v.Err = v.visitExprs(c.Args)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if changed {
+				cpy := t.CopyNode()
+				cpy.Control = &CursorForLoopControl{CurVar: c.CurVar, Args: newArgs}
+				newStmt = cpy
+			}
+		case *DynamicForLoopControl:
+			e, v.Err = v.visitExpr(c.Query)
+			if v.Err != nil {
+				return stmt, false
+			}
+			var newParams []tree.Expr
+			var changed bool
+			newParams, changed, v.Err = v.visitExprs(c.Params)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if c.Query != e || changed {
+				cpy := t.CopyNode()
+				cpy.Control = &DynamicForLoopControl{Query: e, Params: newParams}
+				newStmt = cpy
+			}
 		}
 
-	case *ForEachArray, *Perform:
-		panic(unimp.New("plpgsql visitor", "Unimplemented PLpgSQL visitor"))
+	case *ForEachArray:
+		e, v.Err = v.visitExpr(t.Expr)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
 	}
 	if v.Err != nil {
 		return stmt, false
@@ -318,7 +429,8 @@ func (v *SQLStmtVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool)
 
 // TypeRefVisitor calls the given replace function on each type reference
 // contained in the visited PLpgSQL statements. Note that this currently only
-// includes `Declaration`. SQL statements and expressions are not visited.
+// includes `Declaration` and the arguments of `CursorDeclaration`. SQL
+// statements and expressions are not visited.
 type TypeRefVisitor struct {
 	Fn  func(typ tree.ResolvableTypeReference) (newTyp tree.ResolvableTypeReference, err error)
 	Err error
@@ -331,7 +443,8 @@ func (v *TypeRefVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool)
 		return stmt, false
 	}
 	newStmt = stmt
-	if t, ok := stmt.(*Declaration); ok {
+	switch t := stmt.(type) {
+	case *Declaration:
 		var newTyp tree.ResolvableTypeReference
 		newTyp, v.Err = v.Fn(t.Typ)
 		if v.Err != nil {
@@ -343,6 +456,20 @@ func (v *TypeRefVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool)
 				newStmt.(*Declaration).Typ = newTyp
 			}
 		}
+	case *CursorDeclaration:
+		for i := range t.Args {
+			var newTyp tree.ResolvableTypeReference
+			newTyp, v.Err = v.Fn(t.Args[i].Typ)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if t.Args[i].Typ != newTyp {
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
+				}
+				newStmt.(*CursorDeclaration).Args[i].Typ = newTyp
+			}
+		}
 	}
 	return newStmt, true
 }
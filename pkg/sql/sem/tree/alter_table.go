@@ -78,6 +78,7 @@ func (*AlterTableSetIdentity) alterTableCmd()        {}
 func (*AlterTableIdentity) alterTableCmd()           {}
 func (*AlterTableDropIdentity) alterTableCmd()       {}
 func (*AlterTableSetRLSMode) alterTableCmd()         {}
+func (*AlterTableInherit) alterTableCmd()            {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
@@ -103,6 +104,7 @@ var _ AlterTableCmd = &AlterTableSetIdentity{}
 var _ AlterTableCmd = &AlterTableIdentity{}
 var _ AlterTableCmd = &AlterTableDropIdentity{}
 var _ AlterTableCmd = &AlterTableSetRLSMode{}
+var _ AlterTableCmd = &AlterTableInherit{}
 
 // ColumnMutationCmd is the subset of AlterTableCmds that modify an
 // existing column.
@@ -176,12 +178,13 @@ func (node *AlterTable) HoistAddColumnConstraints(onHoistedFKConstraint func())
 					targetCol = append(targetCol, d.References.Col)
 				}
 				fk := &ForeignKeyConstraintTableDef{
-					Table:    *d.References.Table,
-					FromCols: NameList{d.Name},
-					ToCols:   targetCol,
-					Name:     d.References.ConstraintName,
-					Actions:  d.References.Actions,
-					Match:    d.References.Match,
+					Table:         *d.References.Table,
+					FromCols:      NameList{d.Name},
+					ToCols:        targetCol,
+					Name:          d.References.ConstraintName,
+					Actions:       d.References.Actions,
+					Match:         d.References.Match,
+					Deferrability: d.References.Deferrability,
 				}
 				constraint := &AlterTableAddConstraint{
 					ConstraintDef:      fk,
@@ -941,6 +944,32 @@ func (node *AlterTableSetRLSMode) Format(ctx *FmtCtx) {
 	ctx.WriteString(" ROW LEVEL SECURITY")
 }
 
+// AlterTableInherit represents the following alter table command:
+// [NO] INHERIT parent_table
+type AlterTableInherit struct {
+	Parent TableName
+	// NoInherit is true for NO INHERIT, which removes the parent table from the
+	// table's list of parents.
+	NoInherit bool
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableInherit) TelemetryName() string {
+	if node.NoInherit {
+		return "no_inherit"
+	}
+	return "inherit"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableInherit) Format(ctx *FmtCtx) {
+	if node.NoInherit {
+		ctx.WriteString(" NO")
+	}
+	ctx.WriteString(" INHERIT ")
+	ctx.FormatNode(&node.Parent)
+}
+
 // GetTableType returns a string representing the type of table the command
 // is operating on.
 // It is assumed if the table is not a sequence or a view, then it is a
@@ -138,3 +138,56 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability describes whether the checking of a constraint can be
+// deferred until the end of the transaction, and whether it is deferred by
+// default. See SET CONSTRAINTS.
+type ConstraintDeferrability int8
+
+// The values for ConstraintDeferrability.
+const (
+	// ConstraintNotDeferrable constraints are always checked at the end of
+	// each statement. This is the default.
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	// ConstraintInitiallyImmediate constraints are deferrable, but are checked
+	// at the end of each statement unless they are deferred with SET
+	// CONSTRAINTS.
+	ConstraintInitiallyImmediate
+	// ConstraintInitiallyDeferred constraints are checked at the end of the
+	// transaction unless they are made immediate with SET CONSTRAINTS.
+	ConstraintInitiallyDeferred
+)
+
+// MakeConstraintDeferrability returns the ConstraintDeferrability of a
+// constraint with the given attributes.
+func MakeConstraintDeferrability(deferrable, initiallyDeferred bool) ConstraintDeferrability {
+	switch {
+	case !deferrable:
+		return ConstraintNotDeferrable
+	case initiallyDeferred:
+		return ConstraintInitiallyDeferred
+	default:
+		return ConstraintInitiallyImmediate
+	}
+}
+
+// IsDeferrable returns true if the checking of the constraint can be deferred.
+func (x ConstraintDeferrability) IsDeferrable() bool {
+	return x != ConstraintNotDeferrable
+}
+
+// IsInitiallyDeferred returns true if the checking of the constraint is
+// deferred by default.
+func (x ConstraintDeferrability) IsInitiallyDeferred() bool {
+	return x == ConstraintInitiallyDeferred
+}
+
+// Format implements the NodeFormatter interface.
+func (x *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	switch *x {
+	case ConstraintInitiallyImmediate:
+		ctx.WriteString(" DEFERRABLE")
+	case ConstraintInitiallyDeferred:
+		ctx.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+	}
+}
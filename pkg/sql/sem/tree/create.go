@@ -24,8 +24,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
@@ -375,12 +377,51 @@ type CreateType struct {
 	CompositeTypeList []CompositeTypeElem
 	// IfNotExists is true if IF NOT EXISTS was requested.
 	IfNotExists bool
+	// Domain is set when this represents a CREATE DOMAIN statement.
+	Domain *DomainTypeDef
+}
+
+// DomainTypeDef is the definition of a domain in a CREATE DOMAIN statement.
+type DomainTypeDef struct {
+	// BaseType is the underlying type of the domain.
+	BaseType ResolvableTypeReference
+	// DefaultExpr is the default expression of the domain, or nil.
+	DefaultExpr Expr
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// Checks are the CHECK constraints of the domain.
+	Checks []DomainCheckConstraint
+}
+
+// DomainCheckConstraint is a CHECK constraint on a domain. The expression
+// refers to the value being checked with the VALUE keyword.
+type DomainCheckConstraint struct {
+	// Name is the name of the constraint, or the empty string if the
+	// constraint was not named.
+	Name Name
+	Expr Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainCheckConstraint) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteString(" ")
+	}
+	ctx.WriteString("CHECK (")
+	ctx.FormatNode(node.Expr)
+	ctx.WriteString(")")
 }
 
 var _ Statement = &CreateType{}
 
 // Format implements the NodeFormatter interface.
 func (node *CreateType) Format(ctx *FmtCtx) {
+	if node.Variety == Domain {
+		node.formatDomain(ctx)
+		return
+	}
 	ctx.WriteString("CREATE TYPE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
@@ -407,6 +448,28 @@ func (node *CreateType) Format(ctx *FmtCtx) {
 	}
 }
 
+// formatDomain formats a CREATE DOMAIN statement.
+func (node *CreateType) formatDomain(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(node.TypeName)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Domain.BaseType)
+	if node.Domain.DefaultExpr != nil {
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.Domain.DefaultExpr)
+	}
+	if node.Domain.NotNull {
+		ctx.WriteString(" NOT NULL")
+	}
+	for i := range node.Domain.Checks {
+		ctx.WriteString(" ")
+		ctx.FormatNode(&node.Domain.Checks[i])
+	}
+}
+
 func (node *CreateType) String() string {
 	return AsString(node)
 }
@@ -425,6 +488,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExclusionConstraintTableDef) tableDef()  {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -501,6 +565,7 @@ type ColumnTableDef struct {
 		IsUnique       bool
 		WithoutIndex   bool
 		ConstraintName Name
+		Deferrability  ConstraintDeferrability
 	}
 	DefaultExpr struct {
 		Expr           Expr
@@ -517,6 +582,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrability  ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -578,7 +644,24 @@ func NewColumnTableDef(
 		IsSerial: isSerial,
 	}
 	d.Nullable.Nullability = SilentNull
+	// lastConstraint is the deferrability of the last UNIQUE or REFERENCES
+	// constraint, to which the subsequent constraint attributes apply.
+	var lastConstraint *ConstraintDeferrability
+	var attrs constraintAttrs
+	var pkDeferrability ConstraintDeferrability
 	for _, c := range qualifications {
+		if attr, ok := c.Qualification.(ColumnConstraintAttr); ok {
+			if lastConstraint == nil {
+				return nil, pgerror.Newf(pgcode.Syntax, "misplaced %s clause", attr)
+			}
+			if err := attrs.apply(attr); err != nil {
+				return nil, err
+			}
+			*lastConstraint = attrs.deferrability()
+			continue
+		}
+		lastConstraint = nil
+		attrs = constraintAttrs{}
 		switch t := c.Qualification.(type) {
 		case ColumnCollation:
 			locale := string(t)
@@ -674,6 +757,7 @@ func NewColumnTableDef(
 			d.PrimaryKey.IsPrimaryKey = true
 			d.PrimaryKey.StorageParams = c.Qualification.(PrimaryKeyConstraint).StorageParams
 			d.Unique.ConstraintName = c.Name
+			lastConstraint = &pkDeferrability
 		case ShardedPrimaryKeyConstraint:
 			d.PrimaryKey.IsPrimaryKey = true
 			constraint := c.Qualification.(ShardedPrimaryKeyConstraint)
@@ -681,10 +765,12 @@ func NewColumnTableDef(
 			d.PrimaryKey.ShardBuckets = constraint.ShardBuckets
 			d.PrimaryKey.StorageParams = constraint.StorageParams
 			d.Unique.ConstraintName = c.Name
+			lastConstraint = &pkDeferrability
 		case UniqueConstraint:
 			d.Unique.IsUnique = true
 			d.Unique.WithoutIndex = t.WithoutIndex
 			d.Unique.ConstraintName = c.Name
+			lastConstraint = &d.Unique.Deferrability
 		case *ColumnCheckConstraint:
 			d.CheckExprs = append(d.CheckExprs, ColumnTableDefCheckExpr{
 				Expr:           t.Expr,
@@ -700,6 +786,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			lastConstraint = &d.References.Deferrability
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -720,6 +807,12 @@ func NewColumnTableDef(
 			return nil, errors.AssertionFailedf("unexpected column qualification: %T", c)
 		}
 	}
+	if err := CheckUniqueConstraintDeferrability(true /* primaryKey */, false /* withoutIndex */, pkDeferrability); err != nil {
+		return nil, err
+	}
+	if err := CheckUniqueConstraintDeferrability(false /* primaryKey */, d.Unique.WithoutIndex, d.Unique.Deferrability); err != nil {
+		return nil, err
+	}
 
 	return d, nil
 }
@@ -811,6 +904,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			if node.Unique.WithoutIndex {
 				ctx.WriteString(" WITHOUT INDEX")
 			}
+			ctx.FormatNode(&node.Unique.Deferrability)
 		}
 	}
 	if node.HasDefaultExpr() {
@@ -870,6 +964,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrability)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -950,6 +1045,7 @@ func (*ColumnFKConstraint) columnQualification()         {}
 func (*ColumnFamilyConstraint) columnQualification()     {}
 func (*GeneratedAlwaysAsIdentity) columnQualification()  {}
 func (*GeneratedByDefAsIdentity) columnQualification()   {}
+func (ColumnConstraintAttr) columnQualification()        {}
 
 // ColumnCollation represents a COLLATE clause for a column.
 type ColumnCollation string
@@ -1001,6 +1097,89 @@ type UniqueConstraint struct {
 	WithoutIndex bool
 }
 
+// ColumnConstraintAttr represents a [NOT] DEFERRABLE or INITIALLY {DEFERRED |
+// IMMEDIATE} clause on a column. It applies to the preceding UNIQUE or
+// REFERENCES constraint of the column.
+type ColumnConstraintAttr int8
+
+// The values for ColumnConstraintAttr.
+const (
+	ConstraintAttrDeferrable ColumnConstraintAttr = iota
+	ConstraintAttrNotDeferrable
+	ConstraintAttrInitiallyDeferred
+	ConstraintAttrInitiallyImmediate
+)
+
+// String implements the fmt.Stringer interface.
+func (x ColumnConstraintAttr) String() string {
+	switch x {
+	case ConstraintAttrDeferrable:
+		return "DEFERRABLE"
+	case ConstraintAttrNotDeferrable:
+		return "NOT DEFERRABLE"
+	case ConstraintAttrInitiallyDeferred:
+		return "INITIALLY DEFERRED"
+	case ConstraintAttrInitiallyImmediate:
+		return "INITIALLY IMMEDIATE"
+	default:
+		return strconv.Itoa(int(x))
+	}
+}
+
+// constraintAttrs accumulates the ColumnConstraintAttrs of a constraint.
+type constraintAttrs struct {
+	deferrable, notDeferrable             bool
+	initiallyDeferred, initiallyImmediate bool
+}
+
+func (a *constraintAttrs) apply(attr ColumnConstraintAttr) error {
+	switch attr {
+	case ConstraintAttrDeferrable, ConstraintAttrNotDeferrable:
+		if a.deferrable || a.notDeferrable {
+			return pgerror.New(pgcode.Syntax,
+				"multiple DEFERRABLE/NOT DEFERRABLE clauses not allowed")
+		}
+		a.deferrable = attr == ConstraintAttrDeferrable
+		a.notDeferrable = attr == ConstraintAttrNotDeferrable
+	case ConstraintAttrInitiallyDeferred, ConstraintAttrInitiallyImmediate:
+		if a.initiallyDeferred || a.initiallyImmediate {
+			return pgerror.New(pgcode.Syntax,
+				"multiple INITIALLY IMMEDIATE/DEFERRED clauses not allowed")
+		}
+		a.initiallyDeferred = attr == ConstraintAttrInitiallyDeferred
+		a.initiallyImmediate = attr == ConstraintAttrInitiallyImmediate
+	}
+	if a.notDeferrable && a.initiallyDeferred {
+		return pgerror.New(pgcode.Syntax,
+			"constraint declared INITIALLY DEFERRED must be DEFERRABLE")
+	}
+	return nil
+}
+
+// deferrability returns the ConstraintDeferrability described by the
+// attributes. INITIALLY DEFERRED implies DEFERRABLE.
+func (a *constraintAttrs) deferrability() ConstraintDeferrability {
+	return MakeConstraintDeferrability(a.deferrable || a.initiallyDeferred, a.initiallyDeferred)
+}
+
+// CheckUniqueConstraintDeferrability returns an error if a PRIMARY KEY or
+// UNIQUE constraint with the given deferrability is not supported. Uniqueness
+// that is enforced by an index is checked when the index entries are written,
+// so only UNIQUE WITHOUT INDEX constraints can be deferred.
+func CheckUniqueConstraintDeferrability(
+	primaryKey, withoutIndex bool, d ConstraintDeferrability,
+) error {
+	if !d.IsDeferrable() || withoutIndex {
+		return nil
+	}
+	if primaryKey {
+		return unimplemented.NewWithIssueDetail(31632, "deferrable primary key",
+			"deferrable PRIMARY KEY constraints are not supported")
+	}
+	return unimplemented.NewWithIssueDetail(31632, "deferrable unique",
+		"deferrable UNIQUE constraints are only supported for UNIQUE WITHOUT INDEX constraints")
+}
+
 // ColumnCheckConstraint represents either a check on a column.
 type ColumnCheckConstraint struct {
 	Expr Expr
@@ -1104,14 +1283,16 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExclusionConstraintTableDef) constraintTableDef()  {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
 type UniqueConstraintTableDef struct {
 	IndexTableDef
-	PrimaryKey   bool
-	WithoutIndex bool
-	IfNotExists  bool
+	PrimaryKey    bool
+	WithoutIndex  bool
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 	// FormatAsIndex indicates if the constraint should be formatted as an index
 	// definition. This is needed since indexes support syntax for things like
 	// storage parameters and sharding, while constraints do not.
@@ -1167,6 +1348,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrability)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1186,13 +1368,14 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name        Name
-	Table       TableName
-	FromCols    NameList
-	ToCols      NameList
-	Actions     ReferenceActions
-	Match       CompositeKeyMatchMethod
-	IfNotExists bool
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1223,6 +1406,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrability)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1269,6 +1453,100 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExclusionConstraintTableDef represents an EXCLUDE constraint within a CREATE
+// TABLE statement. The constraint guarantees that for any two rows in the
+// table, at least one of the operators returns false or NULL when it is
+// applied to the corresponding columns of the two rows.
+type ExclusionConstraintTableDef struct {
+	Name Name
+	// Using is the index access method named in the EXCLUDE USING clause. It is
+	// empty if the clause was omitted.
+	Using         string
+	Elems         ExclusionElemList
+	Predicate     Expr
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
+}
+
+// ExclusionElem is a single "column WITH operator" element of an EXCLUDE
+// constraint.
+type ExclusionElem struct {
+	Column   Name
+	Operator treecmp.ComparisonOperator
+}
+
+// ExclusionElemList is a list of ExclusionElems.
+type ExclusionElemList []ExclusionElem
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Using != "" {
+		ctx.WriteString("USING ")
+		ctx.WriteString(node.Using)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+	ctx.FormatNode(&node.Deferrability)
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Column)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// Format implements the NodeFormatter interface.
+func (l *ExclusionElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
+// ExclusionOperator returns the comparison operator with the given symbol if
+// it may be used in an exclusion constraint. Only operators that are
+// commutative are allowed, since an exclusion constraint compares each pair
+// of rows without regard to their order.
+func ExclusionOperator(symbol string) (treecmp.ComparisonOperator, error) {
+	for _, sym := range []treecmp.ComparisonOperatorSymbol{
+		treecmp.EQ, treecmp.NE, treecmp.Overlaps,
+	} {
+		if sym.String() == symbol {
+			return treecmp.MakeComparisonOperator(sym), nil
+		}
+	}
+	return treecmp.ComparisonOperator{}, pgerror.Newf(pgcode.WrongObjectType,
+		"operator %s is not supported in exclusion constraints", symbol)
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1513,6 +1791,10 @@ type CreateTable struct {
 	Defs     TableDefs
 	AsSource *Select
 	Locality *Locality
+	// Inherits lists the parent tables named in an INHERITS clause.
+	Inherits TableNames
+	// Foreign is set when this represents a CREATE FOREIGN TABLE statement.
+	Foreign *ForeignTableDef
 }
 
 // As returns true if this table represents a CREATE TABLE ... AS statement,
@@ -1545,6 +1827,9 @@ func (node *CreateTable) Format(ctx *FmtCtx) {
 	case PersistenceUnlogged:
 		ctx.WriteString("UNLOGGED ")
 	}
+	if node.Foreign != nil {
+		ctx.WriteString("FOREIGN ")
+	}
 	ctx.WriteString("TABLE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
@@ -1573,6 +1858,15 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 		ctx.WriteString(" (")
 		ctx.FormatNode(&node.Defs)
 		ctx.WriteByte(')')
+		if len(node.Inherits) > 0 {
+			ctx.WriteString(" INHERITS (")
+			ctx.FormatNode(&node.Inherits)
+			ctx.WriteByte(')')
+		}
+		if node.Foreign != nil {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(node.Foreign)
+		}
 		if node.PartitionByTable != nil {
 			ctx.FormatNode(node.PartitionByTable)
 		}
@@ -1633,12 +1927,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:         *col.References.Table,
+					FromCols:      NameList{col.Name},
+					ToCols:        targetCol,
+					Name:          col.References.ConstraintName,
+					Actions:       col.References.Actions,
+					Match:         col.References.Match,
+					Deferrability: col.References.Deferrability,
 				})
 				col.References.Table = nil
 			}
@@ -0,0 +1,133 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace bool
+	Name    RoutineName
+	Params  RoutineParams
+	// StateFunc is the state transition function (SFUNC). It is called for
+	// each input row with the current state value followed by the aggregate's
+	// arguments, and returns the new state value.
+	StateFunc RoutineName
+	// StateType is the data type of the aggregate's state value (STYPE).
+	StateType ResolvableTypeReference
+	// FinalFunc, if set, computes the aggregate's result from the final state
+	// value (FINALFUNC). Otherwise, the final state value is the result.
+	FinalFunc *RoutineName
+	// CombineFunc, if set, combines two partial state values into one
+	// (COMBINEFUNC).
+	CombineFunc *RoutineName
+	// InitCond, if set, is the textual representation of the initial state
+	// value (INITCOND). Otherwise, the initial state value is NULL.
+	InitCond *string
+}
+
+// AggregateOptionKind identifies an option in the definition of a CREATE
+// AGGREGATE statement.
+type AggregateOptionKind int
+
+const (
+	// AggregateOptionStateFunc is the SFUNC option.
+	AggregateOptionStateFunc AggregateOptionKind = iota
+	// AggregateOptionStateType is the STYPE option.
+	AggregateOptionStateType
+	// AggregateOptionFinalFunc is the FINALFUNC option.
+	AggregateOptionFinalFunc
+	// AggregateOptionCombineFunc is the COMBINEFUNC option.
+	AggregateOptionCombineFunc
+	// AggregateOptionInitCond is the INITCOND option.
+	AggregateOptionInitCond
+)
+
+// AggregateOption is a single option in the definition of a CREATE AGGREGATE
+// statement. Only the field corresponding to the Kind is set.
+type AggregateOption struct {
+	Kind     AggregateOptionKind
+	Func     RoutineName
+	Type     ResolvableTypeReference
+	InitCond string
+}
+
+// MakeCreateAggregate returns a CreateAggregate with the given options. An
+// error is returned if an option is specified more than once, or if either of
+// the required SFUNC and STYPE options is missing.
+func MakeCreateAggregate(
+	replace bool, name RoutineName, params RoutineParams, options []AggregateOption,
+) (*CreateAggregate, error) {
+	n := &CreateAggregate{
+		Replace: replace,
+		Name:    name,
+		Params:  params,
+	}
+	var seen [AggregateOptionInitCond + 1]bool
+	for i := range options {
+		opt := &options[i]
+		if seen[opt.Kind] {
+			return nil, ErrConflictingRoutineOption
+		}
+		seen[opt.Kind] = true
+		switch opt.Kind {
+		case AggregateOptionStateFunc:
+			n.StateFunc = opt.Func
+		case AggregateOptionStateType:
+			n.StateType = opt.Type
+		case AggregateOptionFinalFunc:
+			n.FinalFunc = &opt.Func
+		case AggregateOptionCombineFunc:
+			n.CombineFunc = &opt.Func
+		case AggregateOptionInitCond:
+			n.InitCond = &opt.InitCond
+		}
+	}
+	if !seen[AggregateOptionStateType] {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if !seen[AggregateOptionStateFunc] {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	return n, nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (SFUNC = ")
+	ctx.FormatNode(&node.StateFunc)
+	ctx.WriteString(", STYPE = ")
+	ctx.FormatTypeReference(node.StateType)
+	if node.FinalFunc != nil {
+		ctx.WriteString(", FINALFUNC = ")
+		ctx.FormatNode(node.FinalFunc)
+	}
+	if node.CombineFunc != nil {
+		ctx.WriteString(", COMBINEFUNC = ")
+		ctx.FormatNode(node.CombineFunc)
+	}
+	if node.InitCond != nil {
+		ctx.WriteString(", INITCOND = ")
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, *node.InitCond, ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteByte(')')
+}
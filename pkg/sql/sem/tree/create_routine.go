@@ -6,6 +6,7 @@
 package tree
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -198,6 +199,11 @@ func (RoutineLeakproof) routineOption()         {}
 func (RoutineBodyStr) routineOption()           {}
 func (RoutineLanguage) routineOption()          {}
 func (RoutineSecurity) routineOption()          {}
+func (RoutineCost) routineOption()              {}
+func (RoutineRows) routineOption()              {}
+func (RoutineParallel) routineOption()          {}
+func (RoutineSupport) routineOption()           {}
+func (*RoutineSet) routineOption()              {}
 
 // RoutineNullInputBehavior represent the UDF property on null parameters.
 type RoutineNullInputBehavior int
@@ -332,6 +338,129 @@ func (node RoutineSecurity) Format(ctx *FmtCtx) {
 	}
 }
 
+// RoutineCost is the estimated execution cost of a function, in units of
+// cpu_operator_cost. It is used by the optimizer when costing calls to the
+// function.
+type RoutineCost float64
+
+// Format implements the NodeFormatter interface.
+func (node RoutineCost) Format(ctx *FmtCtx) {
+	ctx.WriteString("COST ")
+	ctx.WriteString(strconv.FormatFloat(float64(node), 'g', -1, 64))
+}
+
+// RoutineRows is the estimated number of rows returned by a set-returning
+// function. It is used by the optimizer when estimating the cardinality of
+// calls to the function.
+type RoutineRows float64
+
+// Format implements the NodeFormatter interface.
+func (node RoutineRows) Format(ctx *FmtCtx) {
+	ctx.WriteString("ROWS ")
+	ctx.WriteString(strconv.FormatFloat(float64(node), 'g', -1, 64))
+}
+
+// RoutineParallel indicates whether a function is safe to run in parallel.
+type RoutineParallel int
+
+const (
+	// RoutineParallelUnsafe indicates that the function cannot be executed in
+	// parallel mode. This is the default if no parallel option is provided.
+	RoutineParallelUnsafe RoutineParallel = iota
+	// RoutineParallelRestricted indicates that the function can be executed in
+	// parallel mode, but only in the parallel group leader.
+	RoutineParallelRestricted
+	// RoutineParallelSafe indicates that the function is safe to run in
+	// parallel mode without restriction.
+	RoutineParallelSafe
+)
+
+// Format implements the NodeFormatter interface.
+func (node RoutineParallel) Format(ctx *FmtCtx) {
+	ctx.WriteString("PARALLEL ")
+	switch node {
+	case RoutineParallelUnsafe:
+		ctx.WriteString("UNSAFE")
+	case RoutineParallelRestricted:
+		ctx.WriteString("RESTRICTED")
+	case RoutineParallelSafe:
+		ctx.WriteString("SAFE")
+	default:
+		panic(pgerror.New(pgcode.InvalidParameterValue, "unknown routine option"))
+	}
+}
+
+// AsRoutineParallel converts a string to a RoutineParallel. An error is
+// returned if the string is not one of UNSAFE, RESTRICTED, or SAFE.
+func AsRoutineParallel(parallel string) (RoutineParallel, error) {
+	switch strings.ToLower(parallel) {
+	case "unsafe":
+		return RoutineParallelUnsafe, nil
+	case "restricted":
+		return RoutineParallelRestricted, nil
+	case "safe":
+		return RoutineParallelSafe, nil
+	}
+	return 0, pgerror.Newf(pgcode.Syntax,
+		"parameter \"parallel\" must be SAFE, RESTRICTED, or UNSAFE")
+}
+
+// RoutineSupport names the planner support function of a function.
+type RoutineSupport Name
+
+// Format implements the NodeFormatter interface.
+func (node RoutineSupport) Format(ctx *FmtCtx) {
+	ctx.WriteString("SUPPORT ")
+	name := Name(node)
+	ctx.FormatNode(&name)
+}
+
+// RoutineSet represents a SET or RESET clause of a routine definition, which
+// overrides the value of a session variable while the routine executes.
+type RoutineSet struct {
+	// Name is the name of the session variable. It is empty if ResetAll is
+	// true.
+	Name string
+	// Values are the values the session variable is set to. It is empty if
+	// FromCurrent, Reset, or ResetAll is true.
+	Values Exprs
+	// FromCurrent is true for SET ... FROM CURRENT, which captures the value
+	// of the session variable at the time the routine is created.
+	FromCurrent bool
+	// Reset is true for RESET clauses, which remove an override from the
+	// routine. It is only valid in ALTER FUNCTION and ALTER PROCEDURE.
+	Reset bool
+	// ResetAll is true for RESET ALL, which removes all overrides from the
+	// routine.
+	ResetAll bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *RoutineSet) Format(ctx *FmtCtx) {
+	if node.ResetAll {
+		ctx.WriteString("RESET ALL")
+		return
+	}
+	if node.Reset {
+		ctx.WriteString("RESET ")
+	} else {
+		ctx.WriteString("SET ")
+	}
+	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+		// Session var names never contain PII and should be distinguished
+		// for feature tracking purposes.
+		ctx.FormatNameP(&node.Name)
+	})
+	switch {
+	case node.Reset:
+	case node.FromCurrent:
+		ctx.WriteString(" FROM CURRENT")
+	default:
+		ctx.WriteString(" = ")
+		ctx.FormatNode(&node.Values)
+	}
+}
+
 // RoutineBodyStr is a string containing all statements in a UDF body.
 type RoutineBodyStr string
 
@@ -406,10 +535,10 @@ const (
 )
 
 // IsInParamClass returns true if the given parameter class specifies an input
-// parameter (i.e. either unspecified, IN or, INOUT).
+// parameter (i.e. either unspecified, IN, INOUT, or VARIADIC).
 func IsInParamClass(class RoutineParamClass) bool {
 	switch class {
-	case RoutineParamDefault, RoutineParamIn, RoutineParamInOut:
+	case RoutineParamDefault, RoutineParamIn, RoutineParamInOut, RoutineParamVariadic:
 		return true
 	default:
 		return false
@@ -445,10 +574,13 @@ type RoutineReturnType struct {
 	SetOf bool
 }
 
-// DropRoutine represents a DROP FUNCTION or DROP PROCEDURE statement.
+// DropRoutine represents a DROP FUNCTION, DROP PROCEDURE, or DROP AGGREGATE
+// statement.
 type DropRoutine struct {
-	IfExists     bool
-	Procedure    bool
+	IfExists  bool
+	Procedure bool
+	// Aggregate is true for DROP AGGREGATE statements.
+	Aggregate    bool
 	Routines     RoutineObjs
 	DropBehavior DropBehavior
 }
@@ -457,6 +589,8 @@ type DropRoutine struct {
 func (node *DropRoutine) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("DROP AGGREGATE ")
 	} else {
 		ctx.WriteString("DROP FUNCTION ")
 	}
@@ -516,18 +650,21 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterRoutineRename represents a ALTER FUNCTION...RENAME or
-// ALTER PROCEDURE...RENAME statement.
+// AlterRoutineRename represents a ALTER FUNCTION...RENAME,
+// ALTER PROCEDURE...RENAME, or ALTER AGGREGATE...RENAME statement.
 type AlterRoutineRename struct {
 	Function  RoutineObj
 	NewName   Name
 	Procedure bool
+	Aggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineRename) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -536,18 +673,21 @@ func (node *AlterRoutineRename) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.NewName)
 }
 
-// AlterRoutineSetSchema represents a ALTER FUNCTION...SET SCHEMA or
-// ALTER PROCEDURE...SET SCHEMA statement.
+// AlterRoutineSetSchema represents a ALTER FUNCTION...SET SCHEMA,
+// ALTER PROCEDURE...SET SCHEMA, or ALTER AGGREGATE...SET SCHEMA statement.
 type AlterRoutineSetSchema struct {
 	Function      RoutineObj
 	NewSchemaName Name
 	Procedure     bool
+	Aggregate     bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineSetSchema) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -556,18 +696,21 @@ func (node *AlterRoutineSetSchema) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.NewSchemaName)
 }
 
-// AlterRoutineSetOwner represents the ALTER FUNCTION...OWNER TO or
-// ALTER PROCEDURE...OWNER TO statement.
+// AlterRoutineSetOwner represents the ALTER FUNCTION...OWNER TO,
+// ALTER PROCEDURE...OWNER TO, or ALTER AGGREGATE...OWNER TO statement.
 type AlterRoutineSetOwner struct {
 	Function  RoutineObj
 	NewOwner  RoleSpec
 	Procedure bool
+	Aggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineSetOwner) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -638,6 +781,8 @@ const (
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
 	PolicyUsingExpr                 SchemaExprContext = "POLICY USING"
 	PolicyWithCheckExpr             SchemaExprContext = "POLICY WITH CHECK"
+	DomainDefaultExpr               SchemaExprContext = "DEFAULT (in DOMAIN)"
+	DomainCheckExpr                 SchemaExprContext = "DOMAIN CHECK"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
@@ -651,11 +796,12 @@ func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
 // routine options in the given slice.
 func ValidateRoutineOptions(options RoutineOptions, isProc bool) error {
 	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior, hasSecurity bool
+	var hasCost, hasRows, hasParallel, hasSupport bool
 	conflictingErr := func(opt RoutineOption) error {
 		return errors.Wrapf(ErrConflictingRoutineOption, "%s", AsString(opt))
 	}
 	for _, option := range options {
-		switch option.(type) {
+		switch t := option.(type) {
 		case RoutineLanguage:
 			if hasLang {
 				return conflictingErr(option)
@@ -695,6 +841,47 @@ func ValidateRoutineOptions(options RoutineOptions, isProc bool) error {
 				return conflictingErr(option)
 			}
 			hasSecurity = true
+		case RoutineCost:
+			if isProc {
+				return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cost attribute not allowed in procedure definition")
+			}
+			if hasCost {
+				return conflictingErr(option)
+			}
+			if t <= 0 {
+				return pgerror.New(pgcode.InvalidParameterValue, "COST must be positive")
+			}
+			hasCost = true
+		case RoutineRows:
+			if isProc {
+				return pgerror.Newf(pgcode.InvalidFunctionDefinition, "rows attribute not allowed in procedure definition")
+			}
+			if hasRows {
+				return conflictingErr(option)
+			}
+			if t <= 0 {
+				return pgerror.New(pgcode.InvalidParameterValue, "ROWS must be positive")
+			}
+			hasRows = true
+		case RoutineParallel:
+			if isProc {
+				return pgerror.Newf(pgcode.InvalidFunctionDefinition, "parallel attribute not allowed in procedure definition")
+			}
+			if hasParallel {
+				return conflictingErr(option)
+			}
+			hasParallel = true
+		case RoutineSupport:
+			if isProc {
+				return pgerror.Newf(pgcode.InvalidFunctionDefinition, "support attribute not allowed in procedure definition")
+			}
+			if hasSupport {
+				return conflictingErr(option)
+			}
+			hasSupport = true
+		case *RoutineSet:
+			// Multiple SET and RESET clauses are allowed; later clauses take
+			// precedence over earlier ones.
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "unknown function option: ", AsString(option))
 		}
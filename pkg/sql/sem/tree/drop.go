@@ -83,11 +83,17 @@ type DropTable struct {
 	Names        TableNames
 	IfExists     bool
 	DropBehavior DropBehavior
+	// Foreign is true if this represents a DROP FOREIGN TABLE statement.
+	Foreign bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *DropTable) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP TABLE ")
+	ctx.WriteString("DROP ")
+	if node.Foreign {
+		ctx.WriteString("FOREIGN ")
+	}
+	ctx.WriteString("TABLE ")
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -169,13 +175,19 @@ type DropType struct {
 	Names        []*UnresolvedObjectName
 	IfExists     bool
 	DropBehavior DropBehavior
+	// Domain is true if this represents a DROP DOMAIN statement.
+	Domain bool
 }
 
 var _ Statement = &DropType{}
 
 // Format implements the NodeFormatter interface.
 func (node *DropType) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP TYPE ")
+	if node.Domain {
+		ctx.WriteString("DROP DOMAIN ")
+	} else {
+		ctx.WriteString("DROP TYPE ")
+	}
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
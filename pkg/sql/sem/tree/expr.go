@@ -1272,6 +1272,11 @@ type FuncExpr struct {
 	// InCall is true when the FuncExpr is part of a CALL statement.
 	InCall bool
 
+	// Variadic is true when the last argument was marked VARIADIC, i.e. it is
+	// an array that is passed directly to the variadic parameter of a
+	// user-defined routine rather than expanded into individual arguments.
+	Variadic bool
+
 	typeAnnotation
 	fnProps *FunctionProperties
 	fn      *Overload
@@ -1387,7 +1392,17 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 
 	ctx.WriteByte('(')
 	ctx.WriteString(typ)
-	ctx.FormatNode(&node.Exprs)
+	if n := len(node.Exprs); node.Variadic && n > 0 {
+		if n > 1 {
+			prefix := node.Exprs[:n-1]
+			ctx.FormatNode(&prefix)
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString("VARIADIC ")
+		ctx.FormatNode(node.Exprs[n-1])
+	} else {
+		ctx.FormatNode(&node.Exprs)
+	}
 	if node.AggType == GeneralAgg && len(node.OrderBy) > 0 {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(&node.OrderBy)
@@ -1684,7 +1699,9 @@ type ColumnAccessExpr struct {
 	//   ByIndex is false,
 	// - or checked for validity during type checking if ByIndex is true.
 	// The first column in the tuple is at index 0. The input
-	// syntax (E).@N populates N-1 in this field.
+	// syntax (E).@N populates N-1 in this field. It is -1 if the tuple
+	// has the RECORD type, in which case the column is resolved by name
+	// during execution.
 	ColIndex int
 
 	typeAnnotation
@@ -1782,6 +1799,8 @@ func (node *TupleStar) String() string        { return AsString(node) }
 func (node *AnnotateTypeExpr) String() string { return AsString(node) }
 func (node *UnaryExpr) String() string        { return AsString(node) }
 func (node DefaultVal) String() string        { return AsString(node) }
+func (node *GroupingExpr) String() string     { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node PartitionMaxVal) String() string   { return AsString(node) }
 func (node PartitionMinVal) String() string   { return AsString(node) }
 func (node *Placeholder) String() string      { return AsString(node) }
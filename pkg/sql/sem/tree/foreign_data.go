@@ -0,0 +1,106 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// ForeignOption is a single generic option in the OPTIONS clause of a foreign
+// server or foreign table definition, e.g. `host 'localhost'`.
+type ForeignOption struct {
+	Name  Name
+	Value string
+}
+
+// ForeignOptions is a list of generic options of a foreign server or foreign
+// table.
+type ForeignOptions []ForeignOption
+
+// Format implements the NodeFormatter interface.
+func (node *ForeignOptions) Format(ctx *FmtCtx) {
+	for i := range *node {
+		opt := &(*node)[i]
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&opt.Name)
+		ctx.WriteByte(' ')
+		if opt.Name == "password" && !ctx.flags.HasFlags(FmtShowPasswords) {
+			ctx.WriteString(PasswordSubstitution)
+		} else if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, opt.Value, ctx.flags.EncodeFlags())
+		}
+	}
+}
+
+// formatWithKeyword formats the options preceded by the OPTIONS keyword, if
+// there are any.
+func (node *ForeignOptions) formatWithKeyword(ctx *FmtCtx) {
+	if len(*node) == 0 {
+		return
+	}
+	ctx.WriteString(" OPTIONS (")
+	ctx.FormatNode(node)
+	ctx.WriteByte(')')
+}
+
+// ForeignTableDef holds the foreign-data clauses of a CREATE FOREIGN TABLE
+// statement.
+type ForeignTableDef struct {
+	// Server is the name of the foreign server the rows are read through.
+	Server Name
+	// Options are the wrapper-specific options of the table.
+	Options ForeignOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ForeignTableDef) Format(ctx *FmtCtx) {
+	ctx.WriteString("SERVER ")
+	ctx.FormatNode(&node.Server)
+	node.Options.formatWithKeyword(ctx)
+}
+
+// CreateServer represents a CREATE SERVER statement.
+type CreateServer struct {
+	IfNotExists bool
+	Name        Name
+	// Wrapper is the name of the foreign-data wrapper used by the server.
+	Wrapper Name
+	Options ForeignOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SERVER ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" FOREIGN DATA WRAPPER ")
+	ctx.FormatNode(&node.Wrapper)
+	node.Options.formatWithKeyword(ctx)
+}
+
+// DropServer represents a DROP SERVER statement.
+type DropServer struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP SERVER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
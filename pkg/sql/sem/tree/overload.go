@@ -299,11 +299,60 @@ type Overload struct {
 	// UDFContainsOnlySignature is false, then DEFAULT expressions are included
 	// into RoutineParams.
 	DefaultExprs Exprs
+	// Variadic is set for user-defined routines whose last input parameter is
+	// declared VARIADIC. The type of that parameter (the last element of Types)
+	// is an array, and the routine can be called with any number of trailing
+	// arguments of the array's element type.
+	Variadic bool
 
 	// SecurityMode is true when privilege checks during function execution
 	// should be performed against the function owner rather than the invoking
 	// user.
 	SecurityMode RoutineSecurity
+
+	// UDFAggregate is set for user-defined aggregate functions. It is only set
+	// when UDFContainsOnlySignature is false.
+	UDFAggregate *UDFAggregate
+
+	// Cost is the estimated execution cost of a user-defined function, in units
+	// of cpu_operator_cost. It is zero if the COST option was not specified.
+	Cost float64
+	// Rows is the estimated number of rows returned by a set-returning
+	// user-defined function. It is zero if the ROWS option was not specified.
+	Rows float64
+	// Config contains the session variable overrides that are applied while a
+	// user-defined routine executes. It is only set when
+	// UDFContainsOnlySignature is false.
+	Config RoutineConfig
+}
+
+// RoutineConfigSetting is a session variable override that is applied while a
+// user-defined routine executes.
+type RoutineConfigSetting struct {
+	// Name is the name of the session variable.
+	Name string
+	// Value is the value of the session variable, in the format accepted by
+	// SET.
+	Value string
+}
+
+// RoutineConfig is a list of session variable overrides of a user-defined
+// routine, in the order they should be applied.
+type RoutineConfig []RoutineConfigSetting
+
+// UDFAggregate describes how a user-defined aggregate function is computed by
+// invoking its user-defined support functions.
+type UDFAggregate struct {
+	// TransitionFunc is the OID of the state transition function.
+	TransitionFunc oid.Oid
+	// FinalFunc is the OID of the final function, or zero if the aggregate has
+	// no final function.
+	FinalFunc oid.Oid
+	// StateType is the type of the aggregate's state value.
+	StateType *types.T
+	// InitCond is the textual representation of the initial state value, or nil
+	// if the initial state value is NULL.
+	InitCond *string
 }
 
 // params implements the overloadImpl interface.
@@ -1123,30 +1172,9 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 		if !hasPolymorphicTyp {
 			return true
 		}
-		argTypes := make([]*types.T, 0, len(params))
-		outArgTypes := make([]*types.T, 0, len(outParams))
-		for i := range s.exprs {
-			typedExpr, err := s.exprs[i].TypeCheck(ctx, semaCtx, types.AnyElement)
-			if err != nil {
-				panic(errors.HandleAsAssertionFailure(err))
-			}
-			if ol.Type == ProcedureRoutine && foundOutParams {
-				if _, isOutParam := toParamOrdinal(i, ol.OutParamOrdinals); isOutParam {
-					// A CALL statement must specify an argument for each OUT parameter
-					// of the procedure.
-					outArgTypes = append(outArgTypes, typedExpr.ResolvedType())
-					continue
-				}
-			}
-			argTypes = append(argTypes, typedExpr.ResolvedType())
-		}
 		// Check the concrete types of the arguments supplied for IN parameters.
-		// Only pass the parameters up to len(argTypes), since polymorphic type
-		// checking for default expressions happens later.
-		var anyElemTyp *types.T
-		if ok, _, anyElemTyp = ResolvePolymorphicArgTypes(
-			params[:len(argTypes)], argTypes, nil /* anyElemTyp */, false, /* enforceConsistency */
-		); !ok {
+		ok, polyTypes, outArgTypes := s.resolvePolymorphicArgTypes(ctx, semaCtx, ol)
+		if !ok {
 			return false
 		}
 		if ol.Type != ProcedureRoutine || !foundOutParams {
@@ -1157,7 +1185,7 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 		// Note that DEFAULT expressions cannot be used for OUT parameters, so there
 		// is no need to truncate the outParams slice.
 		ok, _, _ = ResolvePolymorphicArgTypes(
-			outParams, outArgTypes, anyElemTyp, false, /* enforceConsistency */
+			outParams, outArgTypes, polyTypes.anyElemTyp, false, /* enforceConsistency */
 		)
 		return ok
 	})
@@ -1616,6 +1644,108 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 	return nil
 }
 
+// polymorphicArgTypes holds the concrete types resolved for the polymorphic
+// parameters of a routine overload.
+type polymorphicArgTypes struct {
+	// anyElemTyp is the type resolved for ANYELEMENT, ANYENUM and ANYARRAY
+	// parameters, or nil if it could not be determined.
+	anyElemTyp *types.T
+	// anyCompatTyp is the common type resolved for parameters of the
+	// ANYCOMPATIBLE family, or nil if it could not be determined.
+	anyCompatTyp *types.T
+	// unresolvedLiteral is true if a string literal was supplied for a
+	// polymorphic parameter whose concrete type could not be determined.
+	unresolvedLiteral bool
+}
+
+// resolvePolymorphicArgTypes checks the types of the arguments supplied for
+// the IN parameters of the given routine overload with
+// ResolvePolymorphicArgTypes and ResolveAnyCompatibleArgTypes. It returns
+// whether the arguments are valid, the concrete types of the polymorphic
+// parameters, and the types of the arguments supplied for OUT parameters in a
+// CALL statement.
+//
+// As in Postgres, string literals are treated as having unknown type, so that
+// they take on the type resolved from the other arguments.
+func (s *overloadTypeChecker) resolvePolymorphicArgTypes(
+	ctx context.Context, semaCtx *SemaContext, ol *Overload,
+) (ok bool, polyTypes polymorphicArgTypes, outArgTypes []*types.T) {
+	params := ol.Types.(ParamTypes)
+	argTypes := make([]*types.T, 0, len(params))
+	var strLiteralIdxs intsets.Fast
+	for i := range s.exprs {
+		typedExpr, err := s.exprs[i].TypeCheck(ctx, semaCtx, types.AnyElement)
+		if err != nil {
+			panic(errors.HandleAsAssertionFailure(err))
+		}
+		if ol.Type == ProcedureRoutine && len(ol.OutParamOrdinals) > 0 {
+			if _, isOutParam := toParamOrdinal(i, ol.OutParamOrdinals); isOutParam {
+				// A CALL statement must specify an argument for each OUT parameter
+				// of the procedure.
+				outArgTypes = append(outArgTypes, typedExpr.ResolvedType())
+				continue
+			}
+		}
+		if _, ok := s.exprs[i].(*StrVal); ok {
+			strLiteralIdxs.Add(len(argTypes))
+			argTypes = append(argTypes, types.Unknown)
+			continue
+		}
+		argTypes = append(argTypes, typedExpr.ResolvedType())
+	}
+	// Only pass the parameters up to len(argTypes), since polymorphic type
+	// checking for default expressions happens later.
+	usesDefaults := len(argTypes) < len(params)
+	params = params[:len(argTypes)]
+	if ok, _, polyTypes.anyElemTyp = ResolvePolymorphicArgTypes(
+		params, argTypes, nil /* anyElemTyp */, false, /* enforceConsistency */
+	); !ok {
+		return false, polymorphicArgTypes{}, nil
+	}
+	if ok, _, polyTypes.anyCompatTyp = ResolveAnyCompatibleArgTypes(
+		params, argTypes, false, /* enforceConsistency */
+	); !ok {
+		return false, polymorphicArgTypes{}, nil
+	}
+	if usesDefaults {
+		// The concrete type may still be determined by the default expressions.
+		return true, polyTypes, outArgTypes
+	}
+	for i, ok := strLiteralIdxs.Next(0); ok; i, ok = strLiteralIdxs.Next(i + 1) {
+		typ := params[i].Typ
+		if !typ.IsPolymorphicType() {
+			continue
+		}
+		if typ.IsAnyCompatibleType() {
+			polyTypes.unresolvedLiteral = polyTypes.unresolvedLiteral || polyTypes.anyCompatTyp == nil
+		} else {
+			polyTypes.unresolvedLiteral = polyTypes.unresolvedLiteral || polyTypes.anyElemTyp == nil
+		}
+	}
+	return true, polyTypes, outArgTypes
+}
+
+// replace returns the concrete type of a polymorphic parameter of type typ. It
+// returns typ if the concrete type could not be determined.
+func (p polymorphicArgTypes) replace(typ *types.T) *types.T {
+	polyTyp := p.anyElemTyp
+	if typ.IsAnyCompatibleType() {
+		polyTyp = p.anyCompatTyp
+	}
+	if polyTyp == nil {
+		return typ
+	}
+	if typ.Family() != types.ArrayFamily {
+		return polyTyp
+	}
+	if polyTyp.Family() == types.ArrayFamily {
+		// Nested arrays are not supported. Leave it to the caller to report the
+		// error.
+		return typ
+	}
+	return types.MakeArray(polyTyp)
+}
+
 // filterAttempt attempts to filter the overloads down to a single candidate.
 // If it succeeds, it will return true, along with the overload (in a slice for
 // convenience) and a possible error. If it fails, it will return false and
@@ -1712,9 +1842,29 @@ func checkReturn(
 		idx := s.overloadIdxs[0]
 		routineType, outParamOrdinals, outParams := s.overloads[idx].outParamInfo()
 		params := s.params[idx]
+		// Constants supplied for the polymorphic parameters of a routine take on
+		// the concrete type resolved from the arguments, if there is one.
+		var polyTypes polymorphicArgTypes
+		if ol, ok := s.overloads[idx].(*Overload); ok && ol.Type != BuiltinRoutine && !s.constIdxs.Empty() {
+			for _, param := range ol.Types.(ParamTypes) {
+				if param.Typ.IsPolymorphicType() {
+					_, polyTypes, _ = s.resolvePolymorphicArgTypes(ctx, semaCtx, ol)
+					break
+				}
+			}
+		}
+		if polyTypes.unresolvedLiteral {
+			// NOTE: This is the same error as returned by Postgres.
+			return true, pgerror.New(pgcode.DatatypeMismatch,
+				"could not determine polymorphic type because input has type unknown",
+			)
+		}
 		for i, ok := s.constIdxs.Next(0); ok; i, ok = s.constIdxs.Next(i + 1) {
 			p, ordinal := getParamsAndOrdinal(routineType, i, params, outParamOrdinals, outParams)
 			des := p.GetAt(ordinal)
+			if des != nil && des.IsPolymorphicType() {
+				des = polyTypes.replace(des)
+			}
 			typ, err := s.exprs[i].TypeCheck(ctx, semaCtx, des)
 			if err != nil {
 				return false, pgerror.Wrapf(
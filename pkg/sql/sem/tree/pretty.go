@@ -696,6 +696,12 @@ func (node *Subquery) doc(p *PrettyCfg) pretty.Doc {
 
 func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 	d := p.Doc(node.Expr)
+	if node.Only {
+		d = pretty.Concat(
+			p.keywordWithText("", "ONLY", " "),
+			d,
+		)
+	}
 	if node.Lateral {
 		d = pretty.Concat(
 			p.keywordWithText("", "LATERAL", " "),
@@ -723,6 +729,29 @@ func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.TableSample != nil {
+		d = p.nestUnder(d, p.Doc(node.TableSample))
+	}
+	return d
+}
+
+func (node *TableSample) doc(p *PrettyCfg) pretty.Doc {
+	d := pretty.ConcatSpace(
+		pretty.Keyword("TABLESAMPLE"),
+		pretty.Concat(
+			pretty.Keyword(node.Method.String()),
+			p.bracket(" (", p.Doc(node.Percent), ")"),
+		),
+	)
+	if node.Repeatable != nil {
+		d = pretty.ConcatSpace(
+			d,
+			pretty.Concat(
+				pretty.Keyword("REPEATABLE"),
+				p.bracket(" (", p.Doc(node.Repeatable), ")"),
+			),
+		)
+	}
 	return d
 }
 
@@ -730,7 +759,20 @@ func (node *FuncExpr) doc(p *PrettyCfg) pretty.Doc {
 	d := p.Doc(&node.Func)
 
 	if len(node.Exprs) > 0 {
-		args := node.Exprs.doc(p)
+		var args pretty.Doc
+		if n := len(node.Exprs); node.Variadic {
+			argDocs := make([]pretty.Doc, n)
+			for i, e := range node.Exprs {
+				if p.Simplify {
+					e = StripParens(e)
+				}
+				argDocs[i] = p.Doc(e)
+			}
+			argDocs[n-1] = pretty.ConcatSpace(pretty.Keyword("VARIADIC"), argDocs[n-1])
+			args = p.commaSeparated(argDocs...)
+		} else {
+			args = node.Exprs.doc(p)
+		}
 		if node.Type != 0 {
 			args = pretty.ConcatLine(
 				pretty.Text(funcTypeName[node.Type]),
@@ -1256,6 +1298,8 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	//
 	// CREATE [TEMP | UNLOGGED] TABLE [IF NOT EXISTS] name ( .... ) [AS]
 	//     [SELECT ...] - for CREATE TABLE AS
+	//     [INHERITS ...]
+	//     [SERVER ...] - for CREATE FOREIGN TABLE
 	//     [INTERLEAVE ...]
 	//     [PARTITION BY ...]
 	//
@@ -1266,6 +1310,9 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	case PersistenceUnlogged:
 		title = pretty.ConcatSpace(title, pretty.Keyword("UNLOGGED"))
 	}
+	if node.Foreign != nil {
+		title = pretty.ConcatSpace(title, pretty.Keyword("FOREIGN"))
+	}
 	title = pretty.ConcatSpace(title, pretty.Keyword("TABLE"))
 	if node.IfNotExists {
 		title = pretty.ConcatSpace(title, pretty.Keyword("IF NOT EXISTS"))
@@ -1292,6 +1339,15 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	if node.As() {
 		clauses = append(clauses, p.Doc(node.AsSource))
 	}
+	if len(node.Inherits) > 0 {
+		clauses = append(clauses, pretty.ConcatSpace(
+			pretty.Keyword("INHERITS"),
+			p.bracket("(", p.Doc(&node.Inherits), ")"),
+		))
+	}
+	if node.Foreign != nil {
+		clauses = append(clauses, p.Doc(node.Foreign))
+	}
 	if node.PartitionByTable != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByTable))
 	}
@@ -1789,6 +1845,7 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//    [WHERE ...]
 	//    [NOT VISIBLE | VISIBILITY ...]
 	//
@@ -1798,6 +1855,7 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//    [WHERE ...]
 	//    [NOT VISIBLE | VISIBILITY ...]
 	//
@@ -1836,6 +1894,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if node.Deferrability.IsDeferrable() {
+		clauses = append(clauses, node.Deferrability.doc())
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1866,6 +1927,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl (...)
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	// or (no constraint name):
 	//
@@ -1873,6 +1935,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl [(...)]
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	clauses := make([]pretty.Doc, 0, 4)
 	title := pretty.ConcatSpace(
@@ -1899,9 +1962,20 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if node.Deferrability.IsDeferrable() {
+		clauses = append(clauses, node.Deferrability.doc())
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
+func (x ConstraintDeferrability) doc() pretty.Doc {
+	if x.IsInitiallyDeferred() {
+		return pretty.Keyword("DEFERRABLE INITIALLY DEFERRED")
+	}
+	return pretty.Keyword("DEFERRABLE")
+}
+
 func (p *PrettyCfg) maybePrependConstraintName(constraintName *Name, d pretty.Doc) pretty.Doc {
 	if *constraintName != "" {
 		return pretty.Fold(pretty.ConcatSpace,
@@ -2038,6 +2112,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if node.Unique.WithoutIndex {
 			pkConstraint = pretty.ConcatSpace(pkConstraint, pretty.Keyword("WITHOUT INDEX"))
 		}
+		if node.Unique.Deferrability.IsDeferrable() {
+			pkConstraint = pretty.ConcatSpace(pkConstraint, node.Unique.Deferrability.doc())
+		}
 	}
 	if pkConstraint != pretty.Nil {
 		clauses = append(clauses, p.maybePrependConstraintName(&node.Unique.ConstraintName, pkConstraint))
@@ -2085,6 +2162,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if node.References.Deferrability.IsDeferrable() {
+			fkDetails = append(fkDetails, node.References.Deferrability.doc())
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
@@ -145,6 +145,11 @@ type RoutineExpr struct {
 	// implement the PL/pgSQL body.
 	TriggerFunc bool
 
+	// Config contains the session variable overrides that are applied while the
+	// routine executes. Like TriggerFunc, it is only set for the outermost
+	// routine, and not any sub-routines used to implement the PL/pgSQL body.
+	Config RoutineConfig
+
 	// BlockStart is true if this routine marks the start of a PL/pgSQL block with
 	// an exception handler. It determines when to initialize the state shared
 	// between sub-routines for the block.
@@ -345,6 +350,9 @@ type TxnControlPlanGenerator func(
 type TxnControlExpr struct {
 	Op    StoredProcTxnOp
 	Modes TransactionModes
+	// Chain is true if the new transaction should inherit the characteristics
+	// of the current transaction that are not set by Modes.
+	Chain bool
 	Args  TypedExprs
 	Gen   TxnControlPlanGenerator
 
@@ -358,6 +366,7 @@ var _ Expr = &TxnControlExpr{}
 func NewTxnControlExpr(
 	opType StoredProcTxnOp,
 	txnModes TransactionModes,
+	chain bool,
 	args TypedExprs,
 	gen TxnControlPlanGenerator,
 	name string,
@@ -366,6 +375,7 @@ func NewTxnControlExpr(
 	return &TxnControlExpr{
 		Op:    opType,
 		Modes: txnModes,
+		Chain: chain,
 		Args:  args,
 		Gen:   gen,
 		Name:  name,
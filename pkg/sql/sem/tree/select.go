@@ -598,11 +598,15 @@ func (ih *IndexFlags) zigzagForced() bool {
 // AliasedTableExpr represents a table expression coupled with an optional
 // alias.
 type AliasedTableExpr struct {
-	Expr       TableExpr
-	IndexFlags *IndexFlags
-	Ordinality bool
-	Lateral    bool
-	As         AliasClause
+	Expr        TableExpr
+	IndexFlags  *IndexFlags
+	Ordinality  bool
+	Lateral     bool
+	As          AliasClause
+	TableSample *TableSample
+	// Only is true if the table was prefixed with ONLY, in which case scans of
+	// the table do not include the rows of inheriting tables.
+	Only bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -610,6 +614,9 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 	if node.Lateral {
 		ctx.WriteString("LATERAL ")
 	}
+	if node.Only {
+		ctx.WriteString("ONLY ")
+	}
 	ctx.FormatNode(node.Expr)
 	if node.IndexFlags != nil {
 		ctx.FormatNode(node.IndexFlags)
@@ -621,6 +628,55 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(&node.As)
 	}
+	if node.TableSample != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.TableSample)
+	}
+}
+
+// TableSampleMethod is the sampling method of a TABLESAMPLE clause.
+type TableSampleMethod int
+
+const (
+	// TableSampleBernoulli selects each row of the table independently with
+	// the given probability.
+	TableSampleBernoulli TableSampleMethod = iota
+	// TableSampleSystem selects each range of the table independently with
+	// the given probability, and returns all the rows of the selected ranges.
+	TableSampleSystem
+)
+
+var tableSampleMethodName = [...]string{
+	TableSampleBernoulli: "BERNOULLI",
+	TableSampleSystem:    "SYSTEM",
+}
+
+func (m TableSampleMethod) String() string {
+	return tableSampleMethodName[m]
+}
+
+// TableSample represents a TABLESAMPLE clause.
+type TableSample struct {
+	Method TableSampleMethod
+	// Percent is the percentage of the table to sample, between 0 and 100.
+	Percent Expr
+	// Repeatable is the seed of the REPEATABLE clause, or nil if there is no
+	// such clause.
+	Repeatable Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TableSample) Format(ctx *FmtCtx) {
+	ctx.WriteString("TABLESAMPLE ")
+	ctx.WriteString(node.Method.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(node.Percent)
+	ctx.WriteByte(')')
+	if node.Repeatable != nil {
+		ctx.WriteString(" REPEATABLE (")
+		ctx.FormatNode(node.Repeatable)
+		ctx.WriteByte(')')
+	}
 }
 
 // ParenTableExpr represents a parenthesized TableExpr.
@@ -789,6 +845,55 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType represents the kind of a GroupingSet.
+type GroupingSetType int
+
+const (
+	// RollupGroupingSet represents ROLLUP (...).
+	RollupGroupingSet GroupingSetType = iota
+	// CubeGroupingSet represents CUBE (...).
+	CubeGroupingSet
+	// GroupingSetsList represents GROUPING SETS (...).
+	GroupingSetsList
+)
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. Each of the Exprs of a ROLLUP or CUBE is either a single expression
+// or a Tuple, which groups its elements into a single unit. The Exprs of a
+// GROUPING SETS list can also be nested GroupingSets.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	switch node.Type {
+	case RollupGroupingSet:
+		ctx.WriteString("ROLLUP (")
+	case CubeGroupingSet:
+		ctx.WriteString("CUBE (")
+	case GroupingSetsList:
+		ctx.WriteString("GROUPING SETS (")
+	}
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
+// GroupingExpr represents a GROUPING(...) operation, which returns a bit mask
+// indicating which of its arguments are not included in the grouping set of
+// the current row.
+type GroupingExpr struct {
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingExpr) Format(ctx *FmtCtx) {
+	ctx.WriteString("GROUPING(")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -88,12 +88,14 @@ const (
 	AlterTableTag          = "ALTER TABLE"
 	AlterPolicyTag         = "ALTER POLICY"
 	BackupTag              = "BACKUP"
+	CreateAggregateTag     = "CREATE AGGREGATE"
 	CreateIndexTag         = "CREATE INDEX"
 	CreateFunctionTag      = "CREATE FUNCTION"
 	CreateProcedureTag     = "CREATE PROCEDURE"
 	CreateTriggerTag       = "CREATE TRIGGER"
 	CreateSchemaTag        = "CREATE SCHEMA"
 	CreateSequenceTag      = "CREATE SEQUENCE"
+	CreateServerTag        = "CREATE SERVER"
 	CreateDatabaseTag      = "CREATE DATABASE"
 	CreatePolicyTag        = "CREATE POLICY"
 	CommentOnColumnTag     = "COMMENT ON COLUMN"
@@ -103,6 +105,7 @@ const (
 	CommentOnSchemaTag     = "COMMENT ON SCHEMA"
 	CommentOnTableTag      = "COMMENT ON TABLE"
 	CommentOnTypeTag       = "COMMENT ON TYPE"
+	DropAggregateTag       = "DROP AGGREGATE"
 	DropDatabaseTag        = "DROP DATABASE"
 	DropFunctionTag        = "DROP FUNCTION"
 	DropPolicyTag          = "DROP POLICY"
@@ -112,6 +115,7 @@ const (
 	DropOwnedByTag         = "DROP OWNED BY"
 	DropSchemaTag          = "DROP SCHEMA"
 	DropSequenceTag        = "DROP SEQUENCE"
+	DropServerTag          = "DROP SERVER"
 	DropTableTag           = "DROP TABLE"
 	DropTypeTag            = "DROP TYPE"
 	DropViewTag            = "DROP VIEW"
@@ -615,7 +619,12 @@ func (*AlterType) StatementReturnType() StatementReturnType { return DDL }
 func (*AlterType) StatementType() StatementType { return TypeDDL }
 
 // StatementTag implements the Statement interface.
-func (*AlterType) StatementTag() string { return "ALTER TYPE" }
+func (n *AlterType) StatementTag() string {
+	if n.Domain {
+		return "ALTER DOMAIN"
+	}
+	return "ALTER TYPE"
+}
 
 func (*AlterType) hiddenFromShowQueries() {}
 
@@ -1070,6 +1079,9 @@ func (n *CreateTable) StatementTag() string {
 	if n.As() {
 		return "CREATE TABLE AS"
 	}
+	if n.Foreign != nil {
+		return "CREATE FOREIGN TABLE"
+	}
 	return "CREATE TABLE"
 }
 
@@ -1083,7 +1095,12 @@ func (*CreateType) StatementReturnType() StatementReturnType { return DDL }
 func (*CreateType) StatementType() StatementType { return TypeDDL }
 
 // StatementTag implements the Statement interface.
-func (*CreateType) StatementTag() string { return "CREATE TYPE" }
+func (n *CreateType) StatementTag() string {
+	if n.Variety == Domain {
+		return "CREATE DOMAIN"
+	}
+	return "CREATE TYPE"
+}
 
 func (*CreateType) modifiesSchema() bool { return true }
 
@@ -1215,7 +1232,30 @@ func (*DropTable) StatementReturnType() StatementReturnType { return DDL }
 func (*DropTable) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropTable) StatementTag() string { return DropTableTag }
+func (n *DropTable) StatementTag() string {
+	if n.Foreign {
+		return "DROP FOREIGN TABLE"
+	}
+	return DropTableTag
+}
+
+// StatementReturnType implements the Statement interface.
+func (*CreateServer) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateServer) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateServer) StatementTag() string { return CreateServerTag }
+
+// StatementReturnType implements the Statement interface.
+func (*DropServer) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropServer) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropServer) StatementTag() string { return DropServerTag }
 
 // StatementReturnType implements the Statement interface.
 func (*DropView) StatementReturnType() StatementReturnType { return DDL }
@@ -1253,7 +1293,12 @@ func (*DropType) StatementReturnType() StatementReturnType { return DDL }
 func (*DropType) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropType) StatementTag() string { return DropTypeTag }
+func (n *DropType) StatementTag() string {
+	if n.Domain {
+		return "DROP DOMAIN"
+	}
+	return DropTypeTag
+}
 
 // StatementReturnType implements the Statement interface.
 func (*DropSchema) StatementReturnType() StatementReturnType { return DDL }
@@ -1376,6 +1421,24 @@ func (*LiteralValuesClause) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*LiteralValuesClause) StatementTag() string { return "VALUES" }
 
+// StatementReturnType implements the Statement interface.
+func (*Listen) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Listen) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Listen) StatementTag() string { return "LISTEN" }
+
+// StatementReturnType implements the Statement interface.
+func (*Notify) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Notify) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Notify) StatementTag() string { return "NOTIFY" }
+
 // StatementReturnType implements the Statement interface.
 func (*ParenSelect) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1646,6 +1709,15 @@ func (*SetClusterSetting) StatementType() StatementType { return TypeDCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*SetClusterSetting) StatementTag() string { return "SET CLUSTER SETTING" }
 
+// StatementReturnType implements the Statement interface.
+func (*SetConstraints) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*SetConstraints) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*SetConstraints) StatementTag() string { return "SET CONSTRAINTS" }
+
 // StatementReturnType implements the Statement interface.
 func (*SetTransaction) StatementReturnType() StatementReturnType { return Ack }
 
@@ -2350,6 +2422,15 @@ func (n *CreateRoutine) StatementTag() string {
 	return CreateFunctionTag
 }
 
+// StatementReturnType implements the Statement interface.
+func (*CreateAggregate) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateAggregate) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateAggregate) StatementTag() string { return CreateAggregateTag }
+
 // StatementReturnType implements the Statement interface.
 func (*RoutineReturn) StatementReturnType() StatementReturnType { return Rows }
 
@@ -2370,6 +2451,9 @@ func (n *DropRoutine) StatementTag() string {
 	if n.Procedure {
 		return DropProcedureTag
 	}
+	if n.Aggregate {
+		return DropAggregateTag
+	}
 	return DropFunctionTag
 }
 
@@ -2414,6 +2498,8 @@ func (*AlterRoutineRename) StatementType() StatementType { return TypeDDL }
 func (n *AlterRoutineRename) StatementTag() string {
 	if n.Procedure {
 		return "ALTER PROCEDURE"
+	} else if n.Aggregate {
+		return "ALTER AGGREGATE"
 	} else {
 		return "ALTER FUNCTION"
 	}
@@ -2429,6 +2515,8 @@ func (*AlterRoutineSetSchema) StatementType() StatementType { return TypeDDL }
 func (n *AlterRoutineSetSchema) StatementTag() string {
 	if n.Procedure {
 		return "ALTER PROCEDURE"
+	} else if n.Aggregate {
+		return "ALTER AGGREGATE"
 	} else {
 		return "ALTER FUNCTION"
 	}
@@ -2444,6 +2532,8 @@ func (*AlterRoutineSetOwner) StatementType() StatementType { return TypeDDL }
 func (n *AlterRoutineSetOwner) StatementTag() string {
 	if n.Procedure {
 		return "ALTER PROCEDURE"
+	} else if n.Aggregate {
+		return "ALTER AGGREGATE"
 	} else {
 		return "ALTER FUNCTION"
 	}
@@ -2540,6 +2630,7 @@ func (n *CopyTo) String() string                              { return AsString(
 func (n *CreateChangefeed) String() string                    { return AsString(n) }
 func (n *CreateDatabase) String() string                      { return AsString(n) }
 func (n *CreateExtension) String() string                     { return AsString(n) }
+func (n *CreateAggregate) String() string                     { return AsString(n) }
 func (n *CreateRoutine) String() string                       { return AsString(n) }
 func (n *CreateTrigger) String() string                       { return AsString(n) }
 func (n *CreateIndex) String() string                         { return AsString(n) }
@@ -2551,6 +2642,7 @@ func (n *CreateTenant) String() string                        { return AsString(
 func (n *CreateTenantFromReplication) String() string         { return AsString(n) }
 func (n *CreateSchema) String() string                        { return AsString(n) }
 func (n *CreateSequence) String() string                      { return AsString(n) }
+func (n *CreateServer) String() string                        { return AsString(n) }
 func (n *CreateStats) String() string                         { return AsString(n) }
 func (n *CreateView) String() string                          { return AsString(n) }
 func (n *Deallocate) String() string                          { return AsString(n) }
@@ -2565,6 +2657,7 @@ func (n *DropIndex) String() string                           { return AsString(
 func (n *DropOwnedBy) String() string                         { return AsString(n) }
 func (n *DropSchema) String() string                          { return AsString(n) }
 func (n *DropSequence) String() string                        { return AsString(n) }
+func (n *DropServer) String() string                          { return AsString(n) }
 func (n *DropTable) String() string                           { return AsString(n) }
 func (n *DropType) String() string                            { return AsString(n) }
 func (n *DropView) String() string                            { return AsString(n) }
@@ -2611,6 +2704,7 @@ func (n *Scrub) String() string                               { return AsString(
 func (n *Select) String() string                              { return AsString(n) }
 func (n *SelectClause) String() string                        { return AsString(n) }
 func (n *SetClusterSetting) String() string                   { return AsString(n) }
+func (n *SetConstraints) String() string                      { return AsString(n) }
 func (n *SetZoneConfig) String() string                       { return AsString(n) }
 func (n *SetSessionAuthorizationDefault) String() string      { return AsString(n) }
 func (n *SetSessionCharacteristics) String() string           { return AsString(n) }
@@ -901,6 +901,18 @@ func (expr *ColumnAccessExpr) TypeCheck(
 		return nil, NewTypeIsNotCompositeError(resolvedType)
 	}
 
+	if !expr.ByIndex && resolvedType.Identical(types.AnyTuple) {
+		// The shape of the record is not known until execution (e.g. a PL/pgSQL
+		// RECORD variable), so the field is resolved at runtime. The type of the
+		// field is taken from the context, or defaults to STRING.
+		expr.ColIndex = -1
+		expr.typ = types.String
+		if desired.Family() != types.UnknownFamily && !desired.IsWildcardType() {
+			expr.typ = desired
+		}
+		return expr, nil
+	}
+
 	if !expr.ByIndex && len(resolvedType.TupleLabels()) == 0 {
 		return nil, pgerror.Newf(pgcode.UndefinedColumn, "could not identify column %q in record data type",
 			expr.ColName)
@@ -1024,6 +1036,8 @@ var (
 	errInvalidMaxUsage     = pgerror.New(pgcode.Syntax, "MAXVALUE can only appear within a range partition expression")
 	errInvalidMinUsage     = pgerror.New(pgcode.Syntax, "MINVALUE can only appear within a range partition expression")
 	errPrivateFunction     = pgerror.New(pgcode.ReservedName, "function reserved for internal use")
+	errInvalidGroupingSet  = pgerror.New(pgcode.Syntax, "ROLLUP, CUBE and GROUPING SETS can only appear in a GROUP BY clause")
+	errInvalidGrouping     = pgerror.New(pgcode.Grouping, "GROUPING can only appear in the SELECT list, HAVING or ORDER BY of a query with GROUP BY")
 )
 
 // NewAggInAggError creates an error for the case when an aggregate function is
@@ -1237,8 +1251,12 @@ func (expr *FuncExpr) TypeCheck(
 		return sb.String()
 	}
 
+	// Variadic routines are resolved against copies of their overloads with the
+	// variadic parameter expanded to match the supplied arguments.
+	overloads, expandedVariadics := expandVariadicOverloads(def.Overloads, expr)
+
 	s := getOverloadTypeChecker(
-		(*qualifiedOverloads)(&def.Overloads), expr.Exprs...,
+		(*qualifiedOverloads)(&overloads), expr.Exprs...,
 	)
 	defer s.release()
 
@@ -1254,7 +1272,7 @@ func (expr *FuncExpr) TypeCheck(
 			// resetting the UDF overloads to their original state.
 			var functionIdxs []int
 			var functionOverloads []QualifiedOverload
-			for idx, o := range def.Overloads {
+			for idx, o := range overloads {
 				if o.Type == UDFRoutine {
 					o.Type = ProcedureRoutine
 					functionIdxs = append(functionIdxs, idx)
@@ -1264,7 +1282,7 @@ func (expr *FuncExpr) TypeCheck(
 			if len(functionIdxs) > 0 {
 				defer func() {
 					for _, idx := range functionIdxs {
-						def.Overloads[idx].Type = UDFRoutine
+						overloads[idx].Type = UDFRoutine
 					}
 				}()
 				s2 := getOverloadTypeChecker((*qualifiedOverloads)(&functionOverloads), expr.Exprs...)
@@ -1290,13 +1308,13 @@ func (expr *FuncExpr) TypeCheck(
 	var hasUDFOverload bool
 	var calledOnNullInputFns, notCalledOnNullInputFns intsets.Fast
 	for _, idx := range s.overloadIdxs {
-		if def.Overloads[idx].CalledOnNullInput {
+		if overloads[idx].CalledOnNullInput {
 			calledOnNullInputFns.Add(int(idx))
 		} else {
 			notCalledOnNullInputFns.Add(int(idx))
 		}
 		// TODO(harding): Check if this is a record-returning UDF instead.
-		if def.Overloads[idx].Type == UDFRoutine {
+		if overloads[idx].Type == UDFRoutine {
 			hasUDFOverload = true
 		}
 	}
@@ -1316,7 +1334,7 @@ func (expr *FuncExpr) TypeCheck(
 			if s.typedExprs[i].ResolvedType().Family() == types.UnknownFamily {
 				var filtered intsets.Fast
 				for j, ok := notCalledOnNullInputFns.Next(0); ok; j, ok = notCalledOnNullInputFns.Next(j + 1) {
-					if def.Overloads[j].params().GetAt(i).Equivalent(types.String) {
+					if overloads[j].params().GetAt(i).Equivalent(types.String) {
 						filtered.Add(j)
 					}
 				}
@@ -1377,11 +1395,11 @@ func (expr *FuncExpr) TypeCheck(
 		// If the function is resolved by OID, we know that there is always only one
 		// overload qualified. As long as it passes the argument type checks above,
 		// there is no need to worry about the search path.
-		favoredOverload = def.Overloads[0]
+		favoredOverload = overloads[0]
 	} else {
 		// Get overloads from the most significant schema in search path.
 		favoredOverload, err = getMostSignificantOverload(
-			def.Overloads, s.overloads, s.overloadIdxs, searchPath, expr, s.typedExprs,
+			overloads, s.overloads, s.overloadIdxs, searchPath, expr, s.typedExprs,
 			func() string { return getFuncSig(expr, s.typedExprs, desired) },
 		)
 		if err != nil {
@@ -1389,6 +1407,18 @@ func (expr *FuncExpr) TypeCheck(
 		}
 	}
 
+	// If a variadic routine was chosen, collect the trailing arguments into an
+	// array for the variadic parameter.
+	if declared, ok := expandedVariadics[favoredOverload.Overload]; ok {
+		if s.typedExprs, err = packVariadicArgs(
+			declared, favoredOverload.Overload, expr.InCall, s.typedExprs,
+		); err != nil {
+			return nil, err
+		}
+		favoredOverload.Overload = declared
+		expr.Variadic = true
+	}
+
 	// Just pick the first overload from the search path.
 	overloadImpl := favoredOverload.Overload
 	if overloadImpl.Private {
@@ -1480,6 +1510,9 @@ func (expr *FuncExpr) TypeCheck(
 		}
 	}
 
+	if len(expr.Exprs) != len(s.typedExprs) {
+		expr.Exprs = make(Exprs, len(s.typedExprs))
+	}
 	for i, subExpr := range s.typedExprs {
 		expr.Exprs[i] = subExpr
 	}
@@ -1509,6 +1542,136 @@ func (expr *FuncExpr) TypeCheck(
 	return expr, nil
 }
 
+// expandVariadicOverloads returns the overloads that should be considered when
+// type-checking the given function call. Unless the call marks its last
+// argument VARIADIC, each overload of a variadic routine is replaced with a
+// copy in which the variadic array parameter is expanded into one parameter of
+// the array's element type for each trailing argument. The returned map
+// associates each such copy with the declared overload. If the call marks its
+// last argument VARIADIC, that argument is passed directly to the variadic
+// parameter, so only variadic routines are considered.
+func expandVariadicOverloads(
+	overloads []QualifiedOverload, expr *FuncExpr,
+) ([]QualifiedOverload, map[*Overload]*Overload) {
+	if expr.Variadic {
+		ret := make([]QualifiedOverload, 0, len(overloads))
+		for _, o := range overloads {
+			if o.Variadic {
+				ret = append(ret, o)
+			}
+		}
+		return ret, nil
+	}
+	var ret []QualifiedOverload
+	var expanded map[*Overload]*Overload
+	for i, o := range overloads {
+		if !o.Variadic {
+			continue
+		}
+		params, ok := o.Types.(ParamTypes)
+		if !ok || len(params) == 0 {
+			continue
+		}
+		numInputArgs := len(expr.Exprs)
+		if expr.InCall && o.Type == ProcedureRoutine {
+			// Arguments for OUT parameters are included in a CALL statement.
+			numInputArgs -= len(o.OutParamOrdinals)
+		}
+		numVariadicArgs := numInputArgs - (len(params) - 1)
+		if numVariadicArgs < 1 {
+			// No arguments were supplied for the variadic parameter, so the
+			// declared overload can only match if the parameter has a DEFAULT
+			// expression.
+			continue
+		}
+		if ret == nil {
+			ret = make([]QualifiedOverload, len(overloads))
+			copy(ret, overloads)
+			expanded = make(map[*Overload]*Overload)
+		}
+		variadicParam := params[len(params)-1]
+		expandedParams := make(ParamTypes, 0, numInputArgs)
+		expandedParams = append(expandedParams, params[:len(params)-1]...)
+		for j := 0; j < numVariadicArgs; j++ {
+			expandedParams = append(expandedParams, ParamType{
+				Name: variadicParam.Name, Typ: variadicParam.Typ.ArrayContents(),
+			})
+		}
+		expandedOverload := *o.Overload
+		expandedOverload.Types = expandedParams
+		// Every input parameter is supplied, so no DEFAULT expressions apply.
+		expandedOverload.DefaultExprs = nil
+		ret[i].Overload = &expandedOverload
+		expanded[&expandedOverload] = o.Overload
+	}
+	if ret == nil {
+		return overloads, nil
+	}
+	return ret, expanded
+}
+
+// packVariadicArgs collects the trailing arguments of a call to the given
+// variadic routine into an array, which is passed to the variadic parameter.
+// expanded is the copy of the overload that was used for overload resolution
+// (see expandVariadicOverloads).
+func packVariadicArgs(declared, expanded *Overload, inCall bool, args []TypedExpr) ([]TypedExpr, error) {
+	params := declared.Types.(ParamTypes)
+	var outParamOrdinals []int32
+	if inCall && declared.Type == ProcedureRoutine {
+		outParamOrdinals = declared.OutParamOrdinals
+	}
+	numFixedArgs := len(params) - 1 + len(outParamOrdinals)
+	arrTyp := params[len(params)-1].Typ
+	elemTyp := arrTyp.ArrayContents()
+	if elemTyp.IsPolymorphicType() {
+		// Determine the concrete element type from all the input arguments, since
+		// the variadic parameter shares it with any other polymorphic parameters.
+		inputArgTypes := make([]*types.T, 0, len(args))
+		for i := range args {
+			if _, isOutParam := toParamOrdinal(i, outParamOrdinals); !isOutParam {
+				inputArgTypes = append(inputArgTypes, args[i].ResolvedType())
+			}
+		}
+		var ok bool
+		var anyElemTyp *types.T
+		if elemTyp.IsAnyCompatibleType() {
+			ok, _, anyElemTyp = ResolveAnyCompatibleArgTypes(
+				expanded.Types.(ParamTypes), inputArgTypes, false, /* enforceConsistency */
+			)
+		} else {
+			ok, _, anyElemTyp = ResolvePolymorphicArgTypes(
+				expanded.Types.(ParamTypes), inputArgTypes, nil /* anyElemTyp */, false, /* enforceConsistency */
+			)
+		}
+		if !ok {
+			return nil, errors.AssertionFailedf("invalid arguments for variadic parameter of type %s", arrTyp)
+		}
+		if anyElemTyp == nil {
+			return nil, pgerror.New(pgcode.DatatypeMismatch,
+				"could not determine polymorphic type because input has type unknown",
+			)
+		}
+		if anyElemTyp.Family() == types.ArrayFamily {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"could not find array type for data type %s", anyElemTyp.Name(),
+			)
+		}
+		elemTyp = anyElemTyp
+		arrTyp = types.MakeArray(elemTyp)
+	}
+	elems := make(TypedExprs, 0, len(args)-numFixedArgs)
+	for _, arg := range args[numFixedArgs:] {
+		if typ := arg.ResolvedType(); typ.Family() != types.UnknownFamily && !typ.Identical(elemTyp) {
+			arg = NewTypedCastExpr(arg, elemTyp)
+		}
+		elems = append(elems, arg)
+	}
+	packed := make([]TypedExpr, numFixedArgs+1)
+	copy(packed, args[:numFixedArgs])
+	packed[numFixedArgs] = NewTypedArray(elems, arrTyp)
+	return packed, nil
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *IfErrExpr) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
@@ -1845,6 +2008,22 @@ func (expr DefaultVal) TypeCheck(
 	return nil, errInvalidDefaultUsage
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGroupingSet
+}
+
+// TypeCheck implements the Expr interface. GROUPING operations are replaced
+// by the optimizer before type checking, so reaching this method means the
+// operation was used in a context that doesn't allow it.
+func (expr *GroupingExpr) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGrouping
+}
+
 // TypeCheck implements the Expr interface.
 func (expr PartitionMinVal) TypeCheck(
 	_ context.Context, _ *SemaContext, desired *types.T,
@@ -3663,17 +3842,22 @@ func getMostSignificantOverload(
 // true if the supplied argument types are valid, as well as the determined
 // element type (nil if there were no polymorphic parameters).
 //
-// CRDB currently supports two polymorphic types:
+// CRDB currently supports three polymorphic types in this family:
 // * ANYELEMENT allows any argument type.
 // * ANYARRAY allows only array types.
+// * ANYENUM allows only enum types.
+//
+// Parameters of the ANYCOMPATIBLE family are resolved independently by
+// ResolveAnyCompatibleArgTypes, and are ignored here.
 //
 // The rules for argument validity are as follows:
-//  1. The arguments supplied for ANYELEMENT parameters must all have the same
-//     type.
+//  1. The arguments supplied for ANYELEMENT and ANYENUM parameters must all
+//     have the same type.
 //  2. The supplied types for ANYARRAY parameters must match each other, and the
-//     array *element* type must match all ANYELEMENT parameters.
-//  3. NULL arguments are exempt from the above two rules. However, there must
-//     be at least one non-NULL argument in order to resolve a concrete type.
+//     array *element* type must match all ANYELEMENT and ANYENUM parameters.
+//  3. If there are any ANYENUM parameters, the resolved type must be an enum.
+//  4. NULL arguments are exempt from the above rules. However, there must be
+//     at least one non-NULL argument in order to resolve a concrete type.
 //
 // anyElemTyp, if non-nil, allows the caller to pass in the expected concrete
 // type for ANYELEMENT parameters.
@@ -3686,9 +3870,10 @@ func ResolvePolymorphicArgTypes(
 	paramTypes ParamTypes, argTypes []*types.T, anyElemTyp *types.T, enforceConsistency bool,
 ) (ok bool, numPolyParams int, _ *types.T) {
 	var anyArrayTyp *types.T
+	var sawAnyEnum bool
 	for i := range paramTypes {
 		paramTyp := paramTypes[i].Typ
-		if !paramTyp.IsPolymorphicType() {
+		if !paramTyp.IsPolymorphicType() || paramTyp.IsAnyCompatibleType() {
 			continue
 		}
 		argTyp := argTypes[i]
@@ -3720,7 +3905,21 @@ func ResolvePolymorphicArgTypes(
 				return false, 0, nil
 			}
 		case types.EnumFamily:
-			panic(unimplemented.NewWithIssue(123048, "ANYENUM is not yet supported"))
+			sawAnyEnum = true
+			if argTyp.Family() != types.EnumFamily {
+				if enforceConsistency {
+					panic(pgerror.Newf(pgcode.DatatypeMismatch,
+						"argument declared anyenum is not an enum but type %s", argTyp,
+					))
+				}
+				return false, 0, nil
+			}
+			if anyElemTyp == nil {
+				anyElemTyp = argTyp
+			} else if !anyElemTyp.Identical(argTyp) {
+				maybeMakeNotAlikeErr("anyenum", anyElemTyp, argTyp)
+				return false, 0, nil
+			}
 		default:
 			panic(errors.AssertionFailedf("unexpected type: %s", paramTyp.SQLStringForError()))
 		}
@@ -3750,9 +3949,104 @@ func ResolvePolymorphicArgTypes(
 			return false, 0, nil
 		}
 	}
+	if sawAnyEnum && anyElemTyp != nil && anyElemTyp.Family() != types.EnumFamily {
+		if enforceConsistency {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"type matched to anyenum is not an enum type: %s", anyElemTyp,
+			))
+		}
+		return false, 0, nil
+	}
 	return true, numPolyParams, anyElemTyp
 }
 
+// ResolveAnyCompatibleArgTypes is similar to ResolvePolymorphicArgTypes, but
+// determines the common type for the parameters of the ANYCOMPATIBLE family. It
+// returns true if the supplied argument types are valid, as well as the common
+// type (nil if there were no ANYCOMPATIBLE parameters, or all the supplied
+// arguments were NULL).
+//
+// CRDB currently supports three polymorphic types in this family:
+// * ANYCOMPATIBLE allows any argument type.
+// * ANYCOMPATIBLEARRAY allows only array types.
+// * ANYCOMPATIBLENONARRAY allows any non-array type.
+//
+// The rules for argument validity are as follows:
+//  1. The arguments supplied for ANYCOMPATIBLEARRAY parameters must be arrays.
+//  2. The common type is chosen among the types of the arguments supplied for
+//     ANYCOMPATIBLE and ANYCOMPATIBLENONARRAY parameters and the element types
+//     of the arguments supplied for ANYCOMPATIBLEARRAY parameters, in the same
+//     way as for UNION and CASE: a candidate type is replaced by the type of
+//     another argument if it can be implicitly cast to that type, but not
+//     vice-versa. Each of those types must be implicitly castable to the
+//     common type.
+//  3. If there are any ANYCOMPATIBLENONARRAY parameters, the common type must
+//     not be an array.
+//  4. NULL arguments are exempt from the above rules.
+//
+// See ResolvePolymorphicArgTypes for the meaning of enforceConsistency.
+func ResolveAnyCompatibleArgTypes(
+	paramTypes ParamTypes, argTypes []*types.T, enforceConsistency bool,
+) (ok bool, numCompatParams int, _ *types.T) {
+	var compatTyps []*types.T
+	var sawNonArray bool
+	for i := range paramTypes {
+		paramTyp := paramTypes[i].Typ
+		if !paramTyp.IsAnyCompatibleType() {
+			continue
+		}
+		argTyp := argTypes[i]
+		numCompatParams++
+		if paramTyp.Identical(types.AnyCompatibleNonArray) {
+			sawNonArray = true
+		}
+		if argTyp.Family() == types.UnknownFamily {
+			continue
+		}
+		if paramTyp.Family() == types.ArrayFamily {
+			if argTyp.Family() != types.ArrayFamily {
+				if enforceConsistency {
+					panic(pgerror.Newf(pgcode.DatatypeMismatch,
+						"argument declared anycompatiblearray is not an array but type %s", argTyp,
+					))
+				}
+				return false, 0, nil
+			}
+			argTyp = argTyp.ArrayContents()
+		}
+		compatTyps = append(compatTyps, argTyp)
+	}
+	if len(compatTyps) == 0 {
+		return true, numCompatParams, nil
+	}
+	commonTyp := compatTyps[0]
+	for _, typ := range compatTyps[1:] {
+		if cast.ValidCast(commonTyp, typ, cast.ContextImplicit) &&
+			!cast.ValidCast(typ, commonTyp, cast.ContextImplicit) {
+			commonTyp = typ
+		}
+	}
+	for _, typ := range compatTyps {
+		if !typ.Equivalent(commonTyp) && !cast.ValidCast(typ, commonTyp, cast.ContextImplicit) {
+			if enforceConsistency {
+				panic(pgerror.Newf(pgcode.DatatypeMismatch,
+					"anycompatible types %s and %s cannot be matched", commonTyp, typ,
+				))
+			}
+			return false, 0, nil
+		}
+	}
+	if sawNonArray && commonTyp.Family() == types.ArrayFamily {
+		if enforceConsistency {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"type matched to anycompatiblenonarray is an array type: %s", commonTyp,
+			))
+		}
+		return false, 0, nil
+	}
+	return true, numCompatParams, commonTyp
+}
+
 // UnsupportedTypeChecker is used to check that a type is supported by the
 // current cluster version. It is an interface because some packages cannot
 // import the clusterversion package.
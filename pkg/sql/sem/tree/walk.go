@@ -324,6 +324,24 @@ func (expr *FuncExpr) Walk(v Visitor) Expr {
 	return ret
 }
 
+// Walk implements the Expr interface.
+func (expr *GroupingSet) Walk(v Visitor) Expr {
+	exprs, changed := walkExprSlice(v, expr.Exprs)
+	if changed {
+		return &GroupingSet{Type: expr.Type, Exprs: exprs}
+	}
+	return expr
+}
+
+// Walk implements the Expr interface.
+func (expr *GroupingExpr) Walk(v Visitor) Expr {
+	exprs, changed := walkExprSlice(v, expr.Exprs)
+	if changed {
+		return &GroupingExpr{Exprs: exprs}
+	}
+	return expr
+}
+
 // Walk implements the Expr interface.
 func (expr *IfExpr) Walk(v Visitor) Expr {
 	c, changedC := WalkExpr(v, expr.Cond)
@@ -529,13 +547,43 @@ func (expr *Subquery) WalkTableExpr(v Visitor) TableExpr {
 
 // WalkTableExpr implements the TableExpr interface.
 func (expr *AliasedTableExpr) WalkTableExpr(v Visitor) TableExpr {
+	ret := expr
 	newExpr, changed := walkTableExpr(v, expr.Expr)
 	if changed {
 		exprCopy := *expr
 		exprCopy.Expr = newExpr
-		return &exprCopy
+		ret = &exprCopy
 	}
-	return expr
+	if expr.TableSample != nil {
+		sample, changed := expr.TableSample.walk(v)
+		if changed {
+			if ret == expr {
+				exprCopy := *expr
+				ret = &exprCopy
+			}
+			ret.TableSample = sample
+		}
+	}
+	return ret
+}
+
+func (node *TableSample) walk(v Visitor) (*TableSample, bool) {
+	ret := node
+	if e, changed := WalkExpr(v, node.Percent); changed {
+		sampleCopy := *node
+		sampleCopy.Percent = e
+		ret = &sampleCopy
+	}
+	if node.Repeatable != nil {
+		if e, changed := WalkExpr(v, node.Repeatable); changed {
+			if ret == node {
+				sampleCopy := *node
+				ret = &sampleCopy
+			}
+			ret.Repeatable = e
+		}
+	}
+	return ret, ret != node
 }
 
 // WalkTableExpr implements the TableExpr interface.
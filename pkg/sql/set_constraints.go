@@ -0,0 +1,148 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// SetConstraints implements the SET CONSTRAINTS statement.
+// See https://www.postgresql.org/docs/current/sql-set-constraints.html for
+// details.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	if !p.IsActive(ctx, clusterversion.V25_3_Start) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"SET CONSTRAINTS is not supported until the cluster is upgraded to 25.3",
+		)
+	}
+	return &setConstraintsNode{n: n}, nil
+}
+
+type setConstraintsNode struct {
+	zeroInputPlanNode
+	n *tree.SetConstraints
+}
+
+func (n *setConstraintsNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *setConstraintsNode) Values() tree.Datums            { return nil }
+func (n *setConstraintsNode) Close(_ context.Context)        {}
+func (n *setConstraintsNode) startExec(params runParams) error {
+	p := params.p
+	if p.extendedEvalCtx.TxnImplicit {
+		p.BufferClientNotice(
+			params.ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return nil
+	}
+	dc := p.extendedEvalCtx.deferredConstraints
+	if dc == nil {
+		return nil
+	}
+
+	if len(n.n.Names) == 0 {
+		dc.setAllModes(n.n.Deferred)
+		if n.n.Deferred {
+			return nil
+		}
+		return p.validateDeferredConstraints(params.ctx, nil /* include */)
+	}
+
+	keys := make(map[deferredConstraintKey]struct{})
+	for _, name := range n.n.Names {
+		resolved, err := p.resolveDeferrableConstraint(params.ctx, string(name))
+		if err != nil {
+			return err
+		}
+		for _, k := range resolved {
+			keys[k] = struct{}{}
+		}
+	}
+	keyList := make([]deferredConstraintKey, 0, len(keys))
+	for k := range keys {
+		keyList = append(keyList, k)
+	}
+	dc.setModes(keyList, n.n.Deferred)
+	if n.n.Deferred {
+		return nil
+	}
+	return p.validateDeferredConstraints(params.ctx, func(k deferredConstraintKey) bool {
+		_, ok := keys[k]
+		return ok
+	})
+}
+
+// resolveDeferrableConstraint returns the constraints with the given name in
+// the first schema on the search path which contains a table with such a
+// constraint. An error is returned if there are no such constraints, or if any
+// of them is not DEFERRABLE.
+func (p *planner) resolveDeferrableConstraint(
+	ctx context.Context, name string,
+) ([]deferredConstraintKey, error) {
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	tables, err := p.Descriptors().GetAllTablesInDatabase(ctx, p.txn, db)
+	if err != nil {
+		return nil, err
+	}
+	// Collect the matching constraints by schema.
+	type match struct {
+		key        deferredConstraintKey
+		deferrable bool
+	}
+	matches := make(map[string][]match)
+	if err := tables.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok || tbl.IsVirtualTable() || tbl.Dropped() {
+			return nil
+		}
+		c := catalog.FindConstraintByName(tbl, name)
+		if c == nil {
+			return nil
+		}
+		sc, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Schema(ctx, tbl.GetParentSchemaID())
+		if err != nil {
+			return err
+		}
+		matches[sc.GetName()] = append(matches[sc.GetName()], match{
+			key:        deferredConstraintKey{tableID: tbl.GetID(), name: name},
+			deferrable: constraintDeferrability(c).IsDeferrable(),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	iter := p.SessionData().SearchPath.Iter()
+	for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+		found := matches[scName]
+		if len(found) == 0 {
+			continue
+		}
+		keys := make([]deferredConstraintKey, len(found))
+		for i, m := range found {
+			if !m.deferrable {
+				return nil, pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q is not deferrable", name)
+			}
+			keys[i] = m.key
+		}
+		return keys, nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject, "constraint %q does not exist", name)
+}
@@ -88,11 +88,20 @@ func ShowCreateTable(
 	if desc.IsTemporary() {
 		f.WriteString("TEMP ")
 	}
+	if desc.IsForeignTable() {
+		f.WriteString("FOREIGN ")
+	}
 	f.WriteString("TABLE ")
 	f.FormatNode(tn)
 	f.WriteString(" (")
 	// Inaccessible columns are not displayed in SHOW CREATE TABLE.
-	for i, col := range desc.AccessibleColumns() {
+	columns := desc.AccessibleColumns()
+	if desc.IsForeignTable() {
+		// Foreign tables have no primary key, so their hidden rowid column is not
+		// displayed either.
+		columns = desc.VisibleColumns()
+	}
+	for i, col := range columns {
 		if i != 0 {
 			f.WriteString(",")
 		}
@@ -107,7 +116,7 @@ func ShowCreateTable(
 		f.WriteString(colstr)
 	}
 
-	if desc.IsPhysicalTable() {
+	if desc.IsPhysicalTable() && !desc.IsForeignTable() {
 		f.WriteString(",\n\tCONSTRAINT ")
 		formatQuoteNames(&f.Buffer, desc.GetPrimaryIndex().GetName())
 		f.WriteString(" ")
@@ -194,6 +203,10 @@ func ShowCreateTable(
 	if err := showConstraintClause(ctx, desc, p.EvalContext(), &p.semaCtx, p.SessionData(), f); err != nil {
 		return "", err
 	}
+	if err := showInheritsClause(desc, dbPrefix, lCtx, f); err != nil {
+		return "", err
+	}
+	showForeignTableClause(desc, f)
 
 	if err := ShowCreatePartitioning(
 		a, p.ExecCfg().Codec, desc, desc.GetPrimaryIndex(), desc.GetPrimaryIndex().GetPartitioning(),
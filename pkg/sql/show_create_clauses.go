@@ -587,6 +587,12 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if fk.Deferrable {
+		buf.WriteString(" DEFERRABLE")
+		if fk.InitiallyDeferred {
+			buf.WriteString(" INITIALLY DEFERRED")
+		}
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -625,8 +631,56 @@ func ShowCreateSequence(
 	return f.CloseAndGetString(), nil
 }
 
+// showInheritsClause creates the INHERITS clause for a CREATE statement,
+// writing it to tree.FmtCtx f.
+func showInheritsClause(
+	desc catalog.TableDescriptor, dbPrefix string, lCtx simpleSchemaResolver, f *tree.FmtCtx,
+) error {
+	if len(desc.GetInherits()) == 0 {
+		return nil
+	}
+	f.WriteString(" INHERITS (")
+	for i, parentID := range desc.GetInherits() {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		var parentName tree.TableName
+		if lCtx != nil {
+			parent, err := lCtx.getTableByID(parentID)
+			if err != nil {
+				return err
+			}
+			parentName, err = getTableNameFromTableDescriptor(lCtx, parent, dbPrefix)
+			if err != nil {
+				return err
+			}
+		} else {
+			parentName = tree.MakeTableNameWithSchema(tree.Name(""), catconstants.PublicSchemaName, tree.Name(fmt.Sprintf("[%d as ref]", parentID)))
+			parentName.ExplicitSchema = false
+		}
+		f.FormatNode(&parentName)
+	}
+	f.WriteString(")")
+	return nil
+}
+
 // showFamilyClause creates the FAMILY clauses for a CREATE statement, writing them
 // to tree.FmtCtx f
+// showForeignTableClause creates the SERVER and OPTIONS clauses of a foreign
+// table.
+func showForeignTableClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
+	if !desc.IsForeignTable() {
+		return
+	}
+	ft := desc.GetForeignTable()
+	def := tree.ForeignTableDef{Server: tree.Name(ft.Server)}
+	for _, opt := range ft.Options {
+		def.Options = append(def.Options, tree.ForeignOption{Name: tree.Name(opt.Name), Value: opt.Value})
+	}
+	f.WriteString(" ")
+	f.FormatNode(&def)
+}
+
 func showFamilyClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
 	// Do not show family in SHOW CREATE TABLE if there is only one and
 	// it is named "primary".
@@ -852,6 +906,14 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
+		if c.UniqueWithoutIndexDesc().IsExclusion() {
+			if err := showExclusionConstraint(
+				ctx, desc, c, evalCtx, semaCtx, sessionData, exprFmtFlags, f,
+			); err != nil {
+				return err
+			}
+			continue
+		}
 		f.WriteString("UNIQUE WITHOUT INDEX (")
 		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
 		if err != nil {
@@ -859,6 +921,12 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		if uc := c.UniqueWithoutIndexDesc(); uc.Deferrable {
+			f.WriteString(" DEFERRABLE")
+			if uc.InitiallyDeferred {
+				f.WriteString(" INITIALLY DEFERRED")
+			}
+		}
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -876,3 +944,58 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// showExclusionConstraint writes the EXCLUDE clause of the given exclusion
+// constraint, which is stored as a UNIQUE WITHOUT INDEX constraint, into f.
+func showExclusionConstraint(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	c catalog.UniqueWithoutIndexConstraint,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+	exprFmtFlags tree.FmtFlags,
+	f *tree.FmtCtx,
+) error {
+	uc := c.UniqueWithoutIndexDesc()
+	colNames, err := catalog.ColumnNamesForIDs(desc, uc.ColumnIDs)
+	if err != nil {
+		return err
+	}
+	f.WriteString("EXCLUDE ")
+	if uc.ExclusionMethod != "" {
+		f.WriteString("USING ")
+		f.WriteString(uc.ExclusionMethod)
+		f.WriteString(" ")
+	}
+	f.WriteString("(")
+	for i := range colNames {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		formatQuoteNames(&f.Buffer, colNames[i])
+		f.WriteString(" WITH ")
+		f.WriteString(uc.ExclusionOperators[i])
+	}
+	f.WriteString(")")
+	if c.IsPartial() {
+		f.WriteString(" WHERE ")
+		pred, err := schemaexpr.FormatExprForDisplay(
+			ctx, desc, c.GetPredicate(), evalCtx, semaCtx, sessionData, exprFmtFlags,
+		)
+		if err != nil {
+			return err
+		}
+		f.WriteString(pred)
+	}
+	if uc.Deferrable {
+		f.WriteString(" DEFERRABLE")
+		if uc.InitiallyDeferred {
+			f.WriteString(" INITIALLY DEFERRED")
+		}
+	}
+	if !c.IsConstraintValidated() {
+		f.WriteString(" NOT VALID")
+	}
+	return nil
+}
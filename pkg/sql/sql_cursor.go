@@ -8,16 +8,20 @@ package sql
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -31,9 +35,6 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 	if s.Binary {
 		return nil, unimplemented.NewWithIssue(77099, "DECLARE BINARY CURSOR")
 	}
-	if s.Scroll == tree.Scroll {
-		return nil, unimplemented.NewWithIssue(77102, "DECLARE SCROLL CURSOR")
-	}
 
 	return &delayedNode{
 		name: s.String(),
@@ -100,6 +101,13 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 					"Holdable cursors must be READ ONLY.",
 				)
 			}
+			if s.Scroll == tree.Scroll && pt.flags.IsSet(planFlagContainsLocking) {
+				return nil, errors.WithDetail(
+					pgerror.Newf(pgcode.FeatureNotSupported,
+						"DECLARE SCROLL CURSOR must not contain locking"),
+					"Scrollable cursors must be READ ONLY.",
+				)
+			}
 			if pt.flags.IsSet(planFlagContainsMutation) {
 				// Cursors with mutations are invalid.
 				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
@@ -120,6 +128,14 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 				created:    timeutil.Now(),
 				withHold:   s.Hold,
 			}
+			if s.Scroll == tree.Scroll {
+				// Buffer the rows of a scrollable cursor as they are read, so that
+				// the cursor can move backward.
+				if cursor.Rows, err = newScrollableRows(p, rows, s.Hold); err != nil {
+					_ = rows.Close()
+					return nil, err
+				}
+			}
 			if err := p.sqlCursors.addCursor(s.Name, cursor); err != nil {
 				// This case shouldn't happen because cursor names are scoped to a session,
 				// and sessions can't have more than one statement running at once. But
@@ -144,7 +160,10 @@ func (p *planner) checkIfCursorExists(name tree.Name) error {
 	return nil
 }
 
-var errBackwardScan = pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward")
+var errBackwardScan = errors.WithHint(
+	pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward"),
+	"Declare it with SCROLL option to enable backward scan.",
+)
 
 // FetchCursor implements the FETCH and MOVE statements.
 // See https://www.postgresql.org/docs/current/sql-fetch.html for details.
@@ -161,7 +180,7 @@ func (p *planner) newFetchNode(s *tree.CursorStmt) (*fetchNode, error) {
 			pgcode.InvalidCursorName, "cursor %q does not exist", s.Name,
 		)
 	}
-	if s.Count < 0 || s.FetchType == tree.FetchBackwardAll {
+	if !cursor.isScrollable() && (s.Count < 0 || s.FetchType == tree.FetchBackwardAll) {
 		return nil, errBackwardScan
 	}
 	node := &fetchNode{
@@ -208,6 +227,9 @@ func (f *fetchNode) startInternal() error {
 }
 
 func (f *fetchNode) nextInternal(ctx context.Context) (bool, error) {
+	if rows, ok := f.cursor.Rows.(*scrollableRows); ok {
+		return f.nextScrollable(ctx, rows)
+	}
 	if f.fetchType == tree.FetchAll {
 		return f.cursor.Next(ctx)
 	}
@@ -260,6 +282,58 @@ func (f *fetchNode) nextInternal(ctx context.Context) (bool, error) {
 	return f.cursor.Next(ctx)
 }
 
+// nextScrollable is the variant of nextInternal for SCROLL cursors, which can
+// be positioned anywhere in the result set.
+func (f *fetchNode) nextScrollable(ctx context.Context, rows *scrollableRows) (bool, error) {
+	if !f.seeked {
+		f.seeked = true
+		switch f.fetchType {
+		case tree.FetchFirst:
+			return rows.seek(ctx, 1)
+		case tree.FetchLast:
+			n, err := rows.len(ctx)
+			if err != nil {
+				return false, err
+			}
+			return rows.seek(ctx, n)
+		case tree.FetchAbsolute:
+			pos := f.offset
+			if pos < 0 {
+				// A negative position counts backward from the end of the result.
+				n, err := rows.len(ctx)
+				if err != nil {
+					return false, err
+				}
+				pos = n + 1 + pos
+			}
+			return rows.seek(ctx, pos)
+		case tree.FetchRelative:
+			return rows.seek(ctx, rows.pos+f.offset)
+		case tree.FetchNormal:
+			if f.n == 0 {
+				// FETCH 0 re-fetches the current row.
+				return rows.seek(ctx, rows.pos)
+			}
+		}
+	}
+	switch f.fetchType {
+	case tree.FetchAll:
+		return rows.seek(ctx, rows.pos+1)
+	case tree.FetchBackwardAll:
+		return rows.seek(ctx, rows.pos-1)
+	case tree.FetchNormal:
+		if f.n > 0 {
+			f.n--
+			return rows.seek(ctx, rows.pos+1)
+		}
+		if f.n < 0 {
+			f.n++
+			return rows.seek(ctx, rows.pos-1)
+		}
+	}
+	return false, nil
+}
+
 func (f *fetchNode) startExec(params runParams) error {
 	return f.startInternal()
 }
@@ -337,6 +411,92 @@ func (p *planner) PLpgSQLFetchCursor(
 	return res, err
 }
 
+// PLpgSQLOpenDynamicCursor implements the eval.Planner interface.
+func (p *planner) PLpgSQLOpenDynamicCursor(
+	ctx context.Context,
+	cursorName tree.Name,
+	query string,
+	params tree.Datums,
+	foundColumn bool,
+	scroll bool,
+) (retErr error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return pgerror.Newf(
+			pgcode.InvalidCursorDefinition, "cannot open %s query as cursor", stmt.AST.StatementTag(),
+		)
+	}
+	if err := p.checkIfCursorExists(cursorName); err != nil {
+		return err
+	}
+	qargs := make([]interface{}, len(params))
+	for i := range params {
+		qargs[i] = params[i]
+	}
+	rows, cols, err := p.InternalSQLTxn().QueryBufferedExWithCols(
+		ctx, "plpgsql-open-dynamic", p.txn, sessiondata.NoSessionDataOverride, query, qargs...,
+	)
+	if err != nil {
+		return err
+	}
+	if foundColumn {
+		cols = append(colinfo.ResultColumns{{Name: "found", Typ: types.Bool}}, cols...)
+	}
+	helper, err := newPLpgSQLCursorHelper(p, cursorName, query, cols, len(sel.Locking) > 0, scroll)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			retErr = errors.CombineErrors(retErr, helper.Close())
+		}
+	}()
+	for _, row := range rows {
+		if foundColumn {
+			row = append(tree.Datums{tree.DBoolTrue}, row...)
+		}
+		if err := helper.container.AddRow(helper.ctx, row); err != nil {
+			return err
+		}
+	}
+	return helper.createCursor(p)
+}
+
+// PLpgSQLExecuteDynamic implements the eval.Planner interface.
+func (p *planner) PLpgSQLExecuteDynamic(
+	ctx context.Context, query string, params tree.Datums,
+) (rows []tree.Datums, rowTyp *types.T, err error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, isCall := stmt.AST.(*tree.Call); !isCall && stmt.AST.StatementType() == tree.TypeTCL {
+		return nil, nil, unimplemented.New(
+			"plpgsql execute tcl", "EXECUTE of transaction commands is not implemented",
+		)
+	}
+	qargs := make([]interface{}, len(params))
+	for i := range params {
+		qargs[i] = params[i]
+	}
+	rows, cols, err := p.InternalSQLTxn().QueryBufferedExWithCols(
+		ctx, "plpgsql-execute-dynamic", p.txn, sessiondata.NoSessionDataOverride, query, qargs...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	typs := make([]*types.T, len(cols))
+	labels := make([]string, len(cols))
+	for i := range cols {
+		typs[i], labels[i] = cols[i].Typ, cols[i].Name
+	}
+	return rows, types.MakeLabeledTuple(typs, labels), nil
+}
+
 type sqlCursor struct {
 	isql.Rows
 	// txn is the transaction object that the internal executor for this cursor
@@ -362,6 +522,13 @@ type sqlCursor struct {
 	committed bool
 }
 
+// isScrollable returns true if the cursor was declared with the SCROLL option,
+// and can therefore move backward.
+func (s *sqlCursor) isScrollable() bool {
+	_, ok := s.Rows.(*scrollableRows)
+	return ok
+}
+
 // Next implements the Rows interface.
 func (s *sqlCursor) Next(ctx context.Context) (bool, error) {
 	more, err := s.Rows.Next(ctx)
@@ -597,6 +764,15 @@ func (p *planner) checkNoConflictingCursors(stmt tree.Statement) error {
 // persistCursor runs the given cursor to completion and stores the result in a
 // row container that can outlive the cursor's transaction.
 func persistCursor(p *planner, cursor *sqlCursor) (retErr error) {
+	if rows, ok := cursor.Rows.(*scrollableRows); ok {
+		// A scrollable cursor already buffers its rows in a row container owned
+		// by the session, so it only needs to read the remaining rows.
+		if err := rows.persist(); err != nil {
+			return err
+		}
+		cursor.persisted = true
+		return nil
+	}
 	// Use context.Background() because the cursor can outlive the context in
 	// which it was created.
 	helper := persistedCursorHelper{
@@ -701,3 +877,149 @@ func (h *persistedCursorHelper) Types() colinfo.ResultColumns {
 func (h *persistedCursorHelper) HasResults() bool {
 	return h.lastRow != nil
 }
+
+// scrollableRows wraps the rows produced by the query of a SCROLL cursor. The
+// rows are buffered in a disk-spilling row container as they are read, so that
+// the cursor can be positioned anywhere in the result set. Rows are only read
+// from the query when the cursor moves past the buffered rows.
+type scrollableRows struct {
+	ctx context.Context
+
+	// input produces the rows of the cursor's query. It is set to nil once it
+	// has been exhausted and closed.
+	input      isql.Rows
+	resultCols colinfo.ResultColumns
+	buf        indexedRowContainerHelper
+
+	// pos is the current position of the cursor. Zero is before the first row,
+	// and one past the number of rows is after the last row.
+	pos int64
+	cur tree.Datums
+}
+
+var _ isql.Rows = &scrollableRows{}
+
+// newScrollableRows returns a scrollableRows that buffers the rows from the
+// given input. If withHold is true, the rows are buffered using the session's
+// memory monitor, since the cursor can outlive its transaction.
+func newScrollableRows(p *planner, input isql.Rows, withHold bool) (*scrollableRows, error) {
+	mon := p.Mon()
+	if withHold {
+		mon = p.sessionMonitor
+		if mon == nil {
+			return nil, errors.AssertionFailedf("cannot open cursor WITH HOLD without an active session")
+		}
+	}
+	// Use context.Background() because the cursor can outlive the context in
+	// which it was created.
+	r := &scrollableRows{
+		ctx:        context.Background(),
+		input:      input,
+		resultCols: input.Types(),
+	}
+	r.buf.InitWithParentMon(
+		r.ctx,
+		getTypesFromResultColumns(r.resultCols),
+		mon,
+		p.ExtendedEvalContextCopy(),
+		"scroll_cursor", /* opName */
+	)
+	return r, nil
+}
+
+// fill reads rows from the input until at least n rows are buffered, or the
+// input is exhausted.
+func (r *scrollableRows) fill(ctx context.Context, n int64) error {
+	for r.input != nil && int64(r.buf.Len()) < n {
+		more, err := r.input.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !more {
+			err = r.input.Close()
+			r.input = nil
+			return err
+		}
+		if err = r.buf.AddRow(r.ctx, r.input.Cur()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// len returns the total number of rows produced by the cursor's query. It
+// reads all remaining rows from the input.
+func (r *scrollableRows) len(ctx context.Context) (int64, error) {
+	if err := r.fill(ctx, math.MaxInt64); err != nil {
+		return 0, err
+	}
+	return int64(r.buf.Len()), nil
+}
+
+// seek positions the cursor at the given (one-based) row position, and returns
+// true if the cursor is positioned on a row. Positions before the first row or
+// after the last row leave the cursor just before the first row or just after
+// the last row, respectively.
+func (r *scrollableRows) seek(ctx context.Context, pos int64) (bool, error) {
+	r.cur = nil
+	if pos <= 0 {
+		r.pos = 0
+		return false, nil
+	}
+	if err := r.fill(ctx, pos); err != nil {
+		return false, err
+	}
+	if n := int64(r.buf.Len()); pos > n {
+		r.pos = n + 1
+		return false, nil
+	}
+	row, err := r.buf.GetRow(r.ctx, int(pos-1))
+	if err != nil {
+		return false, err
+	}
+	r.pos, r.cur = pos, row
+	return true, nil
+}
+
+// persist reads all remaining rows from the input, so that the cursor no
+// longer depends on its transaction.
+func (r *scrollableRows) persist() error {
+	_, err := r.len(r.ctx)
+	return err
+}
+
+// Next implements the isql.Rows interface.
+func (r *scrollableRows) Next(ctx context.Context) (bool, error) {
+	return r.seek(ctx, r.pos+1)
+}
+
+// Cur implements the isql.Rows interface.
+func (r *scrollableRows) Cur() tree.Datums {
+	return r.cur
+}
+
+// RowsAffected implements the isql.Rows interface.
+func (r *scrollableRows) RowsAffected() int {
+	return r.buf.Len()
+}
+
+// Close implements the isql.Rows interface.
+func (r *scrollableRows) Close() error {
+	var err error
+	if r.input != nil {
+		err = r.input.Close()
+		r.input = nil
+	}
+	r.buf.Close(r.ctx)
+	return err
+}
+
+// Types implements the isql.Rows interface.
+func (r *scrollableRows) Types() colinfo.ResultColumns {
+	return r.resultCols
+}
+
+// HasResults implements the isql.Rows interface.
+func (r *scrollableRows) HasResults() bool {
+	return r.buf.Len() > 0
+}
@@ -89,6 +89,12 @@ func (ex *connExecutor) execPrepareTransactionInOpenStateInternal(
 			"cannot prepare a transaction that has already performed schema changes")
 	}
 
+	// Check the constraints whose checking was deferred until the end of the
+	// transaction.
+	if err := ex.planner.validateDeferredConstraints(ctx, nil /* include */); err != nil {
+		return err
+	}
+
 	txn := ex.state.mu.txn
 	txnID := txn.ID()
 	txnKey := txn.Key()
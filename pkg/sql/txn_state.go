@@ -411,6 +411,30 @@ func (ts *txnState) setReadOnlyMode(mode tree.ReadWriteMode) error {
 	return nil
 }
 
+// chainedTxnModes returns the isolation level, priority, and read-only mode of
+// the current transaction as transaction modes. They are used to start a new
+// transaction with the same characteristics after COMMIT AND CHAIN or ROLLBACK
+// AND CHAIN.
+func (ts *txnState) chainedTxnModes() tree.TransactionModes {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	modes := tree.TransactionModes{
+		Isolation:     tree.FromKVIsoLevel(ts.mu.isolationLevel),
+		UserPriority:  tree.Normal,
+		ReadWriteMode: tree.ReadWrite,
+	}
+	switch ts.mu.priority {
+	case roachpb.MinUserPriority:
+		modes.UserPriority = tree.Low
+	case roachpb.MaxUserPriority:
+		modes.UserPriority = tree.High
+	}
+	if ts.readOnly.Load() {
+		modes.ReadWriteMode = tree.ReadOnly
+	}
+	return modes
+}
+
 // advanceCode is part of advanceInfo; it instructs the module managing the
 // statements buffer on what action to take.
 type advanceCode int
@@ -210,6 +210,9 @@ func init() {
 // type.
 func CalcArrayOid(elemTyp *T) oid.Oid {
 	o := elemTyp.Oid()
+	if elemTyp.IsDomain() {
+		return elemTyp.UserDefinedArrayOID()
+	}
 	switch elemTyp.Family() {
 	case ArrayFamily:
 		// Postgres nested arrays return the OID of the nested array (i.e. the
@@ -249,6 +252,8 @@ func CalcArrayOid(elemTyp *T) oid.Oid {
 	// init method).
 	if o == oid.T_json {
 		o = oid.T__json
+	} else if o == oidext.T_anycompatible || o == oidext.T_anycompatiblenonarray {
+		o = oidext.T_anycompatiblearray
 	} else {
 		o = oidToArrayOid[o]
 	}
@@ -213,6 +213,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the type metadata.
 	Version uint32
@@ -239,6 +242,22 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed for evaluation.
+type DomainMetadata struct {
+	// BaseType is the underlying type of the domain.
+	BaseType *T
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, or the
+	// empty string if it has none.
+	DefaultExpr string
+	// CheckNames and CheckExprs are the names and the serialized expressions
+	// of the domain's CHECK constraints. The expressions refer to the value
+	// being checked with the VALUE keyword.
+	CheckNames []string
+	CheckExprs []string
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -616,6 +635,27 @@ var (
 	AnyEnum = &T{InternalType: InternalType{
 		Family: EnumFamily, Locale: &emptyLocale, Oid: oid.T_anyenum}}
 
+	// AnyCompatible is a special type used only during static analysis as a
+	// wildcard type for routine parameters. Unlike AnyElement, the arguments
+	// supplied for AnyCompatible parameters do not need to have the same type;
+	// they are cast to a common type instead. Execution-time values should never
+	// have this type.
+	AnyCompatible = &T{InternalType: InternalType{
+		Family: AnyFamily, Oid: oidext.T_anycompatible, Locale: &emptyLocale}}
+
+	// AnyCompatibleArray is a special type used only during static analysis as
+	// a wildcard type that matches an array whose element type is resolved in
+	// the same way as AnyCompatible. Execution-time values should never have
+	// this type.
+	AnyCompatibleArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: AnyCompatible, Oid: oidext.T_anycompatiblearray, Locale: &emptyLocale}}
+
+	// AnyCompatibleNonArray is the same as AnyCompatible, except that the
+	// resolved type must not be an array. Execution-time values should never
+	// have this type.
+	AnyCompatibleNonArray = &T{InternalType: InternalType{
+		Family: AnyFamily, Oid: oidext.T_anycompatiblenonarray, Locale: &emptyLocale}}
+
 	// AnyTuple is a special type used only during static analysis as a wildcard
 	// type that matches a tuple with any number of fields of any type (including
 	// tuple types). Execution-time values should never have this type.
@@ -1225,6 +1265,20 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type over the given base
+// type, with the given stable type ID. Values of the domain have the same
+// family and physical representation as values of the base type. Note that it
+// does not hydrate cached fields on the type.
+func MakeDomain(baseType *T, typeOID, arrayTypeOID oid.Oid) *T {
+	typ := *baseType.CopyForHydrate()
+	typ.InternalType.Oid = typeOID
+	typ.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID: arrayTypeOID,
+	}
+	typ.TypeMeta = UserDefinedTypeMetadata{}
+	return &typ
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1549,6 +1603,37 @@ func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
 }
 
+// IsDomain returns whether or not t is a user defined domain type. Domain
+// types have the family of their base type, unlike other user defined types
+// which are enums, composite types, or arrays.
+func (t *T) IsDomain() bool {
+	switch t.Family() {
+	case EnumFamily, TupleFamily, ArrayFamily:
+		return false
+	}
+	return t.UserDefined()
+}
+
+// DomainBaseType returns the base type of a domain type, or nil if t is not a
+// hydrated domain type.
+func (t *T) DomainBaseType() *T {
+	if t.TypeMeta.DomainData == nil {
+		return nil
+	}
+	return t.TypeMeta.DomainData.BaseType
+}
+
+// domainSQLString returns the name of a domain type.
+func (t *T) domainSQLString() string {
+	// We do not expect to be in a situation where we want to format a
+	// user-defined type to a string and do not have the TypeMeta hydrated, but
+	// returning a less informative string is better than a nil-pointer panic.
+	if t.TypeMeta.Name == nil {
+		return fmt.Sprintf("@%d", t.Oid())
+	}
+	return t.TypeMeta.Name.FQName(false /* explicitCatalog */)
+}
+
 // IsOIDUserDefinedType returns whether or not o corresponds to a user
 // defined type.
 func IsOIDUserDefinedType(o oid.Oid) bool {
@@ -1612,11 +1697,21 @@ func (f Family) Name() redact.SafeString {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		if t.TypeMeta.Name == nil {
+			return "unknown_domain"
+		}
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		switch t.Oid() {
 		case oid.T_any:
 			return "any"
+		case oidext.T_anycompatible:
+			return "anycompatible"
+		case oidext.T_anycompatiblenonarray:
+			return "anycompatiblenonarray"
 		default:
 			return "anyelement"
 		}
@@ -1629,6 +1724,8 @@ func (t *T) Name() string {
 			return "int2vector"
 		case oid.T_anyarray:
 			return "anyarray"
+		case oidext.T_anycompatiblearray:
+			return "anycompatiblearray"
 		}
 		return t.ArrayContents().Name() + "[]"
 
@@ -1759,9 +1856,16 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() {
+		return t.Name()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
+		switch t.Oid() {
+		case oidext.T_anycompatible, oidext.T_anycompatiblenonarray:
+			return t.Name()
+		}
 		return "anyelement"
 	case ArrayFamily:
 		switch t.Oid() {
@@ -1771,6 +1875,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 			return "int2vector"
 		case oid.T_anyarray:
 			return "anyarray"
+		case oidext.T_anycompatiblearray:
+			return "anycompatiblearray"
 		}
 		// If we have a typemod specified then pass it down when
 		// formatting the array type.
@@ -1976,6 +2082,9 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		return t.domainSQLString()
+	}
 	switch t.Family() {
 	case BitFamily:
 		switch t.Oid() {
@@ -2156,6 +2265,8 @@ func (t *T) SQLStringForError() redact.RedactableString {
 			prefix = "RECORD"
 		case ArrayFamily:
 			prefix = "ARRAY"
+		default:
+			prefix = "DOMAIN"
 		}
 		return redact.Sprintf("USER DEFINED %s: %s", redact.Safe(prefix), t.SQLString())
 	}
@@ -2308,6 +2419,7 @@ func (t *T) Equal(other *T) bool {
 func (t *T) IsWildcardType() bool {
 	for _, wildcard := range []*T{
 		Any, AnyElement, AnyArray, AnyCollatedString, AnyEnum, AnyEnumArray, AnyTuple, AnyTupleArray,
+		AnyCompatible, AnyCompatibleArray, AnyCompatibleNonArray,
 	} {
 		// Note that pointer comparison is insufficient since we might have
 		// deserialized t from disk.
@@ -2327,6 +2439,18 @@ func (t *T) IsPolymorphicType() bool {
 			return true
 		}
 	}
+	return t.IsAnyCompatibleType()
+}
+
+// IsAnyCompatibleType returns true if the type is one of the polymorphic
+// ANYCOMPATIBLE family of types, which are resolved to a common type that all
+// the supplied arguments can be implicitly cast to.
+func (t *T) IsAnyCompatibleType() bool {
+	for _, poly := range []*T{AnyCompatible, AnyCompatibleArray, AnyCompatibleNonArray} {
+		if t.Identical(poly) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -3153,6 +3277,12 @@ var unreservedTypeTokens = map[string]*T{
 
 	"string": String,
 	"uuid":   Uuid,
+
+	// Polymorphic pseudo-types that are not included in OidToType.
+	"anycompatible":         AnyCompatible,
+	"anycompatiblearray":    AnyCompatibleArray,
+	"anycompatiblenonarray": AnyCompatibleNonArray,
+	"anyenum":               AnyEnum,
 }
 
 // The following map must include all types predefined in PostgreSQL
@@ -3160,18 +3290,19 @@ var unreservedTypeTokens = map[string]*T{
 // github issues. It is also possible, but not necessary, to include
 // PostgreSQL types that are already implemented in CockroachDB.
 var postgresPredefinedTypeIssues = map[string]int{
-	"box":           21286,
-	"cidr":          18846,
-	"circle":        21286,
-	"jsonpath":      22513,
-	"line":          21286,
-	"lseg":          21286,
-	"macaddr":       45813,
-	"macaddr8":      45813,
-	"money":         41578,
-	"path":          21286,
-	"txid_snapshot": -1,
-	"xml":           43355,
+	"anycompatiblerange": 123048,
+	"box":                21286,
+	"cidr":               18846,
+	"circle":             21286,
+	"jsonpath":           22513,
+	"line":               21286,
+	"lseg":               21286,
+	"macaddr":            45813,
+	"macaddr8":           45813,
+	"money":              41578,
+	"path":               21286,
+	"txid_snapshot":      -1,
+	"xml":                43355,
 }
 
 // SQLString outputs the GeoMetadata in a SQL-compatible string.
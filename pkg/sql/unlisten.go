@@ -8,17 +8,43 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
+// Unlisten implements the UNLISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-unlisten.html for details.
 func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
-	p.BufferClientNotice(ctx,
-		pgerror.WithSeverity(
-			unimplemented.NewWithIssuef(41522, "CRDB does not support LISTEN, making UNLISTEN a no-op"),
-			"NOTICE",
-		),
-	)
-	return newZeroNode(nil /* columns */), nil
+	if n.Star {
+		return &unlistenNode{all: true}, nil
+	}
+	if n.ChannelName.NumParts > 1 {
+		return nil, pgerror.Newf(pgcode.InvalidName, "invalid channel name: %s", n.ChannelName)
+	}
+	return &unlistenNode{channel: n.ChannelName.Object()}, nil
 }
+
+type unlistenNode struct {
+	zeroInputPlanNode
+	channel string
+	all     bool
+}
+
+func (n *unlistenNode) startExec(params runParams) error {
+	l := params.p.notificationListener
+	if l == nil {
+		// The session cannot be listening on any channel.
+		return nil
+	}
+	if n.all {
+		l.UnlistenAll()
+	} else {
+		l.Unlisten(n.channel)
+	}
+	return nil
+}
+
+func (n *unlistenNode) Next(runParams) (bool, error) { return false, nil }
+func (n *unlistenNode) Values() tree.Datums          { return nil }
+func (n *unlistenNode) Close(context.Context)        {}
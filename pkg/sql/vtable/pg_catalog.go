@@ -1792,7 +1792,9 @@ CREATE TABLE pg_catalog.pg_largeobject_metadata (
 	lomacl STRING[]
 )`
 
-// PgCatalogReplicationSlots is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogReplicationSlots describes the schema of the
+// pg_catalog.pg_replication_slots view.
+// https://www.postgresql.org/docs/16/view-pg-replication-slots.html
 const PgCatalogReplicationSlots = `
 CREATE TABLE pg_catalog.pg_replication_slots (
 	slot_name NAME,
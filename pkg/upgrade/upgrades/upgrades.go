@@ -127,6 +127,22 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionNotRequired("cluster restore does not restore the new column or index"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"create notifications table",
+		clusterversion.V25_3_NotificationsTable.Version(),
+		upgrade.NoPrecondition,
+		createNotificationsTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
+	upgrade.NewTenantUpgrade(
+		"create replication slots table",
+		clusterversion.V25_3_ReplicationSlotsTable.Version(),
+		upgrade.NoPrecondition,
+		createReplicationSlotsTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
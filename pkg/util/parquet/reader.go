@@ -0,0 +1,116 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package parquet
+
+import (
+	"github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// ReadRows reads the rows of a parquet file written by a Writer and calls fn
+// for each of them. Unlike ReadFile, it does not rely on the reader metadata
+// written in test builds: the columns of the file are decoded as the given
+// types, which must match the types the file was written with. Tuple columns
+// are not supported.
+//
+// The rows of a row group are decoded before fn is called for any of them.
+// The datums passed to fn may be retained by the caller.
+func ReadRows(r parquet.ReaderAtSeeker, typs []*types.T, fn func(tree.Datums) error) (err error) {
+	decoders := make([]decoder, len(typs))
+	for i, typ := range typs {
+		elemTyp := typ
+		switch typ.Family() {
+		case types.TupleFamily:
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"reading parquet columns of type %s is not supported", typ.SQLString())
+		case types.ArrayFamily:
+			elemTyp = typ.ArrayContents()
+		}
+		if decoders[i], err = decoderFromFamilyAndType(elemTyp.Oid(), elemTyp.Family()); err != nil {
+			return err
+		}
+	}
+
+	reader, err := file.NewParquetReader(r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			err = errors.CombineErrors(err, closeErr)
+		}
+	}()
+
+	for rg := 0; rg < reader.NumRowGroups(); rg++ {
+		rgr := reader.RowGroup(rg)
+		if rgr.NumColumns() != len(typs) {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"expected %d columns in parquet file, found %d", len(typs), rgr.NumColumns())
+		}
+		numRows := rgr.NumRows()
+		rows := make([][]tree.Datum, numRows)
+		for i := range rows {
+			rows[i] = make(tree.Datums, len(typs))
+		}
+		for colIdx, typ := range typs {
+			col, err := rgr.Column(colIdx)
+			if err != nil {
+				return err
+			}
+			// See the comments above arrayEntryNonNilDefLevel for how arrays are
+			// represented.
+			isArray := col.Descriptor().MaxDefinitionLevel() == 3
+			if isArray != (typ.Family() == types.ArrayFamily) {
+				return pgerror.Newf(pgcode.DatatypeMismatch,
+					"parquet column %q cannot be read as %s", col.Descriptor().Name(), typ.SQLString())
+			}
+			colDatums, err := readColInRowGroup(col, decoders[colIdx], numRows, isArray, false /* isTuple */)
+			if err != nil {
+				return err
+			}
+			for i := range colDatums {
+				if colDatums[i], err = completeDatum(colDatums[i], typ); err != nil {
+					return err
+				}
+			}
+			decodeValuesIntoDatumsHelper(colDatums, rows, colIdx, 0 /* startingRowIdx */)
+		}
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// completeDatum fills in the type information of a decoded datum of the given
+// type which the decoders leave out.
+func completeDatum(d tree.Datum, typ *types.T) (tree.Datum, error) {
+	switch t := d.(type) {
+	case *tree.DArray:
+		t.ParamTyp = typ.ArrayContents()
+		for i := range t.Array {
+			var err error
+			if t.Array[i], err = completeDatum(t.Array[i], t.ParamTyp); err != nil {
+				return nil, err
+			}
+			if t.Array[i] == tree.DNull {
+				t.HasNulls = true
+			} else {
+				t.HasNonNulls = true
+			}
+		}
+	case *tree.DCollatedString:
+		return tree.NewDCollatedString(t.Contents, typ.Locale(), &tree.CollationEnvironment{})
+	}
+	return d, nil
+}
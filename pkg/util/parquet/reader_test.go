@@ -0,0 +1,62 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package parquet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRows(t *testing.T) {
+	colNames := []string{"i", "s", "d", "a"}
+	colTypes := []*types.T{types.Int, types.String, types.Decimal, types.IntArray}
+	schemaDef, err := NewSchema(colNames, colTypes)
+	require.NoError(t, err)
+
+	arr := tree.NewDArray(types.Int)
+	require.NoError(t, arr.Append(tree.NewDInt(1)))
+	require.NoError(t, arr.Append(tree.DNull))
+	d1, err := tree.ParseDDecimal("1.5")
+	require.NoError(t, err)
+	d2, err := tree.ParseDDecimal("-3")
+	require.NoError(t, err)
+	written := [][]tree.Datum{
+		{tree.NewDInt(1), tree.NewDString("a"), d1, arr},
+		{tree.DNull, tree.DNull, tree.DNull, tree.DNull},
+		{tree.NewDInt(3), tree.NewDString(""), d2, tree.NewDArray(types.Int)},
+	}
+
+	var buf bytes.Buffer
+	// Use a small row group size to read multiple row groups.
+	writer, err := NewWriter(schemaDef, &buf, WithMaxRowGroupLength(2))
+	require.NoError(t, err)
+	for _, row := range written {
+		require.NoError(t, writer.AddRow(row))
+	}
+	require.NoError(t, writer.Close())
+
+	var read [][]tree.Datum
+	require.NoError(t, ReadRows(bytes.NewReader(buf.Bytes()), colTypes, func(row tree.Datums) error {
+		read = append(read, row)
+		return nil
+	}))
+	require.Len(t, read, len(written))
+	for i := range written {
+		for j := range written[i] {
+			require.Equal(t, tree.AsString(written[i][j]), tree.AsString(read[i][j]))
+			if read[i][j] != tree.DNull {
+				require.True(t, read[i][j].ResolvedType().Equivalent(colTypes[j]))
+			}
+		}
+	}
+
+	err = ReadRows(bytes.NewReader(buf.Bytes()), colTypes[:2], func(tree.Datums) error { return nil })
+	require.ErrorContains(t, err, "expected 2 columns in parquet file, found 4")
+}